@@ -0,0 +1,389 @@
+package arbitrage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+const (
+	defaultScanInterval = time.Second * 10
+	// feeCacheDuration is how long exchange fees are cached for as fetching
+	// them may require an API request
+	feeCacheDuration = time.Hour
+	// eventType and triangularEventType are the communications event types for
+	// new opportunities
+	eventType           = "ARBITRAGE"
+	triangularEventType = "TRIANGULAR_ARBITRAGE"
+)
+
+// vars related to the arbitrage scanner
+var (
+	ErrScannerAlreadyStarted = errors.New("arbitrage scanner already started")
+)
+
+// New returns a scanner for the exchanges. relatable decides which currency
+// pairs are compared across exchanges and convert normalises differing fiat
+// quote currencies
+func New(cfg config.ArbitrageConfig, exchanges []exchange.IBotExchange, relatable RelatableFunc, convert ConvertFunc) *Scanner {
+	if cfg.ScanInterval <= 0 {
+		cfg.ScanInterval = defaultScanInterval
+	}
+	return &Scanner{
+		cfg:       cfg,
+		exchanges: exchanges,
+		relatable: relatable,
+		convert:   convert,
+		fees:      make(map[string]fee),
+	}
+}
+
+// SetComms sets where newly found opportunities are reported
+func (s *Scanner) SetComms(n Notifier) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.notifier = n
+}
+
+// SetHandler sets a function which receives the opportunities found by every
+// scan which finds at least one
+func (s *Scanner) SetHandler(h func([]Opportunity)) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.handler = h
+}
+
+// Start scans the orderbook cache every scan interval until Stop is called
+func (s *Scanner) Start() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.shutdown != nil {
+		return ErrScannerAlreadyStarted
+	}
+	s.shutdown = make(chan struct{})
+	s.wg.Add(1)
+	go s.run(s.shutdown)
+	return nil
+}
+
+// Stop stops scanning and waits for an in progress scan to finish
+func (s *Scanner) Stop() {
+	s.mtx.Lock()
+	if s.shutdown == nil {
+		s.mtx.Unlock()
+		return
+	}
+	close(s.shutdown)
+	s.shutdown = nil
+	s.mtx.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Scanner) run(shutdown chan struct{}) {
+	defer s.wg.Done()
+	t := time.NewTicker(s.cfg.ScanInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-t.C:
+			if s.cfg.Enabled {
+				s.Scan()
+			}
+			if s.cfg.Triangular.Enabled {
+				s.ScanTriangular()
+			}
+		}
+	}
+}
+
+// GetOpportunities returns the opportunities found by the last scan
+func (s *Scanner) GetOpportunities() []Opportunity {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]Opportunity(nil), s.opportunities...)
+}
+
+// Scan compares the cached orderbooks of every relatable currency pair across
+// exchanges and returns the opportunities ordered by net profit percentage
+func (s *Scanner) Scan() []Opportunity {
+	markets := s.getMarkets()
+	now := time.Now()
+
+	var opportunities []Opportunity
+	for i := range markets {
+		for j := range markets {
+			buy, sell := &markets[i], &markets[j]
+			if buy.exchange.GetName() == sell.exchange.GetName() ||
+				!s.isRelatable(buy.pair, sell.pair) {
+				continue
+			}
+			o, ok := s.evaluate(buy, sell)
+			if !ok {
+				continue
+			}
+			o.Time = now
+			opportunities = append(opportunities, o)
+		}
+	}
+
+	sort.Slice(opportunities, func(i, j int) bool {
+		return opportunities[i].NetProfitPercent > opportunities[j].NetProfitPercent
+	})
+	s.publish(opportunities)
+	return opportunities
+}
+
+// getMarkets returns the cached spot orderbooks of the enabled pairs being
+// scanned
+func (s *Scanner) getMarkets() []market {
+	var filter []pair.CurrencyPair
+	if s.cfg.Pairs != "" {
+		for _, p := range common.SplitStrings(s.cfg.Pairs, ",") {
+			filter = append(filter, pair.NewCurrencyPairFromString(common.StringToUpper(p)))
+		}
+	}
+
+	var markets []market
+	for _, exch := range s.exchanges {
+		for _, p := range exch.GetEnabledCurrencies() {
+			if len(filter) > 0 && !pair.Contains(filter, p, true) {
+				continue
+			}
+			ob, err := orderbook.GetOrderbook(exch.GetName(), p, orderbook.Spot)
+			if err != nil {
+				continue
+			}
+			if m, ok := newMarket(exch, p, ob); ok {
+				markets = append(markets, m)
+			}
+		}
+	}
+	return markets
+}
+
+// newMarket returns an orderbook with its bids sorted from highest to lowest
+// and its asks from lowest to highest, ok is false when either side is empty
+func newMarket(exch exchange.IBotExchange, p pair.CurrencyPair, ob orderbook.Base) (m market, ok bool) {
+	if len(ob.Bids) == 0 || len(ob.Asks) == 0 {
+		return market{}, false
+	}
+	m = market{exchange: exch, pair: p}
+	for _, b := range ob.Bids {
+		m.bids = append(m.bids, level{price: b.Price, amount: b.Amount})
+	}
+	for _, a := range ob.Asks {
+		m.asks = append(m.asks, level{price: a.Price, amount: a.Amount})
+	}
+	sort.Slice(m.bids, func(i, j int) bool { return m.bids[i].price > m.bids[j].price })
+	sort.Slice(m.asks, func(i, j int) bool { return m.asks[i].price < m.asks[j].price })
+	return m, true
+}
+
+// isRelatable returns whether the base currency bought with p1 can be sold
+// with p2
+func (s *Scanner) isRelatable(p1, p2 pair.CurrencyPair) bool {
+	if p1.FirstCurrency.Upper() == p2.SecondCurrency.Upper() {
+		return false
+	}
+	return s.relatable(p1, p2, s.cfg.IncludeUSDT)
+}
+
+// evaluate walks the ask side of the buy market and the bid side of the sell
+// market for as long as each level is profitable after trading fees
+func (s *Scanner) evaluate(buy, sell *market) (Opportunity, bool) {
+	rate, err := s.getRate(sell.pair.SecondCurrency.String(), buy.pair.SecondCurrency.String())
+	if err != nil {
+		return Opportunity{}, false
+	}
+	buyFee := s.getTradeFee(buy)
+	sellFee := s.getTradeFee(sell)
+
+	var amount, cost, proceeds float64
+	i, j := 0, 0
+	askRemaining, bidRemaining := buy.asks[0].amount, sell.bids[0].amount
+	for i < len(buy.asks) && j < len(sell.bids) {
+		ask := buy.asks[i].price
+		bid := sell.bids[j].price * rate
+		if bid*(1-sellFee) <= ask*(1+buyFee) {
+			break
+		}
+		qty := math.Min(askRemaining, bidRemaining)
+		amount += qty
+		cost += qty * ask
+		proceeds += qty * bid
+		askRemaining -= qty
+		bidRemaining -= qty
+		if askRemaining <= 0 {
+			i++
+			if i < len(buy.asks) {
+				askRemaining = buy.asks[i].amount
+			}
+		}
+		if bidRemaining <= 0 {
+			j++
+			if j < len(sell.bids) {
+				bidRemaining = sell.bids[j].amount
+			}
+		}
+	}
+	if amount <= 0 {
+		return Opportunity{}, false
+	}
+
+	o := Opportunity{
+		BuyExchange:  buy.exchange.GetName(),
+		BuyPair:      buy.pair,
+		SellExchange: sell.exchange.GetName(),
+		SellPair:     sell.pair,
+		Currency:     buy.pair.SecondCurrency.Upper().String(),
+		BuyPrice:     cost / amount,
+		SellPrice:    proceeds / amount,
+		Amount:       amount,
+		Cost:         cost,
+		Proceeds:     proceeds,
+		TradingFees:  cost*buyFee + proceeds*sellFee,
+	}
+
+	if s.cfg.IncludeWithdrawalFees {
+		withdrawalFee, err := s.getWithdrawalFee(buy.exchange, buy.pair.FirstCurrency.String(), amount)
+		if err != nil {
+			return Opportunity{}, false
+		}
+		o.WithdrawalFee = withdrawalFee * o.SellPrice
+	}
+
+	o.NetProfit = o.Proceeds - o.Cost - o.TradingFees - o.WithdrawalFee
+	o.NetProfitPercent = o.NetProfit / o.Cost * 100
+	if o.NetProfit <= 0 || o.NetProfitPercent < s.cfg.MinProfitPercent {
+		return Opportunity{}, false
+	}
+	return o, true
+}
+
+// getRate returns the rate to convert a price quoted in from into to
+func (s *Scanner) getRate(from, to string) (float64, error) {
+	from = common.StringToUpper(from)
+	to = common.StringToUpper(to)
+	if from == to || (isBitcoin(from) && isBitcoin(to)) ||
+		(s.cfg.IncludeUSDT && isDollar(from) && isDollar(to)) {
+		return 1, nil
+	}
+	return s.convert(1, from, to)
+}
+
+func isBitcoin(c string) bool {
+	return c == "BTC" || c == "XBT"
+}
+
+func isDollar(c string) bool {
+	return c == "USD" || c == "USDT"
+}
+
+// getTradeFee returns the taker fee of a market as a fraction of the traded
+// value, falling back to the configured default fee
+func (s *Scanner) getTradeFee(m *market) float64 {
+	value, err := s.getFee(m.exchange.GetName()+"_trade_"+m.pair.Pair().String(), func() (float64, error) {
+		return m.exchange.GetFeeByType(exchange.FeeBuilder{
+			FeeType:        exchange.CryptocurrencyTradeFee,
+			FirstCurrency:  m.pair.FirstCurrency.String(),
+			SecondCurrency: m.pair.SecondCurrency.String(),
+			Delimiter:      m.pair.Delimiter,
+			PurchasePrice:  1,
+			Amount:         1,
+		})
+	})
+	if err != nil {
+		return s.cfg.DefaultTradeFee / 100
+	}
+	return value
+}
+
+// getWithdrawalFee returns the fee in the withdrawn currency for withdrawing
+// from an exchange. Withdrawal fees are assumed to be fixed so the first fee
+// returned is cached regardless of amount
+func (s *Scanner) getWithdrawalFee(exch exchange.IBotExchange, currency string, amount float64) (float64, error) {
+	return s.getFee(exch.GetName()+"_withdrawal_"+currency, func() (float64, error) {
+		return exch.GetFeeByType(exchange.FeeBuilder{
+			FeeType:       exchange.CryptocurrencyWithdrawalFee,
+			FirstCurrency: currency,
+			CurrencyItem:  currency,
+			Amount:        amount,
+		})
+	})
+}
+
+// getFee returns a cached fee, fetching it when missing or stale
+func (s *Scanner) getFee(key string, fetch func() (float64, error)) (float64, error) {
+	s.mtx.Lock()
+	f, ok := s.fees[key]
+	s.mtx.Unlock()
+	if ok && time.Since(f.updated) < feeCacheDuration {
+		return f.value, f.err
+	}
+
+	value, err := fetch()
+	s.mtx.Lock()
+	s.fees[key] = fee{value: value, err: err, updated: time.Now()}
+	s.mtx.Unlock()
+	return value, err
+}
+
+// publish stores the result of a scan, passes it to the handler and reports
+// opportunities which were not found by the previous scan
+func (s *Scanner) publish(opportunities []Opportunity) {
+	s.mtx.Lock()
+	previous := s.opportunities
+	s.opportunities = opportunities
+	notifier := s.notifier
+	handler := s.handler
+	s.mtx.Unlock()
+
+	if handler != nil && len(opportunities) > 0 {
+		handler(opportunities)
+	}
+
+	for i := range opportunities {
+		if !contains(previous, &opportunities[i]) {
+			notify(notifier, eventType, opportunities[i].String())
+		}
+	}
+}
+
+func notify(n Notifier, eventType, message string) {
+	log.Printf("Arbitrage: %s", message)
+	if n != nil {
+		n.PushEvent(base.Event{Type: eventType, TradeDetails: message})
+	}
+}
+
+// contains returns whether an opportunity for the same markets is in the list
+func contains(opportunities []Opportunity, o *Opportunity) bool {
+	for i := range opportunities {
+		if opportunities[i].BuyExchange == o.BuyExchange &&
+			opportunities[i].SellExchange == o.SellExchange &&
+			opportunities[i].BuyPair.Equal(o.BuyPair, true) &&
+			opportunities[i].SellPair.Equal(o.SellPair, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the opportunity
+func (o *Opportunity) String() string {
+	return fmt.Sprintf("buy %f %s on %s at %f, sell on %s %s at %f for a net profit of %f %s (%.2f%%)",
+		o.Amount, o.BuyPair.Pair(), o.BuyExchange, o.BuyPrice, o.SellExchange,
+		o.SellPair.Pair(), o.SellPrice, o.NetProfit, o.Currency, o.NetProfitPercent)
+}
@@ -0,0 +1,216 @@
+package arbitrage
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+var (
+	btcusd = pair.NewCurrencyPair("BTC", "USD")
+	btceur = pair.NewCurrencyPair("BTC", "EUR")
+)
+
+// testFees counts the fee requests made to a test exchange and fails them
+// with err when set
+type testFees struct {
+	calls int
+	err   error
+}
+
+// newTestExchange returns an exchange charging tradeFee on trades and 0.01 on
+// withdrawals
+func newTestExchange(name string, tradeFee float64, pairs ...pair.CurrencyPair) (*exchangetest.Exchange, *testFees) {
+	e := exchangetest.New(name, pairs...)
+	fees := &testFees{}
+	e.GetFeeByTypeFunc = func(feeBuilder exchange.FeeBuilder) (float64, error) {
+		fees.calls++
+		if fees.err != nil {
+			return 0, fees.err
+		}
+		if feeBuilder.FeeType == exchange.CryptocurrencyWithdrawalFee {
+			return 0.01, nil
+		}
+		return tradeFee * feeBuilder.PurchasePrice * feeBuilder.Amount, nil
+	}
+	return e, fees
+}
+
+// testNotifier records the events pushed to it
+type testNotifier struct {
+	events []base.Event
+	mtx    sync.Mutex
+}
+
+func (n *testNotifier) PushEvent(event base.Event) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.events = append(n.events, event)
+}
+
+func relatable(p1, p2 pair.CurrencyPair, includeUSDT bool) bool {
+	return p1.FirstCurrency == p2.FirstCurrency
+}
+
+func convert(amount float64, from, to string) (float64, error) {
+	switch {
+	case from == "EUR" && to == "USD":
+		return amount * 1.1, nil
+	case from == "USD" && to == "EUR":
+		return amount / 1.1, nil
+	}
+	return 0, errors.New("unsupported conversion")
+}
+
+func isClose(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// newTestExchanges seeds the orderbook cache so that buying BTC with USD on
+// the first exchange and selling it for EUR on the second is profitable
+func newTestExchanges(prefix string) (buy, sell *exchangetest.Exchange, buyFees, sellFees *testFees) {
+	buy, buyFees = newTestExchange(prefix+"USD", 0.001, btcusd)
+	sell, sellFees = newTestExchange(prefix+"EUR", 0.002, btceur)
+
+	orderbook.ProcessOrderbook(buy.Name, btcusd, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 101, Amount: 1}, {Price: 100, Amount: 1}},
+	}, orderbook.Spot)
+	orderbook.ProcessOrderbook(sell.Name, btceur, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 100, Amount: 0.5}, {Price: 95, Amount: 2}},
+		Asks: []orderbook.Item{{Price: 120, Amount: 1}},
+	}, orderbook.Spot)
+	return buy, sell, buyFees, sellFees
+}
+
+func TestScan(t *testing.T) {
+	buy, sell, buyFees, sellFees := newTestExchanges("ArbScan")
+	s := New(config.ArbitrageConfig{IncludeWithdrawalFees: true},
+		[]exchange.IBotExchange{buy, sell}, relatable, convert)
+	n := &testNotifier{}
+	s.SetComms(n)
+	var handled []Opportunity
+	s.SetHandler(func(o []Opportunity) { handled = o })
+
+	result := s.Scan()
+	if len(result) != 1 {
+		t.Fatalf("Test failed. Expected 1 opportunity got %d", len(result))
+	}
+
+	// both asks are bought and 1.5 of the EUR bids, converted at 1.1, are
+	// sold with the 0.01 BTC withdrawal fee valued at the sell price
+	o := result[0]
+	if o.BuyExchange != buy.Name || o.SellExchange != sell.Name || o.Currency != "USD" {
+		t.Errorf("Test failed. Unexpected opportunity markets %+v", o)
+	}
+	if !isClose(o.Amount, 2) || !isClose(o.Cost, 201) || !isClose(o.Proceeds, 211.75) {
+		t.Errorf("Test failed. Unexpected opportunity size %+v", o)
+	}
+	if !isClose(o.TradingFees, 0.6245) || !isClose(o.WithdrawalFee, 1.05875) {
+		t.Errorf("Test failed. Unexpected opportunity fees %+v", o)
+	}
+	if !isClose(o.NetProfit, 9.06675) || !isClose(o.NetProfitPercent, 9.06675/201*100) {
+		t.Errorf("Test failed. Unexpected opportunity profit %+v", o)
+	}
+
+	if len(handled) != 1 || len(s.GetOpportunities()) != 1 {
+		t.Error("Test failed. Expected opportunities to be published")
+	}
+
+	s.Scan()
+	if len(n.events) != 1 || n.events[0].Type != eventType {
+		t.Errorf("Test failed. Expected a single event for a repeated opportunity got %v", n.events)
+	}
+	if buyFees.calls != 2 || sellFees.calls != 1 {
+		t.Errorf("Test failed. Expected fees to be cached got %d %d calls",
+			buyFees.calls, sellFees.calls)
+	}
+}
+
+func TestScanFilters(t *testing.T) {
+	buy, sell, buyFees, sellFees := newTestExchanges("ArbFilter")
+	exchanges := []exchange.IBotExchange{buy, sell}
+
+	s := New(config.ArbitrageConfig{MinProfitPercent: 6}, exchanges, relatable, convert)
+	if len(s.Scan()) != 0 {
+		t.Error("Test failed. Expected opportunity below minimum profit to be ignored")
+	}
+
+	s = New(config.ArbitrageConfig{Pairs: "LTC-USD"}, exchanges, relatable, convert)
+	if len(s.Scan()) != 0 {
+		t.Error("Test failed. Expected pairs not being scanned to be ignored")
+	}
+
+	// the fallback fee makes the 95 EUR bid unprofitable
+	sellFees.err = errors.New("fee unavailable")
+	s = New(config.ArbitrageConfig{DefaultTradeFee: 5}, exchanges, relatable, convert)
+	result := s.Scan()
+	if len(result) != 1 || !isClose(result[0].Amount, 0.5) {
+		t.Errorf("Test failed. Expected default fee to limit size got %+v", result)
+	}
+
+	s = New(config.ArbitrageConfig{IncludeWithdrawalFees: true}, exchanges, relatable, convert)
+	buyFees.err = sellFees.err
+	if len(s.Scan()) != 0 {
+		t.Error("Test failed. Expected opportunity with unknown withdrawal fee to be ignored")
+	}
+}
+
+func TestGetRate(t *testing.T) {
+	s := New(config.ArbitrageConfig{}, nil, relatable, convert)
+	if rate, err := s.getRate("xbt", "BTC"); err != nil || rate != 1 {
+		t.Errorf("Test failed. Expected XBT to equal BTC got %f %v", rate, err)
+	}
+	if _, err := s.getRate("USDT", "USD"); err == nil {
+		t.Error("Test failed. Expected USDT to require conversion")
+	}
+	if rate, err := s.getRate("EUR", "USD"); err != nil || rate != 1.1 {
+		t.Errorf("Test failed. Expected EUR conversion got %f %v", rate, err)
+	}
+
+	s.cfg.IncludeUSDT = true
+	if rate, err := s.getRate("USDT", "USD"); err != nil || rate != 1 {
+		t.Errorf("Test failed. Expected USDT to equal USD got %f %v", rate, err)
+	}
+}
+
+func TestStartStop(t *testing.T) {
+	buy, sell, _, _ := newTestExchanges("ArbStart")
+	s := New(config.ArbitrageConfig{Enabled: true, ScanInterval: time.Millisecond},
+		[]exchange.IBotExchange{buy, sell}, relatable, convert)
+	found := make(chan []Opportunity, 1)
+	s.SetHandler(func(o []Opportunity) {
+		select {
+		case found <- o:
+		default:
+		}
+	})
+
+	err := s.Start()
+	if err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+	if s.Start() != ErrScannerAlreadyStarted {
+		t.Error("Test failed. Expected ErrScannerAlreadyStarted")
+	}
+
+	select {
+	case o := <-found:
+		if len(o) != 1 {
+			t.Errorf("Test failed. Expected 1 opportunity got %d", len(o))
+		}
+	case <-time.After(time.Second * 5):
+		t.Error("Test failed. Timed out waiting for scan")
+	}
+	s.Stop()
+	s.Stop()
+}
@@ -0,0 +1,132 @@
+package arbitrage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Opportunity holds a currency bought on one exchange and sold on another for
+// a profit after fees. Prices and amounts on the sell side are converted into
+// the quote currency of the buy side
+type Opportunity struct {
+	Time         time.Time         `json:"time"`
+	BuyExchange  string            `json:"buyExchange"`
+	BuyPair      pair.CurrencyPair `json:"buyPair"`
+	SellExchange string            `json:"sellExchange"`
+	SellPair     pair.CurrencyPair `json:"sellPair"`
+	// Currency is the quote currency of the buy side that all values are
+	// denominated in
+	Currency string `json:"currency"`
+	// BuyPrice and SellPrice are the volume weighted prices for Amount
+	BuyPrice  float64 `json:"buyPrice"`
+	SellPrice float64 `json:"sellPrice"`
+	// Amount is the executable size in the base currency
+	Amount           float64 `json:"amount"`
+	Cost             float64 `json:"cost"`
+	Proceeds         float64 `json:"proceeds"`
+	TradingFees      float64 `json:"tradingFees"`
+	WithdrawalFee    float64 `json:"withdrawalFee"`
+	NetProfit        float64 `json:"netProfit"`
+	NetProfitPercent float64 `json:"netProfitPercent"`
+}
+
+// TriangularOpportunity holds a profitable cycle of three trades on a single
+// exchange which starts and ends in the same currency
+type TriangularOpportunity struct {
+	Time     time.Time `json:"time"`
+	Exchange string    `json:"exchange"`
+	// Currency is the currency the cycle starts and ends in
+	Currency string `json:"currency"`
+	Legs     []Leg  `json:"legs"`
+	// Amount is the amount of Currency traded by the first leg and Return
+	// the amount received by the last leg after fees
+	Amount           float64 `json:"amount"`
+	Return           float64 `json:"return"`
+	NetProfit        float64 `json:"netProfit"`
+	NetProfitPercent float64 `json:"netProfitPercent"`
+}
+
+// Leg is an order placed as part of a triangular arbitrage cycle
+type Leg struct {
+	Pair pair.CurrencyPair  `json:"pair"`
+	Side exchange.OrderSide `json:"side"`
+	// Price is the worst price reached, which fills Amount when used as the
+	// limit price
+	Price float64 `json:"price"`
+	// Amount is in the base currency of the pair
+	Amount float64 `json:"amount"`
+}
+
+// RelatableFunc reports whether two currency pairs represent the same market,
+// for example BTC-USD and XBT-USD
+type RelatableFunc func(p1, p2 pair.CurrencyPair, includeUSDT bool) bool
+
+// ConvertFunc converts an amount between two fiat currencies
+type ConvertFunc func(amount float64, from, to string) (float64, error)
+
+// Notifier is implemented by communications.Communications and receives newly
+// found opportunities
+type Notifier interface {
+	PushEvent(event base.Event)
+}
+
+// Scanner periodically walks the orderbook cache of its exchanges looking for
+// arbitrage opportunities
+type Scanner struct {
+	cfg                     config.ArbitrageConfig
+	exchanges               []exchange.IBotExchange
+	relatable               RelatableFunc
+	convert                 ConvertFunc
+	notifier                Notifier
+	handler                 func([]Opportunity)
+	triangularHandler       func([]TriangularOpportunity)
+	fees                    map[string]fee
+	opportunities           []Opportunity
+	triangularOpportunities []TriangularOpportunity
+	shutdown                chan struct{}
+	wg                      sync.WaitGroup
+	mtx                     sync.Mutex
+}
+
+// market is a cached orderbook for an exchange currency pair
+type market struct {
+	exchange exchange.IBotExchange
+	pair     pair.CurrencyPair
+	bids     []level
+	asks     []level
+}
+
+// level is a price level with its price converted into a common quote
+// currency
+type level struct {
+	price  float64
+	amount float64
+}
+
+// edge is a trade converting one currency into another on a market
+type edge struct {
+	to     string
+	market *market
+	side   exchange.OrderSide
+}
+
+// conversion is a price level of a leg expressed in the currency given to
+// the leg. Capacity is the amount of that currency the level can take and
+// rate the amount of the received currency returned for each unit after fees
+type conversion struct {
+	price    float64
+	capacity float64
+	rate     float64
+}
+
+// fee is a cached exchange fee
+type fee struct {
+	value   float64
+	err     error
+	updated time.Time
+}
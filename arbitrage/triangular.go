@@ -0,0 +1,278 @@
+package arbitrage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// SetTriangularHandler sets a function which receives the cycles found by
+// every triangular scan which finds at least one
+func (s *Scanner) SetTriangularHandler(h func([]TriangularOpportunity)) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.triangularHandler = h
+}
+
+// GetTriangularOpportunities returns the cycles found by the last triangular
+// scan
+func (s *Scanner) GetTriangularOpportunities() []TriangularOpportunity {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]TriangularOpportunity(nil), s.triangularOpportunities...)
+}
+
+// ScanTriangular searches the cached orderbooks of each exchange for cycles
+// of three trades which return more of the starting currency than they use,
+// net of the exchange's trading fees. Cycles are returned ordered by net
+// profit percentage
+func (s *Scanner) ScanTriangular() []TriangularOpportunity {
+	var names, currencies []string
+	if s.cfg.Triangular.Exchanges != "" {
+		names = common.SplitStrings(common.StringToLower(s.cfg.Triangular.Exchanges), ",")
+	}
+	if s.cfg.Triangular.Currencies != "" {
+		currencies = common.SplitStrings(common.StringToUpper(s.cfg.Triangular.Currencies), ",")
+	}
+
+	var opportunities []TriangularOpportunity
+	for _, exch := range s.exchanges {
+		if len(names) > 0 && !common.StringDataCompare(names, common.StringToLower(exch.GetName())) {
+			continue
+		}
+
+		var markets []market
+		for _, p := range exch.GetEnabledCurrencies() {
+			ob, err := orderbook.GetOrderbook(exch.GetName(), p, orderbook.Spot)
+			if err != nil {
+				continue
+			}
+			if m, ok := newMarket(exch, p, ob); ok {
+				markets = append(markets, m)
+			}
+		}
+
+		opportunities = append(opportunities, findCycles(exch.GetName(), markets,
+			s.getTradeFee, currencies, 0, s.cfg.Triangular.MinProfitPercent)...)
+	}
+
+	sort.Slice(opportunities, func(i, j int) bool {
+		return opportunities[i].NetProfitPercent > opportunities[j].NetProfitPercent
+	})
+	s.publishTriangular(opportunities)
+	return opportunities
+}
+
+// findCycles returns the profitable cycles of three trades between the
+// markets of an exchange. Cycles only start from the given currencies, or are
+// returned once starting from their alphabetically first currency when none
+// are given. The amount traded is limited to maxAmount of the starting
+// currency when it is above zero
+func findCycles(exchangeName string, markets []market, tradeFee func(*market) float64, currencies []string, maxAmount, minProfitPercent float64) []TriangularOpportunity {
+	graph := make(map[string][]edge)
+	for i := range markets {
+		m := &markets[i]
+		base := m.pair.FirstCurrency.Upper().String()
+		quote := m.pair.SecondCurrency.Upper().String()
+		graph[quote] = append(graph[quote], edge{to: base, market: m, side: exchange.Buy})
+		graph[base] = append(graph[base], edge{to: quote, market: m, side: exchange.Sell})
+	}
+
+	var starts []string
+	for c := range graph {
+		if len(currencies) == 0 || common.StringDataCompare(currencies, c) {
+			starts = append(starts, c)
+		}
+	}
+	sort.Strings(starts)
+
+	now := time.Now()
+	var opportunities []TriangularOpportunity
+	for _, a := range starts {
+		for _, first := range graph[a] {
+			b := first.to
+			if len(currencies) == 0 && b < a {
+				continue
+			}
+			for _, second := range graph[b] {
+				c := second.to
+				if c == a || second.market == first.market ||
+					(len(currencies) == 0 && c < a) {
+					continue
+				}
+				for _, third := range graph[c] {
+					if third.to != a || third.market == first.market || third.market == second.market {
+						continue
+					}
+					o, ok := sizeCycle([]edge{first, second, third}, tradeFee, maxAmount)
+					if !ok {
+						continue
+					}
+					o.Time = now
+					o.Exchange = exchangeName
+					o.Currency = a
+					if o.NetProfitPercent >= minProfitPercent {
+						opportunities = append(opportunities, o)
+					}
+				}
+			}
+		}
+	}
+	return opportunities
+}
+
+// getConversions returns the levels of the orderbook side traded by an edge
+// in the currency given to it
+func getConversions(e edge, fee float64) []conversion {
+	var conversions []conversion
+	if e.side == exchange.Buy {
+		for _, a := range e.market.asks {
+			if a.price <= 0 {
+				continue
+			}
+			conversions = append(conversions, conversion{
+				price:    a.price,
+				capacity: a.price * a.amount,
+				rate:     (1 - fee) / a.price,
+			})
+		}
+		return conversions
+	}
+	for _, b := range e.market.bids {
+		conversions = append(conversions, conversion{
+			price:    b.price,
+			capacity: b.amount,
+			rate:     b.price * (1 - fee),
+		})
+	}
+	return conversions
+}
+
+// sizeCycle passes the starting currency through each leg of the cycle for as
+// long as the combined rate of the levels being traded returns more than is
+// given to the first leg
+func sizeCycle(edges []edge, tradeFee func(*market) float64, maxAmount float64) (TriangularOpportunity, bool) {
+	levels := make([][]conversion, len(edges))
+	index := make([]int, len(edges))
+	remaining := make([]float64, len(edges))
+	legs := make([]Leg, len(edges))
+	for i := range edges {
+		levels[i] = getConversions(edges[i], tradeFee(edges[i].market))
+		if len(levels[i]) == 0 {
+			return TriangularOpportunity{}, false
+		}
+		remaining[i] = levels[i][0].capacity
+		legs[i] = Leg{Pair: edges[i].market.pair, Side: edges[i].side}
+	}
+
+	var amount, received float64
+	for {
+		// the amount of the starting currency which exhausts the first level
+		// to run out and the combined rate until then
+		step, rate := math.Inf(1), 1.0
+		for i := range levels {
+			if index[i] >= len(levels[i]) {
+				step = 0
+				break
+			}
+			step = math.Min(step, remaining[i]/rate)
+			rate *= levels[i][index[i]].rate
+		}
+		if maxAmount > 0 {
+			step = math.Min(step, maxAmount-amount)
+		}
+		if step <= 0 || rate <= 1 {
+			break
+		}
+
+		in := step
+		for i := range levels {
+			c := levels[i][index[i]]
+			if legs[i].Side == exchange.Buy {
+				legs[i].Amount += in / c.price
+			} else {
+				legs[i].Amount += in
+			}
+			legs[i].Price = c.price
+			remaining[i] -= in
+			in *= c.rate
+			if remaining[i] <= c.capacity*1e-9 {
+				index[i]++
+				if index[i] < len(levels[i]) {
+					remaining[i] = levels[i][index[i]].capacity
+				}
+			}
+		}
+		amount += step
+		received += in
+	}
+	if amount <= 0 {
+		return TriangularOpportunity{}, false
+	}
+
+	return TriangularOpportunity{
+		Legs:             legs,
+		Amount:           amount,
+		Return:           received,
+		NetProfit:        received - amount,
+		NetProfitPercent: (received - amount) / amount * 100,
+	}, true
+}
+
+// publishTriangular stores the result of a triangular scan, passes it to the
+// handler and reports cycles which were not found by the previous scan
+func (s *Scanner) publishTriangular(opportunities []TriangularOpportunity) {
+	s.mtx.Lock()
+	previous := s.triangularOpportunities
+	s.triangularOpportunities = opportunities
+	notifier := s.notifier
+	handler := s.triangularHandler
+	s.mtx.Unlock()
+
+	if handler != nil && len(opportunities) > 0 {
+		handler(opportunities)
+	}
+
+	for i := range opportunities {
+		if !containsCycle(previous, &opportunities[i]) {
+			notify(notifier, triangularEventType, opportunities[i].String())
+		}
+	}
+}
+
+// containsCycle returns whether the same cycle is in the list
+func containsCycle(opportunities []TriangularOpportunity, o *TriangularOpportunity) bool {
+	for i := range opportunities {
+		if opportunities[i].Exchange != o.Exchange || len(opportunities[i].Legs) != len(o.Legs) {
+			continue
+		}
+		match := true
+		for j := range o.Legs {
+			if opportunities[i].Legs[j].Side != o.Legs[j].Side ||
+				!opportunities[i].Legs[j].Pair.Equal(o.Legs[j].Pair, true) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the cycle
+func (o *TriangularOpportunity) String() string {
+	legs := make([]string, len(o.Legs))
+	for i := range o.Legs {
+		legs[i] = fmt.Sprintf("%s %f %s at %f", o.Legs[i].Side, o.Legs[i].Amount,
+			o.Legs[i].Pair.Pair(), o.Legs[i].Price)
+	}
+	return fmt.Sprintf("%s: %s for a net profit of %f %s (%.2f%%)", o.Exchange,
+		common.JoinStrings(legs, ", "), o.NetProfit, o.Currency, o.NetProfitPercent)
+}
@@ -0,0 +1,324 @@
+package arbitrage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/strategy"
+)
+
+// TriangularStrategyName is the name the triangular arbitrage strategy is
+// registered under
+const TriangularStrategyName = "triangular_arbitrage"
+
+const (
+	// defaultCooldown is the time the triangular strategy waits after
+	// executing a cycle before looking for the next so that the orderbook
+	// cache can catch up with its own trades
+	defaultCooldown = time.Second * 10
+
+	// fillConfirmAttempts is the number of times the state of a leg is looked
+	// up before it is treated as failed, waiting fillConfirmInterval between
+	// lookups
+	fillConfirmAttempts = 5
+	fillConfirmInterval = time.Millisecond * 200
+)
+
+// vars related to the triangular arbitrage strategy
+var (
+	ErrCurrencyNotSet  = errors.New("triangular arbitrage strategy currency not set")
+	ErrMaxAmountNotSet = errors.New("triangular arbitrage strategy max amount must be above zero")
+	ErrLegNotPlaced    = errors.New("leg was not placed by the exchange")
+	ErrLegNotClosed    = errors.New("leg is still open on the exchange")
+)
+
+// TriangularParams are the parameters of the triangular arbitrage strategy
+type TriangularParams struct {
+	// Currency is the currency held which each cycle starts and ends in
+	Currency string `json:"currency"`
+	// MaxAmount is the most of Currency given to the first leg of a cycle
+	MaxAmount float64 `json:"maxAmount"`
+	// TradeFee is the exchange's taker fee percentage, the fee of each pair
+	// is fetched from the exchange when it is not set
+	TradeFee         float64       `json:"tradeFee"`
+	MinProfitPercent float64       `json:"minProfitPercent"`
+	Cooldown         time.Duration `json:"cooldown"`
+}
+
+// triangularStrategy executes the most profitable cycle found whenever an
+// orderbook on one of its exchanges is updated
+type triangularStrategy struct {
+	params   TriangularParams
+	executed map[string]time.Time
+	// fees caches the trade fee rate of each exchange pair fetched from the
+	// exchange
+	fees map[string]float64
+	// unsupported holds the exchanges which cannot submit immediate or cancel
+	// or fill or kill limit orders, cycles are not executed on them
+	unsupported map[string]bool
+}
+
+func init() {
+	err := strategy.Register(TriangularStrategyName, newTriangularStrategy)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newTriangularStrategy(params json.RawMessage) (strategy.Strategy, error) {
+	s := &triangularStrategy{
+		executed:    make(map[string]time.Time),
+		fees:        make(map[string]float64),
+		unsupported: make(map[string]bool),
+	}
+	if len(params) > 0 {
+		err := json.Unmarshal(params, &s.params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if s.params.Currency == "" {
+		return nil, ErrCurrencyNotSet
+	}
+	if s.params.MaxAmount <= 0 {
+		return nil, ErrMaxAmountNotSet
+	}
+	if s.params.Cooldown <= 0 {
+		s.params.Cooldown = defaultCooldown
+	}
+	s.params.Currency = common.StringToUpper(s.params.Currency)
+	return s, nil
+}
+
+// OnTicker is not used by the triangular strategy
+func (s *triangularStrategy) OnTicker(h *strategy.Handle, t strategy.Ticker) {}
+
+// OnOrderbook looks for a cycle on the exchange of the updated orderbook and
+// executes it
+func (s *triangularStrategy) OnOrderbook(h *strategy.Handle, ob strategy.Orderbook) {
+	if time.Since(s.executed[ob.Exchange]) < s.params.Cooldown {
+		return
+	}
+	tif, ok := s.getTimeInForce(h, ob.Exchange)
+	if !ok {
+		return
+	}
+
+	pairs, err := h.GetPairs(ob.Exchange)
+	if err != nil {
+		return
+	}
+	var markets []market
+	for _, p := range pairs {
+		// markets without a known fee are left out rather than assumed free
+		if _, err := s.getTradeFee(h, ob.Exchange, p); err != nil {
+			continue
+		}
+		book, err := h.GetOrderbook(ob.Exchange, p, ob.AssetType)
+		if err != nil {
+			continue
+		}
+		if m, ok := newMarket(nil, p, book); ok {
+			markets = append(markets, m)
+		}
+	}
+
+	cycles := findCycles(ob.Exchange, markets, func(m *market) float64 {
+		fee, _ := s.getTradeFee(h, ob.Exchange, m.pair)
+		return fee
+	}, []string{s.params.Currency}, s.params.MaxAmount, s.params.MinProfitPercent)
+	if len(cycles) == 0 {
+		return
+	}
+	best := cycles[0]
+	for i := range cycles {
+		if cycles[i].NetProfitPercent > best.NetProfitPercent {
+			best = cycles[i]
+		}
+	}
+
+	s.executed[ob.Exchange] = time.Now()
+	log.Printf("Strategy %s executing %s", h.GetName(), best.String())
+	s.execute(h, ob.Exchange, best, tif)
+}
+
+// getTimeInForce returns the time in force the legs of a cycle are submitted
+// with on an exchange, fill or kill when supported or else immediate or
+// cancel. Legs must not rest on the book so exchanges supporting neither are
+// not traded
+func (s *triangularStrategy) getTimeInForce(h *strategy.Handle, exchangeName string) (exchange.TimeInForce, bool) {
+	switch {
+	case h.SupportsOrderSubmissionFeatures(exchangeName,
+		exchange.LimitOrderSupport|exchange.FillOrKillOrderSupport):
+		return exchange.FOK, true
+	case h.SupportsOrderSubmissionFeatures(exchangeName,
+		exchange.LimitOrderSupport|exchange.ImmediateOrCancelOrderSupport):
+		return exchange.IOC, true
+	}
+	if !s.unsupported[exchangeName] {
+		s.unsupported[exchangeName] = true
+		log.Printf("Strategy %s: %s does not support immediate or cancel or fill or kill limit orders, cycles will not be executed",
+			h.GetName(), exchangeName)
+	}
+	return "", false
+}
+
+// execute submits the legs of a cycle one at a time. The fill of each leg is
+// confirmed before the next leg is submitted, scaled to the amount received,
+// and the filled legs are unwound if a leg fails to fill
+func (s *triangularStrategy) execute(h *strategy.Handle, exchangeName string, cycle TriangularOpportunity, tif exchange.TimeInForce) {
+	var filled []Leg
+	ratio := 1.0
+	for _, leg := range cycle.Legs {
+		planned := leg.Amount
+		leg.Amount *= ratio
+		executed, err := s.submitLeg(h, exchangeName, leg, tif)
+		if err != nil || executed <= 0 {
+			log.Printf("Strategy %s %s %s leg did not fill, unwinding %d filled legs. Err: %v",
+				h.GetName(), leg.Side, leg.Pair.Pair(), len(filled), err)
+			s.unwind(h, exchangeName, filled)
+			return
+		}
+		if executed > leg.Amount {
+			executed = leg.Amount
+		}
+		leg.Amount = executed
+		filled = append(filled, leg)
+		ratio = executed / planned
+	}
+	log.Printf("Strategy %s executed cycle on %s with %.2f%% of the planned amount",
+		h.GetName(), exchangeName, ratio*100)
+}
+
+// submitLeg submits a leg as a limit order with the supplied time in force and
+// returns the amount executed once the order has closed
+func (s *triangularStrategy) submitLeg(h *strategy.Handle, exchangeName string, leg Leg, tif exchange.TimeInForce) (float64, error) {
+	resp, err := h.SubmitOrder(exchangeName, &exchange.OrderSubmission{
+		CurrencyPair: leg.Pair,
+		OrderSide:    leg.Side,
+		OrderType:    exchange.Limit,
+		Price:        leg.Price,
+		Amount:       leg.Amount,
+		TimeInForce:  tif,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !resp.IsOrderPlaced {
+		return 0, ErrLegNotPlaced
+	}
+	return s.confirmFill(h, exchangeName, resp.OrderID, leg.Pair)
+}
+
+// confirmFill looks up a placed order until it has closed and returns its
+// executed amount. An order still open after every attempt is cancelled so
+// that it cannot fill after the cycle has been unwound
+func (s *triangularStrategy) confirmFill(h *strategy.Handle, exchangeName, orderID string, p pair.CurrencyPair) (float64, error) {
+	var detail exchange.OrderDetail
+	var err error
+	for i := 0; i < fillConfirmAttempts; i++ {
+		if i > 0 {
+			time.Sleep(fillConfirmInterval)
+		}
+		detail, err = h.GetOrder(exchangeName, orderID, p)
+		if err != nil {
+			continue
+		}
+		switch exchange.FormatOrderStatus(detail.Status) {
+		case exchange.FilledOrderStatus, exchange.CancelledOrderStatus, exchange.RejectedOrderStatus:
+			return detail.ExecutedAmount, nil
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	err = h.CancelOrder(exchangeName, exchange.OrderCancellation{
+		OrderID:      orderID,
+		CurrencyPair: p,
+		Side:         detail.OrderSide,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s and could not be cancelled: %s", ErrLegNotClosed, err)
+	}
+	// the order may have filled further before it was cancelled
+	if closed, err := h.GetOrder(exchangeName, orderID, p); err == nil {
+		detail = closed
+	}
+	return detail.ExecutedAmount, nil
+}
+
+// unwind reverses the filled legs of a cycle, most recent first, with market
+// orders so that the strategy returns to holding the cycle's currency
+func (s *triangularStrategy) unwind(h *strategy.Handle, exchangeName string, filled []Leg) {
+	if len(filled) == 0 {
+		return
+	}
+	if !h.SupportsOrderSubmissionFeatures(exchangeName, exchange.MarketOrderSupport) {
+		log.Printf("Strategy %s: %s does not support market orders, %d filled legs must be unwound manually",
+			h.GetName(), exchangeName, len(filled))
+		return
+	}
+
+	for i := len(filled) - 1; i >= 0; i-- {
+		leg := filled[i]
+		side := exchange.Sell
+		if leg.Side == exchange.Sell {
+			side = exchange.Buy
+		}
+		resp, err := h.SubmitOrder(exchangeName, &exchange.OrderSubmission{
+			CurrencyPair: leg.Pair,
+			OrderSide:    side,
+			OrderType:    exchange.Market,
+			Amount:       leg.Amount,
+		})
+		if err == nil && !resp.IsOrderPlaced {
+			err = ErrLegNotPlaced
+		}
+		if err != nil {
+			log.Printf("Strategy %s failed to unwind %s %s leg, %d legs must be unwound manually. Err: %s",
+				h.GetName(), leg.Side, leg.Pair.Pair(), i+1, err)
+			return
+		}
+	}
+}
+
+// getTradeFee returns the trade fee rate of an exchange pair, the configured
+// TradeFee when set or else the exchange's fee which is cached once fetched
+func (s *triangularStrategy) getTradeFee(h *strategy.Handle, exchangeName string, p pair.CurrencyPair) (float64, error) {
+	if s.params.TradeFee > 0 {
+		return s.params.TradeFee / 100, nil
+	}
+	key := exchangeName + "_" + p.Pair().String()
+	if fee, ok := s.fees[key]; ok {
+		return fee, nil
+	}
+	fee, err := h.GetFeeByType(exchangeName, exchange.FeeBuilder{
+		FeeType:        exchange.CryptocurrencyTradeFee,
+		FirstCurrency:  p.FirstCurrency.String(),
+		SecondCurrency: p.SecondCurrency.String(),
+		Delimiter:      p.Delimiter,
+		PurchasePrice:  1,
+		Amount:         1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.fees[key] = fee
+	return fee, nil
+}
+
+// OnTrade is not used by the triangular strategy
+func (s *triangularStrategy) OnTrade(h *strategy.Handle, trade exchange.TradeData) {}
+
+// OnFill is not used by the triangular strategy
+func (s *triangularStrategy) OnFill(h *strategy.Handle, fill exchange.FillEvent) {}
+
+// OnTimer is not used by the triangular strategy
+func (s *triangularStrategy) OnTimer(h *strategy.Handle, t time.Time) {}
@@ -0,0 +1,237 @@
+package arbitrage
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/strategy"
+)
+
+var (
+	btcusdt = pair.NewCurrencyPair("BTC", "USDT")
+	ethbtc  = pair.NewCurrencyPair("ETH", "BTC")
+	ethusdt = pair.NewCurrencyPair("ETH", "USDT")
+)
+
+// newTriangularExchange seeds the orderbook cache so that trading USDT for
+// BTC, BTC for ETH and ETH back to USDT returns 10% before fees
+func newTriangularExchange(name string) *exchangetest.Exchange {
+	orderbook.ProcessOrderbook(name, btcusdt, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 100, Amount: 1}},
+	}, orderbook.Spot)
+	orderbook.ProcessOrderbook(name, ethbtc, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 0.049, Amount: 10}},
+		Asks: []orderbook.Item{{Price: 0.05, Amount: 10}},
+	}, orderbook.Spot)
+	orderbook.ProcessOrderbook(name, ethusdt, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 5.5, Amount: 5}},
+		Asks: []orderbook.Item{{Price: 5.6, Amount: 5}},
+	}, orderbook.Spot)
+	e, _ := newTestExchange(name, 0.001, btcusdt, ethbtc, ethusdt)
+	return e
+}
+
+func TestScanTriangular(t *testing.T) {
+	exch := newTriangularExchange("TriScan")
+	cfg := config.ArbitrageConfig{
+		Triangular: config.TriangularArbitrageConfig{Enabled: true, Currencies: "usdt"},
+	}
+	s := New(cfg, []exchange.IBotExchange{exch, exchangetest.New("TriOther")}, relatable, convert)
+	n := &testNotifier{}
+	s.SetComms(n)
+
+	result := s.ScanTriangular()
+	if len(result) != 1 {
+		t.Fatalf("Test failed. Expected 1 cycle got %d", len(result))
+	}
+
+	// the 5 ETH bid limits the cycle
+	o := result[0]
+	amount := 5 / (0.999 / 100 * 0.999 / 0.05)
+	if o.Exchange != "TriScan" || o.Currency != "USDT" || !isClose(o.Amount, amount) ||
+		!isClose(o.Return, 5*5.5*0.999) {
+		t.Errorf("Test failed. Unexpected cycle %+v", o)
+	}
+	if len(o.Legs) != 3 ||
+		o.Legs[0].Side != exchange.Buy || !o.Legs[0].Pair.Equal(btcusdt, true) ||
+		o.Legs[1].Side != exchange.Buy || !o.Legs[1].Pair.Equal(ethbtc, true) ||
+		o.Legs[2].Side != exchange.Sell || !o.Legs[2].Pair.Equal(ethusdt, true) {
+		t.Fatalf("Test failed. Unexpected cycle legs %+v", o.Legs)
+	}
+	if !isClose(o.Legs[0].Amount, amount/100) || !isClose(o.Legs[2].Amount, 5) ||
+		o.Legs[1].Price != 0.05 {
+		t.Errorf("Test failed. Unexpected cycle leg sizes %+v", o.Legs)
+	}
+
+	s.ScanTriangular()
+	if len(n.events) != 1 || n.events[0].Type != triangularEventType {
+		t.Errorf("Test failed. Expected a single event for a repeated cycle got %v", n.events)
+	}
+	if len(s.GetTriangularOpportunities()) != 1 {
+		t.Error("Test failed. Expected cycles to be stored")
+	}
+
+	// without starting currencies the cycle is reported once from BTC
+	s = New(config.ArbitrageConfig{}, []exchange.IBotExchange{exch}, relatable, convert)
+	result = s.ScanTriangular()
+	if len(result) != 1 || result[0].Currency != "BTC" {
+		t.Errorf("Test failed. Expected a single cycle from BTC got %+v", result)
+	}
+
+	s.cfg.Triangular.MinProfitPercent = 10
+	if len(s.ScanTriangular()) != 0 {
+		t.Error("Test failed. Expected cycle below minimum profit to be ignored")
+	}
+
+	s.cfg.Triangular.MinProfitPercent = 0
+	s.cfg.Triangular.Exchanges = "TriOther"
+	if len(s.ScanTriangular()) != 0 {
+		t.Error("Test failed. Expected exchanges not being scanned to be ignored")
+	}
+}
+
+func TestTriangularStrategy(t *testing.T) {
+	_, err := newTriangularStrategy(json.RawMessage(`{"maxAmount":1}`))
+	if err != ErrCurrencyNotSet {
+		t.Errorf("Test failed. Expected %s got %v", ErrCurrencyNotSet, err)
+	}
+	_, err = newTriangularStrategy(json.RawMessage(`{"currency":"USDT"}`))
+	if err != ErrMaxAmountNotSet {
+		t.Errorf("Test failed. Expected %s got %v", ErrMaxAmountNotSet, err)
+	}
+
+	exch := newTriangularExchange("TriStrategy")
+	exch.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.FillOrKillOrderSupport
+	exch.FillOrders = true
+	r := startTriangularStrategy(t, exch)
+	defer r.Stop()
+
+	r.ProcessOrderbook(exch.Name, orderbook.Spot, orderbook.Base{Pair: btcusdt})
+	r.ProcessOrderbook(exch.Name, orderbook.Spot, orderbook.Base{Pair: ethbtc})
+
+	submitted := waitForOrders(exch, 3)
+
+	// the second orderbook update is ignored during the cooldown
+	time.Sleep(time.Millisecond * 50)
+	if len(exch.Submitted()) != 3 {
+		t.Fatalf("Test failed. Expected 3 legs to be submitted got %d", len(exch.Submitted()))
+	}
+	if !isClose(submitted[0].Amount, 0.1) || submitted[0].Price != 100 ||
+		!isClose(submitted[1].Amount, 0.1*0.999/0.05) ||
+		submitted[2].OrderSide != exchange.Sell || !isClose(submitted[2].Amount, 0.1*0.999/0.05*0.999) {
+		t.Errorf("Test failed. Unexpected legs submitted %+v", submitted)
+	}
+	for i := range submitted {
+		if submitted[i].TimeInForce != exchange.FOK {
+			t.Errorf("Test failed. Expected leg %d to be fill or kill got %s", i, submitted[i].TimeInForce)
+		}
+	}
+}
+
+func TestTriangularStrategyUnwind(t *testing.T) {
+	exch := newTriangularExchange("TriUnwind")
+	exch.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport |
+		exchange.ImmediateOrCancelOrderSupport
+
+	// the first leg fills in half, the second is not filled
+	var submitted []exchange.OrderSubmission
+	var mtx sync.Mutex
+	exch.SubmitOrderFunc = func(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		submitted = append(submitted, *order)
+		detail := exchange.OrderDetail{
+			ID:             strconv.Itoa(len(submitted)),
+			CurrencyPair:   order.CurrencyPair,
+			OrderSide:      order.OrderSide,
+			Amount:         order.Amount,
+			ExecutedAmount: order.Amount,
+			Status:         exchangetest.StatusFilled,
+		}
+		switch len(submitted) {
+		case 1:
+			detail.ExecutedAmount = order.Amount / 2
+			detail.Status = exchangetest.StatusCancelled
+		case 2:
+			detail.ExecutedAmount = 0
+			detail.Status = exchangetest.StatusCancelled
+		}
+		exch.History = append(exch.History, detail)
+		return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: detail.ID}, nil
+	}
+	r := startTriangularStrategy(t, exch)
+
+	r.ProcessOrderbook(exch.Name, orderbook.Spot, orderbook.Base{Pair: btcusdt})
+	for start := time.Now(); time.Since(start) < time.Second*5; time.Sleep(time.Millisecond * 10) {
+		mtx.Lock()
+		count := len(submitted)
+		mtx.Unlock()
+		if count >= 3 {
+			break
+		}
+	}
+	r.Stop()
+
+	if len(submitted) != 3 {
+		t.Fatalf("Test failed. Expected 2 legs and 1 unwind order got %d", len(submitted))
+	}
+	if submitted[0].TimeInForce != exchange.IOC || !isClose(submitted[1].Amount, 0.05*0.999/0.05) {
+		t.Errorf("Test failed. Expected second leg scaled to the first leg's fill got %+v", submitted[1])
+	}
+	if submitted[2].OrderType != exchange.Market || submitted[2].OrderSide != exchange.Sell ||
+		!isClose(submitted[2].Amount, 0.05) || !submitted[2].CurrencyPair.Equal(btcusdt, true) {
+		t.Errorf("Test failed. Expected first leg to be unwound got %+v", submitted[2])
+	}
+}
+
+func TestTriangularStrategyUnsupported(t *testing.T) {
+	exch := newTriangularExchange("TriUnsupported")
+	exch.OrderSubmissionFeatures = exchange.LimitOrderSupport
+	r := startTriangularStrategy(t, exch)
+	defer r.Stop()
+
+	r.ProcessOrderbook(exch.Name, orderbook.Spot, orderbook.Base{Pair: btcusdt})
+	time.Sleep(time.Millisecond * 50)
+	if len(exch.Submitted()) != 0 {
+		t.Errorf("Test failed. Expected no legs without immediate or cancel support got %d", len(exch.Submitted()))
+	}
+}
+
+func startTriangularStrategy(t *testing.T, exch *exchangetest.Exchange) *strategy.Runtime {
+	r, err := strategy.New([]config.StrategyConfig{
+		{
+			Name:       TriangularStrategyName,
+			Enabled:    true,
+			Exchanges:  exch.Name,
+			Parameters: json.RawMessage(`{"currency":"usdt","maxAmount":10}`),
+		},
+	}, []exchange.IBotExchange{exch})
+	if err != nil {
+		t.Fatalf("Test failed. strategy.New error: %s", err)
+	}
+	err = r.Start()
+	if err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+	return r
+}
+
+func waitForOrders(exch *exchangetest.Exchange, count int) []exchange.OrderSubmission {
+	var submitted []exchange.OrderSubmission
+	for start := time.Now(); time.Since(start) < time.Second*5; time.Sleep(time.Millisecond * 10) {
+		submitted = exch.Submitted()
+		if len(submitted) >= count {
+			break
+		}
+	}
+	return submitted
+}
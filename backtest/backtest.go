@@ -0,0 +1,673 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/recorder"
+)
+
+// vars related to backtesting
+var (
+	ErrNoExchangeName      = errors.New("backtest exchange name not set")
+	ErrNoEvents            = errors.New("backtest has no events to replay")
+	ErrNoMarketData        = errors.New("no market data has been replayed to fill against")
+	ErrInsufficientFunds   = errors.New("insufficient funds to place order")
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrOrderNotOpen        = errors.New("order is not open")
+	ErrInvalidStartBalance = errors.New("starting balances must not be negative")
+)
+
+// supportedFeatures are the order types and options the simulator can fill
+const supportedFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+
+// NewConfigFromExchange returns a backtest config which replays data under the
+// exchange's name and charges the exchange's maker and taker fees
+func NewConfigFromExchange(b *exchange.Base, p pair.CurrencyPair, assetType string) Config {
+	return Config{
+		ExchangeName: b.Name,
+		Pair:         p,
+		AssetType:    assetType,
+		MakerFee:     b.MakerFee,
+		TakerFee:     b.TakerFee,
+	}
+}
+
+// NewEngine returns a backtest engine for the supplied config
+func NewEngine(cfg Config) (*Engine, error) {
+	if cfg.ExchangeName == "" {
+		return nil, ErrNoExchangeName
+	}
+	if cfg.Pair.Empty() {
+		return nil, exchange.ErrOrderSubmissionPairIsEmpty
+	}
+	if cfg.StartingBase < 0 || cfg.StartingQuote < 0 {
+		return nil, ErrInvalidStartBalance
+	}
+	if cfg.AssetType == "" {
+		cfg.AssetType = ticker.Spot
+	}
+	return &Engine{
+		cfg:   cfg,
+		base:  cfg.StartingBase,
+		quote: cfg.StartingQuote,
+	}, nil
+}
+
+// CandleEvents converts candles into replayable events
+func CandleEvents(candles []kline.Candle) []Event {
+	events := make([]Event, 0, len(candles))
+	for x := range candles {
+		events = append(events, Event{
+			Type:      CandleEvent,
+			Timestamp: candles[x].Time,
+			Candle:    candles[x],
+		})
+	}
+	return events
+}
+
+// TradeEvents converts trades into replayable events
+func TradeEvents(trades []exchange.TradeHistory) []Event {
+	events := make([]Event, 0, len(trades))
+	for x := range trades {
+		events = append(events, Event{
+			Type:      TradeEvent,
+			Timestamp: trades[x].Timestamp,
+			Trade:     trades[x],
+		})
+	}
+	return events
+}
+
+// OrderbookEvents converts recorded orderbook snapshots into replayable
+// events, each snapshot is replayed at its LastUpdated time
+func OrderbookEvents(books []orderbook.Base) []Event {
+	events := make([]Event, 0, len(books))
+	for x := range books {
+		events = append(events, Event{
+			Type:      OrderbookEvent,
+			Timestamp: books[x].LastUpdated,
+			Orderbook: books[x],
+		})
+	}
+	return events
+}
+
+// MergeEvents combines multiple event streams into a single stream ordered by
+// time
+func MergeEvents(streams ...[]Event) []Event {
+	var events []Event
+	for x := range streams {
+		events = append(events, streams[x]...)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events
+}
+
+// LoadCandles retrieves historic candles from an exchange as replayable events
+func LoadCandles(exch exchange.IBotExchange, p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]Event, error) {
+	item, err := exch.GetHistoricCandles(p, assetType, interval, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return CandleEvents(item.Candles), nil
+}
+
+// LoadTrades retrieves historic trades from an exchange as replayable events
+func LoadTrades(exch exchange.IBotExchange, p pair.CurrencyPair, assetType string, start, end time.Time) ([]Event, error) {
+	trades, err := exch.GetExchangeHistory(exchange.TradeHistoryRequest{
+		CurrencyPair: p,
+		AssetType:    assetType,
+		StartTime:    start,
+		EndTime:      end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return TradeEvents(trades), nil
+}
+
+// LoadRecording reads the exchange's recorded trades, klines and orderbooks for
+// the pair as replayable events
+func LoadRecording(dir, exchangeName string, p pair.CurrencyPair, start, end time.Time) ([]Event, error) {
+	reader, err := recorder.NewReader(dir, recorder.Filter{
+		Exchange: exchangeName,
+		Pair:     p,
+		Types: []recorder.RecordType{
+			recorder.TradeRecord,
+			recorder.KlineRecord,
+			recorder.OrderbookRecord,
+		},
+		StartTime: start,
+		EndTime:   end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return RecordEvents(records), nil
+}
+
+// RecordEvents converts recorded market data into replayable events, ticker
+// records carry no tradeable volume and are skipped
+func RecordEvents(records []recorder.Record) []Event {
+	var events []Event
+	for x := range records {
+		r := records[x]
+		switch {
+		case r.Type == recorder.TradeRecord && r.Trade != nil:
+			events = append(events, Event{
+				Type:      TradeEvent,
+				Timestamp: r.Timestamp,
+				Trade: exchange.TradeHistory{
+					Exchange:     r.Exchange,
+					CurrencyPair: r.Pair,
+					AssetType:    r.AssetType,
+					Side:         exchange.OrderSide(r.Trade.Side),
+					Price:        r.Trade.Price,
+					Amount:       r.Trade.Amount,
+					Timestamp:    r.Timestamp,
+				},
+			})
+		case r.Type == recorder.KlineRecord && r.Kline != nil:
+			events = append(events, Event{
+				Type:      CandleEvent,
+				Timestamp: r.Timestamp,
+				Candle: kline.Candle{
+					Time:   r.Kline.StartTime,
+					Open:   r.Kline.OpenPrice,
+					High:   r.Kline.HighPrice,
+					Low:    r.Kline.LowPrice,
+					Close:  r.Kline.ClosePrice,
+					Volume: r.Kline.Volume,
+				},
+			})
+		case r.Type == recorder.OrderbookRecord && r.Orderbook != nil:
+			events = append(events, Event{
+				Type:      OrderbookEvent,
+				Timestamp: r.Timestamp,
+				Orderbook: *r.Orderbook,
+			})
+		}
+	}
+	return events
+}
+
+// Run replays the events in time order, calling the strategy after each event
+// and returns a report of the simulated trading
+func (e *Engine) Run(events []Event, s Strategy) (Report, error) {
+	if len(events) == 0 {
+		return Report{}, ErrNoEvents
+	}
+
+	events = MergeEvents(events)
+	e.strategy = s
+	for x := range events {
+		e.replay(events[x])
+		if x == 0 {
+			// the starting balances are valued at the first replayed price
+			e.startEquity = e.GetEquity()
+		}
+		if s != nil {
+			s.OnEvent(e, events[x])
+		}
+		e.equity = append(e.equity, EquityPoint{
+			Timestamp: e.now,
+			Equity:    e.GetEquity(),
+		})
+	}
+	return e.report(), nil
+}
+
+// replay processes an event through the ticker and orderbook packages and
+// fills any resting orders the event trades through
+func (e *Engine) replay(event Event) {
+	e.now = event.Timestamp
+	switch event.Type {
+	case CandleEvent:
+		c := event.Candle
+		e.lastPrice = c.Close
+		ticker.ProcessTicker(e.cfg.ExchangeName, e.cfg.Pair, ticker.Price{
+			Last:   c.Close,
+			High:   c.High,
+			Low:    c.Low,
+			Bid:    c.Close,
+			Ask:    c.Close,
+			Volume: c.Volume,
+		}, e.cfg.AssetType)
+		e.matchResting(c.Low, c.High, 0)
+	case TradeEvent:
+		t := event.Trade
+		e.lastPrice = t.Price
+		ticker.ProcessTicker(e.cfg.ExchangeName, e.cfg.Pair, ticker.Price{
+			Last: t.Price,
+			Bid:  t.Price,
+			Ask:  t.Price,
+		}, e.cfg.AssetType)
+		e.matchResting(t.Price, t.Price, t.Amount)
+	case OrderbookEvent:
+		ob := event.Orderbook
+		ob.Bids = append([]orderbook.Item(nil), ob.Bids...)
+		ob.Asks = append([]orderbook.Item(nil), ob.Asks...)
+		sort.Slice(ob.Bids, func(i, j int) bool { return ob.Bids[i].Price > ob.Bids[j].Price })
+		sort.Slice(ob.Asks, func(i, j int) bool { return ob.Asks[i].Price < ob.Asks[j].Price })
+		orderbook.ProcessOrderbook(e.cfg.ExchangeName, e.cfg.Pair, ob, e.cfg.AssetType)
+		e.book = ob
+		e.hasBook = true
+		e.matchRestingBook()
+	}
+}
+
+// matchResting fills resting limit orders as makers when the traded price
+// range reaches their price. A non zero volume limits the total amount filled
+func (e *Engine) matchResting(low, high, volume float64) {
+	limited := volume > 0
+	for _, o := range e.getOpenOrders() {
+		if o.Type != exchange.Limit {
+			continue
+		}
+		if o.Side == exchange.Buy && !crosses(o, low) ||
+			o.Side == exchange.Sell && !crosses(o, high) {
+			continue
+		}
+		amount := o.Amount - o.ExecutedAmount
+		if limited {
+			if volume <= 0 {
+				return
+			}
+			amount = math.Min(amount, volume)
+		}
+		volume -= e.fill(o, o.Price, amount, true)
+	}
+}
+
+// matchRestingBook fills resting limit orders as makers against the replayed
+// orderbook levels which cross their price
+func (e *Engine) matchRestingBook() {
+	for _, o := range e.getOpenOrders() {
+		if o.Type != exchange.Limit {
+			continue
+		}
+		levels := e.getLevels(o.Side)
+		for x := range *levels {
+			level := &(*levels)[x]
+			if !crosses(o, level.Price) || level.Amount <= 0 {
+				break
+			}
+			remaining := o.Amount - o.ExecutedAmount
+			if remaining <= 0 {
+				break
+			}
+			amount := math.Min(remaining, level.Amount)
+			amount = e.fill(o, o.Price, amount, true)
+			if amount == 0 {
+				break
+			}
+			level.Amount -= amount
+		}
+	}
+}
+
+// getLevels returns the orderbook side an order on the supplied side would
+// trade against
+func (e *Engine) getLevels(side exchange.OrderSide) *[]orderbook.Item {
+	if side == exchange.Buy {
+		return &e.book.Asks
+	}
+	return &e.book.Bids
+}
+
+// crosses returns whether an order can trade at the supplied price
+func crosses(o *Order, price float64) bool {
+	if o.Type != exchange.Limit {
+		return true
+	}
+	if o.Side == exchange.Buy {
+		return price <= o.Price
+	}
+	return price >= o.Price
+}
+
+// SubmitOrder simulates placing a market or limit order. Marketable orders are
+// filled immediately as a taker against the last replayed orderbook or price,
+// the remainder of limit orders rests until the replayed data trades through
+// its price
+func (e *Engine) SubmitOrder(s *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var resp exchange.SubmitOrderResponse
+	err := s.Validate(supportedFeatures)
+	if err != nil {
+		return resp, err
+	}
+	if !s.CurrencyPair.Equal(e.cfg.Pair, true) {
+		return resp, fmt.Errorf("backtest only supports currency pair %s",
+			e.cfg.Pair.Pair())
+	}
+	if !e.hasBook && e.lastPrice == 0 {
+		return resp, ErrNoMarketData
+	}
+
+	e.nextID++
+	o := &Order{
+		ID:        strconv.FormatInt(e.nextID, 10),
+		Side:      s.OrderSide,
+		Type:      s.OrderType,
+		Price:     s.Price,
+		Amount:    s.Amount,
+		Status:    exchange.NewOrderStatus,
+		CreatedAt: e.now,
+	}
+
+	if !e.canAfford(o) {
+		return resp, ErrInsufficientFunds
+	}
+
+	e.orders = append(e.orders, o)
+	e.take(o)
+	if o.Type == exchange.Market && o.Status != exchange.FilledOrderStatus {
+		// market orders do not rest once the available liquidity is consumed
+		o.Status = exchange.CancelledOrderStatus
+	}
+
+	resp.IsOrderPlaced = true
+	resp.OrderID = o.ID
+	return resp, nil
+}
+
+// canAfford returns whether the available balances can cover the full order
+// at its limit price or the current reference price
+func (e *Engine) canAfford(o *Order) bool {
+	if o.Side == exchange.Sell {
+		return e.availableBase() >= o.Amount
+	}
+	price := o.Price
+	if o.Type == exchange.Market {
+		price = e.referencePrice(exchange.Buy)
+	}
+	fee := math.Max(e.cfg.MakerFee, e.cfg.TakerFee)
+	return e.availableQuote() >= price*o.Amount*(1+fee/100)
+}
+
+// referencePrice returns the best price an order on the side could trade at
+func (e *Engine) referencePrice(side exchange.OrderSide) float64 {
+	if e.hasBook {
+		levels := e.getLevels(side)
+		for x := range *levels {
+			if (*levels)[x].Amount > 0 {
+				return (*levels)[x].Price
+			}
+		}
+	}
+	return e.lastPrice
+}
+
+// take fills as much of an order as possible as a taker
+func (e *Engine) take(o *Order) {
+	if !e.hasBook {
+		if crosses(o, e.lastPrice) {
+			e.fill(o, e.lastPrice, o.Amount-o.ExecutedAmount, false)
+		}
+		return
+	}
+
+	levels := e.getLevels(o.Side)
+	for x := range *levels {
+		level := &(*levels)[x]
+		remaining := o.Amount - o.ExecutedAmount
+		if remaining <= 0 || !crosses(o, level.Price) {
+			break
+		}
+		if level.Amount <= 0 {
+			continue
+		}
+		amount := e.fill(o, level.Price, math.Min(remaining, level.Amount), false)
+		if amount == 0 {
+			break
+		}
+		level.Amount -= amount
+	}
+}
+
+// fill executes part of an order, limited by the available balances, and
+// returns the amount filled
+func (e *Engine) fill(o *Order, price, amount float64, maker bool) float64 {
+	if amount <= 0 || price <= 0 {
+		return 0
+	}
+
+	feeRate := e.cfg.TakerFee / 100
+	if maker {
+		feeRate = e.cfg.MakerFee / 100
+	}
+
+	// balances reserved for this order are available to fill it
+	if o.Side == exchange.Buy {
+		available := e.availableQuote() + e.reserved(o)
+		amount = math.Min(amount, available/(price*(1+feeRate)))
+	} else {
+		available := e.availableBase() + e.reserved(o)
+		amount = math.Min(amount, available)
+	}
+	if amount <= 0 {
+		return 0
+	}
+
+	notional := price * amount
+	fee := notional * feeRate
+	f := Fill{
+		OrderID:   o.ID,
+		Timestamp: e.now,
+		Side:      o.Side,
+		Price:     price,
+		Amount:    amount,
+		Fee:       fee,
+		IsMaker:   maker,
+	}
+
+	if o.Side == exchange.Buy {
+		e.quote -= notional + fee
+		e.base += amount
+		e.positionCost += notional + fee
+	} else {
+		var avgCost float64
+		if e.base > 0 {
+			avgCost = e.positionCost / e.base
+		}
+		f.RealisedPnL = notional - fee - avgCost*amount
+		e.positionCost -= avgCost * amount
+		e.base -= amount
+		e.quote += notional - fee
+	}
+
+	o.ExecutedAmount += amount
+	if o.Amount-o.ExecutedAmount <= o.Amount*1e-12 {
+		o.Status = exchange.FilledOrderStatus
+	} else {
+		o.Status = exchange.PartiallyFilledOrderStatus
+	}
+
+	e.fills = append(e.fills, f)
+	if e.strategy != nil {
+		e.strategy.OnFill(e, f)
+	}
+	return amount
+}
+
+// reserved returns the balance held by an open limit order
+func (e *Engine) reserved(o *Order) float64 {
+	if o.Type != exchange.Limit || !isOpen(o) {
+		return 0
+	}
+	remaining := o.Amount - o.ExecutedAmount
+	if o.Side == exchange.Sell {
+		return remaining
+	}
+	fee := math.Max(e.cfg.MakerFee, e.cfg.TakerFee)
+	return o.Price * remaining * (1 + fee/100)
+}
+
+func isOpen(o *Order) bool {
+	return o.Status == exchange.NewOrderStatus ||
+		o.Status == exchange.PartiallyFilledOrderStatus
+}
+
+// availableBase returns the base balance not held by open sell orders
+func (e *Engine) availableBase() float64 {
+	available := e.base
+	for _, o := range e.orders {
+		if o.Side == exchange.Sell {
+			available -= e.reserved(o)
+		}
+	}
+	return available
+}
+
+// availableQuote returns the quote balance not held by open buy orders
+func (e *Engine) availableQuote() float64 {
+	available := e.quote
+	for _, o := range e.orders {
+		if o.Side == exchange.Buy {
+			available -= e.reserved(o)
+		}
+	}
+	return available
+}
+
+func (e *Engine) getOpenOrders() []*Order {
+	var open []*Order
+	for _, o := range e.orders {
+		if isOpen(o) {
+			open = append(open, o)
+		}
+	}
+	return open
+}
+
+// CancelOrder cancels an open simulated order
+func (e *Engine) CancelOrder(orderID string) error {
+	for _, o := range e.orders {
+		if o.ID != orderID {
+			continue
+		}
+		if !isOpen(o) {
+			return ErrOrderNotOpen
+		}
+		o.Status = exchange.CancelledOrderStatus
+		return nil
+	}
+	return ErrOrderNotFound
+}
+
+// GetOpenOrders returns a copy of all open simulated orders
+func (e *Engine) GetOpenOrders() []Order {
+	var orders []Order
+	for _, o := range e.getOpenOrders() {
+		orders = append(orders, *o)
+	}
+	return orders
+}
+
+// GetOrder returns a copy of a simulated order
+func (e *Engine) GetOrder(orderID string) (Order, error) {
+	for _, o := range e.orders {
+		if o.ID == orderID {
+			return *o, nil
+		}
+	}
+	return Order{}, ErrOrderNotFound
+}
+
+// GetBalances returns the base and quote currency balances, including any
+// balance held by open orders
+func (e *Engine) GetBalances() (base, quote float64) {
+	return e.base, e.quote
+}
+
+// GetTime returns the timestamp of the event currently being replayed
+func (e *Engine) GetTime() time.Time {
+	return e.now
+}
+
+// GetLastPrice returns the last replayed trade or candle close price, or the
+// mid price of the last replayed orderbook
+func (e *Engine) GetLastPrice() float64 {
+	if e.hasBook && len(e.book.Bids) > 0 && len(e.book.Asks) > 0 {
+		return (e.book.Bids[0].Price + e.book.Asks[0].Price) / 2
+	}
+	return e.lastPrice
+}
+
+// GetEquity returns the value of both balances in the quote currency at the
+// last price
+func (e *Engine) GetEquity() float64 {
+	return e.quote + e.base*e.GetLastPrice()
+}
+
+// report summarises the fills and equity curve of a run
+func (e *Engine) report() Report {
+	r := Report{
+		Fills:       e.fills,
+		EquityCurve: e.equity,
+		TotalFills:  len(e.fills),
+	}
+
+	r.StartEquity = e.startEquity
+	if len(e.equity) > 0 {
+		r.EndEquity = e.equity[len(e.equity)-1].Equity
+	}
+	r.TotalPnL = r.EndEquity - r.StartEquity
+	if r.StartEquity != 0 {
+		r.ReturnPercent = r.TotalPnL / r.StartEquity * 100
+	}
+
+	for x := range e.fills {
+		f := e.fills[x]
+		r.TotalFees += f.Fee
+		r.TotalVolume += f.Price * f.Amount
+		if f.Side != exchange.Sell {
+			continue
+		}
+		r.RealisedPnL += f.RealisedPnL
+		switch {
+		case f.RealisedPnL > 0:
+			r.WinningTrades++
+		case f.RealisedPnL < 0:
+			r.LosingTrades++
+		}
+	}
+	if closed := r.WinningTrades + r.LosingTrades; closed > 0 {
+		r.WinRate = float64(r.WinningTrades) / float64(closed) * 100
+	}
+
+	peak := r.StartEquity
+	for x := range e.equity {
+		equity := e.equity[x].Equity
+		if equity > peak {
+			peak = equity
+		}
+		drawdown := peak - equity
+		if drawdown > r.MaxDrawdown {
+			r.MaxDrawdown = drawdown
+			if peak > 0 {
+				r.MaxDrawdownPct = drawdown / peak * 100
+			}
+		}
+	}
+	return r
+}
@@ -0,0 +1,281 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/recorder"
+)
+
+var testPair = pair.NewCurrencyPair("BTC", "USD")
+
+// scriptedStrategy submits the order mapped to each event index
+type scriptedStrategy struct {
+	t      *testing.T
+	orders map[int]*exchange.OrderSubmission
+	index  int
+	fills  []Fill
+}
+
+func (s *scriptedStrategy) OnEvent(e *Engine, event Event) {
+	if o, ok := s.orders[s.index]; ok {
+		_, err := e.SubmitOrder(o)
+		if err != nil {
+			s.t.Errorf("Test failed. SubmitOrder error: %s", err)
+		}
+	}
+	s.index++
+}
+
+func (s *scriptedStrategy) OnFill(e *Engine, fill Fill) {
+	s.fills = append(s.fills, fill)
+}
+
+func newTestEngine(t *testing.T, name string) *Engine {
+	e, err := NewEngine(Config{
+		ExchangeName:  name,
+		Pair:          testPair,
+		MakerFee:      0.1,
+		TakerFee:      0.2,
+		StartingQuote: 10000,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. NewEngine error: %s", err)
+	}
+	return e
+}
+
+func testCandles(closes ...float64) []kline.Candle {
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	var candles []kline.Candle
+	for x := range closes {
+		candles = append(candles, kline.Candle{
+			Time:   start.Add(time.Duration(x) * time.Hour),
+			Open:   closes[x],
+			High:   closes[x] + 5,
+			Low:    closes[x] - 5,
+			Close:  closes[x],
+			Volume: 10,
+		})
+	}
+	return candles
+}
+
+func TestNewConfigFromExchange(t *testing.T) {
+	b := exchange.Base{Name: "Bitstamp", MakerFee: 0.1, TakerFee: 0.25}
+	cfg := NewConfigFromExchange(&b, testPair, ticker.Spot)
+	if cfg.ExchangeName != "Bitstamp" || cfg.MakerFee != 0.1 || cfg.TakerFee != 0.25 {
+		t.Errorf("Test failed. NewConfigFromExchange unexpected config %v", cfg)
+	}
+
+	_, err := NewEngine(Config{Pair: testPair})
+	if err != ErrNoExchangeName {
+		t.Errorf("Test failed. NewEngine expected %s got %v", ErrNoExchangeName, err)
+	}
+}
+
+func TestRunCandles(t *testing.T) {
+	e := newTestEngine(t, "BacktestCandles")
+	s := &scriptedStrategy{
+		t: t,
+		orders: map[int]*exchange.OrderSubmission{
+			0: {CurrencyPair: testPair, OrderSide: exchange.Buy, OrderType: exchange.Market, Amount: 10},
+			3: {CurrencyPair: testPair, OrderSide: exchange.Sell, OrderType: exchange.Market, Amount: 10},
+		},
+	}
+
+	r, err := e.Run(CandleEvents(testCandles(100, 90, 80, 120)), s)
+	if err != nil {
+		t.Fatalf("Test failed. Run error: %s", err)
+	}
+
+	if ticker, err := ticker.GetTicker("BacktestCandles", testPair, ticker.Spot); err != nil || ticker.Last != 120 {
+		t.Error("Test failed. Run did not process tickers")
+	}
+
+	if r.TotalFills != 2 || len(s.fills) != 2 {
+		t.Fatalf("Test failed. Run expected 2 fills got %d", r.TotalFills)
+	}
+
+	// bought 10 @ 100 and sold 10 @ 120 with a 0.2% taker fee on each side
+	expectedFees := 1000*0.002 + 1200*0.002
+	if math.Abs(r.TotalFees-expectedFees) > 1e-9 {
+		t.Errorf("Test failed. Run expected fees %f got %f", expectedFees, r.TotalFees)
+	}
+	if math.Abs(r.RealisedPnL-(200-expectedFees)) > 1e-9 {
+		t.Errorf("Test failed. Run expected realised PnL %f got %f", 200-expectedFees, r.RealisedPnL)
+	}
+	if math.Abs(r.TotalPnL-r.RealisedPnL) > 1e-9 {
+		t.Errorf("Test failed. Run expected total PnL %f got %f", r.RealisedPnL, r.TotalPnL)
+	}
+	if r.WinningTrades != 1 || r.LosingTrades != 0 || r.WinRate != 100 {
+		t.Errorf("Test failed. Run unexpected trade stats %v", r)
+	}
+
+	// equity falls from 10000 to 10000 - 2 - 200 at the 80 close
+	if math.Abs(r.MaxDrawdown-202) > 1e-9 {
+		t.Errorf("Test failed. Run expected max drawdown 202 got %f", r.MaxDrawdown)
+	}
+}
+
+func TestRestingLimitOrder(t *testing.T) {
+	e := newTestEngine(t, "BacktestLimit")
+	s := &scriptedStrategy{
+		t: t,
+		orders: map[int]*exchange.OrderSubmission{
+			0: {CurrencyPair: testPair, OrderSide: exchange.Buy, OrderType: exchange.Limit, Price: 82, Amount: 1},
+		},
+	}
+
+	r, err := e.Run(CandleEvents(testCandles(100, 90, 80)), s)
+	if err != nil {
+		t.Fatalf("Test failed. Run error: %s", err)
+	}
+	if r.TotalFills != 1 {
+		t.Fatalf("Test failed. RestingLimitOrder expected 1 fill got %d", r.TotalFills)
+	}
+	if !r.Fills[0].IsMaker || r.Fills[0].Price != 82 {
+		t.Errorf("Test failed. RestingLimitOrder unexpected fill %v", r.Fills[0])
+	}
+
+	_, err = e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: testPair, OrderSide: exchange.Buy, OrderType: exchange.Limit, Price: 10, Amount: 10000,
+	})
+	if err != ErrInsufficientFunds {
+		t.Errorf("Test failed. SubmitOrder expected %s got %v", ErrInsufficientFunds, err)
+	}
+
+	resp, err := e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: testPair, OrderSide: exchange.Buy, OrderType: exchange.Limit, Price: 10, Amount: 1,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. SubmitOrder error: %s", err)
+	}
+	if len(e.GetOpenOrders()) != 1 {
+		t.Error("Test failed. SubmitOrder expected order to rest")
+	}
+	if err = e.CancelOrder(resp.OrderID); err != nil {
+		t.Errorf("Test failed. CancelOrder error: %s", err)
+	}
+	if err = e.CancelOrder(resp.OrderID); err != ErrOrderNotOpen {
+		t.Errorf("Test failed. CancelOrder expected %s got %v", ErrOrderNotOpen, err)
+	}
+}
+
+func TestOrderbookFills(t *testing.T) {
+	e := newTestEngine(t, "BacktestOrderbook")
+	s := &scriptedStrategy{
+		t: t,
+		orders: map[int]*exchange.OrderSubmission{
+			0: {CurrencyPair: testPair, OrderSide: exchange.Buy, OrderType: exchange.Market, Amount: 3},
+		},
+	}
+
+	book := orderbook.Base{
+		Pair:        testPair,
+		Bids:        []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks:        []orderbook.Item{{Price: 101, Amount: 1}, {Price: 100, Amount: 1}, {Price: 102, Amount: 5}},
+		LastUpdated: time.Now(),
+	}
+
+	r, err := e.Run(OrderbookEvents([]orderbook.Base{book}), s)
+	if err != nil {
+		t.Fatalf("Test failed. Run error: %s", err)
+	}
+
+	if _, err = orderbook.GetOrderbook("BacktestOrderbook", testPair, ticker.Spot); err != nil {
+		t.Error("Test failed. Run did not process orderbooks")
+	}
+
+	if r.TotalFills != 3 {
+		t.Fatalf("Test failed. OrderbookFills expected 3 fills got %d", r.TotalFills)
+	}
+	if r.TotalVolume != 303 {
+		t.Errorf("Test failed. OrderbookFills expected volume 303 got %f", r.TotalVolume)
+	}
+	if r.Fills[0].Price != 100 || r.Fills[2].Price != 102 {
+		t.Errorf("Test failed. OrderbookFills did not walk the book in price order %v", r.Fills)
+	}
+
+	base, _ := e.GetBalances()
+	if base != 3 {
+		t.Errorf("Test failed. OrderbookFills expected base balance 3 got %f", base)
+	}
+}
+
+func TestTradeEventsLimitVolume(t *testing.T) {
+	e := newTestEngine(t, "BacktestTrades")
+	start := time.Now()
+	trades := []exchange.TradeHistory{
+		{Price: 100, Amount: 1, Timestamp: start},
+		{Price: 95, Amount: 0.5, Timestamp: start.Add(time.Second)},
+	}
+	s := &scriptedStrategy{
+		t: t,
+		orders: map[int]*exchange.OrderSubmission{
+			0: {CurrencyPair: testPair, OrderSide: exchange.Buy, OrderType: exchange.Limit, Price: 96, Amount: 2},
+		},
+	}
+
+	_, err := e.Run(TradeEvents(trades), s)
+	if err != nil {
+		t.Fatalf("Test failed. Run error: %s", err)
+	}
+
+	orders := e.GetOpenOrders()
+	if len(orders) != 1 || orders[0].ExecutedAmount != 0.5 ||
+		orders[0].Status != exchange.PartiallyFilledOrderStatus {
+		t.Errorf("Test failed. TradeEventsLimitVolume unexpected orders %v", orders)
+	}
+
+	if _, err = e.Run(nil, s); err != ErrNoEvents {
+		t.Errorf("Test failed. Run expected %s got %v", ErrNoEvents, err)
+	}
+}
+
+func TestRecordEvents(t *testing.T) {
+	now := time.Now()
+	events := RecordEvents([]recorder.Record{
+		{
+			Type:      recorder.TickerRecord,
+			Timestamp: now,
+			Ticker:    &ticker.Price{Last: 100},
+		},
+		{
+			Type:      recorder.TradeRecord,
+			Timestamp: now,
+			Pair:      testPair,
+			Trade:     &exchange.TradeData{Price: 100, Amount: 1, Side: "buy"},
+		},
+		{
+			Type:      recorder.KlineRecord,
+			Timestamp: now,
+			Kline:     &exchange.KlineData{StartTime: now, OpenPrice: 99, ClosePrice: 101},
+		},
+		{
+			Type:      recorder.OrderbookRecord,
+			Timestamp: now,
+			Orderbook: &orderbook.Base{Bids: []orderbook.Item{{Price: 99, Amount: 1}}},
+		},
+	})
+
+	if len(events) != 3 {
+		t.Fatalf("Test failed. Expected 3 events, received %d", len(events))
+	}
+	if events[0].Type != TradeEvent || events[0].Trade.Price != 100 ||
+		events[0].Trade.Side != exchange.OrderSide("buy") {
+		t.Errorf("Test failed. Unexpected trade event %+v", events[0])
+	}
+	if events[1].Type != CandleEvent || events[1].Candle.Close != 101 {
+		t.Errorf("Test failed. Unexpected candle event %+v", events[1])
+	}
+	if events[2].Type != OrderbookEvent || len(events[2].Orderbook.Bids) != 1 {
+		t.Errorf("Test failed. Unexpected orderbook event %+v", events[2])
+	}
+}
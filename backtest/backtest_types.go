@@ -0,0 +1,126 @@
+package backtest
+
+import (
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// EventType defines the kind of market data carried by an Event
+type EventType int
+
+// Market data event types
+const (
+	CandleEvent EventType = iota
+	TradeEvent
+	OrderbookEvent
+)
+
+// Event holds a single piece of recorded or historic market data to be
+// replayed. Only the field matching Type is set
+type Event struct {
+	Type      EventType
+	Timestamp time.Time
+	Candle    kline.Candle
+	Trade     exchange.TradeHistory
+	Orderbook orderbook.Base
+}
+
+// Config holds the settings for a backtest run
+type Config struct {
+	// ExchangeName is the name the replayed ticker and orderbook data is
+	// processed under
+	ExchangeName string
+	Pair         pair.CurrencyPair
+	AssetType    string
+	// MakerFee and TakerFee are percentages of the notional value of each
+	// fill, matching exchange.Base
+	MakerFee float64
+	TakerFee float64
+	// StartingBase and StartingQuote are the initial balances of the first
+	// and second currencies of Pair
+	StartingBase  float64
+	StartingQuote float64
+}
+
+// Strategy is implemented by trading logic under test. OnEvent is called
+// after each event has been replayed and resting orders matched, OnFill is
+// called for every simulated fill
+type Strategy interface {
+	OnEvent(e *Engine, event Event)
+	OnFill(e *Engine, fill Fill)
+}
+
+// Order holds a simulated order
+type Order struct {
+	ID             string
+	Side           exchange.OrderSide
+	Type           exchange.OrderType
+	Price          float64
+	Amount         float64
+	ExecutedAmount float64
+	Status         exchange.OrderStatus
+	CreatedAt      time.Time
+}
+
+// Fill holds a simulated execution against replayed market data
+type Fill struct {
+	OrderID   string
+	Timestamp time.Time
+	Side      exchange.OrderSide
+	Price     float64
+	Amount    float64
+	Fee       float64
+	IsMaker   bool
+	// RealisedPnL is the profit or loss realised by a sell fill against the
+	// average cost of the position, it is zero for buy fills
+	RealisedPnL float64
+}
+
+// EquityPoint holds the value of the account, in the quote currency, after an
+// event has been replayed
+type EquityPoint struct {
+	Timestamp time.Time
+	Equity    float64
+}
+
+// Report holds the results of a backtest run
+type Report struct {
+	StartEquity    float64
+	EndEquity      float64
+	TotalPnL       float64
+	RealisedPnL    float64
+	ReturnPercent  float64
+	MaxDrawdown    float64
+	MaxDrawdownPct float64
+	TotalFills     int
+	WinningTrades  int
+	LosingTrades   int
+	WinRate        float64
+	TotalFees      float64
+	TotalVolume    float64
+	Fills          []Fill
+	EquityCurve    []EquityPoint
+}
+
+// Engine replays market data through the ticker and orderbook packages and
+// simulates fills for orders placed by a strategy
+type Engine struct {
+	cfg          Config
+	base         float64
+	quote        float64
+	positionCost float64
+	startEquity  float64
+	orders       []*Order
+	fills        []Fill
+	equity       []EquityPoint
+	lastPrice    float64
+	book         orderbook.Base
+	hasBook      bool
+	now          time.Time
+	nextID       int64
+	strategy     Strategy
+}
@@ -1,9 +1,11 @@
 package base
 
 import (
+	"fmt"
 	"log"
 	"time"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
@@ -52,6 +54,20 @@ func (c IComm) PushEvent(event Event) {
 	}
 }
 
+// PushEventToMedium pushes a triggered event to the named communication link
+func (c IComm) PushEventToMedium(medium string, event Event) error {
+	for i := range c {
+		if common.StringToUpper(c[i].GetName()) != common.StringToUpper(medium) {
+			continue
+		}
+		if !c[i].IsEnabled() || !c[i].IsConnected() {
+			return fmt.Errorf("communication medium %s is not connected", medium)
+		}
+		return c[i].PushEvent(event)
+	}
+	return fmt.Errorf("communication medium %s is not enabled", medium)
+}
+
 // GetEnabledCommunicationMediums prints out enabled and connected communication
 // packages
 func (c IComm) GetEnabledCommunicationMediums() {
@@ -79,6 +79,13 @@ func TestPushEvent(t *testing.T) {
 	i.PushEvent(Event{})
 }
 
+func TestPushEventToMedium(t *testing.T) {
+	err := i.PushEventToMedium("Slack", Event{})
+	if err == nil {
+		t.Error("test failed - base PushEventToMedium() error")
+	}
+}
+
 func TestGetEnabledCommunicationMediums(t *testing.T) {
 	i.GetEnabledCommunicationMediums()
 }
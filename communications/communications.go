@@ -7,6 +7,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/communications/smtpservice"
 	"github.com/thrasher-/gocryptotrader/communications/telegram"
 	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
 )
 
 // Communications is the overarching type across the communications packages
@@ -14,6 +15,12 @@ type Communications struct {
 	base.IComm
 }
 
+// exchangeSetter is implemented by communication mediums which route trading
+// commands through the exchanges
+type exchangeSetter interface {
+	SetExchanges(exchanges []exchange.IBotExchange)
+}
+
 // NewComm sets up and returns a pointer to a Communications object
 func NewComm(config config.CommunicationsConfig) *Communications {
 	var comm Communications
@@ -45,3 +52,13 @@ func NewComm(config config.CommunicationsConfig) *Communications {
 	comm.Setup()
 	return &comm
 }
+
+// SetExchanges sets the exchanges used by communication mediums which support
+// trading commands
+func (c *Communications) SetExchanges(exchanges []exchange.IBotExchange) {
+	for i := range c.IComm {
+		if s, ok := c.IComm[i].(exchangeSetter); ok {
+			s.SetExchanges(exchanges)
+		}
+	}
+}
@@ -8,10 +8,20 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/communications/base"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/events"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 const (
@@ -21,25 +31,51 @@ const (
 	methodGetUpdates  = "getUpdates"
 	methodSendMessage = "sendMessage"
 
-	cmdStart     = "/start"
-	cmdStatus    = "/status"
-	cmdHelp      = "/help"
-	cmdSettings  = "/settings"
-	cmdTicker    = "/ticker"
-	cmdPortfolio = "/portfolio"
-	cmdOrders    = "/orderbooks"
+	// maxMessageLength is the longest message text Telegram accepts in UTF-16
+	// code units, longer replies are split across messages
+	maxMessageLength = 4096
+
+	cmdStart      = "/start"
+	cmdStatus     = "/status"
+	cmdHelp       = "/help"
+	cmdSettings   = "/settings"
+	cmdTicker     = "/ticker"
+	cmdPortfolio  = "/portfolio"
+	cmdOrderbooks = "/orderbooks"
+	cmdBalance    = "/balance"
+	cmdOrders     = "/orders"
+	cmdBuy        = "/buy"
+	cmdSell       = "/sell"
+	cmdCancel     = "/cancel"
+	cmdCancelAll  = "/cancelall"
+	cmdAlerts     = "/alerts"
+	cmdConfirm    = "/confirm"
+	cmdAbort      = "/abort"
 
 	cmdHelpReply = `GoCryptoTrader TelegramBot, thank you for using this service!
 	Current commands are:
-	/start  		- Will authenticate your ID
+	/start  		- Displays your chat ID
 	/status 		- Displays the status of the bot
 	/help 			- Displays current command list
 	/settings 	- Displays current bot settings
-	/ticker 		- Displays current ANX ticker data
+	/ticker <exchange> [pair] - Displays current ticker data
 	/portfolio	- Displays your current portfolio
-	/orderbooks - Displays current orderbooks for ANX`
+	/orderbooks <exchange> [pair] - Displays current orderbooks
+	Authorised chats only:
+	/balance <exchange> - Displays your exchange balances
+	/orders <exchange> [pair] - Displays your open orders
+	/buy <exchange> <pair> <amount> [price] - Places a market or limit buy
+	/sell <exchange> <pair> <amount> [price] - Places a market or limit sell
+	/cancel <exchange> <orderID> [pair] - Cancels an order
+	/cancelall <exchange> - Cancels all open orders
+	/alerts 		- Displays your events
+	/confirm 		- Confirms a pending trading command
+	/abort 			- Aborts a pending trading command`
 
 	talkRoot = "GoCryptoTrader bot"
+
+	// confirmationTimeout is how long a trading command waits for /confirm
+	confirmationTimeout = time.Minute
 )
 
 // Telegram is the overarching type across this package
@@ -48,6 +84,9 @@ type Telegram struct {
 	Token             string
 	Offset            int64
 	AuthorisedClients []int64
+	exchanges         []exchange.IBotExchange
+	pending           map[pendingKey]pendingCommand
+	mtx               sync.Mutex
 }
 
 // Setup takes in a Telegram configuration and sets verification token
@@ -56,6 +95,14 @@ func (t *Telegram) Setup(config config.CommunicationsConfig) {
 	t.Enabled = config.TelegramConfig.Enabled
 	t.Token = config.TelegramConfig.VerificationToken
 	t.Verbose = config.TelegramConfig.Verbose
+	t.AuthorisedClients = config.TelegramConfig.AuthorisedChatIDs
+}
+
+// SetExchanges sets the exchanges trading commands are routed through
+func (t *Telegram) SetExchanges(exchanges []exchange.IBotExchange) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.exchanges = exchanges
 }
 
 // Connect starts an initial connection
@@ -92,10 +139,12 @@ func (t *Telegram) PollerStart() {
 
 		for i := range resp.Result {
 			if resp.Result[i].UpdateID > t.Offset {
-				if string(resp.Result[i].Message.Text[0]) == "/" {
-					err = t.HandleMessages(resp.Result[i].Message.Text, resp.Result[i].Message.From.ID)
+				if strings.HasPrefix(resp.Result[i].Message.Text, "/") {
+					err = t.HandleMessages(resp.Result[i].Message.Text,
+						resp.Result[i].Message.Chat.ID, resp.Result[i].Message.From.ID)
 					if err != nil {
-						log.Fatal(err)
+						log.Printf("Telegram: unable to reply to %s: %s",
+							resp.Result[i].Message.Text, err)
 					}
 				}
 				t.Offset = resp.Result[i].UpdateID
@@ -136,32 +185,390 @@ func (t *Telegram) InitialConnect() {
 }
 
 // HandleMessages handles incoming message from the long polling routine
-func (t *Telegram) HandleMessages(text string, chatID int64) error {
-	switch {
-	case common.StringContains(text, cmdHelp):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, cmdHelpReply), chatID)
+func (t *Telegram) HandleMessages(text string, chatID, userID int64) error {
+	return t.SendMessage(t.handleCommand(text, chatID, userID), chatID)
+}
 
-	case common.StringContains(text, cmdStart):
-		return t.SendMessage(fmt.Sprintf("%s: START COMMANDS HERE", talkRoot), chatID)
+// handleCommand runs a command and returns the reply. Trading commands are
+// only accepted from authorised chats and orders are staged until confirmed
+// by the user who sent them
+func (t *Telegram) handleCommand(text string, chatID, userID int64) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return fmt.Sprintf("command %s not recognized", text)
+	}
+	cmd := common.StringToLower(fields[0])
+	// commands sent in groups can be addressed as /command@botname
+	if i := strings.Index(cmd, "@"); i > 0 {
+		cmd = cmd[:i]
+	}
+	args := fields[1:]
+
+	switch cmd {
+	case cmdHelp:
+		return fmt.Sprintf("%s: %s", talkRoot, cmdHelpReply)
+	case cmdStart:
+		return fmt.Sprintf("%s: Hello, your chat ID is %d", talkRoot, chatID)
+	case cmdOrderbooks:
+		return fmt.Sprintf("%s: %s", talkRoot, t.orderbookReply(args))
+	case cmdStatus:
+		return fmt.Sprintf("%s: %s", talkRoot, t.GetStatus())
+	case cmdTicker:
+		return fmt.Sprintf("%s: %s", talkRoot, t.tickerReply(args))
+	case cmdSettings:
+		return fmt.Sprintf("%s: %s", talkRoot, t.GetSettings())
+	case cmdPortfolio:
+		return fmt.Sprintf("%s: %s", talkRoot, t.GetPortfolio())
+	}
 
-	case common.StringContains(text, cmdOrders):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetOrderbook("ANX")), chatID)
+	var reply string
+	switch cmd {
+	case cmdBalance, cmdOrders, cmdBuy, cmdSell, cmdCancel, cmdCancelAll,
+		cmdAlerts, cmdConfirm, cmdAbort:
+		if !t.isAuthorised(chatID) {
+			return fmt.Sprintf("%s: chat ID %d is not authorised for trading commands", talkRoot, chatID)
+		}
+	default:
+		return fmt.Sprintf("command %s not recognized", text)
+	}
+
+	key := pendingKey{chatID: chatID, userID: userID}
+	switch cmd {
+	case cmdBalance:
+		reply = t.balanceReply(args)
+	case cmdOrders:
+		reply = t.ordersReply(args)
+	case cmdBuy:
+		reply = t.stageOrder(key, exchange.Buy, args)
+	case cmdSell:
+		reply = t.stageOrder(key, exchange.Sell, args)
+	case cmdCancel:
+		reply = t.stageCancel(key, args)
+	case cmdCancelAll:
+		reply = t.stageCancelAll(key, args)
+	case cmdAlerts:
+		reply = alertsReply()
+	case cmdConfirm:
+		reply = t.confirm(key)
+	case cmdAbort:
+		reply = t.abort(key)
+	}
+	return fmt.Sprintf("%s: %s", talkRoot, reply)
+}
+
+// isAuthorised returns whether a chat is on the allowlist
+func (t *Telegram) isAuthorised(chatID int64) bool {
+	for i := range t.AuthorisedClients {
+		if t.AuthorisedClients[i] == chatID {
+			return true
+		}
+	}
+	return false
+}
 
-	case common.StringContains(text, cmdStatus):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetStatus()), chatID)
+// getExchange returns a loaded exchange by name
+func (t *Telegram) getExchange(name string) (exchange.IBotExchange, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for i := range t.exchanges {
+		if common.StringToUpper(t.exchanges[i].GetName()) == common.StringToUpper(name) {
+			return t.exchanges[i], nil
+		}
+	}
+	return nil, fmt.Errorf("exchange %s not found", name)
+}
 
-	case common.StringContains(text, cmdTicker):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetTicker("ANX")), chatID)
+// exchangeName returns the loaded name of an exchange, falling back to the
+// name supplied when exchanges have not been set
+func (t *Telegram) exchangeName(name string) string {
+	exch, err := t.getExchange(name)
+	if err != nil {
+		return name
+	}
+	return exch.GetName()
+}
 
-	case common.StringContains(text, cmdSettings):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetSettings()), chatID)
+// tickerReply returns the ticker of an exchange pair, or all staged tickers
+// for the exchange when no pair is supplied
+func (t *Telegram) tickerReply(args []string) string {
+	if len(args) == 0 {
+		return "usage: /ticker <exchange> [pair]"
+	}
+	name := t.exchangeName(args[0])
+	if len(args) == 1 {
+		if tickers := t.GetTicker(name); tickers != "" {
+			return tickers
+		}
+		return fmt.Sprintf("no ticker data for %s", name)
+	}
 
-	case common.StringContains(text, cmdPortfolio):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetPortfolio()), chatID)
+	p, err := pair.ParseCurrencyPair(common.StringToUpper(args[1]))
+	if err != nil {
+		return err.Error()
+	}
+	price, err := ticker.GetTicker(name, p, ticker.Spot)
+	if err != nil {
+		return fmt.Sprintf("no ticker data for %s %s", name, p.Pair())
+	}
+	return fmt.Sprintf("%s %s Ask: %f Bid: %f High: %f Last: %f Low: %f Volume: %f",
+		name, p.Pair(), price.Ask, price.Bid, price.High, price.Last, price.Low, price.Volume)
+}
 
-	default:
-		return t.SendMessage(fmt.Sprintf("command %s not recognized", text), chatID)
+// orderbookReply returns the orderbook summary of an exchange pair, or all
+// staged orderbooks for the exchange when no pair is supplied
+func (t *Telegram) orderbookReply(args []string) string {
+	if len(args) == 0 {
+		return "usage: /orderbooks <exchange> [pair]"
+	}
+	name := t.exchangeName(args[0])
+	if len(args) == 1 {
+		if orderbooks := t.GetOrderbook(name); orderbooks != "" {
+			return orderbooks
+		}
+		return fmt.Sprintf("no orderbook data for %s", name)
+	}
+
+	p, err := pair.ParseCurrencyPair(common.StringToUpper(args[1]))
+	if err != nil {
+		return err.Error()
+	}
+	ob, err := orderbook.GetOrderbook(name, p, orderbook.Spot)
+	if err != nil {
+		return fmt.Sprintf("no orderbook data for %s %s", name, p.Pair())
+	}
+	totalBids, _ := ob.CalculateTotalBids()
+	totalAsks, _ := ob.CalculateTotalAsks()
+	spread, err := ob.GetSpread()
+	if err != nil {
+		return fmt.Sprintf("%s %s TotalAsks: %f TotalBids: %f", name, p.Pair(), totalAsks, totalBids)
+	}
+	return fmt.Sprintf("%s %s Bid: %f Ask: %f Spread: %f bps TotalAsks: %f TotalBids: %f",
+		name, p.Pair(), spread.Bid, spread.Ask, spread.SpreadBps, totalAsks, totalBids)
+}
+
+// balanceReply returns the account balances of an exchange
+func (t *Telegram) balanceReply(args []string) string {
+	if len(args) != 1 {
+		return "usage: /balance <exchange>"
+	}
+	exch, err := t.getExchange(args[0])
+	if err != nil {
+		return err.Error()
+	}
+	info, err := exch.GetAccountInfo()
+	if err != nil {
+		return fmt.Sprintf("unable to get %s balances: %s", exch.GetName(), err)
+	}
+
+	var balances []string
+	for _, c := range info.Currencies {
+		if c.TotalValue == 0 && c.Hold == 0 {
+			continue
+		}
+		balances = append(balances, fmt.Sprintf("%s: %f (%f on hold)", c.CurrencyName, c.TotalValue, c.Hold))
+	}
+	if len(balances) == 0 {
+		return fmt.Sprintf("no %s balances", exch.GetName())
+	}
+	return fmt.Sprintf("%s balances\n%s", exch.GetName(), common.JoinStrings(balances, "\n"))
+}
+
+// ordersReply returns the open orders of an exchange
+func (t *Telegram) ordersReply(args []string) string {
+	if len(args) == 0 || len(args) > 2 {
+		return "usage: /orders <exchange> [pair]"
+	}
+	exch, err := t.getExchange(args[0])
+	if err != nil {
+		return err.Error()
+	}
+	var req exchange.GetOrdersRequest
+	if len(args) == 2 {
+		p, err := pair.ParseCurrencyPair(common.StringToUpper(args[1]))
+		if err != nil {
+			return err.Error()
+		}
+		req.Currencies = []pair.CurrencyPair{p}
+	}
+	orders, err := exch.GetActiveOrders(req)
+	if err != nil {
+		return fmt.Sprintf("unable to get %s orders: %s", exch.GetName(), err)
+	}
+
+	if len(orders) == 0 {
+		return fmt.Sprintf("no open %s orders", exch.GetName())
+	}
+	var lines []string
+	for _, o := range orders {
+		lines = append(lines, fmt.Sprintf("%s %s %s %s %f @ %f", o.ID, o.OrderSide,
+			o.OrderType, o.CurrencyPair.Pair(), o.Amount, o.Price))
+	}
+	return fmt.Sprintf("%s open orders\n%s", exch.GetName(), common.JoinStrings(lines, "\n"))
+}
+
+// alertsReply returns every event on the events chain
+func alertsReply() string {
+	alerts := events.GetEvents()
+	if len(alerts) == 0 {
+		return "no alerts"
+	}
+	var lines []string
+	for i := range alerts {
+		status := "active"
+		if alerts[i].Executed {
+			status = "executed"
+		}
+		lines = append(lines, fmt.Sprintf("%d [%s, triggered %d times]: %s", alerts[i].ID,
+			status, alerts[i].TriggerCount, alerts[i].String()))
+	}
+	return common.JoinStrings(lines, "\n")
+}
+
+// stageOrder stages a market order, or a limit order when a price is given,
+// until it is confirmed
+func (t *Telegram) stageOrder(key pendingKey, side exchange.OrderSide, args []string) string {
+	if len(args) != 3 && len(args) != 4 {
+		return fmt.Sprintf("usage: /%s <exchange> <pair> <amount> [price]", common.StringToLower(side.ToString()))
+	}
+	exch, err := t.getExchange(args[0])
+	if err != nil {
+		return err.Error()
+	}
+	p, err := pair.ParseCurrencyPair(common.StringToUpper(args[1]))
+	if err != nil {
+		return err.Error()
+	}
+	amount, err := strconv.ParseFloat(args[2], 64)
+	if err != nil || amount <= 0 {
+		return "amount must be above zero"
+	}
+	order := exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    side,
+		OrderType:    exchange.Market,
+		Amount:       amount,
+	}
+	description := fmt.Sprintf("%s %f %s at market on %s", side, amount, p.Pair(), exch.GetName())
+	if len(args) == 4 {
+		order.OrderType = exchange.Limit
+		order.Price, err = strconv.ParseFloat(args[3], 64)
+		if err != nil || order.Price <= 0 {
+			return "price must be above zero"
+		}
+		description = fmt.Sprintf("%s %f %s at %f on %s", side, amount, p.Pair(), order.Price, exch.GetName())
+	}
+
+	return t.stage(key, description, func() (string, error) {
+		resp, err := exch.SubmitOrder(&order)
+		if err != nil {
+			return "", err
+		}
+		if !resp.IsOrderPlaced {
+			return "", errors.New("order was not placed")
+		}
+		return fmt.Sprintf("order %s placed", resp.OrderID), nil
+	})
+}
+
+// stageCancel stages cancelling an order until it is confirmed
+func (t *Telegram) stageCancel(key pendingKey, args []string) string {
+	if len(args) != 2 && len(args) != 3 {
+		return "usage: /cancel <exchange> <orderID> [pair]"
 	}
+	exch, err := t.getExchange(args[0])
+	if err != nil {
+		return err.Error()
+	}
+	cancel := exchange.OrderCancellation{OrderID: args[1]}
+	if len(args) == 3 {
+		cancel.CurrencyPair, err = pair.ParseCurrencyPair(common.StringToUpper(args[2]))
+		if err != nil {
+			return err.Error()
+		}
+	}
+
+	return t.stage(key, fmt.Sprintf("cancel order %s on %s", cancel.OrderID, exch.GetName()),
+		func() (string, error) {
+			err := exch.CancelOrder(cancel)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("order %s cancelled", cancel.OrderID), nil
+		})
+}
+
+// stageCancelAll stages cancelling every open order on an exchange until it
+// is confirmed
+func (t *Telegram) stageCancelAll(key pendingKey, args []string) string {
+	if len(args) != 1 {
+		return "usage: /cancelall <exchange>"
+	}
+	exch, err := t.getExchange(args[0])
+	if err != nil {
+		return err.Error()
+	}
+
+	return t.stage(key, fmt.Sprintf("cancel all orders on %s", exch.GetName()),
+		func() (string, error) {
+			resp, err := exch.CancelAllOrders(exchange.OrderCancellation{})
+			if err != nil {
+				return "", err
+			}
+			if len(resp.OrderStatus) == 0 {
+				return fmt.Sprintf("all %s orders cancelled", exch.GetName()), nil
+			}
+			var failed []string
+			for id, status := range resp.OrderStatus {
+				failed = append(failed, fmt.Sprintf("%s: %s", id, status))
+			}
+			sort.Strings(failed)
+			return fmt.Sprintf("some %s orders were not cancelled\n%s", exch.GetName(),
+				common.JoinStrings(failed, "\n")), nil
+		})
+}
+
+// stage holds a trading command for a user in a chat until they confirm it,
+// replacing any command they already have pending
+func (t *Telegram) stage(key pendingKey, description string, execute func() (string, error)) string {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.pending == nil {
+		t.pending = make(map[pendingKey]pendingCommand)
+	}
+	t.pending[key] = pendingCommand{
+		description: description,
+		execute:     execute,
+		expires:     time.Now().Add(confirmationTimeout),
+	}
+	return fmt.Sprintf("reply %s within %s to %s, or %s", cmdConfirm, confirmationTimeout, description, cmdAbort)
+}
+
+// confirm runs the trading command pending for a user in a chat
+func (t *Telegram) confirm(key pendingKey) string {
+	t.mtx.Lock()
+	cmd, ok := t.pending[key]
+	delete(t.pending, key)
+	t.mtx.Unlock()
+
+	if !ok || time.Now().After(cmd.expires) {
+		return "no trading command awaiting confirmation"
+	}
+	result, err := cmd.execute()
+	if err != nil {
+		return fmt.Sprintf("unable to %s: %s", cmd.description, err)
+	}
+	return result
+}
+
+// abort discards the trading command pending for a user in a chat
+func (t *Telegram) abort(key pendingKey) string {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if _, ok := t.pending[key]; !ok {
+		return "no trading command awaiting confirmation"
+	}
+	delete(t.pending, key)
+	return "trading command aborted"
 }
 
 // GetUpdates gets new updates via a long poll connection
@@ -187,8 +594,50 @@ func (t *Telegram) TestConnection() error {
 	return nil
 }
 
-// SendMessage sends a message to a user by their chatID
+// SendMessage sends a message to a user by their chatID, text longer than
+// Telegram allows is sent as several messages
 func (t *Telegram) SendMessage(text string, chatID int64) error {
+	for _, message := range splitMessage(text) {
+		err := t.sendMessage(message, chatID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitMessage splits text into messages within maxMessageLength, breaking
+// each at its last newline where it has one
+func splitMessage(text string) []string {
+	var messages []string
+	for {
+		end, length := len(text), 0
+		for i, r := range text {
+			// characters outside the basic multilingual plane are encoded
+			// as two UTF-16 code units
+			units := 1
+			if r > 0xFFFF {
+				units = 2
+			}
+			if length+units > maxMessageLength {
+				end = i
+				break
+			}
+			length += units
+		}
+		if end == len(text) {
+			return append(messages, text)
+		}
+		if i := strings.LastIndex(text[:end], "\n"); i > 0 {
+			end = i
+		}
+		messages = append(messages, text[:end])
+		text = strings.TrimPrefix(text[end:], "\n")
+	}
+}
+
+// sendMessage sends a single message to a user by their chatID
+func (t *Telegram) sendMessage(text string, chatID int64) error {
 	path := fmt.Sprintf(apiURL, t.Token, methodSendMessage)
 
 	messageToSend := struct {
@@ -1,10 +1,16 @@
 package telegram
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/thrasher-/gocryptotrader/communications/base"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 var T Telegram
@@ -43,42 +49,42 @@ func TestPushEvent(t *testing.T) {
 func TestHandleMessages(t *testing.T) {
 	t.Parallel()
 	chatID := int64(1337)
-	err := T.HandleMessages(cmdHelp, chatID)
+	err := T.HandleMessages(cmdHelp, chatID, chatID)
 	if err.Error() != "Not Found" {
 		t.Errorf("test failed - telegram HandleMessages() error, expected 'Not found' got '%s'",
 			err)
 	}
-	err = T.HandleMessages(cmdStart, chatID)
+	err = T.HandleMessages(cmdStart, chatID, chatID)
 	if err.Error() != "Not Found" {
 		t.Errorf("test failed - telegram HandleMessages() error, expected 'Not found' got '%s'",
 			err)
 	}
-	err = T.HandleMessages(cmdOrders, chatID)
+	err = T.HandleMessages(cmdOrderbooks, chatID, chatID)
 	if err.Error() != "Not Found" {
 		t.Errorf("test failed - telegram HandleMessages() error, expected 'Not found' got '%s'",
 			err)
 	}
-	err = T.HandleMessages(cmdStatus, chatID)
+	err = T.HandleMessages(cmdStatus, chatID, chatID)
 	if err.Error() != "Not Found" {
 		t.Errorf("test failed - telegram HandleMessages() error, expected 'Not found' got '%s'",
 			err)
 	}
-	err = T.HandleMessages(cmdTicker, chatID)
+	err = T.HandleMessages(cmdTicker, chatID, chatID)
 	if err.Error() != "Not Found" {
 		t.Errorf("test failed - telegram HandleMessages() error, expected 'Not found' got '%s'",
 			err)
 	}
-	err = T.HandleMessages(cmdSettings, chatID)
+	err = T.HandleMessages(cmdSettings, chatID, chatID)
 	if err.Error() != "Not Found" {
 		t.Errorf("test failed - telegram HandleMessages() error, expected 'Not found' got '%s'",
 			err)
 	}
-	err = T.HandleMessages(cmdPortfolio, chatID)
+	err = T.HandleMessages(cmdPortfolio, chatID, chatID)
 	if err.Error() != "Not Found" {
 		t.Errorf("test failed - telegram HandleMessages() error, expected 'Not found' got '%s'",
 			err)
 	}
-	err = T.HandleMessages("Not a command", chatID)
+	err = T.HandleMessages("Not a command", chatID, chatID)
 	if err.Error() != "Not Found" {
 		t.Errorf("test failed - telegram HandleMessages() error, expected 'Not found' got '%s'",
 			err)
@@ -118,3 +124,122 @@ func TestSendHTTPRequest(t *testing.T) {
 		t.Error("test failed - telegram SendHTTPRequest() error")
 	}
 }
+
+func TestHandleCommand(t *testing.T) {
+	var tg Telegram
+	e := exchangetest.New("TelegramTest")
+	e.GetAccountInfoFunc = func() (exchange.AccountInfo, error) {
+		return exchange.AccountInfo{Currencies: []exchange.AccountCurrencyInfo{
+			{CurrencyName: "BTC", TotalValue: 1.5, Hold: 0.5},
+			{CurrencyName: "ETH"},
+		}}, nil
+	}
+	e.Active = []exchange.OrderDetail{{ID: "42", OrderSide: exchange.Buy, OrderType: exchange.Limit,
+		CurrencyPair: pair.NewCurrencyPair("BTC", "USD"), Amount: 1, Price: 100}}
+	var cancelledAll bool
+	e.CancelAllOrdersFunc = func(o exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+		cancelledAll = true
+		return exchange.CancelAllOrdersResponse{}, nil
+	}
+	tg.SetExchanges([]exchange.IBotExchange{e})
+	tg.AuthorisedClients = []int64{1}
+	p := pair.NewCurrencyPair("BTC", "USD")
+	ticker.ProcessTicker("TelegramTest", p, ticker.Price{Last: 101.5}, ticker.Spot)
+	orderbook.ProcessOrderbook("TelegramTest", p, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 101, Amount: 2}},
+	}, orderbook.Spot)
+
+	tests := []struct {
+		text     string
+		chatID   int64
+		expected string
+	}{
+		{"/ticker telegramtest btcusd", 2, "Last: 101.500000"},
+		{"/ticker", 2, "usage: /ticker"},
+		{"/ticker TelegramTest BT", 2, "invalid currency pair"},
+		{"/ticker TelegramTest -USD", 2, "invalid currency pair"},
+		{"/ticker TelegramTest BTC-", 2, "invalid currency pair"},
+		{"/buy TelegramTest -USD 1", 1, "invalid currency pair"},
+		{"/sell TelegramTest BTC_ 1", 1, "invalid currency pair"},
+		{"/orderbooks TelegramTest BTC-USD", 2, "Spread: 200.000000 bps"},
+		{"/start", 2, "your chat ID is 2"},
+		{"/balance TelegramTest", 2, "not authorised"},
+		{"/balance TelegramTest", 1, "BTC: 1.500000 (0.500000 on hold)"},
+		{"/balance Unknown", 1, "exchange Unknown not found"},
+		{"/orders@gctbot TelegramTest", 1, "42 Buy Limit BTCUSD"},
+		{"/alerts", 1, "no alerts"},
+		{"/confirm", 1, "no trading command awaiting confirmation"},
+		{"/buy TelegramTest BTCUSD 0", 1, "amount must be above zero"},
+		{"/blah", 1, "not recognized"},
+	}
+	for _, test := range tests {
+		if reply := tg.handleCommand(test.text, test.chatID, 10); !strings.Contains(reply, test.expected) {
+			t.Errorf("Test failed. handleCommand %s expected %s got %s", test.text, test.expected, reply)
+		}
+	}
+
+	if reply := tg.handleCommand("/buy TelegramTest BTCUSD 0.5 100", 1, 10); !strings.Contains(reply, "/confirm") {
+		t.Errorf("Test failed. Expected buy to await confirmation got %s", reply)
+	}
+	if len(e.Submitted()) != 0 {
+		t.Fatal("Test failed. Order submitted before confirmation")
+	}
+	if reply := tg.handleCommand("/confirm", 2, 10); !strings.Contains(reply, "not authorised") {
+		t.Errorf("Test failed. Expected unauthorised confirmation to be rejected got %s", reply)
+	}
+	if reply := tg.handleCommand("/abort", 1, 11); !strings.Contains(reply, "no trading command") {
+		t.Errorf("Test failed. Expected abort by another user to be rejected got %s", reply)
+	}
+	if reply := tg.handleCommand("/confirm", 1, 11); !strings.Contains(reply, "no trading command") {
+		t.Errorf("Test failed. Expected confirmation by another user to be rejected got %s", reply)
+	}
+	if reply := tg.handleCommand("/confirm", 1, 10); reply != talkRoot+": order 1 placed" {
+		t.Errorf("Test failed. Expected order to be placed got %s", reply)
+	}
+	submitted := e.Submitted()
+	if len(submitted) != 1 || submitted[0].OrderType != exchange.Limit ||
+		submitted[0].Price != 100 || submitted[0].OrderSide != exchange.Buy {
+		t.Errorf("Test failed. Unexpected submitted orders %+v", submitted)
+	}
+
+	tg.handleCommand("/sell TelegramTest BTCUSD 1", 1, 10)
+	if reply := tg.handleCommand("/abort", 1, 10); !strings.Contains(reply, "aborted") {
+		t.Errorf("Test failed. Expected sell to be aborted got %s", reply)
+	}
+	tg.handleCommand("/cancel TelegramTest 42", 1, 10)
+	key := pendingKey{chatID: 1, userID: 10}
+	tg.pending[key] = pendingCommand{description: "expired", execute: tg.pending[key].execute}
+	tg.handleCommand("/confirm", 1, 10)
+	tg.handleCommand("/cancelall TelegramTest", 1, 10)
+	if reply := tg.handleCommand("/confirm", 1, 10); !strings.Contains(reply, "all TelegramTest orders cancelled") {
+		t.Errorf("Test failed. Expected orders to be cancelled got %s", reply)
+	}
+	if len(e.Submitted()) != 1 || len(e.Cancelled()) != 0 || !cancelledAll {
+		t.Errorf("Test failed. Expected only confirmed commands to run got %+v %+v", e.Submitted(), e.Cancelled())
+	}
+}
+
+func TestSplitMessage(t *testing.T) {
+	if m := splitMessage("short"); len(m) != 1 || m[0] != "short" {
+		t.Errorf("Test failed. Expected a single message got %v", m)
+	}
+
+	// long replies are split at the last line which fits
+	line := strings.Repeat("a", 99) + "\n"
+	m := splitMessage(strings.Repeat(line, 50))
+	if len(m) != 2 || len(m[0]) != 40*100-1 || m[1] != strings.Repeat(line, 10) {
+		t.Errorf("Test failed. Expected split at a newline got %d messages", len(m))
+	}
+
+	// lines longer than a message are split at the limit, counting characters
+	// outside the basic multilingual plane twice
+	m = splitMessage(strings.Repeat("é", maxMessageLength+1))
+	if len(m) != 2 || len([]rune(m[0])) != maxMessageLength || m[1] != "é" {
+		t.Errorf("Test failed. Expected split at the limit got %d messages", len(m))
+	}
+	m = splitMessage(strings.Repeat("😀", maxMessageLength/2+1))
+	if len(m) != 2 || len([]rune(m[0])) != maxMessageLength/2 || m[1] != "😀" {
+		t.Errorf("Test failed. Expected split at the limit got %d messages", len(m))
+	}
+}
@@ -1,5 +1,20 @@
 package telegram
 
+import "time"
+
+// pendingKey identifies the user in a chat who staged a trading command
+type pendingKey struct {
+	chatID int64
+	userID int64
+}
+
+// pendingCommand is a trading command awaiting confirmation
+type pendingCommand struct {
+	description string
+	execute     func() (string, error)
+	expires     time.Time
+}
+
 // User holds user information
 type User struct {
 	Ok          bool   `json:"ok"`
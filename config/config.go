@@ -42,7 +42,13 @@ const (
 	ErrExchangeEnabledPairsEmpty                    = "Exchange %s: Enabled pairs is empty."
 	ErrExchangeBaseCurrenciesEmpty                  = "Exchange %s: Base currencies is empty."
 	ErrExchangeNotFound                             = "Exchange %s: Not found."
+	ErrExchangePaperTradingBalanceInvalid           = "Exchange %s: Paper trading starting balance for %s must not be negative."
+	ErrExchangeRiskLimitInvalid                     = "Exchange %s: Risk limits for %s must not be negative."
+	ErrExchangeRiskLimitPairInvalid                 = "Exchange %s: Risk limits pair %s is not a valid currency pair."
 	ErrNoEnabledExchanges                           = "No Exchanges enabled."
+	ErrStrategyNameEmpty                            = "Strategy #%d in config: Strategy name is empty."
+	ErrStrategyExchangesEmpty                       = "Strategy %s: Exchanges is empty."
+	ErrStrategyExchangeNotEnabled                   = "Strategy %s: Exchange %s is not enabled."
 	ErrCryptocurrenciesEmpty                        = "Cryptocurrencies variable is empty."
 	ErrFailureOpeningConfig                         = "Fatal error opening %s file. Error: %s"
 	ErrCheckingConfigValues                         = "Fatal error checking config values. Error: %s"
@@ -105,6 +111,9 @@ type Config struct {
 	Webserver         WebserverConfig      `json:"webserver"`
 	Exchanges         []ExchangeConfig     `json:"exchanges"`
 	BankAccounts      []BankAccount        `json:"bankAccounts"`
+	Recorder          RecorderConfig       `json:"recorder"`
+	Strategies        []StrategyConfig     `json:"strategies,omitempty"`
+	Arbitrage         ArbitrageConfig      `json:"arbitrage"`
 
 	// Deprecated config settings, will be removed at a future date
 	CurrencyPairFormat  *CurrencyPairFormatConfig `json:"currencyPairFormat,omitempty"`
@@ -142,6 +151,143 @@ type ExchangeConfig struct {
 	ConfigCurrencyPairFormat  *CurrencyPairFormatConfig `json:"configCurrencyPairFormat"`
 	RequestCurrencyPairFormat *CurrencyPairFormatConfig `json:"requestCurrencyPairFormat"`
 	BankAccounts              []BankAccount             `json:"bankAccounts"`
+	PaperTrading              *PaperTradingConfig       `json:"paperTrading,omitempty"`
+	Recording                 *RecordingConfig          `json:"recording,omitempty"`
+	RiskLimits                *RiskLimitsConfig         `json:"riskLimits,omitempty"`
+}
+
+// RecorderConfig holds the settings for recording market data to disk
+type RecorderConfig struct {
+	Enabled bool `json:"enabled"`
+	// RotationInterval is the maximum age of a recording file before a new
+	// file is started, zero disables time based rotation
+	RotationInterval time.Duration `json:"rotationInterval"`
+	// MaxFileSize is the maximum uncompressed size in bytes of a recording
+	// file before a new file is started, zero disables size based rotation
+	MaxFileSize int64 `json:"maxFileSize"`
+	// FlushInterval is how often buffered records are written to disk,
+	// defaults to 10 seconds when zero
+	FlushInterval time.Duration `json:"flushInterval"`
+}
+
+// RecordingConfig selects the market data recorded for an exchange
+type RecordingConfig struct {
+	Enabled bool `json:"enabled"`
+	// Pairs is a comma separated list of the pairs to record, all pairs are
+	// recorded when empty
+	Pairs      string `json:"pairs,omitempty"`
+	Tickers    bool   `json:"tickers"`
+	Orderbooks bool   `json:"orderbooks"`
+	Trades     bool   `json:"trades"`
+	Klines     bool   `json:"klines"`
+}
+
+// StrategyConfig enables a registered trading strategy and holds the markets
+// it runs against
+type StrategyConfig struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Exchanges is a comma separated list of the exchanges the strategy
+	// receives events from and may trade on
+	Exchanges string `json:"exchanges"`
+	// Pairs is a comma separated list of the pairs the strategy receives
+	// events for and may trade, all enabled pairs are used when empty
+	Pairs string `json:"pairs,omitempty"`
+	// TimerInterval is the interval between OnTimer calls, zero disables the
+	// timer
+	TimerInterval time.Duration `json:"timerInterval"`
+	// Parameters are decoded by the strategy
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
+// ArbitrageConfig holds the settings for scanning the orderbook cache for
+// cross exchange arbitrage opportunities
+type ArbitrageConfig struct {
+	// Enabled turns on scanning for opportunities between exchanges
+	Enabled bool `json:"enabled"`
+	// ScanInterval is the delay between scans of the orderbook cache
+	ScanInterval time.Duration `json:"scanInterval"`
+	// Pairs is a comma separated list of the pairs to scan, all enabled pairs
+	// are scanned when empty
+	Pairs string `json:"pairs,omitempty"`
+	// IncludeUSDT treats USDT quoted pairs as relatable to USD quoted pairs
+	IncludeUSDT bool `json:"includeUSDT"`
+	// MinProfitPercent is the minimum net profit, as a percentage of the cost
+	// of the buy side, for an opportunity to be reported
+	MinProfitPercent float64 `json:"minProfitPercent"`
+	// DefaultTradeFee is the taker fee percentage used for exchanges which
+	// are unable to provide their trading fee
+	DefaultTradeFee float64 `json:"defaultTradeFee"`
+	// IncludeWithdrawalFees deducts the fee for withdrawing the purchased
+	// currency to the selling exchange from the net profit
+	IncludeWithdrawalFees bool `json:"includeWithdrawalFees"`
+	// Triangular holds the settings for scanning for triangular arbitrage
+	// within a single exchange
+	Triangular TriangularArbitrageConfig `json:"triangular"`
+}
+
+// TriangularArbitrageConfig holds the settings for scanning each exchange for
+// profitable cycles of three trades
+type TriangularArbitrageConfig struct {
+	Enabled bool `json:"enabled"`
+	// Exchanges is a comma separated list of the exchanges to scan, all
+	// exchanges are scanned when empty
+	Exchanges string `json:"exchanges,omitempty"`
+	// Currencies is a comma separated list of the currencies a cycle may start
+	// from, each cycle is reported once when empty
+	Currencies string `json:"currencies,omitempty"`
+	// MinProfitPercent is the minimum net profit, as a percentage of the
+	// starting amount, for a cycle to be reported
+	MinProfitPercent float64 `json:"minProfitPercent"`
+}
+
+// RiskLimitsConfig holds the pre-trade risk limits enforced on orders
+// submitted to an exchange
+type RiskLimitsConfig struct {
+	Enabled bool `json:"enabled"`
+	// Default applies to every currency pair on the exchange
+	Default RiskLimits `json:"default"`
+	// Pairs overrides the non zero default limits for a currency pair, keyed
+	// by the pair such as "BTCUSD"
+	Pairs map[string]RiskLimits `json:"pairs,omitempty"`
+}
+
+// RiskLimits holds the limits for a currency pair, a zero value disables the
+// limit
+type RiskLimits struct {
+	// MaxOrderNotional is the maximum value of a single order in the quote
+	// currency
+	MaxOrderNotional float64 `json:"maxOrderNotional"`
+	// MaxPosition is the maximum amount of the base currency held, including
+	// open buy orders
+	MaxPosition float64 `json:"maxPosition"`
+	// MaxOpenOrders is the maximum number of open orders
+	MaxOpenOrders int `json:"maxOpenOrders"`
+	// MaxPriceDeviation is the maximum percentage a limit price may deviate
+	// from the last ticker price
+	MaxPriceDeviation float64 `json:"maxPriceDeviation"`
+	// DailyLossLimit is the maximum realised loss in the quote currency per
+	// UTC day before new orders are rejected
+	DailyLossLimit float64 `json:"dailyLossLimit"`
+}
+
+// IsValid returns whether no limits are negative
+func (r RiskLimits) IsValid() bool {
+	return r.MaxOrderNotional >= 0 && r.MaxPosition >= 0 && r.MaxOpenOrders >= 0 &&
+		r.MaxPriceDeviation >= 0 && r.DailyLossLimit >= 0
+}
+
+// PaperTradingConfig holds the settings for simulating an exchange's order
+// and account functions against its live market data
+type PaperTradingConfig struct {
+	Enabled bool `json:"enabled"`
+	// StartingBalances maps currency codes to the simulated balance held at
+	// startup
+	StartingBalances map[string]float64 `json:"startingBalances"`
+	// MakerFee and TakerFee override the exchange's default fees when non
+	// zero, values are percentages
+	MakerFee float64 `json:"makerFee,omitempty"`
+	TakerFee float64 `json:"takerFee,omitempty"`
 }
 
 // BankAccount holds differing bank account details by supported funding
@@ -222,12 +368,15 @@ type SMTPConfig struct {
 	RecipientList   string `json:"recipientList"`
 }
 
-// TelegramConfig holds all variables to start and run the Telegram package
+// TelegramConfig holds all variables to start and run the Telegram package.
+// AuthorisedChatIDs are the chats allowed to use trading commands and which
+// receive pushed events
 type TelegramConfig struct {
-	Name              string `json:"name"`
-	Enabled           bool   `json:"enabled"`
-	Verbose           bool   `json:"verbose"`
-	VerificationToken string `json:"verificationToken"`
+	Name              string  `json:"name"`
+	Enabled           bool    `json:"enabled"`
+	Verbose           bool    `json:"verbose"`
+	VerificationToken string  `json:"verificationToken"`
+	AuthorisedChatIDs []int64 `json:"authorisedChatIDs"`
 }
 
 // GetCurrencyConfig returns currency configurations
@@ -356,6 +505,54 @@ func (c *Config) GetCommunicationsConfig() CommunicationsConfig {
 	return c.Communications
 }
 
+// GetRecorderConfig returns the market data recorder configuration
+func (c *Config) GetRecorderConfig() RecorderConfig {
+	m.Lock()
+	defer m.Unlock()
+	return c.Recorder
+}
+
+// GetArbitrageConfig returns the arbitrage scanner configuration
+func (c *Config) GetArbitrageConfig() ArbitrageConfig {
+	m.Lock()
+	defer m.Unlock()
+	return c.Arbitrage
+}
+
+// GetStrategiesConfig returns the strategy configurations
+func (c *Config) GetStrategiesConfig() []StrategyConfig {
+	m.Lock()
+	defer m.Unlock()
+	return c.Strategies
+}
+
+// CheckStrategyConfigValues checks that enabled strategies are named and only
+// reference enabled exchanges
+func (c *Config) CheckStrategyConfigValues() error {
+	for x := range c.Strategies {
+		if !c.Strategies[x].Enabled {
+			continue
+		}
+		if c.Strategies[x].Name == "" {
+			return fmt.Errorf(ErrStrategyNameEmpty, x)
+		}
+		if c.Strategies[x].Exchanges == "" {
+			return fmt.Errorf(ErrStrategyExchangesEmpty, c.Strategies[x].Name)
+		}
+		for _, exchName := range common.SplitStrings(c.Strategies[x].Exchanges, ",") {
+			exchName = common.TrimString(exchName, " ")
+			exch, err := c.GetExchangeConfig(exchName)
+			if err != nil {
+				return err
+			}
+			if !exch.Enabled {
+				return fmt.Errorf(ErrStrategyExchangeNotEnabled, c.Strategies[x].Name, exchName)
+			}
+		}
+	}
+	return nil
+}
+
 // UpdateCommunicationsConfig sets a new updated version of a Communications
 // configuration
 func (c *Config) UpdateCommunicationsConfig(config CommunicationsConfig) {
@@ -756,6 +953,28 @@ func (c *Config) CheckExchangeConfigValues() error {
 				log.Printf("Exchange %s: CheckPairConsistency error: %s", exch.Name, err)
 			}
 
+			if exch.PaperTrading != nil && exch.PaperTrading.Enabled {
+				for currency, balance := range exch.PaperTrading.StartingBalances {
+					if balance < 0 {
+						return fmt.Errorf(ErrExchangePaperTradingBalanceInvalid, exch.Name, currency)
+					}
+				}
+			}
+
+			if exch.RiskLimits != nil && exch.RiskLimits.Enabled {
+				if !exch.RiskLimits.Default.IsValid() {
+					return fmt.Errorf(ErrExchangeRiskLimitInvalid, exch.Name, "default")
+				}
+				for p, limits := range exch.RiskLimits.Pairs {
+					if _, err := pair.ParseCurrencyPair(common.StringToUpper(p)); err != nil {
+						return fmt.Errorf(ErrExchangeRiskLimitPairInvalid, exch.Name, p)
+					}
+					if !limits.IsValid() {
+						return fmt.Errorf(ErrExchangeRiskLimitInvalid, exch.Name, p)
+					}
+				}
+			}
+
 			if len(exch.BankAccounts) == 0 {
 				c.Exchanges[i].BankAccounts = append(c.Exchanges[i].BankAccounts, BankAccount{})
 			} else {
@@ -1174,6 +1393,11 @@ func (c *Config) CheckConfig() error {
 		return err
 	}
 
+	err = c.CheckStrategyConfigValues()
+	if err != nil {
+		return fmt.Errorf(ErrCheckingConfigValues, err)
+	}
+
 	if c.GlobalHTTPTimeout <= 0 {
 		log.Printf("Global HTTP Timeout value not set, defaulting to %v.", configDefaultHTTPTimeout)
 		c.GlobalHTTPTimeout = configDefaultHTTPTimeout
@@ -168,6 +168,47 @@ func TestGetCommunicationsConfig(t *testing.T) {
 	_ = cfg.GetCommunicationsConfig()
 }
 
+func TestCheckStrategyConfigValues(t *testing.T) {
+	cfg := Config{
+		Exchanges: []ExchangeConfig{
+			{Name: "Bitstamp", Enabled: true},
+			{Name: "Kraken"},
+		},
+		Strategies: []StrategyConfig{
+			{Name: "test", Enabled: true, Exchanges: "Bitstamp"},
+			{Enabled: false},
+		},
+	}
+
+	err := cfg.CheckStrategyConfigValues()
+	if err != nil {
+		t.Errorf("Test failed. CheckStrategyConfigValues error: %s", err)
+	}
+	if len(cfg.GetStrategiesConfig()) != 2 {
+		t.Error("Test failed. GetStrategiesConfig returned unexpected strategies")
+	}
+
+	cfg.Strategies[1].Enabled = true
+	if cfg.CheckStrategyConfigValues() == nil {
+		t.Error("Test failed. CheckStrategyConfigValues expected error for empty name")
+	}
+
+	cfg.Strategies[1].Name = "test2"
+	if cfg.CheckStrategyConfigValues() == nil {
+		t.Error("Test failed. CheckStrategyConfigValues expected error for empty exchanges")
+	}
+
+	cfg.Strategies[1].Exchanges = "Bitstamp, Kraken"
+	if cfg.CheckStrategyConfigValues() == nil {
+		t.Error("Test failed. CheckStrategyConfigValues expected error for disabled exchange")
+	}
+
+	cfg.Strategies[1].Exchanges = "Bitstamp,Poloniex"
+	if cfg.CheckStrategyConfigValues() == nil {
+		t.Error("Test failed. CheckStrategyConfigValues expected error for missing exchange")
+	}
+}
+
 func TestUpdateCommunicationsConfig(t *testing.T) {
 	cfg := GetConfig()
 	err := cfg.LoadConfig(ConfigTestFile)
@@ -659,6 +700,18 @@ func TestCheckExchangeConfigValues(t *testing.T) {
 		)
 	}
 
+	checkExchangeConfigValues.Exchanges[0].RiskLimits = &RiskLimitsConfig{
+		Enabled: true,
+		Pairs:   map[string]RiskLimits{"BTC": {MaxOrderNotional: 1}},
+	}
+	err = checkExchangeConfigValues.CheckExchangeConfigValues()
+	if err == nil {
+		t.Errorf(
+			"Test failed. checkExchangeConfigValues.CheckExchangeConfigValues expected invalid risk limits pair error",
+		)
+	}
+	checkExchangeConfigValues.Exchanges[0].RiskLimits = nil
+
 	checkExchangeConfigValues.Exchanges[0].BaseCurrencies = ""
 	err = checkExchangeConfigValues.CheckExchangeConfigValues()
 	if err == nil {
@@ -905,3 +958,12 @@ func TestUpdateConfig(t *testing.T) {
 		t.Fatalf("Test failed. Cryptocurrencies should have been repopulated")
 	}
 }
+
+func TestRiskLimitsIsValid(t *testing.T) {
+	if !(RiskLimits{}).IsValid() {
+		t.Error("Test failed. Expected zero risk limits to be valid")
+	}
+	if (RiskLimits{MaxOrderNotional: 1, MaxOpenOrders: -1}).IsValid() {
+		t.Error("Test failed. Expected negative risk limits to be invalid")
+	}
+}
@@ -1,6 +1,7 @@
 package pair
 
 import (
+	"fmt"
 	"math/rand"
 	"strings"
 
@@ -132,6 +133,27 @@ func NewCurrencyPairFromString(currency string) CurrencyPair {
 	return NewCurrencyPair(currency[0:3], currency[3:])
 }
 
+// ParseCurrencyPair converts a currency string into a new CurrencyPair in the
+// same manner as NewCurrencyPairFromString, but returns an error instead of
+// panicking when the string is not a valid pair
+func ParseCurrencyPair(currency string) (CurrencyPair, error) {
+	delimiters := []string{"_", "-"}
+	for _, x := range delimiters {
+		if !strings.Contains(currency, x) {
+			continue
+		}
+		result := strings.Split(currency, x)
+		if len(result) != 2 || result[0] == "" || result[1] == "" {
+			return CurrencyPair{}, fmt.Errorf("invalid currency pair %q", currency)
+		}
+		return NewCurrencyPairDelimiter(currency, x), nil
+	}
+	if len(currency) < 6 {
+		return CurrencyPair{}, fmt.Errorf("invalid currency pair %q", currency)
+	}
+	return NewCurrencyPairFromString(currency), nil
+}
+
 // Contains checks to see if a specified pair exists inside a currency pair
 // array
 func Contains(pairs []CurrencyPair, p CurrencyPair, exact bool) bool {
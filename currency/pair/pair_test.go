@@ -261,6 +261,28 @@ func TestNewCurrencyPairFromString(t *testing.T) {
 	}
 }
 
+func TestParseCurrencyPair(t *testing.T) {
+	t.Parallel()
+	for _, pairStr := range []string{"BTC-USD", "BTC_USD", "BTCUSD"} {
+		pair, err := ParseCurrencyPair(pairStr)
+		if err != nil {
+			t.Errorf("Test failed. ParseCurrencyPair(%s): %s", pairStr, err)
+			continue
+		}
+		if pair.FirstCurrency != "BTC" || pair.SecondCurrency != "USD" {
+			t.Errorf("Test failed. ParseCurrencyPair(%s): unexpected pair %s",
+				pairStr, pair.Pair())
+		}
+	}
+
+	for _, pairStr := range []string{"", "X", "BT", "BTCUS", "-", "BTC-", "_USD", "BTC-USD-EUR"} {
+		_, err := ParseCurrencyPair(pairStr)
+		if err == nil {
+			t.Errorf("Test failed. ParseCurrencyPair(%s) returned no error", pairStr)
+		}
+	}
+}
+
 func TestContains(t *testing.T) {
 	pairOne := NewCurrencyPair("BTC", "USD")
 	pairTwo := NewCurrencyPair("LTC", "USD")
@@ -1,5 +1,23 @@
 package events
 
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
 //
 // import (
 // 	"testing"
@@ -367,3 +385,522 @@ package events
 // 		t.Error("Test Failed. IsValidItem: Error, incorrect return")
 // 	}
 // }
+
+// newTestExchange returns an exchange holding 2 BTC which counts its account
+// info requests
+func newTestExchange() (*exchangetest.Exchange, *int) {
+	exch := exchangetest.New("EventsTest")
+	var accountCalls int
+	exch.GetAccountInfoFunc = func() (exchange.AccountInfo, error) {
+		accountCalls++
+		return exchange.AccountInfo{
+			Currencies: []exchange.AccountCurrencyInfo{{CurrencyName: "BTC", TotalValue: 2}},
+		}, nil
+	}
+	return exch, &accountCalls
+}
+
+type fakeNotifier struct {
+	all    []base.Event
+	medium map[string][]base.Event
+}
+
+func (f *fakeNotifier) PushEvent(event base.Event) {
+	f.all = append(f.all, event)
+}
+
+func (f *fakeNotifier) PushEventToMedium(medium string, event base.Event) error {
+	f.medium[medium] = append(f.medium[medium], event)
+	return nil
+}
+
+func testSetup() pair.CurrencyPair {
+	cfg := config.GetConfig()
+	if !IsValidExchange("EventsTest") {
+		cfg.Exchanges = append(cfg.Exchanges, config.ExchangeConfig{Name: "EventsTest", Enabled: true})
+	}
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	ticker.ProcessTicker("EventsTest", p, ticker.Price{
+		Pair: p, Last: 100, Bid: 99, Ask: 101, Volume: 1000,
+	}, ticker.Spot)
+	orderbook.ProcessOrderbook("EventsTest", p, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 3}},
+		Asks: []orderbook.Item{{Price: 101, Amount: 1}, {Price: 110, Amount: 1}},
+	}, orderbook.Spot)
+	return p
+}
+
+func testCondition(p pair.CurrencyPair, item Item, op Operator, value float64) Condition {
+	return Condition{
+		Exchange: "EventsTest",
+		Pair:     p,
+		Asset:    ticker.Spot,
+		Item:     item,
+		Operator: op,
+		Value:    value,
+	}
+}
+
+func TestIsValidEvent(t *testing.T) {
+	p := testSetup()
+	valid := func() *Event {
+		return &Event{
+			Logic:      Or,
+			Conditions: []Condition{testCondition(p, ItemPrice, GreaterThan, 10)},
+			Actions:    []Action{{Type: ActionTest}},
+		}
+	}
+
+	if err := IsValidEvent(valid()); err != nil {
+		t.Errorf("Test Failed. IsValidEvent: %s", err)
+	}
+
+	tests := []struct {
+		modify   func(e *Event)
+		expected error
+	}{
+		{func(e *Event) { e.Logic = "XOR" }, errInvalidLogic},
+		{func(e *Event) { e.Conditions = nil }, errNoConditions},
+		{func(e *Event) { e.Actions = nil }, errNoActions},
+		{func(e *Event) { e.Cooldown = -time.Second }, errInvalidCooldown},
+		{func(e *Event) { e.Conditions[0].Exchange = "Testys" }, errExchangeDisabled},
+		{func(e *Event) { e.Conditions[0].Item = "Testy" }, errInvalidItem},
+		{func(e *Event) { e.Conditions[0].Operator = "^" }, errInvalidCondition},
+		{func(e *Event) { e.Conditions[0].Pair = pair.CurrencyPair{} }, errInvalidPair},
+		{func(e *Event) { e.Conditions[0].Item = ItemDepth }, errInvalidParam},
+		{func(e *Event) { e.Conditions[0].Item = ItemPercentChange }, errInvalidWindow},
+		{func(e *Event) { e.Conditions[0].Item = ItemBalance }, errInvalidCurrency},
+		{func(e *Event) { e.Actions[0].Type = "blah" }, errInvalidAction},
+		{func(e *Event) { e.Actions[0] = Action{Type: ActionWebhook, URL: "ftp://host"} }, errInvalidWebhook},
+		{func(e *Event) { e.Actions[0] = Action{Type: ActionOrder} }, errInvalidOrder},
+		{func(e *Event) {
+			e.Actions[0] = Action{Type: ActionOrder, Order: &OrderAction{
+				Exchange: "EventsTest", Pair: p, Side: exchange.Buy, OrderType: exchange.Limit, Amount: 1,
+			}}
+		}, errInvalidOrder},
+	}
+	for i, test := range tests {
+		e := valid()
+		test.modify(e)
+		if err := IsValidEvent(e); err != test.expected {
+			t.Errorf("Test Failed. IsValidEvent test %d expected %v got %v", i, test.expected, err)
+		}
+	}
+
+	e := valid()
+	e.Logic = ""
+	e.Conditions[0].Asset = ""
+	if _, err := AddEvent(e); err != nil {
+		t.Fatalf("Test Failed. AddEvent: %s", err)
+	}
+	if e.Logic != And || e.Conditions[0].Asset != ticker.Spot {
+		t.Errorf("Test Failed. AddEvent: defaults not set %+v", e)
+	}
+	if !RemoveEvent(e.ID) || RemoveEvent(e.ID) {
+		t.Error("Test Failed. RemoveEvent: Error, error removing event")
+	}
+}
+
+func TestConditionIsMet(t *testing.T) {
+	p := testSetup()
+	exch, accountCalls := newTestExchange()
+	SetExchanges([]exchange.IBotExchange{exch})
+
+	tests := []struct {
+		item     Item
+		param    float64
+		operator Operator
+		value    float64
+		expected bool
+	}{
+		{ItemPrice, 0, Equal, 100, true},
+		{ItemBid, 0, GreaterThanOrEqual, 99, true},
+		{ItemAsk, 0, LessThan, 101, false},
+		{ItemVolume, 0, GreaterThan, 500, true},
+		{ItemSpread, 0, GreaterThanOrEqual, 200, true},
+		{ItemSpread, 0, GreaterThan, 200, false},
+		{ItemImbalance, 0, Equal, 0.2, true},
+		{ItemImbalance, 200, Equal, 0.5, true},
+		{ItemDepth, 200, Equal, 4, true},
+		{ItemBuySlippage, 2, GreaterThan, 500, true},
+		{ItemSellSlippage, 1, LessThanOrEqual, 100, true},
+	}
+	for _, test := range tests {
+		c := testCondition(p, test.item, test.operator, test.value)
+		c.Param = test.param
+		met, err := c.IsMet()
+		if err != nil || met != test.expected {
+			t.Errorf("Test Failed. IsMet: %s expected %v got %v %v", c.String(), test.expected, met, err)
+		}
+	}
+
+	c := testCondition(p, ItemBuySlippage, GreaterThan, 0)
+	c.Param = 3
+	if _, err := c.IsMet(); err != orderbook.ErrInsufficientLiquidity {
+		t.Errorf("Test Failed. IsMet: expected %s got %v", orderbook.ErrInsufficientLiquidity, err)
+	}
+
+	c = testCondition(p, ItemBalance, GreaterThanOrEqual, 2)
+	c.Currency = "btc"
+	for i := 0; i < 2; i++ {
+		if met, err := c.IsMet(); err != nil || !met {
+			t.Errorf("Test Failed. IsMet: balance expected met got %v %v", met, err)
+		}
+	}
+	if *accountCalls != 1 {
+		t.Errorf("Test Failed. Expected account info to be cached got %d calls", *accountCalls)
+	}
+
+	c = testCondition(p, ItemPercentChange, LessThan, -5)
+	c.Window = time.Minute
+	if _, err := c.IsMet(); err != errInsufficientPrices {
+		t.Errorf("Test Failed. IsMet: expected %s got %v", errInsufficientPrices, err)
+	}
+	history.mtx.Lock()
+	history.samples[c.key()] = []priceSample{
+		{time.Now().Add(-time.Hour * 26), 50},
+		{time.Now().Add(-time.Hour * 25), 60},
+		{time.Now().Add(-time.Hour), 120},
+		{time.Now().Add(-time.Second * 30), 100},
+	}
+	history.mtx.Unlock()
+	met, err := c.IsMet()
+	if err != nil || !met {
+		t.Errorf("Test Failed. IsMet: expected percent change to be met got %v %v", met, err)
+	}
+	history.record(c.key(), 100)
+	history.mtx.Lock()
+	if samples := history.samples[c.key()]; len(samples) != 4 || samples[0].price != 60 {
+		t.Errorf("Test Failed. Expected expired prices to be removed got %v", history.samples[c.key()])
+	}
+	history.mtx.Unlock()
+}
+
+func TestCheckCondition(t *testing.T) {
+	p := testSetup()
+	met := testCondition(p, ItemPrice, GreaterThan, 10)
+	unmet := testCondition(p, ItemPrice, LessThan, 10)
+
+	e := Event{Logic: And, Conditions: []Condition{met, unmet}, Actions: []Action{{Type: ActionTest}}}
+	if e.CheckCondition() {
+		t.Error("Test Failed. CheckCondition: AND triggered with an unmet condition")
+	}
+	e.Logic = Or
+	if !e.CheckCondition() || !e.Executed || e.CheckCondition() {
+		t.Error("Test Failed. CheckCondition: OR event should trigger once")
+	}
+
+	e = Event{Logic: And, Conditions: []Condition{met}, Actions: []Action{{Type: ActionTest}}, Rearm: true}
+	if !e.CheckCondition() || e.CheckCondition() {
+		t.Error("Test Failed. CheckCondition: rearming event triggered before its conditions cleared")
+	}
+	e.Conditions[0] = unmet
+	e.CheckCondition()
+	e.Conditions[0] = met
+	if !e.CheckCondition() || e.Executed || e.TriggerCount != 2 {
+		t.Errorf("Test Failed. CheckCondition: rearmed event did not trigger %+v", e)
+	}
+
+	e = Event{Logic: And, Conditions: []Condition{met}, Actions: []Action{{Type: ActionTest}}, Cooldown: time.Hour}
+	if !e.CheckCondition() || e.CheckCondition() {
+		t.Error("Test Failed. CheckCondition: event triggered during its cooldown")
+	}
+	e.LastTriggered = time.Now().Add(-time.Hour)
+	if !e.CheckCondition() {
+		t.Error("Test Failed. CheckCondition: event did not trigger after its cooldown")
+	}
+}
+
+func TestExecuteAction(t *testing.T) {
+	p := testSetup()
+	exch := exchangetest.New("EventsTest")
+	SetExchanges([]exchange.IBotExchange{exch})
+	n := &fakeNotifier{medium: make(map[string][]base.Event)}
+	SetComms(n)
+
+	var received webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		common.JSONDecode(mustReadAll(r), &received)
+	}))
+	defer server.Close()
+
+	e := Event{
+		ID:         7,
+		Logic:      And,
+		Conditions: []Condition{testCondition(p, ItemPrice, GreaterThan, 10)},
+		Actions: []Action{
+			{Type: ActionNotify},
+			{Type: ActionNotify, Medium: "Slack"},
+			{Type: ActionWebhook, URL: server.URL},
+			{Type: ActionOrder, Order: &OrderAction{
+				Exchange: "eventstest", Pair: p, Side: exchange.Buy, OrderType: exchange.Market, Amount: 1,
+			}},
+		},
+	}
+	if !e.CheckCondition() {
+		t.Fatal("Test Failed. CheckCondition: event did not trigger")
+	}
+	if len(n.all) != 1 || len(n.medium["Slack"]) != 1 {
+		t.Errorf("Test Failed. ExecuteAction: unexpected notifications %+v", n)
+	}
+	if received.ID != 7 || received.TriggerCount != 1 || received.Event != e.String() {
+		t.Errorf("Test Failed. ExecuteAction: unexpected webhook payload %+v", received)
+	}
+	if submitted := exch.Submitted(); len(submitted) != 1 || submitted[0].Amount != 1 || submitted[0].OrderSide != exchange.Buy {
+		t.Errorf("Test Failed. ExecuteAction: unexpected orders %+v", submitted)
+	}
+
+	e.Actions = []Action{{Type: ActionOrder, Order: &OrderAction{Exchange: "Testys"}}}
+	if e.ExecuteAction() {
+		t.Error("Test Failed. ExecuteAction: expected order on unknown exchange to fail")
+	}
+}
+
+func mustReadAll(r *http.Request) []byte {
+	b, _ := ioutil.ReadAll(r.Body)
+	return b
+}
+
+func TestStartStop(t *testing.T) {
+	p := testSetup()
+	Start()
+	Start()
+
+	price := testCondition(p, ItemPrice, GreaterThan, 150)
+	spread := testCondition(p, ItemSpread, LessThan, 100)
+	id, err := AddEvent(&Event{Logic: Or, Conditions: []Condition{price, spread}, Actions: []Action{{Type: ActionTest}}})
+	if err != nil {
+		t.Fatalf("Test Failed. AddEvent: %s", err)
+	}
+	defer RemoveEvent(id)
+
+	executed := func() bool {
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			for _, e := range GetEvents() {
+				if e.ID == id && e.Executed {
+					return true
+				}
+			}
+			time.Sleep(time.Millisecond * 10)
+		}
+		return false
+	}
+
+	orderbook.ProcessOrderbook("OtherExchange", p, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99.9, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 100, Amount: 1}},
+	}, orderbook.Spot)
+	ticker.ProcessTicker("EventsTest", p, ticker.Price{Last: 120}, ticker.Spot)
+	if executed() {
+		t.Fatal("Test Failed. Event triggered by an unmet update")
+	}
+
+	ticker.ProcessTicker("EventsTest", p, ticker.Price{Last: 200}, ticker.Spot)
+	if !executed() {
+		t.Error("Test Failed. Event not triggered by ticker update")
+	}
+
+	// prices are recorded for PERCENT_CHANGE while an earlier AND condition
+	// is unmet and the condition is not evaluated
+	change := testCondition(p, ItemPercentChange, GreaterThan, 0)
+	change.Window = time.Minute
+	changeID, err := AddEvent(&Event{
+		Logic:      And,
+		Conditions: []Condition{testCondition(p, ItemPrice, GreaterThan, 1000), change},
+		Actions:    []Action{{Type: ActionTest}},
+	})
+	if err != nil {
+		t.Fatalf("Test Failed. AddEvent: %s", err)
+	}
+	history.mtx.Lock()
+	delete(history.samples, change.key())
+	history.mtx.Unlock()
+	ticker.ProcessTicker("EventsTest", p, ticker.Price{Last: 210}, ticker.Spot)
+	recorded := false
+	for deadline := time.Now().Add(time.Second); !recorded && time.Now().Before(deadline); {
+		history.mtx.Lock()
+		recorded = len(history.samples[change.key()]) == 1
+		history.mtx.Unlock()
+		time.Sleep(time.Millisecond * 10)
+	}
+	if !recorded {
+		t.Error("Test Failed. Price not recorded for unevaluated PERCENT_CHANGE condition")
+	}
+	RemoveEvent(changeID)
+
+	Stop()
+	Stop()
+	if total, done := GetEventCounter(); total != 1 || done != 1 {
+		t.Errorf("Test Failed. GetEventCounter: expected 1 executed event got %d %d", total, done)
+	}
+}
+
+func TestStartKeepsLatestUpdate(t *testing.T) {
+	p := testSetup()
+	exch := exchangetest.New("EventsTest")
+	// the order action blocks until block is closed
+	block, entered := make(chan struct{}), make(chan struct{})
+	exch.SubmitOrderFunc = func(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+		close(entered)
+		<-block
+		return exchange.SubmitOrderResponse{OrderID: "1", IsOrderPlaced: true}, nil
+	}
+	SetExchanges([]exchange.IBotExchange{exch})
+
+	slow, err := AddEvent(&Event{
+		Logic:      And,
+		Conditions: []Condition{testCondition(p, ItemPrice, GreaterThan, 150)},
+		Actions: []Action{{Type: ActionOrder, Order: &OrderAction{
+			Exchange: "EventsTest", Pair: p, Side: exchange.Buy, OrderType: exchange.Market, Amount: 1,
+		}}},
+	})
+	if err != nil {
+		t.Fatalf("Test Failed. AddEvent: %s", err)
+	}
+	defer RemoveEvent(slow)
+	latest, err := AddEvent(&Event{
+		Logic:      And,
+		Conditions: []Condition{testCondition(p, ItemPrice, GreaterThan, 1000)},
+		Actions:    []Action{{Type: ActionTest}},
+	})
+	if err != nil {
+		t.Fatalf("Test Failed. AddEvent: %s", err)
+	}
+	defer RemoveEvent(latest)
+
+	Start()
+	defer Stop()
+	ticker.ProcessTicker("EventsTest", p, ticker.Price{Last: 200}, ticker.Spot)
+	select {
+	case <-entered:
+	case <-time.After(time.Second):
+		close(block)
+		t.Fatal("Test Failed. Order action not executed")
+	}
+
+	// updates keep being received while the action blocks, more of them for
+	// another pair than a subscription buffers
+	other := pair.NewCurrencyPair("ETH", "USD")
+	for i := 0; i < 500; i++ {
+		ticker.ProcessTicker("EventsTest", other, ticker.Price{Last: 200}, ticker.Spot)
+	}
+	time.Sleep(time.Millisecond * 50)
+	ticker.ProcessTicker("EventsTest", p, ticker.Price{Last: 2000}, ticker.Spot)
+	time.Sleep(time.Millisecond * 50)
+	close(block)
+
+	for deadline := time.Now().Add(time.Second); ; {
+		if e, err := GetEvent(latest); err == nil && e.Executed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Test Failed. Latest update not checked once the action finished")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func TestCheckEventsReleasesLock(t *testing.T) {
+	p := testSetup()
+	exch, _ := newTestExchange()
+	getAccountInfo := exch.GetAccountInfoFunc
+	// account info is fetched once block is closed
+	block, entered := make(chan struct{}), make(chan struct{})
+	exch.GetAccountInfoFunc = func() (exchange.AccountInfo, error) {
+		entered <- struct{}{}
+		<-block
+		return getAccountInfo()
+	}
+	SetExchanges([]exchange.IBotExchange{exch})
+	balances.mtx.Lock()
+	balances.balances = make(map[string]balance)
+	balances.mtx.Unlock()
+
+	c := testCondition(p, ItemBalance, GreaterThanOrEqual, 2)
+	c.Currency = "BTC"
+	id, err := AddEvent(&Event{Logic: And, Conditions: []Condition{c}, Actions: []Action{{Type: ActionTest}}})
+	if err != nil {
+		t.Fatalf("Test Failed. AddEvent: %s", err)
+	}
+	defer RemoveEvent(id)
+
+	done := make(chan struct{})
+	go func() {
+		CheckEvents()
+		close(done)
+	}()
+
+	// the chain can be read while account info is being fetched
+	<-entered
+	read := make(chan struct{})
+	go func() {
+		GetEvents()
+		close(read)
+	}()
+	select {
+	case <-read:
+	case <-time.After(time.Second):
+		t.Error("Test Failed. GetEvents blocked while conditions were checked")
+	}
+
+	close(block)
+	<-done
+	if e, err := GetEvent(id); err != nil || !e.Executed {
+		t.Errorf("Test Failed. Expected BALANCE event to trigger got %+v %v", e, err)
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	p := testSetup()
+	dir, err := ioutil.TempDir("", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func() {
+		m.Lock()
+		Events = nil
+		filePath = ""
+		m.Unlock()
+	}()
+
+	if err = Load(dir); err != nil {
+		t.Fatalf("Test Failed. Load error: %s", err)
+	}
+	condition := testCondition(p, ItemPercentChange, LessThan, -5)
+	condition.Window = time.Hour
+	id, err := AddEvent(&Event{
+		Conditions: []Condition{condition},
+		Actions:    []Action{{Type: ActionNotify, Medium: "Slack"}},
+		Cooldown:   time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Test Failed. AddEvent: %s", err)
+	}
+	removed, err := AddEvent(&Event{Conditions: []Condition{condition}, Actions: []Action{{Type: ActionTest}}})
+	if err != nil {
+		t.Fatalf("Test Failed. AddEvent: %s", err)
+	}
+	RemoveEvent(removed)
+
+	m.Lock()
+	Events = nil
+	lastID = 0
+	m.Unlock()
+	if err = Load(dir); err != nil {
+		t.Fatalf("Test Failed. Load error: %s", err)
+	}
+	e, err := GetEvent(id)
+	if err != nil || e.Cooldown != time.Minute || e.Conditions[0].Window != time.Hour ||
+		!e.Conditions[0].Pair.Equal(p, true) || e.Actions[0].Medium != "Slack" {
+		t.Errorf("Test Failed. Expected event to be restored got %+v %v", e, err)
+	}
+	if _, err = GetEvent(removed); err != ErrEventNotFound {
+		t.Errorf("Test Failed. Expected %s got %v", ErrEventNotFound, err)
+	}
+	if next, _ := AddEvent(&Event{Conditions: []Condition{condition}, Actions: []Action{{Type: ActionTest}}}); next != removed+1 {
+		t.Errorf("Test Failed. Expected IDs to continue from %d got %d", removed, next)
+	}
+}
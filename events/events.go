@@ -1,95 +1,187 @@
 package events
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"log"
-	"strconv"
+	"net/url"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
-	"github.com/thrasher-/gocryptotrader/communications"
 	"github.com/thrasher-/gocryptotrader/communications/base"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 const (
-	itemPrice          = "PRICE"
-	greaterThan        = ">"
-	greaterThanOrEqual = ">="
-	lessThan           = "<"
-	lessThanOrEqual    = "<="
-	isEqual            = "=="
-	actionSMSNotify    = "SMS"
-	actionConsolePrint = "CONSOLE_PRINT"
-	actionTest         = "ACTION_TEST"
+	eventsFile = "events.json"
+
+	// maxWindow is the longest period PERCENT_CHANGE can be measured over
+	maxWindow = time.Hour * 24
+	// sampleInterval is the minimum time between recorded prices
+	sampleInterval = time.Second
+	// balanceCacheDuration is how long account info is reused for BALANCE
+	balanceCacheDuration = time.Second * 30
+	// balanceCheckInterval is how often events with BALANCE conditions are
+	// checked, other conditions are checked as tickers and orderbooks update
+	balanceCheckInterval = balanceCacheDuration
 )
 
 var (
-	errInvalidItem      = errors.New("invalid item")
-	errInvalidCondition = errors.New("invalid conditional option")
-	errInvalidAction    = errors.New("invalid action")
-	errExchangeDisabled = errors.New("desired exchange is disabled")
-
-	// NOTE comms is an interim implementation
-	comms *communications.Communications
+	errInvalidItem        = errors.New("invalid item")
+	errInvalidCondition   = errors.New("invalid conditional option")
+	errInvalidAction      = errors.New("invalid action")
+	errExchangeDisabled   = errors.New("desired exchange is disabled")
+	errNoConditions       = errors.New("event has no conditions")
+	errNoActions          = errors.New("event has no actions")
+	errInvalidLogic       = errors.New("logic must be AND or OR")
+	errInvalidPair        = errors.New("invalid currency pair")
+	errInvalidParam       = errors.New("invalid item parameter")
+	errInvalidWindow      = errors.New("percent change window must be above zero and at most 24 hours")
+	errInvalidCurrency    = errors.New("balance currency is required")
+	errInvalidCooldown    = errors.New("cooldown cannot be negative")
+	errInvalidWebhook     = errors.New("webhook URL must be http or https")
+	errInvalidOrder       = errors.New("invalid order action")
+	errExchangeNotFound   = errors.New("exchange not found")
+	errNoValue            = errors.New("no value available for item")
+	errInsufficientPrices = errors.New("price history does not cover the window")
+	errNoComms            = errors.New("communications are not set")
+
+	// ErrEventNotFound is returned when no event has the requested ID
+	ErrEventNotFound = errors.New("event not found")
+
+	// NOTE comms and exchanges are an interim implementation
+	comms     Notifier
+	exchanges []exchange.IBotExchange
+
+	history  = priceHistory{samples: make(map[string][]priceSample)}
+	balances = balanceCache{balances: make(map[string]balance)}
+	lastID   int
+	filePath string
+
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+	m        sync.Mutex
 )
 
-// Event struct holds the event variables
-type Event struct {
-	ID        int
-	Exchange  string
-	Item      string
-	Condition string
-	Pair      pair.CurrencyPair
-	Asset     string
-	Action    string
-	Executed  bool
-}
-
 // Events variable is a pointer array to the event structures that will be
-// appended
+// appended. It is guarded by the package lock, use GetEvents to read it
 var Events []*Event
 
 // SetComms is an interim function that will support a median integration. This
 // sets the current comms package.
-func SetComms(commsP *communications.Communications) {
-	comms = commsP
+func SetComms(n Notifier) {
+	comms = n
+}
+
+// SetExchanges is an interim function that sets the exchanges used for
+// BALANCE conditions and ORDER actions
+func SetExchanges(e []exchange.IBotExchange) {
+	exchanges = e
+}
+
+// GetFilePath returns the file events are persisted to
+func GetFilePath() string {
+	m.Lock()
+	defer m.Unlock()
+	return filePath
 }
 
-// AddEvent adds an event to the Events chain and returns an index/eventID
-// and an error
-func AddEvent(Exchange, Item, Condition string, CurrencyPair pair.CurrencyPair, Asset, Action string) (int, error) {
-	err := IsValidEvent(Exchange, Item, Condition, Action)
+// Load reads any previously persisted events from the data directory, events
+// are saved there as they are added, removed and triggered
+func Load(dataDir string) error {
+	m.Lock()
+	defer m.Unlock()
+	filePath = dataDir + common.GetOSPathSlash() + eventsFile
+
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil
+	}
+
+	data, err := common.ReadFile(filePath)
 	if err != nil {
-		return 0, err
+		return err
+	}
+
+	var s store
+	err = common.JSONDecode(data, &s)
+	if err != nil {
+		return err
+	}
+
+	Events = s.Events
+	lastID = s.LastID
+	return nil
+}
+
+// Save persists all events to the data directory
+func Save() error {
+	m.Lock()
+	defer m.Unlock()
+	return save()
+}
+
+func save() error {
+	if filePath == "" {
+		return nil
+	}
+	data, err := common.JSONEncode(store{LastID: lastID, Events: Events})
+	if err != nil {
+		return err
+	}
+	return common.WriteFile(filePath, data)
+}
+
+// saveOrLog persists the events and logs any failure
+func saveOrLog() {
+	err := save()
+	if err != nil {
+		log.Printf("Events: unable to save events to %s. Err: %s", filePath, err)
 	}
+}
 
-	Event := &Event{}
+// AddEvent validates an event, sets its defaults and adds it to the Events
+// chain, returning its ID
+func AddEvent(e *Event) (int, error) {
+	if e.Logic == "" {
+		e.Logic = And
+	}
+	for i := range e.Conditions {
+		if e.Conditions[i].Asset == "" {
+			e.Conditions[i].Asset = ticker.Spot
+		}
+	}
 
-	if len(Events) == 0 {
-		Event.ID = 0
-	} else {
-		Event.ID = len(Events) + 1
+	err := IsValidEvent(e)
+	if err != nil {
+		return 0, err
 	}
 
-	Event.Exchange = Exchange
-	Event.Item = Item
-	Event.Condition = Condition
-	Event.Pair = CurrencyPair
-	Event.Asset = Asset
-	Event.Action = Action
-	Event.Executed = false
-	Events = append(Events, Event)
-	return Event.ID, nil
+	m.Lock()
+	defer m.Unlock()
+	lastID++
+	e.ID = lastID
+	e.Executed = false
+	e.AwaitingRearm = false
+	Events = append(Events, e)
+	saveOrLog()
+	return e.ID, nil
 }
 
 // RemoveEvent deletes and event by its ID
 func RemoveEvent(EventID int) bool {
+	m.Lock()
+	defer m.Unlock()
 	for i, x := range Events {
 		if x.ID == EventID {
 			Events = append(Events[:i], Events[i+1:]...)
+			saveOrLog()
 			return true
 		}
 	}
@@ -99,6 +191,8 @@ func RemoveEvent(EventID int) bool {
 // GetEventCounter displays the emount of total events on the chain and the
 // events that have been executed.
 func GetEventCounter() (int, int) {
+	m.Lock()
+	defer m.Unlock()
 	total := len(Events)
 	executed := 0
 
@@ -110,183 +204,726 @@ func GetEventCounter() (int, int) {
 	return total, executed
 }
 
-// ExecuteAction will execute the action pending on the chain
+// ExecuteAction performs every action of the event, returning false if any of
+// them failed
 func (e *Event) ExecuteAction() bool {
-	if common.StringContains(e.Action, ",") {
-		action := common.SplitStrings(e.Action, ",")
-		if action[0] == actionSMSNotify {
-			message := fmt.Sprintf("Event triggered: %s", e.String())
-			if action[1] == "ALL" {
-				comms.PushEvent(base.Event{TradeDetails: message})
-			}
+	success := true
+	for _, a := range e.Actions {
+		err := e.execute(a)
+		if err != nil {
+			log.Printf("Event %d %s action failed: %s", e.ID, a.Type, err)
+			success = false
 		}
-	} else {
-		log.Printf("Event triggered: %s", e.String())
 	}
-	return true
+	return success
+}
+
+// execute performs a single action
+func (e *Event) execute(a Action) error {
+	message := fmt.Sprintf("Event triggered: %s", e.String())
+	switch a.Type {
+	case ActionConsolePrint:
+		log.Print(message)
+	case ActionNotify:
+		if comms == nil {
+			return errNoComms
+		}
+		event := base.Event{Type: "EVENT", TradeDetails: message}
+		if a.Medium == "" {
+			comms.PushEvent(event)
+			return nil
+		}
+		return comms.PushEventToMedium(a.Medium, event)
+	case ActionOrder:
+		return submitOrder(a.Order)
+	case ActionWebhook:
+		payload, err := common.JSONEncode(webhookPayload{
+			ID:           e.ID,
+			Event:        e.String(),
+			TriggerCount: e.TriggerCount,
+			Triggered:    e.LastTriggered,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = common.SendHTTPRequest("POST", a.URL,
+			map[string]string{"Content-Type": "application/json"}, bytes.NewReader(payload))
+		return err
+	case ActionTest:
+	default:
+		return errInvalidAction
+	}
+	return nil
+}
+
+// submitOrder submits the order of an ORDER action to its exchange
+func submitOrder(o *OrderAction) error {
+	exch := getExchange(o.Exchange)
+	if exch == nil {
+		return errExchangeNotFound
+	}
+	resp, err := exch.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: o.Pair,
+		OrderSide:    o.Side,
+		OrderType:    o.OrderType,
+		Price:        o.Price,
+		Amount:       o.Amount,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.IsOrderPlaced {
+		return errors.New("order was not placed")
+	}
+	log.Printf("Event order %s placed on %s", resp.OrderID, exch.GetName())
+	return nil
 }
 
 // String turns the structure event into a string
 func (e *Event) String() string {
-	condition := common.SplitStrings(e.Condition, ",")
-	return fmt.Sprintf(
-		"If the %s%s [%s] %s on %s is %s then %s.", e.Pair.FirstCurrency.String(),
-		e.Pair.SecondCurrency.String(), e.Asset, e.Item, e.Exchange, condition[0]+" "+condition[1], e.Action,
-	)
+	var conditions, actions []string
+	for _, c := range e.Conditions {
+		conditions = append(conditions, c.String())
+	}
+	for _, a := range e.Actions {
+		actions = append(actions, a.String())
+	}
+	return fmt.Sprintf("If %s then %s.",
+		common.JoinStrings(conditions, " "+string(e.Logic)+" "),
+		common.JoinStrings(actions, ", "))
 }
 
-// CheckCondition will check the event structure to see if there is a condition
-// met
-func (e *Event) CheckCondition() bool {
-	condition := common.SplitStrings(e.Condition, ",")
-	targetPrice, _ := strconv.ParseFloat(condition[1], 64)
+// String turns the condition into a string
+func (c *Condition) String() string {
+	item := string(c.Item)
+	switch c.Item {
+	case ItemPercentChange:
+		item = fmt.Sprintf("%s over %s", item, c.Window)
+	case ItemImbalance, ItemDepth:
+		if c.Param > 0 {
+			item = fmt.Sprintf("%s within %v bps", item, c.Param)
+		}
+	case ItemBuySlippage, ItemSellSlippage:
+		item = fmt.Sprintf("%s of %v", item, c.Param)
+	case ItemBalance:
+		return fmt.Sprintf("the %s BALANCE on %s is %s %v", c.Currency, c.Exchange, c.Operator, c.Value)
+	}
+	return fmt.Sprintf("the %s%s [%s] %s on %s is %s %v", c.Pair.FirstCurrency.String(),
+		c.Pair.SecondCurrency.String(), c.Asset, item, c.Exchange, c.Operator, c.Value)
+}
 
-	t, err := ticker.GetTicker(e.Exchange, e.Pair, e.Asset)
-	if err != nil {
+// String turns the action into a string
+func (a *Action) String() string {
+	switch a.Type {
+	case ActionNotify:
+		if a.Medium != "" {
+			return fmt.Sprintf("%s %s", a.Type, a.Medium)
+		}
+	case ActionOrder:
+		if a.Order != nil {
+			return fmt.Sprintf("%s %s %v %s%s on %s", a.Order.OrderType, a.Order.Side, a.Order.Amount,
+				a.Order.Pair.FirstCurrency.String(), a.Order.Pair.SecondCurrency.String(), a.Order.Exchange)
+		}
+	case ActionWebhook:
+		return fmt.Sprintf("%s %s", a.Type, a.URL)
+	}
+	return string(a.Type)
+}
+
+// GetEvents returns a copy of every event on the chain
+func GetEvents() []Event {
+	m.Lock()
+	defer m.Unlock()
+	events := make([]Event, len(Events))
+	for i := range Events {
+		events[i] = *Events[i]
+	}
+	return events
+}
+
+// GetEvent returns a copy of the event with the ID
+func GetEvent(id int) (Event, error) {
+	m.Lock()
+	defer m.Unlock()
+	for _, e := range Events {
+		if e.ID == id {
+			return *e, nil
+		}
+	}
+	return Event{}, ErrEventNotFound
+}
+
+// CheckCondition checks whether the conditions of the event are met and
+// executes its actions if the event is armed, returning whether it triggered
+func (e *Event) CheckCondition() bool {
+	if !e.trigger() {
 		return false
 	}
+	e.ExecuteAction()
+	return true
+}
 
-	lastPrice := t.Last
+// trigger checks whether the conditions of the event are met and records a
+// trigger if the event is armed
+func (e *Event) trigger() bool {
+	if e.Executed {
+		return false
+	}
+	return e.update(e.conditionsMet())
+}
 
-	if lastPrice == 0 {
+// update records whether the conditions of the event are met, returning true
+// and recording a trigger if they are and the event is armed
+func (e *Event) update(met bool) bool {
+	if e.Executed {
+		return false
+	}
+	if !met {
+		e.AwaitingRearm = false
 		return false
 	}
+	if e.AwaitingRearm ||
+		(e.Cooldown > 0 && time.Since(e.LastTriggered) < e.Cooldown) {
+		return false
+	}
+
+	e.TriggerCount++
+	e.LastTriggered = time.Now()
+	e.AwaitingRearm = e.Rearm
+	e.Executed = !e.Rearm && e.Cooldown == 0
+	return true
+}
 
-	switch condition[0] {
-	case greaterThan:
-		{
-			if lastPrice > targetPrice {
-				return e.ExecuteAction()
-			}
+// uses returns whether any condition of the event matches the filter
+func (e *Event) uses(match func(c *Condition) bool) bool {
+	for i := range e.Conditions {
+		if match(&e.Conditions[i]) {
+			return true
 		}
-	case greaterThanOrEqual:
-		{
-			if lastPrice >= targetPrice {
-				return e.ExecuteAction()
-			}
+	}
+	return false
+}
+
+// matches returns whether the condition is on the exchange currency pair and
+// asset type
+func (c *Condition) matches(exchName string, p pair.CurrencyPair, assetType string) bool {
+	return common.StringToUpper(c.Exchange) == common.StringToUpper(exchName) &&
+		c.Pair.Equal(p, false) && c.Asset == assetType
+}
+
+// isTickerItem returns whether the item is read from the ticker cache
+func isTickerItem(item Item) bool {
+	switch item {
+	case ItemPrice, ItemBid, ItemAsk, ItemVolume, ItemPercentChange:
+		return true
+	}
+	return false
+}
+
+// isOrderbookItem returns whether the item is read from the orderbook cache
+func isOrderbookItem(item Item) bool {
+	switch item {
+	case ItemSpread, ItemImbalance, ItemDepth, ItemBuySlippage, ItemSellSlippage:
+		return true
+	}
+	return false
+}
+
+// conditionsMet combines the conditions of the event by its logic
+func (e *Event) conditionsMet() bool {
+	for i := range e.Conditions {
+		met, err := e.Conditions[i].IsMet()
+		if err != nil {
+			met = false
 		}
-	case lessThan:
-		{
-			if lastPrice < targetPrice {
-				return e.ExecuteAction()
-			}
+		if e.Logic == Or && met {
+			return true
 		}
-	case lessThanOrEqual:
-		{
-			if lastPrice <= targetPrice {
-				return e.ExecuteAction()
-			}
+		if e.Logic != Or && !met {
+			return false
 		}
-	case isEqual:
-		{
-			if lastPrice == targetPrice {
-				return e.ExecuteAction()
-			}
+	}
+	return e.Logic != Or && len(e.Conditions) > 0
+}
+
+// IsMet returns whether the current value of the condition item satisfies
+// its operator
+func (c *Condition) IsMet() (bool, error) {
+	value, err := c.GetValue()
+	if err != nil {
+		return false, err
+	}
+
+	switch c.Operator {
+	case GreaterThan:
+		return value > c.Value, nil
+	case GreaterThanOrEqual:
+		return value >= c.Value, nil
+	case LessThan:
+		return value < c.Value, nil
+	case LessThanOrEqual:
+		return value <= c.Value, nil
+	case Equal:
+		return value == c.Value, nil
+	}
+	return false, errInvalidCondition
+}
+
+// GetValue returns the current value of the condition item from the ticker
+// and orderbook caches or the exchange account
+func (c *Condition) GetValue() (float64, error) {
+	switch c.Item {
+	case ItemPrice, ItemBid, ItemAsk, ItemVolume, ItemPercentChange:
+		t, err := ticker.GetTicker(c.Exchange, c.Pair, c.Asset)
+		if err != nil {
+			return 0, err
+		}
+		switch c.Item {
+		case ItemBid:
+			return nonZero(t.Bid)
+		case ItemAsk:
+			return nonZero(t.Ask)
+		case ItemVolume:
+			return t.Volume, nil
+		case ItemPercentChange:
+			return history.percentChange(c.key(), t.Last, c.Window)
 		}
+		return nonZero(t.Last)
+	case ItemBalance:
+		return balances.get(c.Exchange, c.Currency)
 	}
-	return false
+
+	ob, err := orderbook.GetOrderbook(c.Exchange, c.Pair, c.Asset)
+	if err != nil {
+		return 0, err
+	}
+	switch c.Item {
+	case ItemSpread:
+		s, err := ob.GetSpread()
+		return s.SpreadBps, err
+	case ItemImbalance:
+		return ob.GetImbalance(c.Param)
+	case ItemDepth:
+		l, err := ob.GetLiquidity(c.Param)
+		return l.BidAmount + l.AskAmount, err
+	case ItemBuySlippage:
+		return ob.GetSlippage(orderbook.Buy, c.Param)
+	case ItemSellSlippage:
+		return ob.GetSlippage(orderbook.Sell, c.Param)
+	}
+	return 0, errInvalidItem
 }
 
-// IsValidEvent checks the actions to be taken and returns an error if incorrect
-func IsValidEvent(Exchange, Item, Condition, Action string) error {
-	Exchange = common.StringToUpper(Exchange)
-	Item = common.StringToUpper(Item)
-	Action = common.StringToUpper(Action)
+// key returns the price history key of the condition
+func (c *Condition) key() string {
+	return historyKey(c.Exchange, c.Pair, c.Asset)
+}
 
-	if !IsValidExchange(Exchange) {
-		return errExchangeDisabled
+// historyKey returns the price history key of an exchange currency pair and
+// asset type regardless of the pair delimiter
+func historyKey(exchName string, p pair.CurrencyPair, assetType string) string {
+	return common.StringToUpper(exchName) + p.FirstCurrency.Upper().String() +
+		p.SecondCurrency.Upper().String() + assetType
+}
+
+// nonZero returns an error for prices missing from the ticker
+func nonZero(price float64) (float64, error) {
+	if price == 0 {
+		return 0, errNoValue
 	}
+	return price, nil
+}
 
-	if !IsValidItem(Item) {
-		return errInvalidItem
+// record adds a last traded price to the history of the key, at most once
+// every sampleInterval, and removes prices no longer needed by any window
+func (h *priceHistory) record(key string, last float64) {
+	if last == 0 {
+		return
 	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
 
-	if !common.StringContains(Condition, ",") {
-		return errInvalidCondition
+	now := time.Now()
+	samples := h.samples[key]
+	if len(samples) == 0 || now.Sub(samples[len(samples)-1].time) >= sampleInterval {
+		samples = append(samples, priceSample{time: now, price: last})
+	}
+	// keep one sample older than the longest window as its reference
+	for len(samples) > 1 && now.Sub(samples[1].time) >= maxWindow {
+		samples = samples[1:]
 	}
+	h.samples[key] = samples
+}
 
-	condition := common.SplitStrings(Condition, ",")
+// percentChange returns the percentage change of the last price since the
+// most recent recorded price at least window old
+func (h *priceHistory) percentChange(key string, last float64, window time.Duration) (float64, error) {
+	if last == 0 {
+		return 0, errNoValue
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	now := time.Now()
+	samples := h.samples[key]
+	for i := len(samples) - 1; i >= 0; i-- {
+		if now.Sub(samples[i].time) >= window {
+			return (last - samples[i].price) / samples[i].price * 100, nil
+		}
+	}
+	return 0, errInsufficientPrices
+}
 
-	if !IsValidCondition(condition[0]) || len(condition[1]) == 0 {
-		return errInvalidCondition
+// recordPrice records the last traded price of a ticker update when a pending
+// event has a PERCENT_CHANGE condition on it. Prices are recorded for every
+// update regardless of whether the condition is evaluated, so the history is
+// complete when the other conditions of the event are met
+func recordPrice(u ticker.Update) {
+	m.Lock()
+	tracked := false
+	for _, e := range Events {
+		if !e.Executed && e.uses(func(c *Condition) bool {
+			return c.Item == ItemPercentChange && c.matches(u.Exchange, u.Pair, u.AssetType)
+		}) {
+			tracked = true
+			break
+		}
 	}
+	m.Unlock()
 
-	if common.StringContains(Action, ",") {
-		action := common.SplitStrings(Action, ",")
+	if tracked {
+		history.record(historyKey(u.Exchange, u.Pair, u.AssetType), u.Price.Last)
+	}
+}
 
-		if action[0] != actionSMSNotify {
-			return errInvalidAction
+// get returns the total balance of a currency on an exchange, fetching the
+// account info when the cached copy has expired
+func (b *balanceCache) get(exchName, currency string) (float64, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	key := common.StringToUpper(exchName)
+	cached, ok := b.balances[key]
+	if !ok || time.Since(cached.fetched) > balanceCacheDuration {
+		exch := getExchange(exchName)
+		if exch == nil {
+			return 0, errExchangeNotFound
 		}
+		info, err := exch.GetAccountInfo()
+		if err != nil {
+			return 0, err
+		}
+		cached = balance{info: info, fetched: time.Now()}
+		b.balances[key] = cached
+	}
+
+	for _, c := range cached.info.Currencies {
+		if common.StringToUpper(c.CurrencyName) == common.StringToUpper(currency) {
+			return c.TotalValue + c.Hold, nil
+		}
+	}
+	return 0, nil
+}
+
+// getExchange returns the loaded exchange by name
+func getExchange(name string) exchange.IBotExchange {
+	for _, e := range exchanges {
+		if common.StringToUpper(e.GetName()) == common.StringToUpper(name) {
+			return e
+		}
+	}
+	return nil
+}
 
-		if action[1] != "ALL" {
-			comms.PushEvent(base.Event{Type: action[1]})
+// IsValidEvent checks the conditions and actions of an event and returns an
+// error if incorrect
+func IsValidEvent(e *Event) error {
+	if e.Logic != And && e.Logic != Or {
+		return errInvalidLogic
+	}
+	if len(e.Conditions) == 0 {
+		return errNoConditions
+	}
+	if len(e.Actions) == 0 {
+		return errNoActions
+	}
+	if e.Cooldown < 0 {
+		return errInvalidCooldown
+	}
+
+	for i := range e.Conditions {
+		err := IsValidCondition(&e.Conditions[i])
+		if err != nil {
+			return err
 		}
-	} else {
-		if Action != actionConsolePrint && Action != actionTest {
-			return errInvalidAction
+	}
+	for i := range e.Actions {
+		err := IsValidAction(&e.Actions[i])
+		if err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-// CheckEvents is the overarching routine that will iterate through the Events
-// chain
+// CheckEvents checks every pending event on the Events chain and executes
+// the actions of those which trigger
 func CheckEvents() {
+	checkEvents(func(*Event) bool { return true })
+}
+
+// checkEvents checks the pending events selected by filter. Conditions are
+// evaluated and actions executed without the lock held, so BALANCE account
+// info requests, slow webhooks and orders do not block the chain
+func checkEvents(filter func(e *Event) bool) {
+	var pending []*Event
+	var snapshots []Event
+	m.Lock()
+	for _, e := range Events {
+		if !e.Executed && filter(e) {
+			snapshot := *e
+			snapshot.Conditions = append([]Condition(nil), e.Conditions...)
+			pending = append(pending, e)
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	m.Unlock()
+
+	met := make([]bool, len(snapshots))
+	for i := range snapshots {
+		met[i] = snapshots[i].conditionsMet()
+	}
+
+	var triggered []Event
+	m.Lock()
+	current := make(map[*Event]bool, len(Events))
+	for _, e := range Events {
+		current[e] = true
+	}
+	for i, e := range pending {
+		// the event may have been removed while its conditions were checked
+		if current[e] && e.update(met[i]) {
+			triggered = append(triggered, *e)
+		}
+	}
+	if len(triggered) > 0 {
+		saveOrLog()
+	}
+	m.Unlock()
+
+	for i := range triggered {
+		log.Printf("Event %d triggered successfully.\n", triggered[i].ID)
+		triggered[i].ExecuteAction()
+	}
+}
+
+// Start checks events as the tickers and orderbooks their conditions use are
+// processed, and periodically for BALANCE conditions, until Stop is called.
+// Updates are received on their own routine so the subscriptions are drained
+// while conditions are checked and actions executed, only the latest update
+// of each ticker and orderbook is kept waiting to be checked
+func Start() {
+	m.Lock()
+	defer m.Unlock()
+	if shutdown != nil {
+		return
+	}
+	shutdown = make(chan struct{})
+	tickers := ticker.Subscribe("", pair.CurrencyPair{}, "")
+	orderbooks := orderbook.Subscribe("", pair.CurrencyPair{}, "")
+	queue := &checkQueue{
+		checks: make(map[string]func(e *Event) bool),
+		ready:  make(chan struct{}, 1),
+	}
+
+	wg.Add(2)
+	go receiveUpdates(shutdown, tickers, orderbooks, queue)
+	go processChecks(shutdown, queue)
+}
+
+// receiveUpdates queues a check of the events using each ticker and orderbook
+// update, and of BALANCE events every balanceCheckInterval
+func receiveUpdates(shutdown chan struct{}, tickers *ticker.Subscription, orderbooks *orderbook.Subscription, queue *checkQueue) {
+	defer wg.Done()
+	defer ticker.Unsubscribe(tickers)
+	defer orderbook.Unsubscribe(orderbooks)
+
+	t := time.NewTicker(balanceCheckInterval)
+	defer t.Stop()
 	for {
-		total, executed := GetEventCounter()
-		if total > 0 && executed != total {
-			for _, event := range Events {
-				if !event.Executed {
-					success := event.CheckCondition()
-					if success {
-						log.Printf(
-							"Event %d triggered on %s successfully.\n", event.ID,
-							event.Exchange,
-						)
-						event.Executed = true
+		select {
+		case <-shutdown:
+			return
+		case u := <-tickers.C:
+			recordPrice(u)
+			queue.push("TICKER"+historyKey(u.Exchange, u.Pair, u.AssetType), func(e *Event) bool {
+				return e.uses(func(c *Condition) bool {
+					return isTickerItem(c.Item) && c.matches(u.Exchange, u.Pair, u.AssetType)
+				})
+			})
+		case u := <-orderbooks.C:
+			queue.push("ORDERBOOK"+historyKey(u.Exchange, u.Pair, u.AssetType), func(e *Event) bool {
+				return e.uses(func(c *Condition) bool {
+					return isOrderbookItem(c.Item) && c.matches(u.Exchange, u.Pair, u.AssetType)
+				})
+			})
+		case <-t.C:
+			queue.push(string(ItemBalance), func(e *Event) bool {
+				return e.uses(func(c *Condition) bool {
+					return c.Item == ItemBalance
+				})
+			})
+		}
+	}
+}
+
+// processChecks checks the events selected by the queued checks as they are
+// queued
+func processChecks(shutdown chan struct{}, queue *checkQueue) {
+	defer wg.Done()
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-queue.ready:
+			filters := queue.pop()
+			checkEvents(func(e *Event) bool {
+				for _, filter := range filters {
+					if filter(e) {
+						return true
 					}
 				}
-			}
+				return false
+			})
 		}
 	}
 }
 
+// push queues a check, replacing any waiting check with the same key
+func (q *checkQueue) push(key string, filter func(e *Event) bool) {
+	q.mtx.Lock()
+	q.checks[key] = filter
+	q.mtx.Unlock()
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pop removes and returns every waiting check
+func (q *checkQueue) pop() []func(e *Event) bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	filters := make([]func(e *Event) bool, 0, len(q.checks))
+	for key, filter := range q.checks {
+		filters = append(filters, filter)
+		delete(q.checks, key)
+	}
+	return filters
+}
+
+// Stop stops checking events and waits for the routines to exit
+func Stop() {
+	m.Lock()
+	if shutdown == nil {
+		m.Unlock()
+		return
+	}
+	close(shutdown)
+	shutdown = nil
+	m.Unlock()
+	wg.Wait()
+}
+
 // IsValidExchange validates the exchange
 func IsValidExchange(Exchange string) bool {
 	Exchange = common.StringToUpper(Exchange)
 	cfg := config.GetConfig()
 	for _, x := range cfg.Exchanges {
-		if x.Name == Exchange && x.Enabled {
+		if common.StringToUpper(x.Name) == Exchange && x.Enabled {
 			return true
 		}
 	}
 	return false
 }
 
-// IsValidCondition validates passed in condition
-func IsValidCondition(Condition string) bool {
-	switch Condition {
-	case greaterThan, greaterThanOrEqual, lessThan, lessThanOrEqual, isEqual:
+// IsValidOperator validates passed in operator
+func IsValidOperator(op Operator) bool {
+	switch op {
+	case GreaterThan, GreaterThanOrEqual, LessThan, LessThanOrEqual, Equal:
 		return true
 	}
 	return false
 }
 
+// IsValidCondition validates passed in condition
+func IsValidCondition(c *Condition) error {
+	if !IsValidExchange(c.Exchange) {
+		return errExchangeDisabled
+	}
+	if !IsValidItem(c.Item) {
+		return errInvalidItem
+	}
+	if !IsValidOperator(c.Operator) {
+		return errInvalidCondition
+	}
+
+	switch c.Item {
+	case ItemBalance:
+		if c.Currency == "" {
+			return errInvalidCurrency
+		}
+		return nil
+	case ItemPercentChange:
+		if c.Window <= 0 || c.Window > maxWindow {
+			return errInvalidWindow
+		}
+	case ItemImbalance:
+		if c.Param < 0 {
+			return errInvalidParam
+		}
+	case ItemDepth, ItemBuySlippage, ItemSellSlippage:
+		if c.Param <= 0 {
+			return errInvalidParam
+		}
+	}
+	if c.Pair.FirstCurrency == "" || c.Pair.SecondCurrency == "" {
+		return errInvalidPair
+	}
+	return nil
+}
+
 // IsValidAction validates passed in action
-func IsValidAction(Action string) bool {
-	Action = common.StringToUpper(Action)
-	switch Action {
-	case actionSMSNotify, actionConsolePrint, actionTest:
-		return true
+func IsValidAction(a *Action) error {
+	switch a.Type {
+	case ActionConsolePrint, ActionNotify, ActionTest:
+		return nil
+	case ActionWebhook:
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errInvalidWebhook
+		}
+		return nil
+	case ActionOrder:
+		o := a.Order
+		if o == nil || !IsValidExchange(o.Exchange) ||
+			o.Pair.FirstCurrency == "" || o.Pair.SecondCurrency == "" ||
+			(o.Side != exchange.Buy && o.Side != exchange.Sell) || o.Amount <= 0 {
+			return errInvalidOrder
+		}
+		if o.OrderType != exchange.Market && (o.OrderType != exchange.Limit || o.Price <= 0) {
+			return errInvalidOrder
+		}
+		return nil
 	}
-	return false
+	return errInvalidAction
 }
 
 // IsValidItem validates passed in Item
-func IsValidItem(Item string) bool {
-	Item = common.StringToUpper(Item)
-	switch Item {
-	case itemPrice:
+func IsValidItem(item Item) bool {
+	switch item {
+	case ItemPrice, ItemBid, ItemAsk, ItemVolume, ItemPercentChange, ItemSpread,
+		ItemImbalance, ItemDepth, ItemBuySlippage, ItemSellSlippage, ItemBalance:
 		return true
 	}
 	return false
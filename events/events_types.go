@@ -0,0 +1,193 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Item is the value a condition is evaluated against
+type Item string
+
+// Condition items
+const (
+	// ItemPrice is the last traded price from the ticker cache
+	ItemPrice Item = "PRICE"
+	// ItemBid is the best bid from the ticker cache
+	ItemBid Item = "BID"
+	// ItemAsk is the best ask from the ticker cache
+	ItemAsk Item = "ASK"
+	// ItemVolume is the volume from the ticker cache
+	ItemVolume Item = "VOLUME"
+	// ItemPercentChange is the percentage change of the last traded price
+	// over the condition Window
+	ItemPercentChange Item = "PERCENT_CHANGE"
+	// ItemSpread is the spread between the best bid and ask of the orderbook
+	// in basis points
+	ItemSpread Item = "SPREAD"
+	// ItemImbalance is the imbalance between bids and asks within Param basis
+	// points of the mid price, or the whole orderbook when Param is zero
+	ItemImbalance Item = "IMBALANCE"
+	// ItemDepth is the bid and ask amount within Param basis points of the mid
+	// price
+	ItemDepth Item = "DEPTH"
+	// ItemBuySlippage and ItemSellSlippage are the slippage in basis points of
+	// a market order of Param amount
+	ItemBuySlippage  Item = "BUY_SLIPPAGE"
+	ItemSellSlippage Item = "SELL_SLIPPAGE"
+	// ItemBalance is the account balance of the condition Currency
+	ItemBalance Item = "BALANCE"
+)
+
+// Operator compares a condition item with its value
+type Operator string
+
+// Condition operators
+const (
+	GreaterThan        Operator = ">"
+	GreaterThanOrEqual Operator = ">="
+	LessThan           Operator = "<"
+	LessThanOrEqual    Operator = "<="
+	Equal              Operator = "=="
+)
+
+// Logic defines how the conditions of an event are combined
+type Logic string
+
+// Condition logic
+const (
+	// And requires all conditions to be met
+	And Logic = "AND"
+	// Or requires any condition to be met
+	Or Logic = "OR"
+)
+
+// ActionType defines what an action does when its event triggers
+type ActionType string
+
+// Action types
+const (
+	ActionConsolePrint ActionType = "CONSOLE_PRINT"
+	// ActionNotify pushes the event to the action Medium, or all comms
+	// mediums when Medium is empty
+	ActionNotify ActionType = "NOTIFY"
+	// ActionOrder submits the action Order
+	ActionOrder ActionType = "ORDER"
+	// ActionWebhook posts the event to the action URL
+	ActionWebhook ActionType = "WEBHOOK"
+	ActionTest    ActionType = "ACTION_TEST"
+)
+
+// Condition compares an item of an exchange currency pair with a value
+type Condition struct {
+	Exchange string            `json:"exchange"`
+	Pair     pair.CurrencyPair `json:"pair"`
+	Asset    string            `json:"asset"`
+	Item     Item              `json:"item"`
+	// Param is the basis points from the mid price for IMBALANCE and DEPTH
+	// and the order amount for BUY_SLIPPAGE and SELL_SLIPPAGE
+	Param float64 `json:"param,omitempty"`
+	// Window is the period PERCENT_CHANGE is measured over
+	Window time.Duration `json:"window,omitempty"`
+	// Currency is the currency BALANCE is checked for
+	Currency string   `json:"currency,omitempty"`
+	Operator Operator `json:"operator"`
+	Value    float64  `json:"value"`
+}
+
+// OrderAction is the order submitted by an ORDER action
+type OrderAction struct {
+	Exchange  string             `json:"exchange"`
+	Pair      pair.CurrencyPair  `json:"pair"`
+	Side      exchange.OrderSide `json:"side"`
+	OrderType exchange.OrderType `json:"orderType"`
+	Amount    float64            `json:"amount"`
+	// Price is the limit price of Limit orders
+	Price float64 `json:"price,omitempty"`
+}
+
+// Action is performed when its event triggers
+type Action struct {
+	Type   ActionType   `json:"type"`
+	Medium string       `json:"medium,omitempty"`
+	URL    string       `json:"url,omitempty"`
+	Order  *OrderAction `json:"order,omitempty"`
+}
+
+// Event performs its actions when its conditions are met. An event without
+// Rearm or a Cooldown triggers once
+type Event struct {
+	ID         int         `json:"id"`
+	Logic      Logic       `json:"logic"`
+	Conditions []Condition `json:"conditions"`
+	Actions    []Action    `json:"actions"`
+	// Rearm keeps the event active after it triggers, it triggers again once
+	// its conditions have stopped being met and are then met again
+	Rearm bool `json:"rearm"`
+	// Cooldown keeps the event active after it triggers, it does not trigger
+	// again until the cooldown has passed
+	Cooldown time.Duration `json:"cooldown"`
+	// AwaitingRearm is set once a rearming event triggers until its
+	// conditions stop being met
+	AwaitingRearm bool      `json:"awaitingRearm"`
+	Executed      bool      `json:"executed"`
+	TriggerCount  int       `json:"triggerCount"`
+	LastTriggered time.Time `json:"lastTriggered"`
+}
+
+// store is the on disk format of the events chain
+type store struct {
+	LastID int      `json:"lastId"`
+	Events []*Event `json:"events"`
+}
+
+// Notifier is implemented by communications.Communications and receives
+// triggered events
+type Notifier interface {
+	PushEvent(event base.Event)
+	PushEventToMedium(medium string, event base.Event) error
+}
+
+// priceSample is a last traded price recorded for PERCENT_CHANGE
+type priceSample struct {
+	time  time.Time
+	price float64
+}
+
+// priceHistory holds recent last traded prices keyed by exchange, currency
+// pair and asset type
+type priceHistory struct {
+	samples map[string][]priceSample
+	mtx     sync.Mutex
+}
+
+// balance is an account info response cached for BALANCE
+type balance struct {
+	info    exchange.AccountInfo
+	fetched time.Time
+}
+
+// balanceCache holds recent account info keyed by exchange
+type balanceCache struct {
+	balances map[string]balance
+	mtx      sync.Mutex
+}
+
+// checkQueue holds the checks waiting to be made by the events routine, keyed
+// by the ticker or orderbook update which queued them
+type checkQueue struct {
+	checks map[string]func(e *Event) bool
+	ready  chan struct{}
+	mtx    sync.Mutex
+}
+
+// webhookPayload is posted by WEBHOOK actions
+type webhookPayload struct {
+	ID           int       `json:"id"`
+	Event        string    `json:"event"`
+	TriggerCount int       `json:"triggerCount"`
+	Triggered    time.Time `json:"triggered"`
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
 	"github.com/thrasher-/gocryptotrader/exchanges/anx"
 	"github.com/thrasher-/gocryptotrader/exchanges/binance"
@@ -32,12 +34,17 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/localbitcoins"
 	"github.com/thrasher-/gocryptotrader/exchanges/okcoin"
 	"github.com/thrasher-/gocryptotrader/exchanges/okex"
+	"github.com/thrasher-/gocryptotrader/exchanges/papertrade"
 	"github.com/thrasher-/gocryptotrader/exchanges/poloniex"
 	"github.com/thrasher-/gocryptotrader/exchanges/wex"
 	"github.com/thrasher-/gocryptotrader/exchanges/yobit"
 	"github.com/thrasher-/gocryptotrader/exchanges/zb"
 )
 
+// orderReconcileInterval is how often open orders are reconciled with their
+// exchanges
+const orderReconcileInterval = time.Minute
+
 // vars related to exchange functions
 var (
 	ErrNoExchangesLoaded     = errors.New("no exchanges have been loaded")
@@ -116,6 +123,9 @@ func UnloadExchange(name string) error {
 	for x := range bot.exchanges {
 		if bot.exchanges[x].GetName() == name {
 			bot.exchanges[x].SetEnabled(false)
+			if s, ok := bot.exchanges[x].(exchange.Stopper); ok {
+				s.Stop()
+			}
 			bot.exchanges = append(bot.exchanges[:x], bot.exchanges[x+1:]...)
 			return nil
 		}
@@ -205,12 +215,39 @@ func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 	}
 
 	exch.SetDefaults()
-	bot.exchanges = append(bot.exchanges, exch)
 	exchCfg, err := bot.config.GetExchangeConfig(name)
 	if err != nil {
 		return err
 	}
 
+	paperTrading := exchCfg.PaperTrading != nil && exchCfg.PaperTrading.Enabled
+	if paperTrading {
+		log.Printf("%s: Paper trading enabled, orders will be simulated.", exchCfg.Name)
+		paper := papertrade.New(exch, *exchCfg.PaperTrading)
+		paper.SetEventHandler(processOrderEvent)
+		exch = paper
+	}
+	if bot.risk != nil {
+		var limits config.RiskLimitsConfig
+		if exchCfg.RiskLimits != nil {
+			limits = *exchCfg.RiskLimits
+		}
+		if limits.Enabled {
+			log.Printf("%s: Risk limits enabled.", exchCfg.Name)
+		}
+		exch = bot.risk.Wrap(exch, limits)
+	}
+	if bot.orderManager != nil {
+		if paperTrading {
+			bot.orderManager.SetPaperTrading(exch.GetName())
+		}
+		exch = bot.orderManager.Wrap(exch)
+	}
+	if bot.risk != nil {
+		bot.risk.SetOuter(exch)
+	}
+	bot.exchanges = append(bot.exchanges, exch)
+
 	exchCfg.Enabled = true
 	exch.Setup(exchCfg)
 
@@ -267,3 +304,40 @@ func SetupExchanges() {
 	}
 	wg.Wait()
 }
+
+// ReconcileOrders updates the order manager with the current state of orders
+// on all loaded exchanges which support authenticated requests
+func ReconcileOrders() {
+	for x := range bot.exchanges {
+		if !bot.exchanges[x].GetAuthenticatedAPISupport() {
+			continue
+		}
+
+		err := bot.orderManager.Reconcile(bot.exchanges[x])
+		if err != nil {
+			log.Printf("%s: Unable to reconcile orders: %s", bot.exchanges[x].GetName(), err)
+			continue
+		}
+		log.Printf("%s: Orders reconciled successfully.", bot.exchanges[x].GetName())
+	}
+}
+
+// OrderReconcilerRoutine periodically reconciles the open orders of loaded
+// exchanges so that fills on exchanges without fill events are detected
+func OrderReconcilerRoutine() {
+	log.Println("Starting order reconciler routine.")
+	for {
+		time.Sleep(orderReconcileInterval)
+		for x := range bot.exchanges {
+			if !bot.exchanges[x].GetAuthenticatedAPISupport() ||
+				len(bot.orderManager.GetOpenOrders(bot.exchanges[x].GetName())) == 0 {
+				continue
+			}
+
+			err := bot.orderManager.Reconcile(bot.exchanges[x])
+			if err != nil {
+				log.Printf("%s: Unable to reconcile orders: %s", bot.exchanges[x].GetName(), err)
+			}
+		}
+	}
+}
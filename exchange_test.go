@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
 )
 
 var testSetup = false
@@ -136,3 +137,26 @@ func TestSetupExchanges(t *testing.T) {
 	SetupExchanges()
 	CleanupTest(t)
 }
+
+func TestGetExchangeFeatures(t *testing.T) {
+	SetupTest(t)
+
+	features := GetExchangeFeatures(GetExchangeByName("Bitfinex"))
+	if features.ExchangeName != "Bitfinex" {
+		t.Errorf("Test failed. TestGetExchangeFeatures: Unexpected exchange name %s",
+			features.ExchangeName)
+	}
+
+	if !features.Features.TickerFetching.REST ||
+		!features.Features.SupportsSubscription(exchange.TickerChannel) {
+		t.Errorf("Test failed. TestGetExchangeFeatures: Unexpected features %+v",
+			features.Features)
+	}
+
+	all := GetAllEnabledExchangeFeatures()
+	if len(all.Data) == 0 {
+		t.Error("Test failed. TestGetExchangeFeatures: Expected enabled exchange features")
+	}
+
+	CleanupTest(t)
+}
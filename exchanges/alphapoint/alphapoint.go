@@ -56,6 +56,15 @@ func (a *Alphapoint) SetDefaults() {
 	a.SupportsAutoPairUpdating = false
 	a.SupportsRESTTickerBatching = false
 	a.APIWithdrawPermissions = exchange.WithdrawCryptoWith2FA | exchange.AutoWithdrawCryptoWithAPIPermission
+	a.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+	a.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		DepositAddresses:  exchange.FeatureSupport{REST: true},
+	}
 	a.Requester = request.New(a.Name,
 		request.NewRateLimit(time.Minute*10, alphapointAuthRate),
 		request.NewRateLimit(time.Minute*10, alphapointUnauthRate),
@@ -516,7 +516,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.BTC,
 		SecondCurrency: symbol.USD,
 	}
-	response, err := a.SubmitOrder(p, exchange.Buy, exchange.Market, 1, 1, "clientId")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       1,
+		Price:        1,
+		ClientID:     "clientId",
+	}
+	response, err := a.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -99,19 +101,28 @@ func (a *Alphapoint) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrNotYetImplemented
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (a *Alphapoint) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (a *Alphapoint) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (a *Alphapoint) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order and returns a true value when
 // successfully submitted
-func (a *Alphapoint) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (a *Alphapoint) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(a.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
 
-	response, err := a.CreateOrder(p.Pair().String(), side.ToString(), orderType.ToString(), amount, price)
+	response, err := a.CreateOrder(order.CurrencyPair.Pair().String(), order.OrderSide.ToString(), order.OrderType.ToString(), order.Amount, order.Price)
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
 	}
@@ -61,6 +61,15 @@ func (a *ANX) SetDefaults() {
 	a.ConfigCurrencyPairFormat.Index = ""
 	a.APIWithdrawPermissions = exchange.WithdrawCryptoWithEmail | exchange.AutoWithdrawCryptoWithSetup |
 		exchange.WithdrawCryptoWith2FA | exchange.WithdrawFiatViaWebsiteOnly
+	a.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+	a.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	a.AssetTypes = []string{ticker.Spot}
 	a.SupportsAutoPairUpdating = true
 	a.SupportsRESTTickerBatching = false
@@ -251,7 +251,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.BTC,
 		SecondCurrency: symbol.USD,
 	}
-	response, err := a.SubmitOrder(p, exchange.Buy, exchange.Market, 1, 1, "clientId")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       1,
+		Price:        1,
+		ClientID:     "clientId",
+	}
+	response, err := a.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -333,3 +341,39 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	a.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := a.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	a.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := a.GetOrderHistory(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get order history: %s", err)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -194,7 +196,7 @@ func (a *ANX) GetAccountInfo() (exchange.AccountInfo, error) {
 		balance = append(balance, exchange.AccountCurrencyInfo{
 			CurrencyName: currency,
 			TotalValue:   info.AvailableBalance.Value,
-			Hold:         info.Balance.Value,
+			Hold:         info.Balance.Value - info.AvailableBalance.Value,
 		})
 	}
 
@@ -211,34 +213,43 @@ func (a *ANX) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (a *ANX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (a *ANX) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (a *ANX) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (a *ANX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (a *ANX) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(a.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
 
 	var isBuying bool
 	var limitPriceInSettlementCurrency float64
 
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		isBuying = true
 	}
 
-	if orderType == exchange.Limit {
-		limitPriceInSettlementCurrency = price
+	if order.OrderType == exchange.Limit {
+		limitPriceInSettlementCurrency = order.Price
 	}
 
-	response, err := a.NewOrder(orderType.ToString(),
+	response, err := a.NewOrder(order.OrderType.ToString(),
 		isBuying,
-		p.FirstCurrency.String(),
-		amount,
-		p.SecondCurrency.String(),
-		amount,
+		order.CurrencyPair.FirstCurrency.String(),
+		order.Amount,
+		order.CurrencyPair.SecondCurrency.String(),
+		order.Amount,
 		limitPriceInSettlementCurrency,
 		false,
 		"",
@@ -303,6 +314,60 @@ func (a *ANX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (a *ANX) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := a.GetOrderList(true)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := a.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (a *ANX) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := a.GetOrderList(false)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := a.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrders converts ANX order responses to the exchange.OrderDetail type
+func (a *ANX) formatOrders(resp []OrderResponse) []exchange.OrderDetail {
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		side := exchange.Sell
+		if resp[i].BuyTradedCurrency {
+			side = exchange.Buy
+		}
+
+		amount, _ := strconv.ParseFloat(resp[i].TradedCurrencyAmount, 64)
+		remaining, _ := strconv.ParseFloat(resp[i].TradedCurrencyOutstanding, 64)
+		price, _ := strconv.ParseFloat(resp[i].LimitPriceInSettlementCurrency, 64)
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange: a.Name,
+			ID:       resp[i].OrderID,
+			CurrencyPair: pair.NewCurrencyPair(resp[i].TradedCurrency,
+				resp[i].SettlementCurrency),
+			OrderSide:       side,
+			OrderType:       exchange.FormatOrderType(resp[i].OrderType),
+			OrderDate:       time.Unix(0, resp[i].Timestamp*int64(time.Millisecond)),
+			Status:          resp[i].OrderStatus,
+			Price:           price,
+			Amount:          amount,
+			ExecutedAmount:  amount - remaining,
+			RemainingAmount: remaining,
+		})
+	}
+	return orders
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (a *ANX) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -13,6 +13,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -55,8 +56,36 @@ const (
 	// to-do
 	binanceAuthRate   = 0
 	binanceUnauthRate = 0
+
+	// klineLimit is the maximum number of candles returned per klines request
+	klineLimit = 500
+
+	// aggregatedTradeLimit is the maximum number of trades returned per
+	// aggregated trades request
+	aggregatedTradeLimit = 1000
+	// aggregatedTradeWindow is the widest time range accepted by the
+	// aggregated trades endpoint, which must be below an hour
+	aggregatedTradeWindow = time.Hour - time.Millisecond
 )
 
+// klineIntervals are the candle intervals supported by the klines endpoint
+var klineIntervals = []kline.Interval{
+	kline.OneMin,
+	kline.ThreeMin,
+	kline.FiveMin,
+	kline.FifteenMin,
+	kline.ThirtyMin,
+	kline.OneHour,
+	kline.TwoHour,
+	kline.FourHour,
+	kline.SixHour,
+	kline.EightHour,
+	kline.TwelveHour,
+	kline.OneDay,
+	kline.ThreeDay,
+	kline.OneWeek,
+}
+
 // SetDefaults sets the basic defaults for Binance
 func (b *Binance) SetDefaults() {
 	b.Name = "Binance"
@@ -71,6 +100,20 @@ func (b *Binance) SetDefaults() {
 	b.SupportsAutoPairUpdating = true
 	b.SupportsRESTTickerBatching = true
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCrypto
+	b.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport |
+		exchange.StopOrderSupport | exchange.StopLimitOrderSupport | exchange.PostOnlyOrderSupport |
+		exchange.ImmediateOrCancelOrderSupport | exchange.FillOrKillOrderSupport
+	b.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true, Websocket: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		TradeFetching:     exchange.FeatureSupport{REST: true, Websocket: true},
+		KlineFetching:     exchange.FeatureSupport{REST: true, Websocket: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.TickerChannel, exchange.OrderbookChannel, exchange.TradeChannel, exchange.KlineChannel},
+	}
 	b.SetValues()
 	b.Requester = request.New(b.Name,
 		request.NewRateLimit(time.Second, binanceAuthRate),
@@ -124,6 +167,7 @@ func (b *Binance) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		b.Websocket.SetOrderbookFetcher(b.fetchOrderbookSnapshot)
 	}
 }
 
@@ -255,21 +299,35 @@ func (b *Binance) GetHistoricalTrades(symbol string, limit int, fromID int64) ([
 
 // GetAggregatedTrades returns aggregated trade activity
 //
+// AggregatedTradeRequestParams supports 5 parameters
 // symbol: string of currency pair
+// fromID: Optional. The aggregate trade ID to fetch trades from
+// startTime: Optional. The time in milliseconds to fetch trades from
+// endTime: Optional. The time in milliseconds to fetch trades until, must be
+// less than an hour after startTime
 // limit: Optional. Default 500; max 1000.
-func (b *Binance) GetAggregatedTrades(symbol string, limit int) ([]AggregatedTrade, error) {
+func (b *Binance) GetAggregatedTrades(arg AggregatedTradeRequestParams) ([]AggregatedTrade, error) {
 	resp := []AggregatedTrade{}
 
-	if err := b.CheckLimit(limit); err != nil {
+	if err := b.CheckLimit(arg.Limit); err != nil {
 		return resp, err
 	}
-	if err := b.CheckSymbol(symbol); err != nil {
+	if err := b.CheckSymbol(arg.Symbol); err != nil {
 		return resp, err
 	}
 
 	params := url.Values{}
-	params.Set("symbol", common.StringToUpper(symbol))
-	params.Set("limit", strconv.Itoa(limit))
+	params.Set("symbol", common.StringToUpper(arg.Symbol))
+	params.Set("limit", strconv.Itoa(arg.Limit))
+	if arg.FromID != 0 {
+		params.Set("fromId", strconv.FormatInt(arg.FromID, 10))
+	}
+	if arg.StartTime != 0 {
+		params.Set("startTime", strconv.FormatInt(arg.StartTime, 10))
+	}
+	if arg.EndTime != 0 {
+		params.Set("endTime", strconv.FormatInt(arg.EndTime, 10))
+	}
 
 	path := fmt.Sprintf("%s%s?%s", b.APIUrl, aggregatedTrades, params.Encode())
 
@@ -430,7 +488,11 @@ func (b *Binance) NewOrder(o NewOrderRequest) (NewOrderResponse, error) {
 	params.Set("side", string(o.Side))
 	params.Set("type", string(o.TradeType))
 	params.Set("quantity", strconv.FormatFloat(o.Quantity, 'f', -1, 64))
-	if o.TradeType == "LIMIT" {
+	switch o.TradeType {
+	case BinanceRequestParamsOrderLimit,
+		BinanceRequestParamsOrderStopLossLimit,
+		BinanceRequestParamsOrderTakeProfitLimit,
+		BinanceRequestParamsOrderLimitMarker:
 		params.Set("price", strconv.FormatFloat(o.Price, 'f', -1, 64))
 	}
 	if o.TimeInForce != "" {
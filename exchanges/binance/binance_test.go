@@ -2,12 +2,13 @@ package binance
 
 import (
 	"testing"
+	"time"
 
+	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
-
-	"github.com/thrasher-/gocryptotrader/config"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 )
 
 // Please supply your own keys here for due diligence testing
@@ -80,7 +81,7 @@ func TestGetHistoricalTrades(t *testing.T) {
 
 func TestGetAggregatedTrades(t *testing.T) {
 	t.Parallel()
-	_, err := b.GetAggregatedTrades("BTCUSDT", 5)
+	_, err := b.GetAggregatedTrades(AggregatedTradeRequestParams{Symbol: "BTCUSDT", Limit: 5})
 	if err != nil {
 		t.Error("Test Failed - Binance GetAggregatedTrades() error", err)
 	}
@@ -356,7 +357,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.LTC,
 		SecondCurrency: symbol.BTC,
 	}
-	response, err := b.SubmitOrder(p, exchange.Buy, exchange.Market, 1, 1, "clientId")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       1,
+		Price:        1,
+		ClientID:     "clientId",
+	}
+	response, err := b.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -437,3 +446,84 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := b.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := b.GetOrderHistory(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get order history: %s", err)
+	}
+}
+
+func TestGetHistoricCandles(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	end := time.Now()
+	start := end.Add(-7 * 24 * time.Hour)
+	item, err := b.GetHistoricCandles(pair.NewCurrencyPair(symbol.BTC, symbol.USDT), "SPOT", kline.FourHour, start, end)
+	if err != nil {
+		t.Errorf("Could not get historic candles: %s", err)
+	}
+	if err == nil && len(item.Candles) == 0 {
+		t.Error("Expected historic candles to be returned")
+	}
+}
+
+func TestGetExchangeHistory(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	end := time.Now()
+	start := end.Add(-time.Hour)
+	trades, err := b.GetExchangeHistory(exchange.TradeHistoryRequest{
+		CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.USDT),
+		AssetType:    "SPOT",
+		StartTime:    start,
+		EndTime:      end,
+	})
+	if err != nil {
+		t.Errorf("Could not get exchange history: %s", err)
+	}
+	for x := range trades {
+		if trades[x].Timestamp.Before(start) || !trades[x].Timestamp.Before(end) {
+			t.Errorf("Trade %+v outside of requested time range", trades[x])
+		}
+	}
+
+	_, err = b.GetExchangeHistory(exchange.TradeHistoryRequest{
+		CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.USDT),
+		FromTradeID:  "not an ID",
+	})
+	if err == nil {
+		t.Error("Expected error when fetching from an invalid trade ID")
+	}
+}
@@ -216,6 +216,15 @@ type AggregatedTrade struct {
 	BestMatchPrice bool    `json:"M"`
 }
 
+// AggregatedTradeRequestParams represents aggregated trade request data
+type AggregatedTradeRequestParams struct {
+	Symbol    string // Required field; example LTCBTC, BTCUSDT
+	FromID    int64
+	StartTime int64
+	EndTime   int64
+	Limit     int // Default 500; max 1000.
+}
+
 // CandleStick holds kline data
 type CandleStick struct {
 	OpenTime                 float64
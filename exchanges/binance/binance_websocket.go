@@ -7,7 +7,6 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -21,11 +20,20 @@ const (
 	binanceDefaultWebsocketURL = "wss://stream.binance.com:9443"
 )
 
-var lastUpdateID map[string]int64
-var m sync.Mutex
-
 // SeedLocalCache seeds depth data
 func (b *Binance) SeedLocalCache(p pair.CurrencyPair) error {
+	newOrderBook, err := b.fetchOrderbookSnapshot(p, "SPOT")
+	if err != nil {
+		return err
+	}
+
+	return b.Websocket.Orderbook.LoadSnapshot(newOrderBook, b.GetName())
+}
+
+// fetchOrderbookSnapshot retrieves a REST orderbook snapshot for the local
+// cache and stores its last update ID as the sequence depth updates are
+// validated against
+func (b *Binance) fetchOrderbookSnapshot(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
 	var newOrderBook orderbook.Base
 
 	formattedPair := exchange.FormatExchangeCurrency(b.Name, p)
@@ -37,17 +45,9 @@ func (b *Binance) SeedLocalCache(p pair.CurrencyPair) error {
 		})
 
 	if err != nil {
-		return err
+		return newOrderBook, err
 	}
 
-	m.Lock()
-	if lastUpdateID == nil {
-		lastUpdateID = make(map[string]int64)
-	}
-
-	lastUpdateID[formattedPair.String()] = orderbookNew.LastUpdateID
-	m.Unlock()
-
 	for _, bids := range orderbookNew.Bids {
 		newOrderBook.Bids = append(newOrderBook.Bids,
 			orderbook.Item{Amount: bids.Quantity, Price: bids.Price})
@@ -60,29 +60,34 @@ func (b *Binance) SeedLocalCache(p pair.CurrencyPair) error {
 	newOrderBook.Pair = pair.NewCurrencyPairFromString(formattedPair.String())
 	newOrderBook.CurrencyPair = formattedPair.String()
 	newOrderBook.LastUpdated = time.Now()
-	newOrderBook.AssetType = "SPOT"
+	newOrderBook.AssetType = assetType
 
-	return b.Websocket.Orderbook.LoadSnapshot(newOrderBook, b.GetName())
+	b.Websocket.Orderbook.SetSequence(newOrderBook.Pair,
+		assetType,
+		orderbookNew.LastUpdateID)
+
+	return newOrderBook, nil
 }
 
 // UpdateLocalCache updates and returns the most recent iteration of the orderbook
 func (b *Binance) UpdateLocalCache(ob WebsocketDepthStream) error {
-	m.Lock()
-	ID, ok := lastUpdateID[ob.Pair]
-	if !ok {
-		m.Unlock()
-		return errors.New("binance_websocket.go - Unable to find lastUpdateID")
-	}
+	currencyPair := pair.NewCurrencyPairFromString(ob.Pair)
 
-	if ob.LastUpdateID+1 <= ID || ID >= ob.LastUpdateID+1 {
-		// Drop update, out of order
-		m.Unlock()
+	err := b.Websocket.Orderbook.ValidateSequence(currencyPair,
+		"SPOT",
+		ob.FirstUpdateID,
+		ob.LastUpdateID)
+	switch err {
+	case nil:
+	case exchange.ErrOrderbookUpdateOutdated:
+		// Drop update, already included in the local cache
 		return nil
+	case exchange.ErrOrderbookSequenceGap:
+		return b.Websocket.ResyncOrderbook(currencyPair, "SPOT", err)
+	default:
+		return err
 	}
 
-	lastUpdateID[ob.Pair] = ob.LastUpdateID
-	m.Unlock()
-
 	var updateBid, updateAsk []orderbook.Item
 
 	for _, bidsToUpdate := range ob.UpdateBids {
@@ -108,11 +113,10 @@ func (b *Binance) UpdateLocalCache(ob WebsocketDepthStream) error {
 				priceToBeUpdated.Amount, _ = strconv.ParseFloat(asks.(string), 64)
 			}
 		}
-		updateAsk = append(updateBid, priceToBeUpdated)
+		updateAsk = append(updateAsk, priceToBeUpdated)
 	}
 
 	updatedTime := time.Unix(ob.Timestamp, 0)
-	currencyPair := pair.NewCurrencyPairFromString(ob.Pair)
 
 	return b.Websocket.Orderbook.Update(updateBid,
 		updateAsk,
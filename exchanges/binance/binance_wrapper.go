@@ -1,15 +1,16 @@
 package binance
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -152,8 +153,8 @@ func (b *Binance) GetAccountInfo() (exchange.AccountInfo, error) {
 
 		currencyBalance = append(currencyBalance, exchange.AccountCurrencyInfo{
 			CurrencyName: balance.Asset,
-			TotalValue:   freeCurrency + lockedCurrency,
-			Hold:         freeCurrency,
+			TotalValue:   freeCurrency,
+			Hold:         lockedCurrency,
 		})
 	}
 
@@ -169,39 +170,194 @@ func (b *Binance) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (b *Binance) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
-	var resp []exchange.TradeHistory
-	return resp, common.ErrNotYetImplemented
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (b *Binance) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
+	if err := tradeHistoryRequest.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Binance's cursor is the next aggregate trade ID, the request trade ID is
+	// exclusive
+	var cursor string
+	if tradeHistoryRequest.FromTradeID != "" {
+		fromTradeID, err := strconv.ParseInt(tradeHistoryRequest.FromTradeID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trade ID %s: %s", tradeHistoryRequest.FromTradeID, err)
+		}
+		cursor = strconv.FormatInt(fromTradeID+1, 10)
+	}
+
+	symbol := exchange.FormatExchangeCurrency(b.Name, tradeHistoryRequest.CurrencyPair).String()
+	return exchange.PaginateTradeHistory(tradeHistoryRequest, cursor, func(cursor string) ([]exchange.TradeHistory, string, error) {
+		arg := AggregatedTradeRequestParams{Symbol: symbol, Limit: aggregatedTradeLimit}
+		var resp []AggregatedTrade
+		var err error
+		switch {
+		case cursor != "":
+			arg.FromID, err = strconv.ParseInt(cursor, 10, 64)
+			if err != nil {
+				return nil, "", err
+			}
+			resp, err = b.GetAggregatedTrades(arg)
+		case !tradeHistoryRequest.StartTime.IsZero():
+			resp, err = b.getAggregatedTradesFrom(arg, tradeHistoryRequest.StartTime, tradeHistoryRequest.EndTime)
+		default:
+			resp, err = b.GetAggregatedTrades(arg)
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		var trades []exchange.TradeHistory
+		for x := range resp {
+			side := exchange.Buy
+			if resp[x].Maker {
+				side = exchange.Sell
+			}
+
+			trades = append(trades, exchange.TradeHistory{
+				Exchange:     b.Name,
+				TID:          strconv.FormatInt(resp[x].ATradeID, 10),
+				CurrencyPair: tradeHistoryRequest.CurrencyPair,
+				AssetType:    tradeHistoryRequest.AssetType,
+				Side:         side,
+				Price:        resp[x].Price,
+				Amount:       resp[x].Quantity,
+				Timestamp:    time.Unix(0, resp[x].TimeStamp*int64(time.Millisecond)),
+			})
+		}
+
+		// without a cursor or start time only the most recent trades are
+		// returned, a short page from a cursor is the latest page
+		if len(resp) == 0 ||
+			(cursor == "" && tradeHistoryRequest.StartTime.IsZero()) ||
+			(cursor != "" && len(resp) < aggregatedTradeLimit) {
+			return trades, "", nil
+		}
+		return trades, strconv.FormatInt(resp[len(resp)-1].ATradeID+1, 10), nil
+	})
+}
+
+// getAggregatedTradesFrom returns the first trades at or after start, stepping
+// through the time windows accepted by the aggregated trades endpoint until a
+// window holds trades or end, or the current time, is reached
+func (b *Binance) getAggregatedTradesFrom(arg AggregatedTradeRequestParams, start, end time.Time) ([]AggregatedTrade, error) {
+	if end.IsZero() {
+		end = time.Now()
+	}
+	for windowStart := start; windowStart.Before(end); windowStart = windowStart.Add(aggregatedTradeWindow) {
+		arg.StartTime = windowStart.UnixNano() / int64(time.Millisecond)
+		arg.EndTime = windowStart.Add(aggregatedTradeWindow).UnixNano() / int64(time.Millisecond)
+		resp, err := b.GetAggregatedTrades(arg)
+		if err != nil || len(resp) > 0 {
+			return resp, err
+		}
+	}
+	return nil, nil
+}
+
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (b *Binance) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	symbol := exchange.FormatExchangeCurrency(b.Name, p).String()
+	fetcher := kline.Fetcher{
+		SupportedIntervals: klineIntervals,
+		Limit:              klineLimit,
+		Fetch: func(interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+			resp, err := b.GetSpotKline(KlinesRequestParams{
+				Symbol:    symbol,
+				Interval:  TimeInterval(interval.String()),
+				Limit:     klineLimit,
+				StartTime: start.UnixNano() / int64(time.Millisecond),
+				EndTime:   end.UnixNano()/int64(time.Millisecond) - 1,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			var candles []kline.Candle
+			for x := range resp {
+				candles = append(candles, kline.Candle{
+					Time:   time.Unix(0, int64(resp[x].OpenTime)*int64(time.Millisecond)),
+					Open:   resp[x].Open,
+					High:   resp[x].High,
+					Low:    resp[x].Low,
+					Close:  resp[x].Close,
+					Volume: resp[x].Volume,
+				})
+			}
+			return candles, nil
+		},
+	}
+
+	candles, err := fetcher.GetCandles(interval, start, end)
+	if err != nil {
+		return kline.Item{}, err
+	}
+
+	return kline.Item{
+		Exchange:  b.Name,
+		Pair:      p,
+		AssetType: assetType,
+		Interval:  interval,
+		Candles:   candles,
+	}, nil
 }
 
 // SubmitOrder submits a new order
-func (b *Binance) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (b *Binance) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(b.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
 
 	var sideType RequestParamsSideType
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		sideType = BinanceRequestParamsSideBuy
 	} else {
 		sideType = BinanceRequestParamsSideSell
 	}
 
-	var requestParamsOrderType RequestParamsOrderType
-	if orderType == exchange.Market {
-		requestParamsOrderType = BinanceRequestParamsOrderMarket
-	} else if orderType == exchange.Limit {
-		requestParamsOrderType = BinanceRequestParamsOrderLimit
-	} else {
-		submitOrderResponse.IsOrderPlaced = false
-		return submitOrderResponse, errors.New("Unsupported order type")
+	var orderRequest = NewOrderRequest{
+		Symbol:           order.CurrencyPair.FirstCurrency.String() + order.CurrencyPair.SecondCurrency.String(),
+		Side:             sideType,
+		Price:            order.Price,
+		Quantity:         order.Amount,
+		NewClientOrderID: order.ClientID,
 	}
 
-	var orderRequest = NewOrderRequest{
-		Symbol:    p.FirstCurrency.String() + p.SecondCurrency.String(),
-		Side:      sideType,
-		Price:     price,
-		Quantity:  amount,
-		TradeType: requestParamsOrderType,
+	switch order.OrderType {
+	case exchange.Market:
+		orderRequest.TradeType = BinanceRequestParamsOrderMarket
+	case exchange.Limit:
+		orderRequest.TradeType = BinanceRequestParamsOrderLimit
+		if order.PostOnly {
+			orderRequest.TradeType = BinanceRequestParamsOrderLimitMarker
+		}
+	case exchange.ImmediateOrCancel:
+		orderRequest.TradeType = BinanceRequestParamsOrderLimit
+		orderRequest.TimeInForce = BinanceRequestParamsTimeIOC
+	case exchange.Stop:
+		orderRequest.TradeType = BinanceRequestParamsOrderStopLoss
+		orderRequest.StopPrice = order.TriggerPrice
+	case exchange.StopLimit:
+		orderRequest.TradeType = BinanceRequestParamsOrderStopLossLimit
+		orderRequest.StopPrice = order.TriggerPrice
+	}
+
+	// Limit orders require a time in force, LIMIT_MAKER orders reject one
+	if orderRequest.TradeType == BinanceRequestParamsOrderLimit ||
+		orderRequest.TradeType == BinanceRequestParamsOrderStopLossLimit {
+		switch order.TimeInForce {
+		case exchange.IOC:
+			orderRequest.TimeInForce = BinanceRequestParamsTimeIOC
+		case exchange.FOK:
+			orderRequest.TimeInForce = BinanceRequestParamsTimeFOK
+		default:
+			if orderRequest.TimeInForce == "" {
+				orderRequest.TimeInForce = BinanceRequestParamsTimeGTC
+			}
+		}
 	}
 
 	response, err := b.NewOrder(orderRequest)
@@ -263,6 +419,67 @@ func (b *Binance) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (b *Binance) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = b.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := b.OpenOrders(exchange.FormatExchangeCurrency(b.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, b.formatOrders(currencies[x], resp)...)
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (b *Binance) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = b.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := b.AllOrders(exchange.FormatExchangeCurrency(b.Name, currencies[x]).String(), "", "500")
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, b.formatOrders(currencies[x], resp)...)
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrders converts Binance order data to the exchange.OrderDetail type
+func (b *Binance) formatOrders(p pair.CurrencyPair, resp []QueryOrderData) []exchange.OrderDetail {
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        b.Name,
+			ID:              strconv.FormatInt(resp[i].OrderID, 10),
+			ClientOrderID:   resp[i].ClientOrderID,
+			CurrencyPair:    p,
+			OrderSide:       exchange.FormatOrderSide(resp[i].Side),
+			OrderType:       exchange.FormatOrderType(resp[i].Type),
+			OrderDate:       time.Unix(0, int64(resp[i].Time)*int64(time.Millisecond)),
+			Status:          resp[i].Status,
+			Price:           resp[i].Price,
+			Amount:          resp[i].OrigQty,
+			ExecutedAmount:  resp[i].ExecutedQty,
+			RemainingAmount: resp[i].OrigQty - resp[i].ExecutedQty,
+		})
+	}
+	return orders
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (b *Binance) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
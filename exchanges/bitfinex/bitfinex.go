@@ -45,6 +45,7 @@ const (
 	bitfinexOrderCancelReplace = "order/cancel/replace"
 	bitfinexOrderStatus        = "order/status"
 	bitfinexOrders             = "orders"
+	bitfinexInactiveOrders     = "orders/hist"
 	bitfinexPositions          = "positions"
 	bitfinexClaimPosition      = "position/claim"
 	bitfinexHistory            = "history"
@@ -84,6 +85,7 @@ type Bitfinex struct {
 	exchange.Base
 	WebsocketConn         *websocket.Conn
 	WebsocketSubdChannels map[int]WebsocketChanInfo
+	wsWalletBalances      map[string]map[string]float64
 }
 
 // SetDefaults sets the basic defaults for bitfinex
@@ -94,6 +96,19 @@ func (b *Bitfinex) SetDefaults() {
 	b.RESTPollingDelay = 10
 	b.WebsocketSubdChannels = make(map[int]WebsocketChanInfo)
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission | exchange.AutoWithdrawFiatWithAPIPermission
+	b.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport |
+		exchange.StopOrderSupport | exchange.TrailingStopOrderSupport | exchange.HiddenOrderSupport |
+		exchange.FillOrKillOrderSupport
+	b.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true, Websocket: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		TradeFetching:     exchange.FeatureSupport{Websocket: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true, Websocket: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.TickerChannel, exchange.OrderbookChannel, exchange.TradeChannel},
+	}
 	b.RequestCurrencyPairFormat.Delimiter = ""
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = ""
@@ -154,6 +169,7 @@ func (b *Bitfinex) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		b.Websocket.SetOrderbookFetcher(b.UpdateOrderbook)
 	}
 }
 
@@ -688,14 +704,23 @@ func (b *Bitfinex) GetOrderStatus(OrderID int64) (Order, error) {
 		b.SendAuthenticatedHTTPRequest("POST", bitfinexOrderStatus, request, &orderStatus)
 }
 
-// GetActiveOrders returns all active orders and statuses
-func (b *Bitfinex) GetActiveOrders() ([]Order, error) {
+// GetOpenOrders returns all active orders and statuses
+func (b *Bitfinex) GetOpenOrders() ([]Order, error) {
 	response := []Order{}
 
 	return response,
 		b.SendAuthenticatedHTTPRequest("POST", bitfinexOrders, nil, &response)
 }
 
+// GetInactiveOrders returns recently cancelled or fully executed orders, up to
+// a maximum of the last three days
+func (b *Bitfinex) GetInactiveOrders() ([]Order, error) {
+	response := []Order{}
+
+	return response,
+		b.SendAuthenticatedHTTPRequest("POST", bitfinexInactiveOrders, nil, &response)
+}
+
 // GetActivePositions returns an array of active positions
 func (b *Bitfinex) GetActivePositions() ([]Position, error) {
 	response := []Position{}
@@ -1,6 +1,7 @@
 package bitfinex
 
 import (
+	"hash/crc32"
 	"net/url"
 	"reflect"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 )
 
 // Please supply your own keys here to do better tests
@@ -443,15 +445,27 @@ func TestGetOrderStatus(t *testing.T) {
 	}
 }
 
-func TestGetActiveOrders(t *testing.T) {
+func TestGetOpenOrders(t *testing.T) {
 	if b.APIKey == "" || b.APISecret == "" {
 		t.SkipNow()
 	}
 	t.Parallel()
 
-	_, err := b.GetActiveOrders()
+	_, err := b.GetOpenOrders()
 	if err == nil {
-		t.Error("Test Failed - GetActiveOrders() error")
+		t.Error("Test Failed - GetOpenOrders() error")
+	}
+}
+
+func TestGetInactiveOrders(t *testing.T) {
+	if b.APIKey == "" || b.APISecret == "" {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	_, err := b.GetInactiveOrders()
+	if err == nil {
+		t.Error("Test Failed - GetInactiveOrders() error")
 	}
 }
 
@@ -743,7 +757,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.LTC,
 		SecondCurrency: symbol.BTC,
 	}
-	response, err := b.SubmitOrder(p, exchange.Buy, exchange.Market, 1, 1, "clientId")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       1,
+		Price:        1,
+		ClientID:     "clientId",
+	}
+	response, err := b.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -813,3 +835,87 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := b.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := b.GetOrderHistory(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get order history: %s", err)
+	}
+}
+
+func TestCalculateOrderbookChecksum(t *testing.T) {
+	ob := orderbook.Base{
+		Bids: []orderbook.Item{{Price: 6000, Amount: 1.5}, {Price: 6001, Amount: 0.25}},
+		Asks: []orderbook.Item{{Price: 6002, Amount: 2}},
+	}
+
+	expected := crc32.ChecksumIEEE([]byte("6001:0.25:6002:-2:6000:1.5"))
+	if checksum := calculateOrderbookChecksum(ob); checksum != expected {
+		t.Errorf("Test Failed - calculateOrderbookChecksum() expected %d, received %d",
+			expected, checksum)
+	}
+}
+
+func TestWsOrderUpdate(t *testing.T) {
+	var bf Bitfinex
+	bf.Name = "Bitfinex"
+
+	tests := map[string]exchange.OrderStatus{
+		"ACTIVE":                                       exchange.NewOrderStatus,
+		"PARTIALLY FILLED @ 107.6(-0.1)":               exchange.PartiallyFilledOrderStatus,
+		"EXECUTED @ 107.6(-0.2)":                       exchange.FilledOrderStatus,
+		"CANCELED was: PARTIALLY FILLED @ 107.6(-0.1)": exchange.CancelledOrderStatus,
+		"whoops": exchange.UnknownOrderStatus,
+	}
+	for status, expected := range tests {
+		if parseOrderStatus(status) != expected {
+			t.Errorf("Test Failed - parseOrderStatus() %s expected %s, received %s",
+				status, expected, parseOrderStatus(status))
+		}
+	}
+
+	update := bf.wsOrderUpdate(WebsocketOrder{
+		OrderID:    1337,
+		Pair:       "BTCUSD",
+		Amount:     -0.1,
+		OrigAmount: -0.2,
+		OrderType:  "EXCHANGE LIMIT",
+		Status:     "PARTIALLY FILLED @ 107.6(-0.1)",
+		Price:      107.6,
+	})
+	if update.OrderID != "1337" || update.OrderSide != exchange.Sell ||
+		update.OrderType != exchange.Limit || update.Amount != 0.2 ||
+		update.ExecutedAmount != 0.1 || update.RemainingAmount != 0.1 ||
+		update.Exchange != "Bitfinex" {
+		t.Errorf("Test Failed - wsOrderUpdate() unexpected update %+v", update)
+	}
+}
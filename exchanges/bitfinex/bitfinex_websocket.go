@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -32,6 +33,9 @@ const (
 	bitfinexWebsocketOrderCancel        = "oc"
 	bitfinexWebsocketTradeExecuted      = "te"
 	bitfinexWebsocketHeartbeat          = "hb"
+	bitfinexWebsocketChecksum           = "cs"
+	bitfinexWebsocketChecksumFlag       = 131072
+	bitfinexWebsocketChecksumDepth      = 25
 	bitfinexWebsocketAlertRestarting    = "20051"
 	bitfinexWebsocketAlertRefreshing    = "20060"
 	bitfinexWebsocketAlertResume        = "20061"
@@ -101,6 +105,124 @@ func (b *Bitfinex) WsSendAuth() error {
 	return b.WsSend(request)
 }
 
+// wsParseWallet parses a wallet snapshot or update entry
+func wsParseWallet(data []interface{}) WebsocketWallet {
+	return WebsocketWallet{
+		Name:              data[0].(string),
+		Currency:          data[1].(string),
+		Balance:           data[2].(float64),
+		UnsettledInterest: data[3].(float64)}
+}
+
+// wsParseOrder parses an order snapshot or update entry
+func wsParseOrder(data []interface{}) WebsocketOrder {
+	return WebsocketOrder{
+		OrderID:    int64(data[0].(float64)),
+		Pair:       data[1].(string),
+		Amount:     data[2].(float64),
+		OrigAmount: data[3].(float64),
+		OrderType:  data[4].(string),
+		Status:     data[5].(string),
+		Price:      data[6].(float64),
+		PriceAvg:   data[7].(float64),
+		Timestamp:  data[8].(string)}
+}
+
+// wsProcessWallet stores the balance of a wallet and sends a balance update
+// totalling the currency across the exchange, trading and deposit wallets
+func (b *Bitfinex) wsProcessWallet(wallet WebsocketWallet) {
+	if b.wsWalletBalances == nil {
+		b.wsWalletBalances = make(map[string]map[string]float64)
+	}
+
+	currency := common.StringToUpper(wallet.Currency)
+	if _, ok := b.wsWalletBalances[currency]; !ok {
+		b.wsWalletBalances[currency] = make(map[string]float64)
+	}
+	b.wsWalletBalances[currency][wallet.Name] = wallet.Balance
+
+	var total float64
+	for _, balance := range b.wsWalletBalances[currency] {
+		total += balance
+	}
+
+	b.Websocket.DataHandler <- exchange.BalanceUpdate{
+		Timestamp: time.Now(),
+		Exchange:  b.GetName(),
+		Currency:  currency,
+		Total:     total,
+	}
+}
+
+// wsOrderUpdate converts an authenticated order into an order update, order
+// amounts are negative for sell orders
+func (b *Bitfinex) wsOrderUpdate(order WebsocketOrder) exchange.OrderUpdate {
+	side := exchange.Buy
+	if order.OrigAmount < 0 {
+		side = exchange.Sell
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, order.Timestamp)
+	if err != nil {
+		timestamp = time.Now()
+	}
+
+	amount := math.Abs(order.OrigAmount)
+	remaining := math.Abs(order.Amount)
+	return exchange.OrderUpdate{
+		Timestamp:       timestamp,
+		Exchange:        b.GetName(),
+		AssetType:       "SPOT",
+		Pair:            pair.NewCurrencyPairFromString(order.Pair),
+		OrderID:         strconv.FormatInt(order.OrderID, 10),
+		OrderSide:       side,
+		OrderType:       exchange.FormatOrderType(order.OrderType),
+		Status:          parseOrderStatus(order.Status),
+		Price:           order.Price,
+		Amount:          amount,
+		ExecutedAmount:  amount - remaining,
+		RemainingAmount: remaining,
+	}
+}
+
+// wsFillEvent converts an executed trade into a fill event, executed amounts
+// are negative for sells
+func (b *Bitfinex) wsFillEvent(trade WebsocketTradeExecuted) exchange.FillEvent {
+	side := exchange.Buy
+	if trade.AmountExecuted < 0 {
+		side = exchange.Sell
+	}
+
+	return exchange.FillEvent{
+		Timestamp: time.Unix(trade.Timestamp, 0),
+		Exchange:  b.GetName(),
+		AssetType: "SPOT",
+		Pair:      pair.NewCurrencyPairFromString(trade.Pair),
+		OrderID:   strconv.FormatInt(trade.OrderID, 10),
+		TradeID:   strconv.FormatInt(trade.TradeID, 10),
+		OrderSide: side,
+		Price:     trade.PriceExecuted,
+		Amount:    math.Abs(trade.AmountExecuted),
+	}
+}
+
+// parseOrderStatus converts a websocket order status such as
+// "EXECUTED @ 107.6(-0.2)" or "CANCELED was: PARTIALLY FILLED @ 107.6(-0.1)"
+// into the standard order status
+func parseOrderStatus(status string) exchange.OrderStatus {
+	switch {
+	case common.StringContains(status, "CANCELED"):
+		return exchange.CancelledOrderStatus
+	case common.StringContains(status, "EXECUTED"):
+		return exchange.FilledOrderStatus
+	case common.StringContains(status, "PARTIALLY FILLED"):
+		return exchange.PartiallyFilledOrderStatus
+	case common.StringContains(status, "ACTIVE"):
+		return exchange.NewOrderStatus
+	}
+	return exchange.UnknownOrderStatus
+}
+
 // WsSendUnauth sends an unauthenticated payload
 func (b *Bitfinex) WsSendUnauth() error {
 	request := make(map[string]string)
@@ -164,6 +286,15 @@ func (b *Bitfinex) WsConnect() error {
 		}
 	}
 
+	// Request orderbook checksums to validate the local orderbook cache
+	err = b.WsSend(map[string]interface{}{
+		"event": "conf",
+		"flags": bitfinexWebsocketChecksumFlag,
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, x := range channels {
 		for _, y := range b.EnabledPairs {
 			params := make(map[string]string)
@@ -290,6 +421,17 @@ func (b *Bitfinex) WsDataHandler() {
 							}
 						}
 
+						if len(chanData) == 3 && chanData[1] == bitfinexWebsocketChecksum {
+							err := b.WsVerifyChecksum(pair.NewCurrencyPairFromString(chanInfo.Pair),
+								"SPOT",
+								int32(chanData[2].(float64)))
+							if err != nil {
+								b.Websocket.DataHandler <- fmt.Errorf("bitfinex_websocket.go orderbook checksum error: %s",
+									err)
+							}
+							continue
+						}
+
 						switch chanInfo.Channel {
 						case "book":
 							newOrderbook := []WebsocketBook{}
@@ -381,64 +523,25 @@ func (b *Bitfinex) WsDataHandler() {
 
 							case bitfinexWebsocketWalletSnapshot:
 								data := chanData[2].([]interface{})
-								walletSnapshot := []WebsocketWallet{}
 								for _, x := range data {
-									y := x.([]interface{})
-									walletSnapshot = append(walletSnapshot,
-										WebsocketWallet{
-											Name:              y[0].(string),
-											Currency:          y[1].(string),
-											Balance:           y[2].(float64),
-											UnsettledInterest: y[3].(float64)})
+									b.wsProcessWallet(wsParseWallet(x.([]interface{})))
 								}
 
-								b.Websocket.DataHandler <- walletSnapshot
-
 							case bitfinexWebsocketWalletUpdate:
-								data := chanData[2].([]interface{})
-								wallet := WebsocketWallet{
-									Name:              data[0].(string),
-									Currency:          data[1].(string),
-									Balance:           data[2].(float64),
-									UnsettledInterest: data[3].(float64)}
-
-								b.Websocket.DataHandler <- wallet
+								b.wsProcessWallet(wsParseWallet(chanData[2].([]interface{})))
 
 							case bitfinexWebsocketOrderSnapshot:
-								orderSnapshot := []WebsocketOrder{}
 								data := chanData[2].([]interface{})
 								for _, x := range data {
-									y := x.([]interface{})
-									orderSnapshot = append(orderSnapshot,
-										WebsocketOrder{
-											OrderID:    int64(y[0].(float64)),
-											Pair:       y[1].(string),
-											Amount:     y[2].(float64),
-											OrigAmount: y[3].(float64),
-											OrderType:  y[4].(string),
-											Status:     y[5].(string),
-											Price:      y[6].(float64),
-											PriceAvg:   y[7].(float64),
-											Timestamp:  y[8].(string)})
+									b.Websocket.DataHandler <- b.wsOrderUpdate(wsParseOrder(x.([]interface{})))
 								}
 
-								b.Websocket.DataHandler <- orderSnapshot
-
 							case bitfinexWebsocketOrderNew, bitfinexWebsocketOrderUpdate, bitfinexWebsocketOrderCancel:
 								data := chanData[2].([]interface{})
-								order := WebsocketOrder{
-									OrderID:    int64(data[0].(float64)),
-									Pair:       data[1].(string),
-									Amount:     data[2].(float64),
-									OrigAmount: data[3].(float64),
-									OrderType:  data[4].(string),
-									Status:     data[5].(string),
-									Price:      data[6].(float64),
-									PriceAvg:   data[7].(float64),
-									Timestamp:  data[8].(string),
-									Notify:     int(data[9].(float64))}
-
-								b.Websocket.DataHandler <- order
+								order := wsParseOrder(data)
+								order.Notify = int(data[9].(float64))
+
+								b.Websocket.DataHandler <- b.wsOrderUpdate(order)
 
 							case bitfinexWebsocketTradeExecuted:
 								data := chanData[2].([]interface{})
@@ -450,7 +553,7 @@ func (b *Bitfinex) WsDataHandler() {
 									AmountExecuted: data[4].(float64),
 									PriceExecuted:  data[5].(float64)}
 
-								b.Websocket.DataHandler <- trade
+								b.Websocket.DataHandler <- b.wsFillEvent(trade)
 							}
 
 						case "trades":
@@ -634,3 +737,31 @@ func (b *Bitfinex) WsUpdateOrderbook(p pair.CurrencyPair, assetType string, book
 
 	return nil
 }
+
+// WsVerifyChecksum compares the orderbook checksum published by Bitfinex
+// against the local orderbook and resyncs the orderbook on a mismatch
+func (b *Bitfinex) WsVerifyChecksum(p pair.CurrencyPair, assetType string, checksum int32) error {
+	err := b.Websocket.Orderbook.VerifyChecksum(p,
+		assetType,
+		uint32(checksum),
+		calculateOrderbookChecksum)
+	if err == exchange.ErrOrderbookChecksumMismatch {
+		return b.Websocket.ResyncOrderbook(p, assetType, err)
+	}
+	return err
+}
+
+// calculateOrderbookChecksum returns the Bitfinex checksum of an orderbook,
+// asks are represented with negative amounts
+func calculateOrderbookChecksum(ob orderbook.Base) uint32 {
+	return exchange.CalculateOrderbookChecksum(ob,
+		bitfinexWebsocketChecksumDepth,
+		func(item orderbook.Item, isAsk bool) string {
+			amount := item.Amount
+			if isAsk {
+				amount *= -1
+			}
+			return strconv.FormatFloat(item.Price, 'f', -1, 64) + ":" +
+				strconv.FormatFloat(amount, 'f', -1, 64)
+		})
+}
@@ -3,15 +3,17 @@ package bitfinex
 import (
 	"fmt"
 	"log"
-	"net/url"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"net/url"
 )
 
 // Start starts the Bitfinex go routine
@@ -152,7 +154,7 @@ func (b *Bitfinex) GetAccountInfo() (exchange.AccountInfo, error) {
 	for x, y := range accounts {
 		var exchangeCurrency exchange.AccountCurrencyInfo
 		exchangeCurrency.CurrencyName = common.StringToUpper(x)
-		exchangeCurrency.TotalValue = y.Available + y.OnHold
+		exchangeCurrency.TotalValue = y.Available
 		exchangeCurrency.Hold = y.OnHold
 		response.Currencies = append(response.Currencies, exchangeCurrency)
 	}
@@ -167,23 +169,51 @@ func (b *Bitfinex) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (b *Bitfinex) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (b *Bitfinex) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (b *Bitfinex) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (b *Bitfinex) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (b *Bitfinex) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(b.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var isBuying bool
 
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		isBuying = true
 	}
 
-	response, err := b.NewOrder(p.Pair().String(), amount, price, isBuying, orderType.ToString(), false)
+	price := order.Price
+	var orderType string
+	switch order.OrderType {
+	case exchange.Limit:
+		orderType = "exchange limit"
+		if order.TimeInForce == exchange.FOK {
+			orderType = "exchange fill-or-kill"
+		}
+	case exchange.Market:
+		orderType = "exchange market"
+	case exchange.Stop:
+		orderType = "exchange stop"
+		price = order.TriggerPrice
+	case exchange.TrailingStop:
+		orderType = "exchange trailing-stop"
+		price = order.TrailingAmount
+	}
+
+	response, err := b.NewOrder(order.CurrencyPair.Pair().String(), order.Amount, price, isBuying, orderType, order.HiddenOrder)
 
 	if response.OrderID > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response.OrderID)
@@ -227,6 +257,69 @@ func (b *Bitfinex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (b *Bitfinex) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := b.GetOpenOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	orders := b.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (b *Bitfinex) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := b.GetInactiveOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	orders := b.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrders converts Bitfinex orders to the exchange.OrderDetail type
+func (b *Bitfinex) formatOrders(resp []Order) []exchange.OrderDetail {
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		var p pair.CurrencyPair
+		if len(resp[i].Symbol) == 6 {
+			p = pair.NewCurrencyPair(common.StringToUpper(resp[i].Symbol[0:3]),
+				common.StringToUpper(resp[i].Symbol[3:]))
+		}
+
+		var orderDate time.Time
+		timestamp, err := strconv.ParseFloat(resp[i].Timestamp, 64)
+		if err == nil {
+			orderDate = time.Unix(int64(timestamp), 0)
+		}
+
+		status := "ACTIVE"
+		if resp[i].IsCancelled {
+			status = "CANCELLED"
+		} else if !resp[i].IsLive {
+			status = "EXECUTED"
+		}
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        b.Name,
+			ID:              strconv.FormatInt(resp[i].ID, 10),
+			CurrencyPair:    p,
+			OrderSide:       exchange.FormatOrderSide(resp[i].Side),
+			OrderType:       exchange.FormatOrderType(resp[i].Type),
+			OrderDate:       orderDate,
+			Status:          status,
+			Price:           resp[i].Price,
+			Amount:          resp[i].OriginalAmount,
+			ExecutedAmount:  resp[i].ExecutedAmount,
+			RemainingAmount: resp[i].RemainingAmount,
+		})
+	}
+	return orders
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (b *Bitfinex) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -82,6 +82,11 @@ func (b *Bitflyer) SetDefaults() {
 	b.Verbose = false
 	b.RESTPollingDelay = 10
 	b.APIWithdrawPermissions = exchange.WithdrawCryptoViaWebsiteOnly | exchange.AutoWithdrawFiat
+	b.OrderSubmissionFeatures = exchange.NoOrderSubmissionFeatures
+	b.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+	}
 	b.RequestCurrencyPairFormat.Delimiter = "_"
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = "_"
@@ -65,6 +65,16 @@ func (b *Bithumb) SetDefaults() {
 	b.Verbose = false
 	b.RESTPollingDelay = 10
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCrypto | exchange.AutoWithdrawFiat
+	b.OrderSubmissionFeatures = exchange.MarketOrderSupport
+	b.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		ModifyOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	b.RequestCurrencyPairFormat.Delimiter = ""
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = ""
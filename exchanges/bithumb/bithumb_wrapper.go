@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -137,7 +139,7 @@ func (b *Bithumb) GetAccountInfo() (exchange.AccountInfo, error) {
 
 		exchangeBalances = append(exchangeBalances, exchange.AccountCurrencyInfo{
 			CurrencyName: key,
-			TotalValue:   totalAmount,
+			TotalValue:   totalAmount - hold,
 			Hold:         hold,
 		})
 	}
@@ -154,25 +156,35 @@ func (b *Bithumb) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (b *Bithumb) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (b *Bithumb) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (b *Bithumb) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (b *Bithumb) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (b *Bithumb) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(b.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var err error
 	var orderID string
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		var result MarketBuy
-		result, err = b.MarketBuyOrder(p.FirstCurrency.String(), amount)
+		result, err = b.MarketBuyOrder(order.CurrencyPair.FirstCurrency.String(), order.Amount)
 		orderID = result.OrderID
-	} else if side == exchange.Sell {
+	} else if order.OrderSide == exchange.Sell {
 		var result MarketSell
-		result, err = b.MarketSellOrder(p.FirstCurrency.String(), amount)
+		result, err = b.MarketSellOrder(order.CurrencyPair.FirstCurrency.String(), order.Amount)
 		orderID = result.OrderID
 	}
 
@@ -243,6 +255,48 @@ func (b *Bithumb) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (b *Bithumb) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = b.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := b.GetOrders("", "", "1000", "", currencies[x].FirstCurrency.String())
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Data {
+			orders = append(orders, exchange.OrderDetail{
+				Exchange: b.Name,
+				ID:       resp.Data[i].OrderID,
+				CurrencyPair: pair.NewCurrencyPair(resp.Data[i].OrderCurrency,
+					resp.Data[i].PaymentCurrency),
+				OrderSide:       exchange.FormatOrderSide(resp.Data[i].Type),
+				OrderType:       exchange.Limit,
+				OrderDate:       time.Unix(0, resp.Data[i].OrderDate*int64(time.Millisecond)),
+				Status:          resp.Data[i].Status,
+				Price:           resp.Data[i].Price,
+				Amount:          resp.Data[i].Units,
+				ExecutedAmount:  resp.Data[i].Units - resp.Data[i].UnitsRemaining,
+				RemainingAmount: resp.Data[i].UnitsRemaining,
+				Fee:             resp.Data[i].Fee,
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (b *Bithumb) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return nil, common.ErrNotYetImplemented
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (b *Bithumb) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -98,6 +99,10 @@ const (
 	// 300 requests per 5 minutes
 	bitmexAuthRate = 40
 
+	// bitmexBucketedLimit is the maximum number of trade buckets returned per
+	// request
+	bitmexBucketedLimit = 500
+
 	// ContractPerpetual perpetual contract type
 	ContractPerpetual = iota
 	// ContractFutures futures contract type
@@ -108,6 +113,14 @@ const (
 	ContractUpsideProfit
 )
 
+// bitmexBucketedIntervals are the trade bucket sizes supported by Bitmex
+var bitmexBucketedIntervals = []kline.Interval{
+	kline.OneMin,
+	kline.FiveMin,
+	kline.OneHour,
+	kline.OneDay,
+}
+
 // SetDefaults sets the basic defaults for Bitmex
 func (b *Bitmex) SetDefaults() {
 	b.Name = "Bitmex"
@@ -115,6 +128,22 @@ func (b *Bitmex) SetDefaults() {
 	b.Verbose = false
 	b.RESTPollingDelay = 10
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission | exchange.WithdrawCryptoWithEmail | exchange.WithdrawCryptoWith2FA
+	b.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport |
+		exchange.StopOrderSupport | exchange.StopLimitOrderSupport | exchange.TrailingStopOrderSupport |
+		exchange.PostOnlyOrderSupport | exchange.ReduceOnlyOrderSupport |
+		exchange.ImmediateOrCancelOrderSupport | exchange.FillOrKillOrderSupport
+	b.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		TradeFetching:     exchange.FeatureSupport{Websocket: true},
+		KlineFetching:     exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		ModifyOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true, Websocket: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.OrderbookChannel, exchange.TradeChannel},
+	}
 	b.RequestCurrencyPairFormat.Delimiter = ""
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = ""
@@ -598,12 +627,12 @@ func (b *Bitmex) GetTrade(params GenericRequestParams) ([]Trade, error) {
 }
 
 // GetPreviousTrades previous trade history in time buckets
-func (b *Bitmex) GetPreviousTrades(params TradeGetBucketedParams) ([]Trade, error) {
-	var trade []Trade
+func (b *Bitmex) GetPreviousTrades(params TradeGetBucketedParams) ([]TradeBucket, error) {
+	var buckets []TradeBucket
 
-	return trade, b.SendHTTPRequest(bitmexEndpointTradeBucketed,
+	return buckets, b.SendHTTPRequest(bitmexEndpointTradeBucketed,
 		params,
-		&trade)
+		&buckets)
 }
 
 // GetUserInfo returns your user information
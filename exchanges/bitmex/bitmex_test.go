@@ -5,11 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	"github.com/thrasher-/gocryptotrader/exchanges"
-
-	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 )
 
 // Please supply your own keys here for due diligence testing
@@ -489,7 +489,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.XBT,
 		SecondCurrency: symbol.USD,
 	}
-	response, err := b.SubmitOrder(p, exchange.Buy, exchange.Market, 1, 1, "clientId")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       1,
+		Price:        1,
+		ClientID:     "clientId",
+	}
+	response, err := b.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -573,3 +581,74 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test Failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := b.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := b.GetOrderHistory(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get order history: %s", err)
+	}
+}
+
+func TestGetHistoricCandles(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	end := time.Now()
+	start := end.Add(-2 * 24 * time.Hour)
+	item, err := b.GetHistoricCandles(pair.NewCurrencyPair(symbol.XBT, symbol.USD), "SPOT", kline.FourHour, start, end)
+	if err != nil {
+		t.Errorf("Could not get historic candles: %s", err)
+	}
+	if err == nil && len(item.Candles) == 0 {
+		t.Error("Expected historic candles to be returned")
+	}
+}
+
+func TestWsProcessExecution(t *testing.T) {
+	fill := b.wsProcessExecution(&Execution{
+		Symbol:        "XBTUSD",
+		LastQty:       100,
+		ExecComm:      15000,
+		SettlCurrency: "XBt",
+	})
+	if fill.Fee != 0.00015 || fill.FeeCurrency != symbol.XBT {
+		t.Errorf("Test failed. Expected fee of 0.00015 XBT, received %v %s",
+			fill.Fee, fill.FeeCurrency)
+	}
+
+	if amount, currency := convertSatoshis(250000000, "XBt"); amount != 2.5 || currency != symbol.XBT {
+		t.Errorf("Test failed. Expected 2.5 XBT, received %v %s", amount, currency)
+	}
+	if amount, currency := convertSatoshis(5, "USDt"); amount != 5 || currency != "USDt" {
+		t.Errorf("Test failed. Expected other currencies unchanged, received %v %s", amount, currency)
+	}
+}
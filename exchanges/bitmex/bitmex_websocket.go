@@ -14,6 +14,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	"github.com/thrasher-/gocryptotrader/exchanges"
 )
 
@@ -57,6 +58,11 @@ const (
 	bitmexActionInsertData  = "insert"
 	bitmexActionDeleteData  = "delete"
 	bitmexActionUpdateData  = "update"
+
+	// bitmexSatoshiCurrency is the satoshi denomination BitMEX reports wallet
+	// balances and execution commissions in
+	bitmexSatoshiCurrency = "XBt"
+	bitmexSatoshisPerXBT  = 1e8
 )
 
 var (
@@ -122,6 +128,11 @@ func (b *Bitmex) WsConnector() error {
 		if err != nil {
 			return err
 		}
+
+		err = b.websocketSubscribeAuthenticated()
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -285,6 +296,57 @@ func (b *Bitmex) wsHandleIncomingData() {
 
 					b.Websocket.DataHandler <- announcement.Data
 
+				case bitmexWSOrder:
+					var orders OrderData
+					err = common.JSONDecode(resp.Raw, &orders)
+					if err != nil {
+						log.Fatal(err)
+					}
+
+					for i := range orders.Data {
+						b.Websocket.DataHandler <- b.wsProcessOrder(&orders.Data[i])
+					}
+
+				case bitmexWSExecution:
+					var executions ExecutionData
+					err = common.JSONDecode(resp.Raw, &executions)
+					if err != nil {
+						log.Fatal(err)
+					}
+
+					if executions.Action == bitmexActionInitialData {
+						continue
+					}
+
+					for i := range executions.Data {
+						if executions.Data[i].ExecType != "Trade" {
+							continue
+						}
+						b.Websocket.DataHandler <- b.wsProcessExecution(&executions.Data[i])
+					}
+
+				case bitmexWSWallet:
+					var wallets WalletData
+					err = common.JSONDecode(resp.Raw, &wallets)
+					if err != nil {
+						log.Fatal(err)
+					}
+
+					for _, wallet := range wallets.Data {
+						// Updates only contain the fields which have changed
+						if wallets.Action != bitmexActionInitialData && wallet.Amount == 0 {
+							continue
+						}
+
+						total, currency := convertSatoshis(float64(wallet.Amount), wallet.Currency)
+						b.Websocket.DataHandler <- exchange.BalanceUpdate{
+							Timestamp: parseWsTimestamp(wallet.Timestamp),
+							Exchange:  b.GetName(),
+							Currency:  currency,
+							Total:     total,
+						}
+					}
+
 				default:
 					log.Fatal("Bitmex websocket error: Table unknown -", decodedResp.Table)
 				}
@@ -293,6 +355,64 @@ func (b *Bitmex) wsHandleIncomingData() {
 	}
 }
 
+// wsProcessOrder converts an order table entry into an order update, updates
+// only contain the fields which have changed
+func (b *Bitmex) wsProcessOrder(o *Order) exchange.OrderUpdate {
+	return exchange.OrderUpdate{
+		Timestamp:       parseWsTimestamp(o.Timestamp),
+		Exchange:        b.GetName(),
+		AssetType:       "CONTRACT",
+		Pair:            pair.NewCurrencyPairFromString(o.Symbol),
+		OrderID:         o.OrderID,
+		ClientOrderID:   o.ClOrdID,
+		OrderSide:       exchange.FormatOrderSide(o.Side),
+		OrderType:       exchange.FormatOrderType(o.OrdType),
+		Status:          exchange.FormatOrderStatus(o.OrdStatus),
+		Price:           o.Price,
+		Amount:          float64(o.OrderQty),
+		ExecutedAmount:  float64(o.CumQty),
+		RemainingAmount: float64(o.LeavesQty),
+	}
+}
+
+// wsProcessExecution converts a trade execution into a fill event
+func (b *Bitmex) wsProcessExecution(e *Execution) exchange.FillEvent {
+	fee, feeCurrency := convertSatoshis(float64(e.ExecComm), e.SettlCurrency)
+	return exchange.FillEvent{
+		Timestamp:   parseWsTimestamp(e.Timestamp),
+		Exchange:    b.GetName(),
+		AssetType:   "CONTRACT",
+		Pair:        pair.NewCurrencyPairFromString(e.Symbol),
+		OrderID:     e.OrderID,
+		TradeID:     e.TrdMatchID,
+		OrderSide:   exchange.FormatOrderSide(e.Side),
+		Price:       e.LastPx,
+		Amount:      float64(e.LastQty),
+		Fee:         fee,
+		FeeCurrency: feeCurrency,
+		IsMaker:     e.LastLiquidityInd == "AddedLiquidity",
+	}
+}
+
+// convertSatoshis converts an amount in XBt into XBT, amounts in any other
+// currency are returned unchanged
+func convertSatoshis(amount float64, currency string) (float64, string) {
+	if currency != bitmexSatoshiCurrency {
+		return amount, currency
+	}
+	return amount / bitmexSatoshisPerXBT, symbol.XBT
+}
+
+// parseWsTimestamp parses an RFC3339 websocket timestamp, returning the
+// current time if the timestamp is missing from an update
+func parseWsTimestamp(timestamp string) time.Time {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Now()
+	}
+	return t
+}
+
 var snapshotloaded = make(map[pair.CurrencyPair]map[string]bool)
 
 // ProcessOrderbook processes orderbook updates
@@ -425,6 +545,19 @@ func (b *Bitmex) websocketSubscribe() error {
 	return nil
 }
 
+// websocketSubscribeAuthenticated subscribes to the order, execution and
+// wallet tables of the authenticated account
+func (b *Bitmex) websocketSubscribeAuthenticated() error {
+	var subscriber WebsocketRequest
+	subscriber.Command = "subscribe"
+	subscriber.Arguments = append(subscriber.Arguments,
+		bitmexWSOrder,
+		bitmexWSExecution,
+		bitmexWSWallet)
+
+	return b.WebsocketConn.WriteJSON(subscriber)
+}
+
 // WebsocketSendAuth sends an authenticated subscription
 func (b *Bitmex) websocketSendAuth() error {
 	timestamp := time.Now().Add(time.Hour * 1).Unix()
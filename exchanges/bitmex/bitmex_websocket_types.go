@@ -70,3 +70,22 @@ type AnnouncementData struct {
 	Data   []Announcement `json:"data"`
 	Action string         `json:"action"`
 }
+
+// OrderData contains authenticated order resp data with action to be taken
+type OrderData struct {
+	Data   []Order `json:"data"`
+	Action string  `json:"action"`
+}
+
+// ExecutionData contains authenticated execution resp data with action to be
+// taken
+type ExecutionData struct {
+	Data   []Execution `json:"data"`
+	Action string      `json:"action"`
+}
+
+// WalletData contains authenticated wallet resp data with action to be taken
+type WalletData struct {
+	Data   []WalletInfo `json:"data"`
+	Action string       `json:"action"`
+}
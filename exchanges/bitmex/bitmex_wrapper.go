@@ -4,12 +4,14 @@ import (
 	"errors"
 	"log"
 	"math"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -155,33 +157,127 @@ func (b *Bitmex) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrNotYetImplemented
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (b *Bitmex) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (b *Bitmex) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (b *Bitmex) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	symbol := exchange.FormatExchangeCurrency(b.Name, p).String()
+	fetcher := kline.Fetcher{
+		SupportedIntervals: bitmexBucketedIntervals,
+		Limit:              bitmexBucketedLimit,
+		Fetch: func(interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+			// Buckets are timestamped by their close time
+			resp, err := b.GetPreviousTrades(TradeGetBucketedParams{
+				Symbol:    symbol,
+				BinSize:   interval.String(),
+				Count:     bitmexBucketedLimit,
+				StartTime: start.Add(interval.Duration()).Format(time.RFC3339),
+				EndTime:   end.Format(time.RFC3339),
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			var candles []kline.Candle
+			for x := range resp {
+				closeTime, err := time.Parse(time.RFC3339, resp[x].Timestamp)
+				if err != nil {
+					return nil, err
+				}
+				candles = append(candles, kline.Candle{
+					Time:   closeTime.Add(-interval.Duration()),
+					Open:   resp[x].Open,
+					High:   resp[x].High,
+					Low:    resp[x].Low,
+					Close:  resp[x].Close,
+					Volume: resp[x].Volume,
+				})
+			}
+			return candles, nil
+		},
+	}
+
+	candles, err := fetcher.GetCandles(interval, start, end)
+	if err != nil {
+		return kline.Item{}, err
+	}
+
+	return kline.Item{
+		Exchange:  b.Name,
+		Pair:      p,
+		AssetType: assetType,
+		Interval:  interval,
+		Candles:   candles,
+	}, nil
+}
+
 // SubmitOrder submits a new order
-func (b *Bitmex) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (b *Bitmex) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(b.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
 
-	if math.Mod(amount, 1) != 0 {
+	if math.Mod(order.Amount, 1) != 0 {
 		return submitOrderResponse,
 			errors.New("contract amount can not have decimals")
 	}
 
 	var orderNewParams = OrderNewParams{
-		OrdType:  side.ToString(),
-		Symbol:   p.Pair().String(),
-		OrderQty: amount,
-		Side:     side.ToString(),
+		ClOrdID:  order.ClientID,
+		OrdType:  order.OrderType.ToString(),
+		Symbol:   order.CurrencyPair.Pair().String(),
+		OrderQty: order.Amount,
+		Side:     order.OrderSide.ToString(),
 	}
 
-	if orderType == exchange.Limit {
-		orderNewParams.Price = price
+	switch order.OrderType {
+	case exchange.Limit:
+		orderNewParams.Price = order.Price
+	case exchange.ImmediateOrCancel:
+		orderNewParams.OrdType = exchange.Limit.ToString()
+		orderNewParams.Price = order.Price
+		orderNewParams.TimeInForce = "ImmediateOrCancel"
+	case exchange.Stop:
+		orderNewParams.StopPx = order.TriggerPrice
+	case exchange.StopLimit:
+		orderNewParams.Price = order.Price
+		orderNewParams.StopPx = order.TriggerPrice
+	case exchange.TrailingStop:
+		// Trailing stops are pegged stop orders, sell stops trail below the
+		// current price so require a negative offset
+		orderNewParams.OrdType = exchange.Stop.ToString()
+		orderNewParams.PegPriceType = "TrailingStopPeg"
+		orderNewParams.PegOffsetValue = order.TrailingAmount
+		if order.OrderSide == exchange.Sell {
+			orderNewParams.PegOffsetValue = -order.TrailingAmount
+		}
 	}
 
+	switch order.TimeInForce {
+	case exchange.GoodTillCancel:
+		orderNewParams.TimeInForce = "GoodTillCancel"
+	case exchange.IOC:
+		orderNewParams.TimeInForce = "ImmediateOrCancel"
+	case exchange.FOK:
+		orderNewParams.TimeInForce = "FillOrKill"
+	}
+
+	var execInst []string
+	if order.PostOnly {
+		execInst = append(execInst, "ParticipateDoNotInitiate")
+	}
+	if order.ReduceOnly {
+		execInst = append(execInst, "ReduceOnly")
+	}
+	orderNewParams.ExecInst = common.JoinStrings(execInst, ",")
+
 	response, err := b.CreateOrder(orderNewParams)
 	if response.OrderID != "" {
 		submitOrderResponse.OrderID = response.OrderID
@@ -249,6 +345,78 @@ func (b *Bitmex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (b *Bitmex) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	params := GenericRequestParams{
+		Filter:  "{\"open\": true}",
+		Count:   500,
+		Reverse: true,
+	}
+
+	resp, err := b.GetOrders(params)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := b.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (b *Bitmex) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	params := GenericRequestParams{
+		Count:   500,
+		Reverse: true,
+	}
+
+	if !getOrdersRequest.StartTicks.IsZero() {
+		params.StartTime = getOrdersRequest.StartTicks.UTC().Format(time.RFC3339)
+	}
+
+	if !getOrdersRequest.EndTicks.IsZero() {
+		params.EndTime = getOrdersRequest.EndTicks.UTC().Format(time.RFC3339)
+	}
+
+	resp, err := b.GetOrders(params)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := b.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrders converts Bitmex orders to the exchange.OrderDetail type
+func (b *Bitmex) formatOrders(resp []Order) []exchange.OrderDetail {
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		var p pair.CurrencyPair
+		if len(resp[i].Symbol) > 3 {
+			p = pair.NewCurrencyPairFromString(resp[i].Symbol)
+		}
+
+		orderDate, _ := time.Parse(time.RFC3339, resp[i].Timestamp)
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        b.Name,
+			AccountID:       strconv.FormatInt(resp[i].Account, 10),
+			ID:              resp[i].OrderID,
+			ClientOrderID:   resp[i].ClOrdID,
+			CurrencyPair:    p,
+			OrderSide:       exchange.FormatOrderSide(resp[i].Side),
+			OrderType:       exchange.FormatOrderType(resp[i].OrdType),
+			OrderDate:       orderDate,
+			Status:          resp[i].OrdStatus,
+			Price:           resp[i].Price,
+			Amount:          float64(resp[i].OrderQty),
+			ExecutedAmount:  float64(resp[i].CumQty),
+			RemainingAmount: float64(resp[i].LeavesQty),
+		})
+	}
+	return orders
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (b *Bitmex) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -68,6 +68,17 @@ func (b *Bitstamp) SetDefaults() {
 	b.Verbose = false
 	b.RESTPollingDelay = 10
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCrypto | exchange.AutoWithdrawFiat
+	b.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+	b.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		TradeFetching:     exchange.FeatureSupport{REST: true, Websocket: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.OrderbookChannel, exchange.TradeChannel},
+	}
 	b.RequestCurrencyPairFormat.Delimiter = ""
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = ""
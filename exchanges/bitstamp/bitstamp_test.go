@@ -9,6 +9,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	"github.com/thrasher-/gocryptotrader/exchanges"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 )
 
@@ -392,7 +393,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.BTC,
 		SecondCurrency: symbol.USD,
 	}
-	response, err := b.SubmitOrder(p, exchange.Buy, exchange.Market, 1, 1, "clientId")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       1,
+		Price:        1,
+		ClientID:     "clientId",
+	}
+	response, err := b.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -462,3 +471,73 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := b.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := b.GetOrderHistory(getOrdersRequest)
+	if err != common.ErrNotYetImplemented {
+		t.Errorf("Expected '%v', received: '%v'", common.ErrNotYetImplemented, err)
+	}
+}
+
+func TestGetExchangeHistory(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	end := time.Now()
+	start := end.Add(-time.Hour)
+	trades, err := b.GetExchangeHistory(exchange.TradeHistoryRequest{
+		CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.USD),
+		AssetType:    "SPOT",
+		StartTime:    start,
+		EndTime:      end,
+	})
+	if err != nil {
+		t.Errorf("Could not get exchange history: %s", err)
+	}
+	for x := range trades {
+		if trades[x].Timestamp.Before(start) || !trades[x].Timestamp.Before(end) {
+			t.Errorf("Trade %+v outside of requested time range", trades[x])
+		}
+	}
+
+	_, err = b.GetExchangeHistory(exchange.TradeHistoryRequest{
+		CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.USD),
+		StartTime:    end.Add(-time.Hour * 48),
+	})
+	if err == nil {
+		t.Error("Expected error when fetching trades older than 24 hours")
+	}
+
+	_, err = b.GetExchangeHistory(exchange.TradeHistoryRequest{
+		CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.USD),
+		FromTradeID:  "wigwham",
+	})
+	if err == nil {
+		t.Error("Expected error when fetching from an invalid trade ID")
+	}
+}
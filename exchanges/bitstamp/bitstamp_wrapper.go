@@ -4,13 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -159,19 +162,87 @@ func (b *Bitstamp) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (b *Bitstamp) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
-	var resp []exchange.TradeHistory
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (b *Bitstamp) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
+	if err := tradeHistoryRequest.Validate(); err != nil {
+		return nil, err
+	}
+
+	var fromTradeID int64
+	if tradeHistoryRequest.FromTradeID != "" {
+		var err error
+		fromTradeID, err = strconv.ParseInt(tradeHistoryRequest.FromTradeID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trade ID %s: %s", tradeHistoryRequest.FromTradeID, err)
+		}
+	}
+
+	// Bitstamp only serves the transactions of the last minute, hour or day in
+	// a single page and can not be paged further back
+	period := "day"
+	if start := tradeHistoryRequest.StartTime; !start.IsZero() {
+		since := time.Since(start)
+		switch {
+		case since > time.Hour*24:
+			return nil, fmt.Errorf("%s: %s, transactions are only available for the last 24 hours",
+				b.GetName(), exchange.ErrTradeHistoryStartOutOfRange)
+		case since <= time.Minute:
+			period = "minute"
+		case since <= time.Hour:
+			period = "hour"
+		}
+	}
+	values := url.Values{}
+	values.Set("time", period)
+
+	return exchange.PaginateTradeHistory(tradeHistoryRequest, "", func(cursor string) ([]exchange.TradeHistory, string, error) {
+		resp, err := b.GetTransactions(tradeHistoryRequest.CurrencyPair.Pair().String(), values)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var trades []exchange.TradeHistory
+		for x := range resp {
+			if resp[x].TradeID <= fromTradeID {
+				continue
+			}
+
+			side := exchange.Buy
+			if resp[x].Type == 1 {
+				side = exchange.Sell
+			}
+
+			trades = append(trades, exchange.TradeHistory{
+				Exchange:     b.GetName(),
+				TID:          strconv.FormatInt(resp[x].TradeID, 10),
+				CurrencyPair: tradeHistoryRequest.CurrencyPair,
+				AssetType:    tradeHistoryRequest.AssetType,
+				Side:         side,
+				Price:        resp[x].Price,
+				Amount:       resp[x].Amount,
+				Timestamp:    time.Unix(resp[x].Date, 0),
+			})
+		}
+		return trades, "", nil
+	})
+}
 
-	return resp, common.ErrNotYetImplemented
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (b *Bitstamp) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
 }
 
 // SubmitOrder submits a new order
-func (b *Bitstamp) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (b *Bitstamp) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	buy := side == exchange.Buy
-	market := orderType == exchange.Market
-	response, err := b.PlaceOrder(p.Pair().String(), price, amount, buy, market)
+	if err := order.Validate(b.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	buy := order.OrderSide == exchange.Buy
+	market := order.OrderType == exchange.Market
+	response, err := b.PlaceOrder(order.CurrencyPair.Pair().String(), order.Price, order.Amount, buy, market)
 
 	if response.ID > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response.ID)
@@ -218,6 +289,51 @@ func (b *Bitstamp) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (b *Bitstamp) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = b.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := b.GetOpenOrders(exchange.FormatExchangeCurrency(b.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp {
+			side := exchange.Buy
+			if resp[i].Type == 1 {
+				side = exchange.Sell
+			}
+
+			orderDate, _ := time.Parse("2006-01-02 15:04:05", resp[i].Date)
+
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:        b.Name,
+				ID:              strconv.FormatInt(resp[i].ID, 10),
+				CurrencyPair:    currencies[x],
+				OrderSide:       side,
+				OrderType:       exchange.Limit,
+				OrderDate:       orderDate,
+				Price:           resp[i].Price,
+				Amount:          resp[i].Amount,
+				RemainingAmount: resp[i].Amount,
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (b *Bitstamp) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return nil, common.ErrNotYetImplemented
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (b *Bitstamp) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -20,6 +20,7 @@ const (
 	bittrexAPIVersion          = "v1.1"
 	bittrexMaxOpenOrders       = 500
 	bittrexMaxOrderCountPerDay = 200000
+	bittrexTimeLayout          = "2006-01-02T15:04:05.999999999"
 
 	// Returned messages from Bittrex API
 	bittrexAddressGenerating      = "ADDRESS_GENERATING"
@@ -69,6 +70,15 @@ func (b *Bittrex) SetDefaults() {
 	b.Verbose = false
 	b.RESTPollingDelay = 10
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission
+	b.OrderSubmissionFeatures = exchange.LimitOrderSupport
+	b.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	b.RequestCurrencyPairFormat.Delimiter = "-"
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = "-"
@@ -421,9 +431,9 @@ func (b *Bittrex) GetOrder(uuid string) (Order, error) {
 	return order, nil
 }
 
-// GetOrderHistory is used to retrieve your order history. If currencyPair
+// GetOrderHistoryForCurrency is used to retrieve your order history. If currencyPair
 // omitted it will return the entire order History.
-func (b *Bittrex) GetOrderHistory(currencyPair string) (Order, error) {
+func (b *Bittrex) GetOrderHistoryForCurrency(currencyPair string) (Order, error) {
 	var orders Order
 	values := url.Values{}
 
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
@@ -198,16 +199,16 @@ func TestGetOrder(t *testing.T) {
 	}
 }
 
-func TestGetOrderHistory(t *testing.T) {
+func TestGetOrderHistoryForCurrency(t *testing.T) {
 	t.Parallel()
 
-	_, err := b.GetOrderHistory("")
+	_, err := b.GetOrderHistoryForCurrency("")
 	if err == nil {
-		t.Error("Test Failed - Bittrex - GetOrderHistory() error")
+		t.Error("Test Failed - Bittrex - GetOrderHistoryForCurrency() error")
 	}
-	_, err = b.GetOrderHistory("btc-ltc")
+	_, err = b.GetOrderHistoryForCurrency("btc-ltc")
 	if err == nil {
-		t.Error("Test Failed - Bittrex - GetOrderHistory() error")
+		t.Error("Test Failed - Bittrex - GetOrderHistoryForCurrency() error")
 	}
 }
 
@@ -357,7 +358,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.BTC,
 		SecondCurrency: symbol.LTC,
 	}
-	response, err := b.SubmitOrder(p, exchange.Buy, exchange.Limit, 1, 1, "clientId")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Amount:       1,
+		Price:        1,
+		ClientID:     "clientId",
+	}
+	response, err := b.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -427,3 +436,52 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := b.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := b.GetOrderHistory(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get order history: %s", err)
+	}
+}
+
+func TestAccountCurrencies(t *testing.T) {
+	t.Parallel()
+	var bal Balances
+	err := common.JSONDecode([]byte(`{"result":[{"Currency":"BTC","Balance":2,"Available":1.5}]}`), &bal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	currencies := accountCurrencies(bal)
+	if len(currencies) != 1 || currencies[0].TotalValue != 1.5 || currencies[0].Hold != 0.5 {
+		t.Errorf("Test failed - Bittrex accountCurrencies() expected available balance 1.5 and hold 0.5 got %+v", currencies)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -70,14 +72,22 @@ func (b *Bittrex) GetAccountInfo() (exchange.AccountInfo, error) {
 		return response, err
 	}
 
+	response.Currencies = accountCurrencies(accountBalance)
+	return response, nil
+}
+
+// accountCurrencies converts the account balances into available and held
+// balances
+func accountCurrencies(accountBalance Balances) []exchange.AccountCurrencyInfo {
+	var currencies []exchange.AccountCurrencyInfo
 	for i := 0; i < len(accountBalance.Result); i++ {
 		var exchangeCurrency exchange.AccountCurrencyInfo
 		exchangeCurrency.CurrencyName = accountBalance.Result[i].Currency
-		exchangeCurrency.TotalValue = accountBalance.Result[i].Balance
+		exchangeCurrency.TotalValue = accountBalance.Result[i].Available
 		exchangeCurrency.Hold = accountBalance.Result[i].Balance - accountBalance.Result[i].Available
-		response.Currencies = append(response.Currencies, exchangeCurrency)
+		currencies = append(currencies, exchangeCurrency)
 	}
-	return response, nil
+	return currencies
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -161,28 +171,38 @@ func (b *Bittrex) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (b *Bittrex) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (b *Bittrex) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (b *Bittrex) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (b *Bittrex) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (b *Bittrex) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	buy := side == exchange.Buy
+	if err := order.Validate(b.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	buy := order.OrderSide == exchange.Buy
 	var response UUID
 	var err error
 
-	if orderType != exchange.Limit {
+	if order.OrderType != exchange.Limit {
 		return submitOrderResponse, errors.New("not supported on exchange")
 	}
 
 	if buy {
-		response, err = b.PlaceBuyLimit(p.Pair().String(), amount, price)
+		response, err = b.PlaceBuyLimit(order.CurrencyPair.Pair().String(), order.Amount, order.Price)
 	} else {
-		response, err = b.PlaceSellLimit(p.Pair().String(), amount, price)
+		response, err = b.PlaceSellLimit(order.CurrencyPair.Pair().String(), order.Amount, order.Price)
 	}
 
 	if response.Result.ID != "" {
@@ -235,6 +255,76 @@ func (b *Bittrex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (b *Bittrex) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := b.GetOpenOrders("")
+	if err != nil {
+		return nil, err
+	}
+
+	orders := b.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (b *Bittrex) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := b.GetOrderHistoryForCurrency("")
+	if err != nil {
+		return nil, err
+	}
+
+	orders := b.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrders converts Bittrex orders to the exchange.OrderDetail type
+func (b *Bittrex) formatOrders(resp Order) []exchange.OrderDetail {
+	var orders []exchange.OrderDetail
+	for i := range resp.Result {
+		var side exchange.OrderSide
+		if common.StringContains(resp.Result[i].Type, "BUY") {
+			side = exchange.Buy
+		} else if common.StringContains(resp.Result[i].Type, "SELL") {
+			side = exchange.Sell
+		}
+
+		orderType := exchange.Limit
+		if resp.Result[i].ImmediateOrCancel {
+			orderType = exchange.ImmediateOrCancel
+		}
+
+		status := "Open"
+		if !resp.Result[i].IsOpen {
+			status = "Closed"
+		}
+
+		var p pair.CurrencyPair
+		if common.StringContains(resp.Result[i].Exchange, "-") {
+			p = pair.NewCurrencyPairDelimiter(resp.Result[i].Exchange, "-")
+		}
+
+		orderDate, _ := time.Parse(bittrexTimeLayout, resp.Result[i].Opened)
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        b.Name,
+			AccountID:       resp.Result[i].AccountID,
+			ID:              resp.Result[i].OrderUUID,
+			CurrencyPair:    p,
+			OrderSide:       side,
+			OrderType:       orderType,
+			OrderDate:       orderDate,
+			Status:          status,
+			Price:           resp.Result[i].Limit,
+			Amount:          resp.Result[i].Quantity,
+			ExecutedAmount:  resp.Result[i].Quantity - resp.Result[i].QuantityRemaining,
+			RemainingAmount: resp.Result[i].QuantityRemaining,
+			Fee:             resp.Result[i].CommissionPaid,
+		})
+	}
+	return orders
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (b *Bittrex) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -33,6 +33,13 @@ func (b *BTCC) SetDefaults() {
 	b.Verbose = false
 	b.RESTPollingDelay = 10
 	b.APIWithdrawPermissions = exchange.NoAPIWithdrawalMethods
+	b.OrderSubmissionFeatures = exchange.NoOrderSubmissionFeatures
+	b.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{Websocket: true},
+		OrderbookFetching: exchange.FeatureSupport{Websocket: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.TickerChannel, exchange.OrderbookChannel},
+	}
 	b.RequestCurrencyPairFormat.Delimiter = ""
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = ""
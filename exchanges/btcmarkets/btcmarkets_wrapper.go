@@ -6,11 +6,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
-
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -129,15 +130,23 @@ func (b *BTCMarkets) GetAccountInfo() (exchange.AccountInfo, error) {
 		return response, err
 	}
 
+	response.Currencies = accountCurrencies(accountBalance)
+	return response, nil
+}
+
+// accountCurrencies converts the account balances into available and pending
+// balances
+func accountCurrencies(accountBalance []AccountBalance) []exchange.AccountCurrencyInfo {
+	var currencies []exchange.AccountCurrencyInfo
 	for i := 0; i < len(accountBalance); i++ {
 		var exchangeCurrency exchange.AccountCurrencyInfo
 		exchangeCurrency.CurrencyName = accountBalance[i].Currency
-		exchangeCurrency.TotalValue = accountBalance[i].Balance
+		exchangeCurrency.TotalValue = accountBalance[i].Balance - accountBalance[i].PendingFunds
 		exchangeCurrency.Hold = accountBalance[i].PendingFunds
 
-		response.Currencies = append(response.Currencies, exchangeCurrency)
+		currencies = append(currencies, exchangeCurrency)
 	}
-	return response, nil
+	return currencies
 }
 
 // GetFundingHistory returns funding history, deposits and
@@ -147,17 +156,27 @@ func (b *BTCMarkets) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (b *BTCMarkets) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (b *BTCMarkets) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (b *BTCMarkets) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (b *BTCMarkets) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (b *BTCMarkets) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	response, err := b.NewOrder(p.FirstCurrency.Upper().String(), p.SecondCurrency.Upper().String(), price, amount, side.ToString(), orderType.ToString(), clientID)
+	if err := order.Validate(b.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	response, err := b.NewOrder(order.CurrencyPair.FirstCurrency.Upper().String(), order.CurrencyPair.SecondCurrency.Upper().String(), order.Price, order.Amount, order.OrderSide.ToString(), order.OrderType.ToString(), order.ClientID)
 
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
@@ -238,21 +257,86 @@ func (b *BTCMarkets) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 		return OrderDetail, errors.New("no orders found")
 	}
 
-	for _, order := range orders {
-		OrderDetail.Amount = order.Volume
-		OrderDetail.BaseCurrency = order.Currency
-		OrderDetail.CreationTime = int64(order.CreationTime)
-		OrderDetail.Exchange = b.GetName()
-		OrderDetail.ID = order.ID
-		OrderDetail.OpenVolume = order.OpenVolume
-		OrderDetail.OrderSide = order.OrderSide
-		OrderDetail.OrderType = order.OrderType
-		OrderDetail.Price = order.Price
-		OrderDetail.QuoteCurrency = order.Instrument
-		OrderDetail.Status = order.Status
+	OrderDetail = b.formatOrders(orders)[0]
+	return OrderDetail, nil
+}
+
+// GetActiveOrders retrieves any orders that are active/open
+func (b *BTCMarkets) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := b.GetOpenOrders()
+	if err != nil {
+		return nil, err
 	}
 
-	return OrderDetail, nil
+	orders := b.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (b *BTCMarkets) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = b.GetEnabledCurrencies()
+	}
+
+	var since int64
+	if !getOrdersRequest.StartTicks.IsZero() {
+		since = getOrdersRequest.StartTicks.UnixNano() / int64(time.Millisecond)
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := b.GetOrders(currencies[x].SecondCurrency.String(),
+			currencies[x].FirstCurrency.String(),
+			200,
+			since,
+			true)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, b.formatOrders(resp)...)
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrders converts BTC Markets orders to the exchange.OrderDetail type
+func (b *BTCMarkets) formatOrders(resp []Order) []exchange.OrderDetail {
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		var fills []exchange.OrderFill
+		var fee float64
+		for j := range resp[i].Trades {
+			fee += resp[i].Trades[j].Fee
+			fills = append(fills, exchange.OrderFill{
+				ID:        strconv.FormatInt(resp[i].Trades[j].ID, 10),
+				Timestamp: time.Unix(0, int64(resp[i].Trades[j].CreationTime)*int64(time.Millisecond)),
+				Price:     resp[i].Trades[j].Price,
+				Amount:    resp[i].Trades[j].Volume,
+				Fee:       resp[i].Trades[j].Fee,
+			})
+		}
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        b.Name,
+			ID:              resp[i].ID,
+			ClientOrderID:   resp[i].ClientRequestID,
+			CurrencyPair:    pair.NewCurrencyPair(resp[i].Instrument, resp[i].Currency),
+			OrderSide:       exchange.FormatOrderSide(resp[i].OrderSide),
+			OrderType:       exchange.FormatOrderType(resp[i].OrderType),
+			OrderDate:       time.Unix(0, int64(resp[i].CreationTime)*int64(time.Millisecond)),
+			Status:          resp[i].Status,
+			Price:           resp[i].Price,
+			Amount:          resp[i].Volume,
+			ExecutedAmount:  resp[i].Volume - resp[i].OpenVolume,
+			RemainingAmount: resp[i].OpenVolume,
+			Fee:             fee,
+			FeeCurrency:     resp[i].Currency,
+			Fills:           fills,
+		})
+	}
+	return orders
 }
 
 // GetDepositAddress returns a deposit address for a specified currency
@@ -15,6 +15,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -54,12 +55,28 @@ const (
 
 	coinbaseproAuthRate   = 5
 	coinbaseproUnauthRate = 3
+
+	// coinbaseproHistoryLimit is the maximum number of candles returned per
+	// historic rates request
+	coinbaseproHistoryLimit = 300
 )
 
+// coinbaseproHistoryIntervals are the candle granularities supported by the
+// historic rates endpoint
+var coinbaseproHistoryIntervals = []kline.Interval{
+	kline.OneMin,
+	kline.FiveMin,
+	kline.FifteenMin,
+	kline.OneHour,
+	kline.SixHour,
+	kline.OneDay,
+}
+
 // CoinbasePro is the overarching type across the coinbasepro package
 type CoinbasePro struct {
 	exchange.Base
 	WebsocketConn *websocket.Conn
+	wsOrderFills  map[string]float64
 }
 
 // SetDefaults sets default values for the exchange
@@ -71,6 +88,18 @@ func (c *CoinbasePro) SetDefaults() {
 	c.MakerFee = 0
 	c.RESTPollingDelay = 10
 	c.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission | exchange.AutoWithdrawFiatWithAPIPermission
+	c.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport |
+		exchange.PostOnlyOrderSupport | exchange.ImmediateOrCancelOrderSupport | exchange.FillOrKillOrderSupport
+	c.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true, Websocket: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		KlineFetching:     exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.TickerChannel, exchange.OrderbookChannel},
+	}
 	c.RequestCurrencyPairFormat.Delimiter = "-"
 	c.RequestCurrencyPairFormat.Uppercase = true
 	c.ConfigCurrencyPairFormat.Delimiter = ""
@@ -134,6 +163,12 @@ func (c *CoinbasePro) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		c.Websocket.SetChannelSubscriber(c.Subscribe)
+		c.Websocket.SetChannelUnsubscriber(c.Unsubscribe)
+		err = c.Websocket.SubscribeToChannels(c.GenerateSubscriptions(c.GetEnabledCurrencies()))
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 }
 
@@ -239,17 +274,17 @@ func (c *CoinbasePro) GetTrades(currencyPair string) ([]Trade, error) {
 
 // GetHistoricRates returns historic rates for a product. Rates are returned in
 // grouped buckets based on requested granularity.
-func (c *CoinbasePro) GetHistoricRates(currencyPair string, start, end, granularity int64) ([]History, error) {
+func (c *CoinbasePro) GetHistoricRates(currencyPair string, start, end time.Time, granularity int64) ([]History, error) {
 	var resp [][]interface{}
 	history := []History{}
 	values := url.Values{}
 
-	if start > 0 {
-		values.Set("start", strconv.FormatInt(start, 10))
+	if !start.IsZero() {
+		values.Set("start", start.UTC().Format(time.RFC3339))
 	}
 
-	if end > 0 {
-		values.Set("end", strconv.FormatInt(end, 10))
+	if !end.IsZero() {
+		values.Set("end", end.UTC().Format(time.RFC3339))
 	}
 
 	if granularity > 0 {
@@ -377,7 +412,7 @@ func (c *CoinbasePro) PlaceLimitOrder(clientRef string, price, amount float64, s
 		request["cancel_after"] = cancelAfter
 	}
 	if timeInforce != "" {
-		request["time_in_foce"] = timeInforce
+		request["time_in_force"] = timeInforce
 	}
 	if clientRef != "" {
 		request["client_oid"] = clientRef
@@ -8,6 +8,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 )
 
 var c CoinbasePro
@@ -60,7 +61,7 @@ func TestGetTrades(t *testing.T) {
 }
 
 func TestGetHistoricRates(t *testing.T) {
-	_, err := c.GetHistoricRates("BTC-USD", 0, 0, 0)
+	_, err := c.GetHistoricRates("BTC-USD", time.Time{}, time.Time{}, 0)
 	if err != nil {
 		t.Error("Test failed - GetHistoricRates() error", err)
 	}
@@ -431,7 +432,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.BTC,
 		SecondCurrency: symbol.LTC,
 	}
-	response, err := c.SubmitOrder(p, exchange.Buy, exchange.Limit, 1, 1, "clientId")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Amount:       1,
+		Price:        1,
+		ClientID:     "clientId",
+	}
+	response, err := c.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -501,3 +510,88 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	c.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := c.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	c.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := c.GetOrderHistory(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get order history: %s", err)
+	}
+}
+
+func TestGetHistoricCandles(t *testing.T) {
+	c.SetDefaults()
+	TestSetup(t)
+
+	end := time.Now()
+	start := end.Add(-7 * 24 * time.Hour)
+	item, err := c.GetHistoricCandles(pair.NewCurrencyPair(symbol.BTC, symbol.USD), "SPOT", kline.FourHour, start, end)
+	if err != nil {
+		t.Errorf("Could not get historic candles: %s", err)
+	}
+	if err == nil && len(item.Candles) == 0 {
+		t.Error("Expected historic candles to be returned")
+	}
+}
+
+func TestWsProcessMatch(t *testing.T) {
+	var cb CoinbasePro
+	cb.Name = "CoinbasePro"
+
+	match := WebsocketMatch{
+		TradeID:      10,
+		MakerOrderID: "maker",
+		TakerOrderID: "taker",
+		Side:         "sell",
+		Size:         0.5,
+		Price:        400,
+		ProductID:    "BTC-USD",
+		TakerUserID:  "user",
+	}
+	fill, update := cb.wsProcessMatch(&match)
+	if fill.OrderID != "taker" || fill.OrderSide != exchange.Buy || fill.IsMaker ||
+		fill.TradeID != "10" || fill.Amount != 0.5 {
+		t.Errorf("Unexpected fill %+v", fill)
+	}
+
+	cb.wsProcessMatch(&match)
+	if update.ExecutedAmount != 0.5 || cb.wsOrderFills["taker"] != 1 {
+		t.Errorf("Unexpected executed amount %f", cb.wsOrderFills["taker"])
+	}
+
+	done := cb.wsProcessDone(&WebsocketDone{OrderID: "taker", ProductID: "BTC-USD", Reason: "filled"})
+	if done.Status != exchange.FilledOrderStatus || done.ExecutedAmount != 1 {
+		t.Errorf("Unexpected order update %+v", done)
+	}
+	if _, ok := cb.wsOrderFills["taker"]; ok {
+		t.Error("Expected fills to be cleared once the order is done")
+	}
+}
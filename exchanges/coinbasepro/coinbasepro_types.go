@@ -341,9 +341,13 @@ type FillResponse struct {
 
 // WebsocketSubscribe takes in subscription information
 type WebsocketSubscribe struct {
-	Type      string       `json:"type"`
-	ProductID string       `json:"product_id,omitempty"`
-	Channels  []WsChannels `json:"channels,omitempty"`
+	Type       string       `json:"type"`
+	ProductID  string       `json:"product_id,omitempty"`
+	Channels   []WsChannels `json:"channels,omitempty"`
+	Signature  string       `json:"signature,omitempty"`
+	Key        string       `json:"key,omitempty"`
+	Passphrase string       `json:"passphrase,omitempty"`
+	Timestamp  string       `json:"timestamp,omitempty"`
 }
 
 // WsChannels defines outgoing channels for subscription purposes
@@ -403,6 +407,7 @@ type WebsocketMatch struct {
 	ProductID    string  `json:"product_id"`
 	Sequence     int64   `json:"sequence"`
 	Time         string  `json:"time"`
+	TakerUserID  string  `json:"taker_user_id"`
 }
 
 // WebsocketChange holds change information
@@ -18,36 +18,66 @@ import (
 
 const (
 	coinbaseproWebsocketURL = "wss://ws-feed.pro.coinbase.com"
+
+	// coinbaseproWebsocketUserChannel receives the order activity of the
+	// authenticated account
+	coinbaseproWebsocketUserChannel = "user"
 )
 
-// WebsocketSubscriber subscribes to websocket channels with respect to enabled
-// currencies
-func (c *CoinbasePro) WebsocketSubscriber() error {
-	currencies := []string{}
-	for _, x := range c.EnabledPairs {
-		currency := x[0:3] + "-" + x[3:]
-		currencies = append(currencies, currency)
+// wsChannelNames maps the generic websocket channels to Coinbase Pro channel
+// names
+var wsChannelNames = map[string]string{
+	exchange.TickerChannel:    "ticker",
+	exchange.OrderbookChannel: "level2",
+}
+
+// Subscribe sends a websocket message to receive data from the channel, a
+// heartbeat is subscribed alongside each product to keep the feed alive and
+// the user channel when authenticated to receive order updates
+func (c *CoinbasePro) Subscribe(channelToSubscribe exchange.WebsocketChannelSubscription) error {
+	additionalChannels := []string{"heartbeat"}
+	if c.AuthenticatedAPISupport {
+		additionalChannels = append(additionalChannels, coinbaseproWebsocketUserChannel)
 	}
+	return c.wsSendChannelRequest("subscribe", channelToSubscribe, additionalChannels...)
+}
 
-	var channels []WsChannels
-	channels = append(channels, WsChannels{
-		Name:       "heartbeat",
-		ProductIDs: currencies,
-	})
+// Unsubscribe sends a websocket message to stop receiving data from the
+// channel
+func (c *CoinbasePro) Unsubscribe(channelToUnsubscribe exchange.WebsocketChannelSubscription) error {
+	return c.wsSendChannelRequest("unsubscribe", channelToUnsubscribe)
+}
+
+// wsSendChannelRequest sends a subscribe or unsubscribe message for a channel
+// subscription along with any additional channel names for the same product
+func (c *CoinbasePro) wsSendChannelRequest(action string, channel exchange.WebsocketChannelSubscription, additionalChannels ...string) error {
+	name, ok := wsChannelNames[channel.Channel]
+	if !ok {
+		return fmt.Errorf("coinbasepro_websocket.go error - unsupported channel %s",
+			channel.Channel)
+	}
 
-	channels = append(channels, WsChannels{
-		Name:       "ticker",
-		ProductIDs: currencies,
-	})
+	productIDs := []string{
+		exchange.FormatExchangeCurrency(c.GetName(), channel.Currency).String(),
+	}
 
-	channels = append(channels, WsChannels{
-		Name:       "level2",
-		ProductIDs: currencies,
-	})
+	channels := []WsChannels{{Name: name, ProductIDs: productIDs}}
+	for _, additional := range additionalChannels {
+		channels = append(channels, WsChannels{Name: additional, ProductIDs: productIDs})
+	}
 
-	subscribe := WebsocketSubscribe{Type: "subscribe", Channels: channels}
+	request := WebsocketSubscribe{Type: action, Channels: channels}
+	if c.AuthenticatedAPISupport {
+		request.Timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+		hmac := common.GetHMAC(common.HashSHA256,
+			[]byte(request.Timestamp+"GET/users/self/verify"),
+			[]byte(c.APISecret))
+		request.Signature = common.Base64Encode(hmac)
+		request.Key = c.APIKey
+		request.Passphrase = c.ClientID
+	}
 
-	json, err := common.JSONEncode(subscribe)
+	json, err := common.JSONEncode(request)
 	if err != nil {
 		return err
 	}
@@ -81,11 +111,6 @@ func (c *CoinbasePro) WsConnect() error {
 			err)
 	}
 
-	err = c.WebsocketSubscriber()
-	if err != nil {
-		return err
-	}
-
 	go c.WsReadData()
 	go c.WsHandleData()
 
@@ -196,6 +221,88 @@ func (c *CoinbasePro) WsHandleData() {
 					log.Fatal(err)
 				}
 
+			case "received":
+				received := WebsocketReceived{}
+				err := common.JSONDecode(resp.Raw, &received)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				c.Websocket.DataHandler <- exchange.OrderUpdate{
+					Timestamp:     parseWsTime(received.Time),
+					Exchange:      c.GetName(),
+					AssetType:     "SPOT",
+					Pair:          pair.NewCurrencyPairFromString(received.ProductID),
+					OrderID:       received.OrderID,
+					ClientOrderID: received.ClientOID,
+					OrderSide:     exchange.FormatOrderSide(received.Side),
+					OrderType:     exchange.FormatOrderType(received.OrderType),
+					Status:        exchange.NewOrderStatus,
+					Price:         received.Price,
+					Amount:        received.Size,
+				}
+
+			case "open":
+				open := WebsocketOpen{}
+				err := common.JSONDecode(resp.Raw, &open)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				c.Websocket.DataHandler <- exchange.OrderUpdate{
+					Timestamp:       parseWsTime(open.Time),
+					Exchange:        c.GetName(),
+					AssetType:       "SPOT",
+					Pair:            pair.NewCurrencyPairFromString(open.ProductID),
+					OrderID:         open.OrderID,
+					OrderSide:       exchange.FormatOrderSide(open.Side),
+					Status:          exchange.NewOrderStatus,
+					Price:           open.Price,
+					RemainingAmount: open.RemainingSize,
+				}
+
+			case "done":
+				done := WebsocketDone{}
+				err := common.JSONDecode(resp.Raw, &done)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				c.Websocket.DataHandler <- c.wsProcessDone(&done)
+
+			case "change":
+				change := WebsocketChange{}
+				err := common.JSONDecode(resp.Raw, &change)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				c.Websocket.DataHandler <- exchange.OrderUpdate{
+					Timestamp: parseWsTime(change.Time),
+					Exchange:  c.GetName(),
+					AssetType: "SPOT",
+					OrderID:   change.OrderID,
+					OrderSide: exchange.FormatOrderSide(change.Side),
+					Status:    exchange.UnknownOrderStatus,
+					Price:     change.Price,
+					Amount:    change.NewSize,
+				}
+
+			case "match":
+				match := WebsocketMatch{}
+				err := common.JSONDecode(resp.Raw, &match)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				fill, update := c.wsProcessMatch(&match)
+				c.Websocket.DataHandler <- fill
+				c.Websocket.DataHandler <- update
+
+			case "activate":
+				// Stop orders are reported as received once triggered
+				continue
+
 			default:
 				log.Fatal("Edge test", string(resp.Raw))
 			}
@@ -203,6 +310,82 @@ func (c *CoinbasePro) WsHandleData() {
 	}
 }
 
+// wsProcessDone converts an order which is no longer on the book into an
+// order update and clears its tracked fills
+func (c *CoinbasePro) wsProcessDone(done *WebsocketDone) exchange.OrderUpdate {
+	status := exchange.FormatOrderStatus(done.Reason)
+	update := exchange.OrderUpdate{
+		Timestamp:       parseWsTime(done.Time),
+		Exchange:        c.GetName(),
+		AssetType:       "SPOT",
+		Pair:            pair.NewCurrencyPairFromString(done.ProductID),
+		OrderID:         done.OrderID,
+		OrderSide:       exchange.FormatOrderSide(done.Side),
+		Status:          status,
+		Price:           done.Price,
+		ExecutedAmount:  c.wsOrderFills[done.OrderID],
+		RemainingAmount: done.RemainingSize,
+	}
+	delete(c.wsOrderFills, done.OrderID)
+	return update
+}
+
+// wsProcessMatch converts a match of an authenticated account order into a
+// fill event and an order update holding the amount executed so far. The side
+// of a match is the side of the maker order
+func (c *CoinbasePro) wsProcessMatch(match *WebsocketMatch) (exchange.FillEvent, exchange.OrderUpdate) {
+	orderID := match.MakerOrderID
+	side := exchange.FormatOrderSide(match.Side)
+	isMaker := match.TakerUserID == ""
+	if !isMaker {
+		orderID = match.TakerOrderID
+		if side == exchange.Buy {
+			side = exchange.Sell
+		} else {
+			side = exchange.Buy
+		}
+	}
+
+	if c.wsOrderFills == nil {
+		c.wsOrderFills = make(map[string]float64)
+	}
+	c.wsOrderFills[orderID] += match.Size
+
+	fill := exchange.FillEvent{
+		Timestamp: parseWsTime(match.Time),
+		Exchange:  c.GetName(),
+		AssetType: "SPOT",
+		Pair:      pair.NewCurrencyPairFromString(match.ProductID),
+		OrderID:   orderID,
+		TradeID:   strconv.Itoa(match.TradeID),
+		OrderSide: side,
+		Price:     match.Price,
+		Amount:    match.Size,
+		IsMaker:   isMaker,
+	}
+
+	return fill, exchange.OrderUpdate{
+		Timestamp:      fill.Timestamp,
+		Exchange:       fill.Exchange,
+		AssetType:      fill.AssetType,
+		Pair:           fill.Pair,
+		OrderID:        orderID,
+		OrderSide:      side,
+		Status:         exchange.PartiallyFilledOrderStatus,
+		ExecutedAmount: c.wsOrderFills[orderID],
+	}
+}
+
+// parseWsTime parses an RFC3339 websocket time, returning the current time if
+// it is missing
+func parseWsTime(t string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return time.Now()
+	}
+	return parsed
+}
+
 // ProcessSnapshot processes the initial orderbook snap shot
 func (c *CoinbasePro) ProcessSnapshot(snapshot WebsocketOrderbookSnapshot) error {
 	var base orderbook.Base
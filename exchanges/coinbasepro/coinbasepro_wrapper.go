@@ -1,13 +1,14 @@
 package coinbasepro
 
 import (
-	"errors"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -136,25 +137,78 @@ func (c *CoinbasePro) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (c *CoinbasePro) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (c *CoinbasePro) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (c *CoinbasePro) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	symbol := exchange.FormatExchangeCurrency(c.Name, p).String()
+	fetcher := kline.Fetcher{
+		SupportedIntervals: coinbaseproHistoryIntervals,
+		Limit:              coinbaseproHistoryLimit,
+		Fetch: func(interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+			// end is inclusive, stop before the candle opening at end
+			resp, err := c.GetHistoricRates(symbol,
+				start,
+				end.Add(-time.Second),
+				interval.Seconds())
+			if err != nil {
+				return nil, err
+			}
+
+			var candles []kline.Candle
+			for x := range resp {
+				candles = append(candles, kline.Candle{
+					Time:   time.Unix(resp[x].Time, 0),
+					Open:   resp[x].Open,
+					High:   resp[x].High,
+					Low:    resp[x].Low,
+					Close:  resp[x].Close,
+					Volume: resp[x].Volume,
+				})
+			}
+			return candles, nil
+		},
+	}
+
+	candles, err := fetcher.GetCandles(interval, start, end)
+	if err != nil {
+		return kline.Item{}, err
+	}
+
+	return kline.Item{
+		Exchange:  c.Name,
+		Pair:      p,
+		AssetType: assetType,
+		Interval:  interval,
+		Candles:   candles,
+	}, nil
+}
+
 // SubmitOrder submits a new order
-func (c *CoinbasePro) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (c *CoinbasePro) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(c.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var response string
 	var err error
-	if orderType == exchange.Market {
-		response, err = c.PlaceMarginOrder("", amount, amount, side.ToString(), p.Pair().String(), "")
-
-	} else if orderType == exchange.Limit {
-		response, err = c.PlaceLimitOrder("", price, amount, side.ToString(), "", "", p.Pair().String(), "", false)
-	} else {
-		err = errors.New("not supported")
+	side := common.StringToLower(order.OrderSide.ToString())
+	switch order.OrderType {
+	case exchange.Market:
+		response, err = c.PlaceMarketOrder(order.ClientID, order.Amount, 0, side, order.CurrencyPair.Pair().String(), "")
+	case exchange.Limit, exchange.ImmediateOrCancel:
+		timeInForce := string(order.TimeInForce)
+		if order.OrderType == exchange.ImmediateOrCancel {
+			timeInForce = string(exchange.IOC)
+		}
+		response, err = c.PlaceLimitOrder(order.ClientID, order.Price, order.Amount, side, timeInForce, "", order.CurrencyPair.Pair().String(), "", order.PostOnly)
 	}
 
 	if response != "" {
@@ -192,6 +246,59 @@ func (c *CoinbasePro) GetOrderInfo(orderID int64) (exchange.OrderDetail, error)
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (c *CoinbasePro) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := c.GetOrders([]string{"open", "pending", "active"}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	orders := c.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (c *CoinbasePro) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := c.GetOrders([]string{"done"}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	orders := c.formatOrders(resp)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrders converts Coinbase Pro orders to the exchange.OrderDetail type
+func (c *CoinbasePro) formatOrders(resp []GeneralizedOrderResponse) []exchange.OrderDetail {
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		var p pair.CurrencyPair
+		if common.StringContains(resp[i].ProductID, "-") {
+			p = pair.NewCurrencyPairDelimiter(resp[i].ProductID, "-")
+		}
+
+		orderDate, _ := time.Parse(time.RFC3339, resp[i].CreatedAt)
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        c.Name,
+			ID:              resp[i].ID,
+			CurrencyPair:    p,
+			OrderSide:       exchange.FormatOrderSide(resp[i].Side),
+			OrderType:       exchange.FormatOrderType(resp[i].Type),
+			OrderDate:       orderDate,
+			Status:          resp[i].Status,
+			Price:           resp[i].Price,
+			Amount:          resp[i].Size,
+			ExecutedAmount:  resp[i].FilledSize,
+			RemainingAmount: resp[i].Size - resp[i].FilledSize,
+			Fee:             resp[i].FillFees,
+			FeeCurrency:     p.SecondCurrency.String(),
+		})
+	}
+	return orders
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (c *CoinbasePro) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -57,6 +57,17 @@ func (c *COINUT) SetDefaults() {
 	c.Verbose = false
 	c.RESTPollingDelay = 10
 	c.APIWithdrawPermissions = exchange.WithdrawCryptoViaWebsiteOnly | exchange.WithdrawFiatViaWebsiteOnly
+	c.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+	c.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true, Websocket: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		TradeFetching:     exchange.FeatureSupport{Websocket: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.TickerChannel, exchange.OrderbookChannel, exchange.TradeChannel},
+	}
 	c.RequestCurrencyPairFormat.Delimiter = ""
 	c.RequestCurrencyPairFormat.Uppercase = true
 	c.ConfigCurrencyPairFormat.Delimiter = ""
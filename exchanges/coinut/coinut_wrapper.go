@@ -6,12 +6,13 @@ import (
 	"log"
 	"strconv"
 	"sync"
-
-	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -201,20 +202,30 @@ func (c *COINUT) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (c *COINUT) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (c *COINUT) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (c *COINUT) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (c *COINUT) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (c *COINUT) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(c.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var err error
 	var APIresponse interface{}
-	isBuyOrder := side == exchange.Buy
-	clientIDInt, err := strconv.ParseUint(clientID, 0, 32)
+	isBuyOrder := order.OrderSide == exchange.Buy
+	clientIDInt, err := strconv.ParseUint(order.ClientID, 0, 32)
 	clientIDUint := uint32(clientIDInt)
 
 	if err != nil {
@@ -226,13 +237,13 @@ func (c *COINUT) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, order
 		return submitOrderResponse, err
 	}
 
-	currencyArray := instruments.Instruments[p.Pair().String()]
+	currencyArray := instruments.Instruments[order.CurrencyPair.Pair().String()]
 	currencyID := currencyArray[0].InstID
 
-	if orderType == exchange.Limit {
-		APIresponse, err = c.NewOrder(currencyID, amount, price, isBuyOrder, clientIDUint)
-	} else if orderType == exchange.Market {
-		APIresponse, err = c.NewOrder(currencyID, amount, 0, isBuyOrder, clientIDUint)
+	if order.OrderType == exchange.Limit {
+		APIresponse, err = c.NewOrder(currencyID, order.Amount, order.Price, isBuyOrder, clientIDUint)
+	} else if order.OrderType == exchange.Market {
+		APIresponse, err = c.NewOrder(currencyID, order.Amount, 0, isBuyOrder, clientIDUint)
 	} else {
 		return submitOrderResponse, errors.New("unsupported order type")
 	}
@@ -345,6 +356,91 @@ func (c *COINUT) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (c *COINUT) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = c.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		instrumentID, ok := c.InstrumentMap[currencies[x].Pair().String()]
+		if !ok {
+			return nil, fmt.Errorf("%s instrument ID not found for currency pair %s",
+				c.Name, currencies[x].Pair().String())
+		}
+
+		resp, err := c.GetOpenOrders(instrumentID)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Orders {
+			orders = append(orders, c.formatOrder(currencies[x], resp.Orders[i]))
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (c *COINUT) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = c.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		instrumentID, ok := c.InstrumentMap[currencies[x].Pair().String()]
+		if !ok {
+			return nil, fmt.Errorf("%s instrument ID not found for currency pair %s",
+				c.Name, currencies[x].Pair().String())
+		}
+
+		resp, err := c.GetTradeHistory(instrumentID, -1, -1)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Trades {
+			order := c.formatOrder(currencies[x], resp.Trades[i].Order)
+			order.Status = "FILLED"
+			order.Fee = resp.Trades[i].Commission.Amount
+			order.FeeCurrency = resp.Trades[i].Commission.Currency
+			order.Fills = []exchange.OrderFill{{
+				Timestamp:   order.OrderDate,
+				Price:       resp.Trades[i].FillPrice,
+				Amount:      resp.Trades[i].FillQuantity,
+				Fee:         resp.Trades[i].Commission.Amount,
+				FeeCurrency: resp.Trades[i].Commission.Currency,
+			}}
+			orders = append(orders, order)
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrder converts a COINUT order to the exchange.OrderDetail type
+func (c *COINUT) formatOrder(p pair.CurrencyPair, order OrderResponse) exchange.OrderDetail {
+	return exchange.OrderDetail{
+		Exchange:        c.Name,
+		ID:              strconv.FormatInt(order.OrderID, 10),
+		ClientOrderID:   strconv.FormatInt(order.ClientOrderID, 10),
+		CurrencyPair:    p,
+		OrderSide:       exchange.FormatOrderSide(order.Side),
+		OrderType:       exchange.Limit,
+		OrderDate:       time.Unix(0, order.Timestamp*int64(time.Microsecond)),
+		Price:           order.Price,
+		Amount:          order.Quantity,
+		ExecutedAmount:  order.Quantity - order.OpenQuantity,
+		RemainingAmount: order.OpenQuantity,
+	}
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (c *COINUT) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
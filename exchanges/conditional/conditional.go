@@ -0,0 +1,506 @@
+package conditional
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	conditionalOrdersFile = "conditional_orders.json"
+	checkInterval         = time.Second
+	eventType             = "CONDITIONAL_ORDER"
+)
+
+// vars related to conditional orders
+var (
+	ErrOrderNotFound         = errors.New("conditional order not found")
+	ErrOrderNotPending       = errors.New("conditional order is not pending")
+	ErrExchangeNotFound      = errors.New("exchange not found")
+	ErrInvalidAmount         = errors.New("order amount must be above zero")
+	ErrInvalidSide           = errors.New("order side must be buy or sell")
+	ErrInvalidType           = errors.New("conditional order type must be stop loss, take profit or trailing stop")
+	ErrInvalidTriggerPrice   = errors.New("trigger price must be above zero")
+	ErrInvalidTrail          = errors.New("trailing stops require either a trail amount or a trail percent below 100")
+	ErrInvalidOrderType      = errors.New("order type must be market or limit")
+	ErrLimitPriceRequired    = errors.New("limit orders require a limit price")
+	ErrOrderTypeNotSupported = errors.New("order type is not supported by the exchange")
+	ErrInvalidSource         = errors.New("price source must be ticker or orderbook")
+	ErrManagerAlreadyStarted = errors.New("conditional order manager already started")
+)
+
+// NewManager returns a conditional order manager which submits orders to the
+// exchanges and persists to the supplied data directory
+func NewManager(dataDir string, exchanges []exchange.IBotExchange) *Manager {
+	return &Manager{
+		filePath:  dataDir + common.GetOSPathSlash() + conditionalOrdersFile,
+		exchanges: exchanges,
+	}
+}
+
+// SetComms sets where triggered and failed conditional orders are reported
+func (m *Manager) SetComms(n Notifier) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.notifier = n
+}
+
+// GetFilePath returns the path of the file conditional orders are persisted
+// to
+func (m *Manager) GetFilePath() string {
+	return m.filePath
+}
+
+// Load reads any previously persisted conditional orders from disk
+func (m *Manager) Load() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, err := os.Stat(m.filePath); os.IsNotExist(err) {
+		return nil
+	}
+
+	data, err := common.ReadFile(m.filePath)
+	if err != nil {
+		return err
+	}
+
+	var s store
+	err = common.JSONDecode(data, &s)
+	if err != nil {
+		return err
+	}
+
+	m.Orders = s.Orders
+	m.LastID = s.LastID
+	return nil
+}
+
+// Save persists all conditional orders to disk
+func (m *Manager) Save() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.save()
+}
+
+func (m *Manager) save() error {
+	data, err := common.JSONEncode(store{LastID: m.LastID, Orders: m.Orders})
+	if err != nil {
+		return err
+	}
+	return common.WriteFile(m.filePath, data)
+}
+
+// saveOrLog persists the conditional orders and logs any failure
+func (m *Manager) saveOrLog() {
+	err := m.save()
+	if err != nil {
+		log.Printf("Conditional orders: unable to save orders to %s. Err: %s", m.filePath, err)
+	}
+}
+
+// Start checks the pending conditional orders every second until stopped
+func (m *Manager) Start() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.shutdown != nil {
+		return ErrManagerAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run(m.shutdown)
+	return nil
+}
+
+// Stop stops checking conditional orders and waits for an in progress check
+// to finish
+func (m *Manager) Stop() {
+	m.mtx.Lock()
+	if m.shutdown == nil {
+		m.mtx.Unlock()
+		return
+	}
+	close(m.shutdown)
+	m.shutdown = nil
+	m.mtx.Unlock()
+	m.wg.Wait()
+}
+
+func (m *Manager) run(shutdown chan struct{}) {
+	defer m.wg.Done()
+	t := time.NewTicker(checkInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-t.C:
+			m.Check()
+		}
+	}
+}
+
+// Add validates and adds a conditional order, returning its ID
+func (m *Manager) Add(o Order) (int64, error) {
+	err := m.prepare(&o)
+	if err != nil {
+		return 0, err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.add(&o)
+	m.saveOrLog()
+	return o.ID, nil
+}
+
+// AddOCO validates and adds two conditional orders linked so that the first
+// to trigger cancels the other, returning their IDs
+func (m *Manager) AddOCO(first, second Order) (int64, int64, error) {
+	err := m.prepare(&first)
+	if err != nil {
+		return 0, 0, err
+	}
+	err = m.prepare(&second)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.add(&first)
+	m.add(&second)
+	first.LinkedID = second.ID
+	second.LinkedID = first.ID
+	m.saveOrLog()
+	return first.ID, second.ID, nil
+}
+
+// Cancel cancels a pending conditional order. Its linked order is left
+// pending
+func (m *Manager) Cancel(id int64) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	o := m.getOrder(id)
+	if o == nil {
+		return ErrOrderNotFound
+	}
+	if o.Status != Pending {
+		return ErrOrderNotPending
+	}
+	o.Status = Cancelled
+	o.UpdatedAt = time.Now()
+	m.saveOrLog()
+	return nil
+}
+
+// GetOrders returns a copy of all conditional orders
+func (m *Manager) GetOrders() []Order {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	orders := make([]Order, len(m.Orders))
+	for i := range m.Orders {
+		orders[i] = *m.Orders[i]
+	}
+	return orders
+}
+
+// GetOrder returns a copy of a conditional order by ID
+func (m *Manager) GetOrder(id int64) (Order, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	o := m.getOrder(id)
+	if o == nil {
+		return Order{}, ErrOrderNotFound
+	}
+	return *o, nil
+}
+
+// Check compares every pending conditional order against its cached price,
+// moving trailing stops and submitting the orders whose trigger has fired.
+// Orders without a cached price are skipped
+func (m *Manager) Check() {
+	type fired struct {
+		order           Order
+		cancelledLinked bool
+	}
+
+	m.mtx.Lock()
+	var triggered []fired
+	var changed bool
+	for _, o := range m.Orders {
+		if o.Status != Pending {
+			continue
+		}
+		price, err := getPrice(o)
+		if err != nil || price <= 0 {
+			continue
+		}
+		if o.trail(price) {
+			changed = true
+		}
+		if !o.isTriggered(price) {
+			continue
+		}
+
+		changed = true
+		o.Status = Triggered
+		o.UpdatedAt = time.Now()
+		f := fired{order: *o}
+		if linked := m.getOrder(o.LinkedID); linked != nil && linked.Status == Pending {
+			linked.Status = Cancelled
+			linked.UpdatedAt = o.UpdatedAt
+			f.cancelledLinked = true
+		}
+		triggered = append(triggered, f)
+	}
+	if changed {
+		m.saveOrLog()
+	}
+	m.mtx.Unlock()
+
+	for i := range triggered {
+		m.submit(triggered[i].order, triggered[i].cancelledLinked)
+	}
+}
+
+// submit places a triggered conditional order on its exchange. When the order
+// fails the linked order it cancelled is restored so the position remains
+// protected
+func (m *Manager) submit(o Order, cancelledLinked bool) {
+	var resp exchange.SubmitOrderResponse
+	var err error
+	exch := m.getExchange(o.Exchange)
+	if exch == nil {
+		err = ErrExchangeNotFound
+	} else {
+		resp, err = exch.SubmitOrder(o.submission())
+		if err == nil && !resp.IsOrderPlaced {
+			err = errors.New("order was not placed")
+		}
+	}
+
+	m.mtx.Lock()
+	order := m.getOrder(o.ID)
+	if err != nil {
+		order.Status = Failed
+		order.Error = err.Error()
+		if linked := m.getOrder(o.LinkedID); linked != nil && cancelledLinked {
+			linked.Status = Pending
+		}
+	} else {
+		order.ExchangeOrderID = resp.OrderID
+	}
+	order.UpdatedAt = time.Now()
+	m.saveOrLog()
+	m.mtx.Unlock()
+
+	if err != nil {
+		m.notify(fmt.Sprintf("%s failed to submit: %s", o.String(), err))
+		return
+	}
+	m.notify(fmt.Sprintf("%s triggered, order %s placed", o.String(), resp.OrderID))
+}
+
+// prepare validates a new conditional order and sets its defaults
+func (m *Manager) prepare(o *Order) error {
+	if o.Amount <= 0 {
+		return ErrInvalidAmount
+	}
+	if o.Side != exchange.Buy && o.Side != exchange.Sell {
+		return ErrInvalidSide
+	}
+	switch o.Type {
+	case StopLoss, TakeProfit:
+		if o.TriggerPrice <= 0 {
+			return ErrInvalidTriggerPrice
+		}
+	case TrailingStop:
+		if (o.TrailAmount > 0) == (o.TrailPercent > 0) ||
+			o.TrailAmount < 0 || o.TrailPercent < 0 || o.TrailPercent >= 100 {
+			return ErrInvalidTrail
+		}
+		o.TriggerPrice = 0
+		o.Extreme = 0
+	default:
+		return ErrInvalidType
+	}
+	switch o.OrderType {
+	case "":
+		o.OrderType = exchange.Market
+	case exchange.Market:
+	case exchange.Limit:
+		if o.LimitPrice <= 0 {
+			return ErrLimitPriceRequired
+		}
+	default:
+		return ErrInvalidOrderType
+	}
+	switch o.Source {
+	case "":
+		o.Source = Ticker
+	case Ticker, Orderbook:
+	default:
+		return ErrInvalidSource
+	}
+	if o.AssetType == "" {
+		o.AssetType = ticker.Spot
+	}
+
+	exch := m.getExchange(o.Exchange)
+	if exch == nil {
+		return ErrExchangeNotFound
+	}
+	// the order is checked against the exchange now rather than when its
+	// trigger fires, as it may not be noticed failing until then
+	s := o.submission()
+	if !exch.SupportsOrderSubmissionFeatures(s.GetRequiredFeatures()) {
+		return ErrOrderTypeNotSupported
+	}
+	err := s.Validate(exch.GetOrderSubmissionFeatures())
+	if err != nil {
+		return err
+	}
+
+	o.Exchange = exch.GetName()
+	o.Status = Pending
+	o.LinkedID = 0
+	o.ExchangeOrderID = ""
+	o.Error = ""
+	return nil
+}
+
+func (m *Manager) add(o *Order) {
+	m.LastID++
+	o.ID = m.LastID
+	o.CreatedAt = time.Now()
+	o.UpdatedAt = o.CreatedAt
+	m.Orders = append(m.Orders, o)
+}
+
+func (m *Manager) getOrder(id int64) *Order {
+	if id == 0 {
+		return nil
+	}
+	for _, o := range m.Orders {
+		if o.ID == id {
+			return o
+		}
+	}
+	return nil
+}
+
+// getExchange returns the exchange by name
+func (m *Manager) getExchange(name string) exchange.IBotExchange {
+	for _, exch := range m.exchanges {
+		if common.StringToLower(exch.GetName()) == common.StringToLower(name) {
+			return exch
+		}
+	}
+	return nil
+}
+
+func (m *Manager) notify(message string) {
+	log.Printf("Conditional orders: %s", message)
+
+	m.mtx.Lock()
+	n := m.notifier
+	m.mtx.Unlock()
+	if n != nil {
+		n.PushEvent(base.Event{Type: eventType, TradeDetails: message})
+	}
+}
+
+// getPrice returns the cached price a conditional order is checked against
+func getPrice(o *Order) (float64, error) {
+	if o.Source == Orderbook {
+		ob, err := orderbook.GetOrderbook(o.Exchange, o.CurrencyPair, o.AssetType)
+		if err != nil {
+			return 0, err
+		}
+		var best float64
+		if o.Side == exchange.Sell {
+			for _, item := range ob.Bids {
+				if item.Price > best {
+					best = item.Price
+				}
+			}
+			return best, nil
+		}
+		for _, item := range ob.Asks {
+			if best == 0 || item.Price < best {
+				best = item.Price
+			}
+		}
+		return best, nil
+	}
+
+	t, err := ticker.GetTicker(o.Exchange, o.CurrencyPair, o.AssetType)
+	if err != nil {
+		return 0, err
+	}
+	return t.Last, nil
+}
+
+// trail moves the trigger price of a trailing stop to follow a new best price,
+// returning whether it moved
+func (o *Order) trail(price float64) bool {
+	if o.Type != TrailingStop {
+		return false
+	}
+	if o.Extreme > 0 &&
+		(o.Side == exchange.Sell && price <= o.Extreme ||
+			o.Side == exchange.Buy && price >= o.Extreme) {
+		return false
+	}
+
+	o.Extreme = price
+	distance := o.TrailAmount
+	if o.TrailPercent > 0 {
+		distance = price * o.TrailPercent / 100
+	}
+	if o.Side == exchange.Sell {
+		o.TriggerPrice = price - distance
+	} else {
+		o.TriggerPrice = price + distance
+	}
+	return true
+}
+
+// isTriggered returns whether the price has reached the trigger price
+func (o *Order) isTriggered(price float64) bool {
+	rising := o.Type == TakeProfit
+	if o.Side == exchange.Buy {
+		rising = !rising
+	}
+	if rising {
+		return price >= o.TriggerPrice
+	}
+	return price <= o.TriggerPrice
+}
+
+// submission returns the order submitted when the conditional order triggers
+func (o *Order) submission() *exchange.OrderSubmission {
+	return &exchange.OrderSubmission{
+		CurrencyPair: o.CurrencyPair,
+		OrderSide:    o.Side,
+		OrderType:    o.OrderType,
+		Price:        o.LimitPrice,
+		Amount:       o.Amount,
+	}
+}
+
+// String returns a summary of the conditional order
+func (o Order) String() string {
+	return fmt.Sprintf("%s %d %s %f %s on %s at %f", o.Type, o.ID, o.Side,
+		o.Amount, o.CurrencyPair.Pair(), o.Exchange, o.TriggerPrice)
+}
@@ -0,0 +1,267 @@
+package conditional
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+var testPair = pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+
+// testNotifier records the events pushed to it
+type testNotifier struct {
+	events []base.Event
+}
+
+func (n *testNotifier) PushEvent(event base.Event) {
+	n.events = append(n.events, event)
+}
+
+func setupManager(t *testing.T, name string) (*Manager, *exchangetest.Exchange, string) {
+	dir, err := ioutil.TempDir("", "conditional")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := exchangetest.New(name, testPair)
+	e.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+	return NewManager(dir, []exchange.IBotExchange{e}), e, dir
+}
+
+func setPrice(name string, price float64) {
+	ticker.ProcessTicker(name, testPair, ticker.Price{Pair: testPair, Last: price}, ticker.Spot)
+}
+
+func TestAddErrors(t *testing.T) {
+	m, e, dir := setupManager(t, "ConditionalErrors")
+	defer os.RemoveAll(dir)
+
+	valid := Order{
+		Exchange:     "conditionalerrors",
+		CurrencyPair: testPair,
+		Type:         StopLoss,
+		Side:         exchange.Sell,
+		Amount:       1,
+		TriggerPrice: 95,
+	}
+	tests := []struct {
+		modify func(*Order)
+		err    error
+	}{
+		{func(o *Order) { o.Amount = 0 }, ErrInvalidAmount},
+		{func(o *Order) { o.Side = exchange.AnyOrderSide }, ErrInvalidSide},
+		{func(o *Order) { o.Type = "" }, ErrInvalidType},
+		{func(o *Order) { o.TriggerPrice = 0 }, ErrInvalidTriggerPrice},
+		{func(o *Order) { o.Type = TrailingStop }, ErrInvalidTrail},
+		{func(o *Order) { o.Type = TrailingStop; o.TrailAmount = 1; o.TrailPercent = 1 }, ErrInvalidTrail},
+		{func(o *Order) { o.OrderType = exchange.ImmediateOrCancel }, ErrInvalidOrderType},
+		{func(o *Order) { o.OrderType = exchange.Limit }, ErrLimitPriceRequired},
+		{func(o *Order) { o.Source = "TRADES" }, ErrInvalidSource},
+		{func(o *Order) { o.Exchange = "missing" }, ErrExchangeNotFound},
+		{func(o *Order) { o.CurrencyPair = pair.CurrencyPair{} }, exchange.ErrOrderSubmissionPairIsEmpty},
+	}
+	for i, test := range tests {
+		o := valid
+		test.modify(&o)
+		if _, err := m.Add(o); err != test.err {
+			t.Errorf("Test failed. Test %d expected %s got %v", i, test.err, err)
+		}
+	}
+
+	id, err := m.Add(valid)
+	if err != nil {
+		t.Fatalf("Test failed. Add error: %s", err)
+	}
+	o, err := m.GetOrder(id)
+	if err != nil || o.Exchange != "ConditionalErrors" || o.OrderType != exchange.Market ||
+		o.Source != Ticker || o.AssetType != ticker.Spot || o.Status != Pending {
+		t.Errorf("Test failed. Expected defaults to be set got %+v %v", o, err)
+	}
+
+	// market orders are rejected by exchanges without market order support
+	e.OrderSubmissionFeatures = exchange.LimitOrderSupport
+	if _, err = m.Add(valid); err != ErrOrderTypeNotSupported {
+		t.Errorf("Test failed. Expected %s got %v", ErrOrderTypeNotSupported, err)
+	}
+	valid.OrderType = exchange.Limit
+	valid.LimitPrice = 94
+	if _, err = m.Add(valid); err != nil {
+		t.Errorf("Test failed. Expected limit order to be added got %v", err)
+	}
+}
+
+func TestStopLossAndTakeProfit(t *testing.T) {
+	m, e, dir := setupManager(t, "ConditionalStop")
+	defer os.RemoveAll(dir)
+	n := &testNotifier{}
+	m.SetComms(n)
+
+	stop, err := m.Add(Order{Exchange: e.Name, CurrencyPair: testPair, Type: StopLoss,
+		Side: exchange.Sell, Amount: 1, TriggerPrice: 95})
+	if err != nil {
+		t.Fatalf("Test failed. Add error: %s", err)
+	}
+	takeProfit, err := m.Add(Order{Exchange: e.Name, CurrencyPair: testPair, Type: TakeProfit,
+		Side: exchange.Buy, Amount: 2, TriggerPrice: 90})
+	if err != nil {
+		t.Fatalf("Test failed. Add error: %s", err)
+	}
+
+	// orders without a cached price are skipped
+	m.Check()
+	setPrice(e.Name, 100)
+	m.Check()
+	if len(e.Submitted()) != 0 {
+		t.Fatalf("Test failed. Expected no orders to trigger got %+v", e.Submitted())
+	}
+
+	setPrice(e.Name, 94)
+	m.Check()
+	if len(e.Submitted()) != 1 || e.Submitted()[0].OrderSide != exchange.Sell ||
+		e.Submitted()[0].OrderType != exchange.Market || e.Submitted()[0].Amount != 1 {
+		t.Fatalf("Test failed. Expected stop loss to be submitted got %+v", e.Submitted())
+	}
+	if o, _ := m.GetOrder(stop); o.Status != Triggered || o.ExchangeOrderID != "1" {
+		t.Errorf("Test failed. Expected triggered stop loss got %+v", o)
+	}
+	if len(n.events) != 1 || n.events[0].Type != eventType {
+		t.Errorf("Test failed. Expected triggered order to be reported got %v", n.events)
+	}
+
+	setPrice(e.Name, 90)
+	m.Check()
+	if len(e.Submitted()) != 2 || e.Submitted()[1].OrderSide != exchange.Buy {
+		t.Fatalf("Test failed. Expected take profit to be submitted got %+v", e.Submitted())
+	}
+	if o, _ := m.GetOrder(takeProfit); o.Status != Triggered {
+		t.Errorf("Test failed. Expected triggered take profit got %+v", o)
+	}
+}
+
+func TestTrailingStop(t *testing.T) {
+	m, e, dir := setupManager(t, "ConditionalTrailing")
+	defer os.RemoveAll(dir)
+
+	id, err := m.Add(Order{Exchange: e.Name, CurrencyPair: testPair, Type: TrailingStop,
+		Side: exchange.Sell, Amount: 1, TrailPercent: 5})
+	if err != nil {
+		t.Fatalf("Test failed. Add error: %s", err)
+	}
+
+	for _, price := range []float64{100, 110, 106} {
+		setPrice(e.Name, price)
+		m.Check()
+	}
+	o, _ := m.GetOrder(id)
+	if o.Extreme != 110 || o.TriggerPrice != 104.5 || o.Status != Pending {
+		t.Fatalf("Test failed. Expected stop to follow the highest price got %+v", o)
+	}
+
+	setPrice(e.Name, 104)
+	m.Check()
+	if o, _ = m.GetOrder(id); o.Status != Triggered || len(e.Submitted()) != 1 {
+		t.Errorf("Test failed. Expected trailing stop to trigger got %+v", o)
+	}
+}
+
+func TestOCO(t *testing.T) {
+	m, e, dir := setupManager(t, "ConditionalOCO")
+	defer os.RemoveAll(dir)
+
+	stop := Order{Exchange: e.Name, CurrencyPair: testPair, Type: StopLoss,
+		Side: exchange.Sell, Amount: 1, TriggerPrice: 95}
+	takeProfit := Order{Exchange: e.Name, CurrencyPair: testPair, Type: TakeProfit,
+		Side: exchange.Sell, Amount: 1, TriggerPrice: 110, OrderType: exchange.Limit, LimitPrice: 109}
+	stopID, takeProfitID, err := m.AddOCO(stop, takeProfit)
+	if err != nil {
+		t.Fatalf("Test failed. AddOCO error: %s", err)
+	}
+
+	// a failed order restores the order it cancelled
+	e.SubmitErr = errors.New("insufficient funds")
+	setPrice(e.Name, 111)
+	m.Check()
+	s, _ := m.GetOrder(stopID)
+	tp, _ := m.GetOrder(takeProfitID)
+	if tp.Status != Failed || tp.Error != "insufficient funds" || s.Status != Pending || s.LinkedID != takeProfitID {
+		t.Fatalf("Test failed. Expected failed take profit to leave stop pending got %+v %+v", tp, s)
+	}
+
+	stopID, takeProfitID, err = m.AddOCO(stop, takeProfit)
+	if err != nil {
+		t.Fatalf("Test failed. AddOCO error: %s", err)
+	}
+	e.SubmitErr = nil
+	m.Check()
+	s, _ = m.GetOrder(stopID)
+	tp, _ = m.GetOrder(takeProfitID)
+	if tp.Status != Triggered || s.Status != Cancelled {
+		t.Errorf("Test failed. Expected take profit to cancel stop got %+v %+v", tp, s)
+	}
+	if len(e.Submitted()) != 1 || e.Submitted()[0].OrderType != exchange.Limit || e.Submitted()[0].Price != 109 {
+		t.Errorf("Test failed. Expected limit take profit to be submitted got %+v", e.Submitted())
+	}
+}
+
+func TestOrderbookSource(t *testing.T) {
+	m, e, dir := setupManager(t, "ConditionalOrderbook")
+	defer os.RemoveAll(dir)
+
+	id, err := m.Add(Order{Exchange: e.Name, CurrencyPair: testPair, Type: StopLoss,
+		Side: exchange.Sell, Amount: 1, TriggerPrice: 95, Source: Orderbook})
+	if err != nil {
+		t.Fatalf("Test failed. Add error: %s", err)
+	}
+
+	setPrice(e.Name, 90)
+	orderbook.ProcessOrderbook(e.Name, testPair, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 94, Amount: 1}, {Price: 96, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 97, Amount: 1}},
+	}, orderbook.Spot)
+	m.Check()
+	if o, _ := m.GetOrder(id); o.Status != Pending {
+		t.Errorf("Test failed. Expected best bid to be used got %+v", o)
+	}
+}
+
+func TestCancelAndLoad(t *testing.T) {
+	m, e, dir := setupManager(t, "ConditionalLoad")
+	defer os.RemoveAll(dir)
+
+	first, err := m.Add(Order{Exchange: e.Name, CurrencyPair: testPair, Type: StopLoss,
+		Side: exchange.Sell, Amount: 1, TriggerPrice: 95})
+	if err != nil {
+		t.Fatalf("Test failed. Add error: %s", err)
+	}
+	if _, err = m.Add(Order{Exchange: e.Name, CurrencyPair: testPair, Type: TrailingStop,
+		Side: exchange.Buy, Amount: 1, TrailAmount: 2}); err != nil {
+		t.Fatalf("Test failed. Add error: %s", err)
+	}
+	if err = m.Cancel(first); err != nil {
+		t.Fatalf("Test failed. Cancel error: %s", err)
+	}
+	if err = m.Cancel(first); err != ErrOrderNotPending {
+		t.Errorf("Test failed. Expected %s got %v", ErrOrderNotPending, err)
+	}
+	if err = m.Cancel(10); err != ErrOrderNotFound {
+		t.Errorf("Test failed. Expected %s got %v", ErrOrderNotFound, err)
+	}
+
+	loaded := NewManager(dir, []exchange.IBotExchange{e})
+	if err = loaded.Load(); err != nil {
+		t.Fatalf("Test failed. Load error: %s", err)
+	}
+	orders := loaded.GetOrders()
+	if len(orders) != 2 || loaded.LastID != 2 || orders[0].Status != Cancelled ||
+		orders[1].Type != TrailingStop || orders[1].TrailAmount != 2 {
+		t.Errorf("Test failed. Expected orders to be restored got %+v", orders)
+	}
+}
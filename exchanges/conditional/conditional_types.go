@@ -0,0 +1,108 @@
+package conditional
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Type defines the trigger of a conditional order
+type Type string
+
+// Conditional order types
+const (
+	// StopLoss triggers a sell once the price falls to the trigger price, or
+	// a buy once the price rises to it
+	StopLoss Type = "STOP_LOSS"
+	// TakeProfit triggers a sell once the price rises to the trigger price, or
+	// a buy once the price falls to it
+	TakeProfit Type = "TAKE_PROFIT"
+	// TrailingStop is a stop loss whose trigger price follows the best price
+	// seen since the order was added at a fixed distance
+	TrailingStop Type = "TRAILING_STOP"
+)
+
+// Status defines the lifecycle state of a conditional order
+type Status string
+
+// Conditional order states
+const (
+	Pending   Status = "PENDING"
+	Triggered Status = "TRIGGERED"
+	Cancelled Status = "CANCELLED"
+	Failed    Status = "FAILED"
+)
+
+// Source defines the cached price a conditional order is checked against
+type Source string
+
+// Price sources
+const (
+	// Ticker uses the last traded price from the ticker cache
+	Ticker Source = "TICKER"
+	// Orderbook uses the best bid of sells and the best ask of buys from the
+	// orderbook cache
+	Orderbook Source = "ORDERBOOK"
+)
+
+// Order is an order held by the bot and submitted to the exchange once its
+// trigger fires
+type Order struct {
+	ID           int64              `json:"id"`
+	Exchange     string             `json:"exchange"`
+	CurrencyPair pair.CurrencyPair  `json:"currencyPair"`
+	AssetType    string             `json:"assetType"`
+	Type         Type               `json:"type"`
+	Side         exchange.OrderSide `json:"side"`
+	Amount       float64            `json:"amount"`
+	Source       Source             `json:"source"`
+	// TriggerPrice is the price the order fires at. For trailing stops it is
+	// calculated from Extreme and the trail
+	TriggerPrice float64 `json:"triggerPrice"`
+	// TrailAmount or TrailPercent sets the distance a trailing stop follows
+	// the price at
+	TrailAmount  float64 `json:"trailAmount,omitempty"`
+	TrailPercent float64 `json:"trailPercent,omitempty"`
+	// Extreme is the highest price seen by a trailing sell or the lowest
+	// price seen by a trailing buy
+	Extreme float64 `json:"extreme,omitempty"`
+	// OrderType is the type of order submitted when triggered, either Market
+	// or Limit at LimitPrice
+	OrderType  exchange.OrderType `json:"orderType"`
+	LimitPrice float64            `json:"limitPrice,omitempty"`
+	// LinkedID is the order cancelled when this one triggers
+	LinkedID        int64     `json:"linkedId,omitempty"`
+	Status          Status    `json:"status"`
+	ExchangeOrderID string    `json:"exchangeOrderId,omitempty"`
+	Error           string    `json:"error,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// Notifier is implemented by communications.Communications and receives
+// triggered and failed conditional orders
+type Notifier interface {
+	PushEvent(event base.Event)
+}
+
+// Manager holds conditional orders, checks them against the ticker and
+// orderbook caches and persists them to disk
+type Manager struct {
+	Orders    []*Order
+	LastID    int64
+	filePath  string
+	exchanges []exchange.IBotExchange
+	notifier  Notifier
+	shutdown  chan struct{}
+	wg        sync.WaitGroup
+	mtx       sync.Mutex
+}
+
+// store is the on disk format of the conditional order manager
+type store struct {
+	LastID int64    `json:"lastId"`
+	Orders []*Order `json:"orders"`
+}
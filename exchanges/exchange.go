@@ -6,6 +6,8 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/nonce"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
@@ -143,6 +146,53 @@ const (
 	UnknownWithdrawalTypeText string = "UNKNOWN"
 )
 
+// Definitions for each order submission feature supported by an exchange
+const (
+	NoOrderSubmissionFeatures         uint32 = 0
+	NoOrderSubmissionFeaturesText     string = "NONE"
+	LimitOrderSupport                 uint32 = (1 << 0)
+	MarketOrderSupport                uint32 = (1 << 1)
+	StopOrderSupport                  uint32 = (1 << 2)
+	StopLimitOrderSupport             uint32 = (1 << 3)
+	TrailingStopOrderSupport          uint32 = (1 << 4)
+	LimitOrderSupportText             string = "LIMIT"
+	MarketOrderSupportText            string = "MARKET"
+	StopOrderSupportText              string = "STOP"
+	StopLimitOrderSupportText         string = "STOP LIMIT"
+	TrailingStopOrderSupportText      string = "TRAILING STOP"
+	PostOnlyOrderSupport              uint32 = (1 << 5)
+	ReduceOnlyOrderSupport            uint32 = (1 << 6)
+	HiddenOrderSupport                uint32 = (1 << 7)
+	PostOnlyOrderSupportText          string = "POST ONLY"
+	ReduceOnlyOrderSupportText        string = "REDUCE ONLY"
+	HiddenOrderSupportText            string = "HIDDEN"
+	ImmediateOrCancelOrderSupport     uint32 = (1 << 8)
+	FillOrKillOrderSupport            uint32 = (1 << 9)
+	ImmediateOrCancelOrderSupportText string = "IMMEDIATE OR CANCEL"
+	FillOrKillOrderSupportText        string = "FILL OR KILL"
+	UnknownOrderSubmissionFeatureText string = "UNKNOWN"
+)
+
+// vars related to order submissions
+var (
+	ErrOrderSubmissionIsNil               = errors.New("order submission is nil")
+	ErrOrderSubmissionPairIsEmpty         = errors.New("order submission currency pair is empty")
+	ErrOrderSubmissionAmountInvalid       = errors.New("order submission amount must be greater than zero")
+	ErrOrderSubmissionFeatureNotSupported = errors.New("order submission feature not supported by exchange")
+)
+
+// vars related to order lookups
+var (
+	ErrOrderNotFound = errors.New("order not found in order history, active orders or order info")
+)
+
+// vars related to trade history requests
+var (
+	ErrTradeHistoryPairIsEmpty      = errors.New("trade history currency pair is empty")
+	ErrTradeHistoryInvalidTimeRange = errors.New("trade history start time must be before end time")
+	ErrTradeHistoryStartOutOfRange  = errors.New("trade history start time is older than the exchange can serve")
+)
+
 // AccountInfo is a Generic type to hold each exchange's holdings in
 // all enabled currencies
 type AccountInfo struct {
@@ -150,36 +200,82 @@ type AccountInfo struct {
 	Currencies   []AccountCurrencyInfo
 }
 
-// AccountCurrencyInfo is a sub type to store currency name and value
+// AccountCurrencyInfo is a sub type to store currency name and value.
+// TotalValue is the balance available to trade and Hold is the balance
+// reserved by open orders, the total balance is their sum
 type AccountCurrencyInfo struct {
 	CurrencyName string
 	TotalValue   float64
 	Hold         float64
 }
 
-// TradeHistory holds exchange history data
+// TradeHistory holds a normalised public trade from an exchange
 type TradeHistory struct {
-	Timestamp int64
-	TID       int64
-	Price     float64
-	Amount    float64
-	Exchange  string
-	Type      string
+	Exchange     string
+	TID          string
+	CurrencyPair pair.CurrencyPair
+	AssetType    string
+	Side         OrderSide
+	Price        float64
+	Amount       float64
+	Timestamp    time.Time
+}
+
+// TradeHistoryRequest is used to retrieve historic trades from an exchange.
+// Trades are fetched from FromTradeID when set, otherwise from StartTime. A
+// zero EndTime fetches trades up until the most recent trade
+type TradeHistoryRequest struct {
+	CurrencyPair pair.CurrencyPair
+	AssetType    string
+	StartTime    time.Time
+	EndTime      time.Time
+	FromTradeID  string
 }
 
+// TradeHistoryFetchFunc retrieves a single page of trades from an exchange
+// starting at the supplied cursor and returns the cursor of the next page
+type TradeHistoryFetchFunc func(cursor string) (trades []TradeHistory, next string, err error)
+
 // OrderDetail holds order detail data
 type OrderDetail struct {
-	Exchange      string
-	ID            string
-	BaseCurrency  string
-	QuoteCurrency string
-	OrderSide     string
-	OrderType     string
-	CreationTime  int64
-	Status        string
-	Price         float64
-	Amount        float64
-	OpenVolume    float64
+	Exchange        string
+	AccountID       string
+	ID              string
+	ClientOrderID   string
+	CurrencyPair    pair.CurrencyPair
+	OrderSide       OrderSide
+	OrderType       OrderType
+	OrderDate       time.Time
+	Status          string
+	Price           float64
+	Amount          float64
+	ExecutedAmount  float64
+	RemainingAmount float64
+	Fee             float64
+	FeeCurrency     string
+	Fills           []OrderFill
+}
+
+// OrderFill holds an individual execution which has (partially) filled an
+// order
+type OrderFill struct {
+	ID          string
+	Timestamp   time.Time
+	Price       float64
+	Amount      float64
+	Fee         float64
+	FeeCurrency string
+	IsMaker     bool
+}
+
+// GetOrdersRequest is used to retrieve and filter active or historic orders
+// from an exchange. Zero values for any field are treated as "match all"
+type GetOrdersRequest struct {
+	Currencies []pair.CurrencyPair
+	OrderSide  OrderSide
+	OrderType  OrderType
+	StartTicks time.Time
+	EndTicks   time.Time
 }
 
 // FundHistory holds exchange funding history data
@@ -208,6 +304,8 @@ type Base struct {
 	RESTPollingDelay                           time.Duration
 	AuthenticatedAPISupport                    bool
 	APIWithdrawPermissions                     uint32
+	OrderSubmissionFeatures                    uint32
+	Features                                   Features
 	APIAuthPEMKeySupport                       bool
 	APISecret, APIKey, APIAuthPEMKey, ClientID string
 	Nonce                                      nonce.Nonce
@@ -232,6 +330,13 @@ type Base struct {
 	*request.Requester
 }
 
+// Stopper is optionally implemented by exchanges which run background routines
+// that must be stopped when the exchange is unloaded. Wrappers of
+// IBotExchange forward Stop to the exchange they wrap
+type Stopper interface {
+	Stop()
+}
+
 // IBotExchange enforces standard functions for all exchanges supported in
 // GoCryptoTrader
 type IBotExchange interface {
@@ -251,7 +356,8 @@ type IBotExchange interface {
 	GetAccountInfo() (AccountInfo, error)
 	GetAuthenticatedAPISupport() bool
 	SetCurrencies(pairs []pair.CurrencyPair, enabledPairs bool) error
-	GetExchangeHistory(pair.CurrencyPair, string) ([]TradeHistory, error)
+	GetExchangeHistory(tradeHistoryRequest TradeHistoryRequest) ([]TradeHistory, error)
+	GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error)
 	SupportsAutoPairUpdates() bool
 	GetLastPairsUpdateTime() int64
 	SupportsRESTTickerBatchUpdates() bool
@@ -260,12 +366,21 @@ type IBotExchange interface {
 	FormatWithdrawPermissions() string
 	SupportsWithdrawPermissions(permissions uint32) bool
 
+	GetOrderSubmissionFeatures() uint32
+	FormatOrderSubmissionFeatures() string
+	SupportsOrderSubmissionFeatures(features uint32) bool
+
+	GetFeatures() Features
+	GetFeeByType(feeBuilder FeeBuilder) (float64, error)
+
 	GetFundingHistory() ([]FundHistory, error)
-	SubmitOrder(p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error)
+	SubmitOrder(order *OrderSubmission) (SubmitOrderResponse, error)
 	ModifyOrder(action ModifyOrder) (string, error)
 	CancelOrder(order OrderCancellation) error
 	CancelAllOrders(orders OrderCancellation) (CancelAllOrdersResponse, error)
 	GetOrderInfo(orderID int64) (OrderDetail, error)
+	GetActiveOrders(getOrdersRequest GetOrdersRequest) ([]OrderDetail, error)
+	GetOrderHistory(getOrdersRequest GetOrdersRequest) ([]OrderDetail, error)
 	GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error)
 
 	WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error)
@@ -529,6 +644,12 @@ func (e *Base) GetName() string {
 	return e.Name
 }
 
+// GetMakerTakerFees returns the exchange's default maker and taker fee
+// percentages
+func (e *Base) GetMakerTakerFees() (maker, taker float64) {
+	return e.MakerFee, e.TakerFee
+}
+
 // GetEnabledCurrencies is a method that returns the enabled currency pairs of
 // the exchange base
 func (e *Base) GetEnabledCurrencies() []pair.CurrencyPair {
@@ -657,15 +778,50 @@ func (e *Base) SetCurrencies(pairs []pair.CurrencyPair, enabledPairs bool) error
 			exchCfg.ConfigCurrencyPairFormat.Uppercase).String())
 	}
 
-	if enabledPairs {
-		exchCfg.EnabledPairs = common.JoinStrings(pairsStr, ",")
-		e.EnabledPairs = pairsStr
-	} else {
+	if !enabledPairs {
 		exchCfg.AvailablePairs = common.JoinStrings(pairsStr, ",")
 		e.AvailablePairs = pairsStr
+		return cfg.UpdateExchangeConfig(exchCfg)
+	}
+
+	if e.Websocket == nil || !e.Websocket.SupportsChannelSubscriptions() {
+		exchCfg.EnabledPairs = common.JoinStrings(pairsStr, ",")
+		e.EnabledPairs = pairsStr
+		return cfg.UpdateExchangeConfig(exchCfg)
+	}
+
+	oldPairs := e.GetEnabledCurrencies()
+	exchCfg.EnabledPairs = common.JoinStrings(pairsStr, ",")
+	e.EnabledPairs = pairsStr
+
+	err = cfg.UpdateExchangeConfig(exchCfg)
+	if err != nil {
+		return err
 	}
 
-	return cfg.UpdateExchangeConfig(exchCfg)
+	// Keep the websocket subscriptions in line with the enabled pairs
+	var removedPairs, newPairs []pair.CurrencyPair
+	for x := range oldPairs {
+		if !pair.Contains(pairs, oldPairs[x], true) {
+			removedPairs = append(removedPairs, oldPairs[x])
+		}
+	}
+	for x := range pairs {
+		if !pair.Contains(oldPairs, pairs[x], true) {
+			newPairs = append(newPairs, pairs[x])
+		}
+	}
+
+	err = e.Websocket.UnsubscribeChannels(e.GenerateSubscriptions(removedPairs))
+	if err != nil {
+		return fmt.Errorf("%s SetCurrencies error - unable to unsubscribe websocket channels: %s", e.Name, err)
+	}
+
+	err = e.Websocket.SubscribeToChannels(e.GenerateSubscriptions(newPairs))
+	if err != nil {
+		return fmt.Errorf("%s SetCurrencies error - unable to subscribe websocket channels: %s", e.Name, err)
+	}
+	return nil
 }
 
 // UpdateCurrencies updates the exchange currency pairs for either enabledPairs or
@@ -743,6 +899,27 @@ type ModifyOrder struct {
 	PostOnly          bool
 }
 
+// OrderSubmission contains the details of an order to be submitted to an
+// exchange
+type OrderSubmission struct {
+	CurrencyPair pair.CurrencyPair
+	OrderSide    OrderSide
+	OrderType    OrderType
+	Amount       float64
+	// Price is the limit price for Limit and StopLimit orders
+	Price float64
+	// TriggerPrice is the price which activates Stop and StopLimit orders
+	TriggerPrice float64
+	// TrailingAmount is the price offset followed by TrailingStop orders
+	TrailingAmount float64
+	TimeInForce    TimeInForce
+	ClientID       string
+
+	PostOnly    bool
+	ReduceOnly  bool
+	HiddenOrder bool
+}
+
 // ModifyOrderResponse is an order modifying return type
 type ModifyOrderResponse struct {
 	OrderID string
@@ -768,9 +945,14 @@ type OrderType string
 
 // OrderType ...types
 const (
+	AnyOrderType      OrderType = "ANY"
 	Limit             OrderType = "Limit"
 	Market            OrderType = "Market"
 	ImmediateOrCancel OrderType = "IMMEDIATE_OR_CANCEL"
+	Stop              OrderType = "Stop"
+	StopLimit         OrderType = "StopLimit"
+	TrailingStop      OrderType = "TrailingStop"
+	UnknownOrderType  OrderType = "UNKNOWN"
 )
 
 // ToString changes the ordertype to the exchange standard and returns a string
@@ -783,8 +965,10 @@ type OrderSide string
 
 // OrderSide types
 const (
-	Buy  OrderSide = "Buy"
-	Sell OrderSide = "Sell"
+	AnyOrderSide     OrderSide = "ANY"
+	Buy              OrderSide = "Buy"
+	Sell             OrderSide = "Sell"
+	UnknownOrderSide OrderSide = "UNKNOWN"
 )
 
 // ToString changes the ordertype to the exchange standard and returns a string
@@ -792,6 +976,285 @@ func (o OrderSide) ToString() string {
 	return fmt.Sprintf("%v", o)
 }
 
+// OrderStatus enforces a standard for order statuses across the code base
+type OrderStatus string
+
+// OrderStatus types
+const (
+	NewOrderStatus             OrderStatus = "NEW"
+	PartiallyFilledOrderStatus OrderStatus = "PARTIALLY_FILLED"
+	FilledOrderStatus          OrderStatus = "FILLED"
+	CancelledOrderStatus       OrderStatus = "CANCELLED"
+	RejectedOrderStatus        OrderStatus = "REJECTED"
+	UnknownOrderStatus         OrderStatus = "UNKNOWN"
+)
+
+// ToString changes the order status to the exchange standard and returns a
+// string
+func (o OrderStatus) ToString() string {
+	return fmt.Sprintf("%v", o)
+}
+
+// TimeInForce defines how long an order remains active before it is executed
+// or expires
+type TimeInForce string
+
+// TimeInForce types
+const (
+	GoodTillCancel TimeInForce = "GTC"
+	IOC            TimeInForce = "IOC"
+	FOK            TimeInForce = "FOK"
+)
+
+// FormatOrderSide converts an exchange specific order side string such as
+// "bid", "BUY" or "sell" into the standard OrderSide type
+func FormatOrderSide(side string) OrderSide {
+	switch common.StringToLower(side) {
+	case "buy", "bid", "b":
+		return Buy
+	case "sell", "ask", "s":
+		return Sell
+	default:
+		return UnknownOrderSide
+	}
+}
+
+// FormatOrderType converts an exchange specific order type string such as
+// "limit" or "MARKET" into the standard OrderType type
+func FormatOrderType(orderType string) OrderType {
+	switch common.StringToLower(orderType) {
+	case "limit", "exchange limit":
+		return Limit
+	case "market", "exchange market":
+		return Market
+	case "immediate_or_cancel", "ioc", "immediate-or-cancel":
+		return ImmediateOrCancel
+	default:
+		return UnknownOrderType
+	}
+}
+
+// FormatOrderStatus converts an exchange specific order status string such as
+// "open", "Filled" or "canceled" into the standard OrderStatus type
+func FormatOrderStatus(status string) OrderStatus {
+	switch common.StringToLower(status) {
+	case "new", "open", "active", "received", "pending", "pendingnew", "suspended":
+		return NewOrderStatus
+	case "partiallyfilled", "partially_filled", "partially filled", "partial":
+		return PartiallyFilledOrderStatus
+	case "filled", "executed":
+		return FilledOrderStatus
+	case "canceled", "cancelled", "expired", "doneforday":
+		return CancelledOrderStatus
+	case "rejected":
+		return RejectedOrderStatus
+	default:
+		return UnknownOrderStatus
+	}
+}
+
+// GetOrderDetail returns the state of a placed order. The order is looked up
+// in the exchange's order history, then its active orders and then by ID, so
+// that fills can be confirmed on exchanges which do not implement every order
+// function. The errors of the failed lookups are returned if none succeed
+func GetOrderDetail(exch IBotExchange, orderID string, p pair.CurrencyPair) (OrderDetail, error) {
+	req := GetOrdersRequest{Currencies: []pair.CurrencyPair{p}}
+	var errs []string
+	for _, get := range []func(GetOrdersRequest) ([]OrderDetail, error){
+		exch.GetOrderHistory, exch.GetActiveOrders,
+	} {
+		orders, err := get(req)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		for i := range orders {
+			if orders[i].ID == orderID {
+				return orders[i], nil
+			}
+		}
+	}
+
+	if id, err := strconv.ParseInt(orderID, 10, 64); err == nil {
+		detail, err := exch.GetOrderInfo(id)
+		if err == nil {
+			if detail.ID == "" {
+				detail.ID = orderID
+			}
+			return detail, nil
+		}
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return OrderDetail{}, fmt.Errorf("%s: %s", ErrOrderNotFound, strings.Join(errs, ", "))
+	}
+	return OrderDetail{}, ErrOrderNotFound
+}
+
+// FilterOrders applies every filter in the supplied request to a list of
+// orders and returns the orders which match
+func FilterOrders(orders []OrderDetail, getOrdersRequest GetOrdersRequest) []OrderDetail {
+	FilterOrdersBySide(&orders, getOrdersRequest.OrderSide)
+	FilterOrdersByType(&orders, getOrdersRequest.OrderType)
+	FilterOrdersByTickRange(&orders, getOrdersRequest.StartTicks, getOrdersRequest.EndTicks)
+	FilterOrdersByCurrencies(&orders, getOrdersRequest.Currencies)
+	return orders
+}
+
+// FilterOrdersBySide removes any OrderDetails that don't match the order side
+// provided. An empty or AnyOrderSide side will not filter any orders
+func FilterOrdersBySide(orders *[]OrderDetail, orderSide OrderSide) {
+	if orderSide == "" || orderSide == AnyOrderSide {
+		return
+	}
+
+	var filteredOrders []OrderDetail
+	for i := range *orders {
+		if common.StringToLower((*orders)[i].OrderSide.ToString()) == common.StringToLower(orderSide.ToString()) {
+			filteredOrders = append(filteredOrders, (*orders)[i])
+		}
+	}
+	*orders = filteredOrders
+}
+
+// FilterOrdersByType removes any OrderDetails that don't match the order type
+// provided. An empty or AnyOrderType type will not filter any orders
+func FilterOrdersByType(orders *[]OrderDetail, orderType OrderType) {
+	if orderType == "" || orderType == AnyOrderType {
+		return
+	}
+
+	var filteredOrders []OrderDetail
+	for i := range *orders {
+		if common.StringToLower((*orders)[i].OrderType.ToString()) == common.StringToLower(orderType.ToString()) {
+			filteredOrders = append(filteredOrders, (*orders)[i])
+		}
+	}
+	*orders = filteredOrders
+}
+
+// FilterOrdersByTickRange removes any OrderDetails outside of the time range
+// provided. A zero start or end time leaves that side of the range open
+func FilterOrdersByTickRange(orders *[]OrderDetail, startTicks, endTicks time.Time) {
+	if startTicks.IsZero() && endTicks.IsZero() {
+		return
+	}
+
+	var filteredOrders []OrderDetail
+	for i := range *orders {
+		orderDate := (*orders)[i].OrderDate
+		if !startTicks.IsZero() && orderDate.Before(startTicks) {
+			continue
+		}
+		if !endTicks.IsZero() && orderDate.After(endTicks) {
+			continue
+		}
+		filteredOrders = append(filteredOrders, (*orders)[i])
+	}
+	*orders = filteredOrders
+}
+
+// FilterOrdersByCurrencies removes any OrderDetails that do not match the
+// provided currency list. An empty currency list will not filter any orders
+func FilterOrdersByCurrencies(orders *[]OrderDetail, currencies []pair.CurrencyPair) {
+	if len(currencies) == 0 {
+		return
+	}
+
+	var filteredOrders []OrderDetail
+	for i := range *orders {
+		if pair.Contains(currencies, (*orders)[i].CurrencyPair, false) {
+			filteredOrders = append(filteredOrders, (*orders)[i])
+		}
+	}
+	*orders = filteredOrders
+}
+
+// Validate checks a trade history request for a currency pair and a valid time
+// range
+func (t *TradeHistoryRequest) Validate() error {
+	if t.CurrencyPair.FirstCurrency == "" || t.CurrencyPair.SecondCurrency == "" {
+		return ErrTradeHistoryPairIsEmpty
+	}
+
+	if !t.StartTime.IsZero() && !t.EndTime.IsZero() && !t.StartTime.Before(t.EndTime) {
+		return ErrTradeHistoryInvalidTimeRange
+	}
+	return nil
+}
+
+// PaginateTradeHistory repeatedly calls fetch, starting at cursor, until the
+// exchange stops returning new trades or a trade at or after the request end
+// time is received. The returned trades are sorted by time, stripped of
+// duplicates and filtered to the requested time range
+func PaginateTradeHistory(tradeHistoryRequest TradeHistoryRequest, cursor string, fetch TradeHistoryFetchFunc) ([]TradeHistory, error) {
+	var trades []TradeHistory
+	for {
+		resp, next, err := fetch(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		trades = append(trades, resp...)
+		if len(resp) == 0 || next == "" || next == cursor {
+			break
+		}
+
+		if !tradeHistoryRequest.EndTime.IsZero() &&
+			!resp[len(resp)-1].Timestamp.Before(tradeHistoryRequest.EndTime) {
+			break
+		}
+		cursor = next
+	}
+
+	SortTradeHistory(&trades)
+	FilterTradeHistoryByTimeRange(&trades, tradeHistoryRequest.StartTime, tradeHistoryRequest.EndTime)
+	return trades, nil
+}
+
+// SortTradeHistory sorts trades by time and removes any duplicate trade IDs
+func SortTradeHistory(trades *[]TradeHistory) {
+	sort.SliceStable(*trades, func(i, j int) bool {
+		return (*trades)[i].Timestamp.Before((*trades)[j].Timestamp)
+	})
+
+	seen := make(map[string]bool)
+	var filteredTrades []TradeHistory
+	for i := range *trades {
+		if tid := (*trades)[i].TID; tid != "" {
+			if seen[tid] {
+				continue
+			}
+			seen[tid] = true
+		}
+		filteredTrades = append(filteredTrades, (*trades)[i])
+	}
+	*trades = filteredTrades
+}
+
+// FilterTradeHistoryByTimeRange removes any trades outside of the time range
+// provided. A zero start or end time leaves that side of the range open, the
+// end time is exclusive
+func FilterTradeHistoryByTimeRange(trades *[]TradeHistory, startTime, endTime time.Time) {
+	if startTime.IsZero() && endTime.IsZero() {
+		return
+	}
+
+	var filteredTrades []TradeHistory
+	for i := range *trades {
+		timestamp := (*trades)[i].Timestamp
+		if !startTime.IsZero() && timestamp.Before(startTime) {
+			continue
+		}
+		if !endTime.IsZero() && !timestamp.Before(endTime) {
+			continue
+		}
+		filteredTrades = append(filteredTrades, (*trades)[i])
+	}
+	*trades = filteredTrades
+}
+
 // SetAPIURL sets configuration API URL for an exchange
 func (e *Base) SetAPIURL(ec config.ExchangeConfig) error {
 	if ec.APIURL == "" || ec.APIURLSecondary == "" {
@@ -894,3 +1357,164 @@ func (e *Base) FormatWithdrawPermissions() string {
 
 	return NoAPIWithdrawalMethodsText
 }
+
+// GetOrderSubmissionFeatures passes through the exchange's supported order
+// submission features
+func (e *Base) GetOrderSubmissionFeatures() uint32 {
+	return e.OrderSubmissionFeatures
+}
+
+// SupportsOrderSubmissionFeatures compares the supplied features with the
+// exchange's to verify they're supported
+func (e *Base) SupportsOrderSubmissionFeatures(features uint32) bool {
+	return features&e.GetOrderSubmissionFeatures() == features
+}
+
+// FormatOrderSubmissionFeatures returns a string definition of the order
+// submission features supported by the exchange
+func (e *Base) FormatOrderSubmissionFeatures() string {
+	return formatOrderSubmissionFeatures(e.GetOrderSubmissionFeatures())
+}
+
+func formatOrderSubmissionFeatures(features uint32) string {
+	var supported []string
+	for i := 0; i < 32; i++ {
+		var check uint32 = 1 << uint32(i)
+		if features&check != 0 {
+			switch check {
+			case LimitOrderSupport:
+				supported = append(supported, LimitOrderSupportText)
+			case MarketOrderSupport:
+				supported = append(supported, MarketOrderSupportText)
+			case StopOrderSupport:
+				supported = append(supported, StopOrderSupportText)
+			case StopLimitOrderSupport:
+				supported = append(supported, StopLimitOrderSupportText)
+			case TrailingStopOrderSupport:
+				supported = append(supported, TrailingStopOrderSupportText)
+			case PostOnlyOrderSupport:
+				supported = append(supported, PostOnlyOrderSupportText)
+			case ReduceOnlyOrderSupport:
+				supported = append(supported, ReduceOnlyOrderSupportText)
+			case HiddenOrderSupport:
+				supported = append(supported, HiddenOrderSupportText)
+			case ImmediateOrCancelOrderSupport:
+				supported = append(supported, ImmediateOrCancelOrderSupportText)
+			case FillOrKillOrderSupport:
+				supported = append(supported, FillOrKillOrderSupportText)
+			default:
+				supported = append(supported, fmt.Sprintf("%s[1<<%v]", UnknownOrderSubmissionFeatureText, i))
+			}
+		}
+	}
+	if len(supported) > 0 {
+		return strings.Join(supported, " & ")
+	}
+
+	return NoOrderSubmissionFeaturesText
+}
+
+// GetRequiredFeatures returns the order submission features an exchange must
+// support to accept the order
+func (o *OrderSubmission) GetRequiredFeatures() uint32 {
+	var features uint32
+	switch o.OrderType {
+	case Limit:
+		features |= LimitOrderSupport
+	case Market:
+		features |= MarketOrderSupport
+	case ImmediateOrCancel:
+		features |= LimitOrderSupport | ImmediateOrCancelOrderSupport
+	case Stop:
+		features |= StopOrderSupport
+	case StopLimit:
+		features |= StopLimitOrderSupport
+	case TrailingStop:
+		features |= TrailingStopOrderSupport
+	}
+
+	switch o.TimeInForce {
+	case IOC:
+		features |= ImmediateOrCancelOrderSupport
+	case FOK:
+		features |= FillOrKillOrderSupport
+	}
+
+	if o.PostOnly {
+		features |= PostOnlyOrderSupport
+	}
+	if o.ReduceOnly {
+		features |= ReduceOnlyOrderSupport
+	}
+	if o.HiddenOrder {
+		features |= HiddenOrderSupport
+	}
+	return features
+}
+
+// Validate checks the order submission is well formed and only uses the
+// supplied supported features. It is called by exchange wrappers before any
+// request is sent
+func (o *OrderSubmission) Validate(supportedFeatures uint32) error {
+	if o == nil {
+		return ErrOrderSubmissionIsNil
+	}
+
+	if o.CurrencyPair.FirstCurrency == "" || o.CurrencyPair.SecondCurrency == "" {
+		return ErrOrderSubmissionPairIsEmpty
+	}
+
+	if o.OrderSide != Buy && o.OrderSide != Sell {
+		return fmt.Errorf("invalid order side %q", o.OrderSide)
+	}
+
+	if o.Amount <= 0 {
+		return ErrOrderSubmissionAmountInvalid
+	}
+
+	switch o.OrderType {
+	case Limit, ImmediateOrCancel:
+		if o.Price <= 0 {
+			return fmt.Errorf("%s orders require a price", o.OrderType)
+		}
+	case Market:
+		if o.PostOnly {
+			return errors.New("market orders cannot be post only")
+		}
+	case Stop:
+		if o.TriggerPrice <= 0 {
+			return fmt.Errorf("%s orders require a trigger price", o.OrderType)
+		}
+	case StopLimit:
+		if o.TriggerPrice <= 0 || o.Price <= 0 {
+			return fmt.Errorf("%s orders require a price and trigger price", o.OrderType)
+		}
+	case TrailingStop:
+		if o.TrailingAmount <= 0 {
+			return fmt.Errorf("%s orders require a trailing amount", o.OrderType)
+		}
+	default:
+		return fmt.Errorf("invalid order type %q", o.OrderType)
+	}
+
+	switch o.TimeInForce {
+	case "", GoodTillCancel:
+	case IOC, FOK:
+		if o.PostOnly {
+			return fmt.Errorf("post only orders cannot use time in force %s", o.TimeInForce)
+		}
+	default:
+		return fmt.Errorf("invalid time in force %q", o.TimeInForce)
+	}
+
+	if o.OrderType == ImmediateOrCancel && o.PostOnly {
+		return errors.New("immediate or cancel orders cannot be post only")
+	}
+
+	if unsupported := o.GetRequiredFeatures() &^ supportedFeatures; unsupported != 0 {
+		return fmt.Errorf("%s: %s",
+			ErrOrderSubmissionFeatureNotSupported,
+			formatOrderSubmissionFeatures(unsupported))
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package exchange
+
+// Websocket subscription channels which can be listed in an exchange's
+// features
+const (
+	TickerChannel    = "ticker"
+	OrderbookChannel = "orderbook"
+	TradeChannel     = "trade"
+	KlineChannel     = "kline"
+)
+
+// Text definitions of feature support
+const (
+	NoFeatureSupportText               = "NONE"
+	RESTFeatureSupportText             = "REST"
+	WebsocketFeatureSupportText        = "WEBSOCKET"
+	RESTAndWebsocketFeatureSupportText = "REST & WEBSOCKET"
+)
+
+// Feature names used when listing exchange features
+const (
+	TickerFetchingFeature    = "Ticker fetching"
+	OrderbookFetchingFeature = "Orderbook fetching"
+	TradeFetchingFeature     = "Trade fetching"
+	KlineFetchingFeature     = "Kline fetching"
+	SubmitOrderFeature       = "Order submission"
+	ModifyOrderFeature       = "Order modification"
+	CancelOrderFeature       = "Order cancellation"
+	AccountInfoFeature       = "Account info"
+	DepositAddressesFeature  = "Deposit addresses"
+	WithdrawalsFeature       = "Withdrawals"
+	FeeFetchingFeature       = "Fee fetching"
+)
+
+// FeatureSupport defines whether a feature is available over an exchange's
+// REST API, its websocket connection or both
+type FeatureSupport struct {
+	REST      bool `json:"rest"`
+	Websocket bool `json:"websocket"`
+}
+
+// Features describes the REST and websocket support of an exchange for each
+// feature reachable through IBotExchange
+type Features struct {
+	TickerFetching    FeatureSupport `json:"tickerFetching"`
+	OrderbookFetching FeatureSupport `json:"orderbookFetching"`
+	TradeFetching     FeatureSupport `json:"tradeFetching"`
+	KlineFetching     FeatureSupport `json:"klineFetching"`
+	SubmitOrder       FeatureSupport `json:"submitOrder"`
+	ModifyOrder       FeatureSupport `json:"modifyOrder"`
+	CancelOrder       FeatureSupport `json:"cancelOrder"`
+	AccountInfo       FeatureSupport `json:"accountInfo"`
+	DepositAddresses  FeatureSupport `json:"depositAddresses"`
+	Withdrawals       FeatureSupport `json:"withdrawals"`
+	FeeFetching       FeatureSupport `json:"feeFetching"`
+	Subscriptions     []string       `json:"subscriptions"`
+}
+
+// FeatureListing pairs a feature name with its support
+type FeatureListing struct {
+	Name    string         `json:"name"`
+	Support FeatureSupport `json:"support"`
+}
+
+// IsSupported returns whether or not the feature is available over REST or
+// websocket
+func (f FeatureSupport) IsSupported() bool {
+	return f.REST || f.Websocket
+}
+
+// String returns a readable definition of the feature support
+func (f FeatureSupport) String() string {
+	switch {
+	case f.REST && f.Websocket:
+		return RESTAndWebsocketFeatureSupportText
+	case f.REST:
+		return RESTFeatureSupportText
+	case f.Websocket:
+		return WebsocketFeatureSupportText
+	}
+	return NoFeatureSupportText
+}
+
+// List returns every feature and its support in a fixed order
+func (f *Features) List() []FeatureListing {
+	return []FeatureListing{
+		{TickerFetchingFeature, f.TickerFetching},
+		{OrderbookFetchingFeature, f.OrderbookFetching},
+		{TradeFetchingFeature, f.TradeFetching},
+		{KlineFetchingFeature, f.KlineFetching},
+		{SubmitOrderFeature, f.SubmitOrder},
+		{ModifyOrderFeature, f.ModifyOrder},
+		{CancelOrderFeature, f.CancelOrder},
+		{AccountInfoFeature, f.AccountInfo},
+		{DepositAddressesFeature, f.DepositAddresses},
+		{WithdrawalsFeature, f.Withdrawals},
+		{FeeFetchingFeature, f.FeeFetching},
+	}
+}
+
+// SupportsSubscription returns whether or not the exchange websocket
+// subscribes to the supplied channel
+func (f *Features) SupportsSubscription(channel string) bool {
+	for x := range f.Subscriptions {
+		if f.Subscriptions[x] == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFeatures returns the REST and websocket features supported by the
+// exchange
+func (e *Base) GetFeatures() Features {
+	return e.Features
+}
@@ -0,0 +1,52 @@
+package exchange
+
+import "testing"
+
+func TestFeatureSupportString(t *testing.T) {
+	tests := map[FeatureSupport]string{
+		{}:                            NoFeatureSupportText,
+		{REST: true}:                  RESTFeatureSupportText,
+		{Websocket: true}:             WebsocketFeatureSupportText,
+		{REST: true, Websocket: true}: RESTAndWebsocketFeatureSupportText,
+	}
+
+	for support, expected := range tests {
+		if support.String() != expected {
+			t.Errorf("Expected: %s, Received: %s", expected, support.String())
+		}
+		if support.IsSupported() != (expected != NoFeatureSupportText) {
+			t.Errorf("Test failed - IsSupported() unexpected result for %s", expected)
+		}
+	}
+}
+
+func TestGetFeatures(t *testing.T) {
+	b := Base{Name: "TESTNAME"}
+	b.Features = Features{
+		TickerFetching: FeatureSupport{REST: true, Websocket: true},
+		SubmitOrder:    FeatureSupport{REST: true},
+		Subscriptions:  []string{TickerChannel},
+	}
+
+	features := b.GetFeatures()
+	list := features.List()
+	if len(list) != 11 {
+		t.Fatalf("Test failed - List() expected 11 features, received %d", len(list))
+	}
+	if list[0].Name != TickerFetchingFeature || !list[0].Support.Websocket {
+		t.Errorf("Test failed - List() unexpected ticker feature %+v", list[0])
+	}
+	if list[4].Name != SubmitOrderFeature || !list[4].Support.REST {
+		t.Errorf("Test failed - List() unexpected order submission feature %+v", list[4])
+	}
+	if list[5].Support.IsSupported() {
+		t.Errorf("Test failed - List() unexpected order modification support")
+	}
+
+	if !features.SupportsSubscription(TickerChannel) {
+		t.Error("Test failed - SupportsSubscription() expected ticker support")
+	}
+	if features.SupportsSubscription(OrderbookChannel) {
+		t.Error("Test failed - SupportsSubscription() unexpected orderbook support")
+	}
+}
@@ -1,13 +1,16 @@
 package exchange
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -386,6 +389,18 @@ func TestGetName(t *testing.T) {
 	}
 }
 
+func TestGetMakerTakerFees(t *testing.T) {
+	b := Base{
+		MakerFee: 0.1,
+		TakerFee: 0.2,
+	}
+
+	maker, taker := b.GetMakerTakerFees()
+	if maker != 0.1 || taker != 0.2 {
+		t.Error("Test Failed - Exchange GetMakerTakerFees() returned incorrect fees")
+	}
+}
+
 func TestGetEnabledCurrencies(t *testing.T) {
 	b := Base{
 		Name: "TESTNAME",
@@ -928,3 +943,374 @@ func TestOrderTypes(t *testing.T) {
 		t.Errorf("test failed - unexpected string %s", os.ToString())
 	}
 }
+
+func TestFormatOrderSideAndType(t *testing.T) {
+	if FormatOrderSide("BID") != Buy {
+		t.Error("Test failed - FormatOrderSide() unexpected result")
+	}
+	if FormatOrderSide("ask") != Sell {
+		t.Error("Test failed - FormatOrderSide() unexpected result")
+	}
+	if FormatOrderSide("whoops") != UnknownOrderSide {
+		t.Error("Test failed - FormatOrderSide() unexpected result")
+	}
+	if FormatOrderType("LIMIT") != Limit {
+		t.Error("Test failed - FormatOrderType() unexpected result")
+	}
+	if FormatOrderType("exchange market") != Market {
+		t.Error("Test failed - FormatOrderType() unexpected result")
+	}
+	if FormatOrderType("whoops") != UnknownOrderType {
+		t.Error("Test failed - FormatOrderType() unexpected result")
+	}
+}
+
+func TestFormatOrderStatus(t *testing.T) {
+	tests := map[string]OrderStatus{
+		"open":            NewOrderStatus,
+		"PartiallyFilled": PartiallyFilledOrderStatus,
+		"Filled":          FilledOrderStatus,
+		"canceled":        CancelledOrderStatus,
+		"Rejected":        RejectedOrderStatus,
+		"whoops":          UnknownOrderStatus,
+	}
+	for status, expected := range tests {
+		if FormatOrderStatus(status) != expected {
+			t.Errorf("Test failed - FormatOrderStatus() %s expected %s, received %s",
+				status, expected, FormatOrderStatus(status))
+		}
+	}
+}
+
+func TestFilterOrdersBySide(t *testing.T) {
+	orders := []OrderDetail{
+		{OrderSide: Buy},
+		{OrderSide: Sell},
+		{},
+	}
+
+	FilterOrdersBySide(&orders, Buy)
+	if len(orders) != 1 {
+		t.Errorf("Test failed - expected 1 order, received %v", len(orders))
+	}
+
+	orders = []OrderDetail{{OrderSide: Buy}, {OrderSide: Sell}}
+	FilterOrdersBySide(&orders, AnyOrderSide)
+	if len(orders) != 2 {
+		t.Errorf("Test failed - expected 2 orders, received %v", len(orders))
+	}
+}
+
+func TestFilterOrdersByType(t *testing.T) {
+	orders := []OrderDetail{
+		{OrderType: Limit},
+		{OrderType: Market},
+		{OrderType: "limit"},
+	}
+
+	FilterOrdersByType(&orders, Limit)
+	if len(orders) != 2 {
+		t.Errorf("Test failed - expected 2 orders, received %v", len(orders))
+	}
+
+	FilterOrdersByType(&orders, "")
+	if len(orders) != 2 {
+		t.Errorf("Test failed - expected 2 orders, received %v", len(orders))
+	}
+}
+
+func TestFilterOrdersByTickRange(t *testing.T) {
+	orders := []OrderDetail{
+		{OrderDate: time.Unix(100, 0)},
+		{OrderDate: time.Unix(110, 0)},
+		{OrderDate: time.Unix(111, 0)},
+	}
+
+	FilterOrdersByTickRange(&orders, time.Unix(0, 0), time.Unix(110, 0))
+	if len(orders) != 2 {
+		t.Errorf("Test failed - expected 2 orders, received %v", len(orders))
+	}
+
+	FilterOrdersByTickRange(&orders, time.Unix(105, 0), time.Time{})
+	if len(orders) != 1 {
+		t.Errorf("Test failed - expected 1 order, received %v", len(orders))
+	}
+
+	FilterOrdersByTickRange(&orders, time.Time{}, time.Time{})
+	if len(orders) != 1 {
+		t.Errorf("Test failed - expected 1 order, received %v", len(orders))
+	}
+}
+
+func TestFilterOrdersByCurrencies(t *testing.T) {
+	orders := []OrderDetail{
+		{CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.USD)},
+		{CurrencyPair: pair.NewCurrencyPair(symbol.LTC, symbol.EUR)},
+		{CurrencyPair: pair.NewCurrencyPair(symbol.DOGE, symbol.RUB)},
+	}
+
+	FilterOrdersByCurrencies(&orders, []pair.CurrencyPair{
+		pair.NewCurrencyPair(symbol.BTC, symbol.USD),
+		pair.NewCurrencyPair(symbol.LTC, symbol.EUR),
+	})
+	if len(orders) != 2 {
+		t.Errorf("Test failed - expected 2 orders, received %v", len(orders))
+	}
+
+	FilterOrdersByCurrencies(&orders, nil)
+	if len(orders) != 2 {
+		t.Errorf("Test failed - expected 2 orders, received %v", len(orders))
+	}
+}
+
+func TestFilterOrders(t *testing.T) {
+	orders := []OrderDetail{
+		{
+			OrderSide:    Buy,
+			OrderType:    Limit,
+			OrderDate:    time.Unix(100, 0),
+			CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.USD),
+		},
+		{
+			OrderSide:    Sell,
+			OrderType:    Limit,
+			OrderDate:    time.Unix(100, 0),
+			CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.USD),
+		},
+		{
+			OrderSide:    Buy,
+			OrderType:    Market,
+			OrderDate:    time.Unix(200, 0),
+			CurrencyPair: pair.NewCurrencyPair(symbol.LTC, symbol.USD),
+		},
+	}
+
+	result := FilterOrders(orders, GetOrdersRequest{
+		OrderSide:  Buy,
+		OrderType:  Limit,
+		StartTicks: time.Unix(50, 0),
+		Currencies: []pair.CurrencyPair{pair.NewCurrencyPair(symbol.BTC, symbol.USD)},
+	})
+	if len(result) != 1 {
+		t.Errorf("Test failed - expected 1 order, received %v", len(result))
+	}
+
+	result = FilterOrders(orders, GetOrdersRequest{})
+	if len(result) != 3 {
+		t.Errorf("Test failed - expected 3 orders, received %v", len(result))
+	}
+}
+
+func TestSupportsOrderSubmissionFeatures(t *testing.T) {
+	UAC := Base{Name: "ANX"}
+	UAC.OrderSubmissionFeatures = LimitOrderSupport | MarketOrderSupport
+
+	if !UAC.SupportsOrderSubmissionFeatures(LimitOrderSupport) {
+		t.Error("Test failed - SupportsOrderSubmissionFeatures() expected limit support")
+	}
+
+	if !UAC.SupportsOrderSubmissionFeatures(LimitOrderSupport | MarketOrderSupport) {
+		t.Error("Test failed - SupportsOrderSubmissionFeatures() expected limit and market support")
+	}
+
+	if UAC.SupportsOrderSubmissionFeatures(LimitOrderSupport | PostOnlyOrderSupport) {
+		t.Error("Test failed - SupportsOrderSubmissionFeatures() unexpected post only support")
+	}
+}
+
+func TestFormatOrderSubmissionFeatures(t *testing.T) {
+	UAC := Base{Name: "ANX"}
+	if features := UAC.FormatOrderSubmissionFeatures(); features != NoOrderSubmissionFeaturesText {
+		t.Errorf("Expected: %s, Received: %s", NoOrderSubmissionFeaturesText, features)
+	}
+
+	UAC.OrderSubmissionFeatures = LimitOrderSupport |
+		MarketOrderSupport |
+		StopOrderSupport |
+		StopLimitOrderSupport |
+		TrailingStopOrderSupport |
+		PostOnlyOrderSupport |
+		ReduceOnlyOrderSupport |
+		HiddenOrderSupport |
+		ImmediateOrCancelOrderSupport |
+		FillOrKillOrderSupport |
+		1<<18
+	expected := "LIMIT & MARKET & STOP & STOP LIMIT & TRAILING STOP & POST ONLY & REDUCE ONLY & HIDDEN & IMMEDIATE OR CANCEL & FILL OR KILL & UNKNOWN[1<<18]"
+	if features := UAC.FormatOrderSubmissionFeatures(); features != expected {
+		t.Errorf("Expected: %s, Received: %s", expected, features)
+	}
+}
+
+func TestOrderSubmissionValidate(t *testing.T) {
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+	supported := LimitOrderSupport | MarketOrderSupport | StopLimitOrderSupport | ImmediateOrCancelOrderSupport
+
+	var order *OrderSubmission
+	if err := order.Validate(supported); err != ErrOrderSubmissionIsNil {
+		t.Errorf("Expected: %v, Received: %v", ErrOrderSubmissionIsNil, err)
+	}
+
+	tests := []struct {
+		order OrderSubmission
+		valid bool
+	}{
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: Limit, Amount: 1, Price: 100}, true},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Sell, OrderType: Market, Amount: 1}, true},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: StopLimit, Amount: 1, Price: 100, TriggerPrice: 99}, true},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: Limit, Amount: 1, Price: 100, TimeInForce: IOC}, true},
+		{OrderSubmission{OrderSide: Buy, OrderType: Limit, Amount: 1, Price: 100}, false},
+		{OrderSubmission{CurrencyPair: p, OrderSide: AnyOrderSide, OrderType: Limit, Amount: 1, Price: 100}, false},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: Limit, Price: 100}, false},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: Limit, Amount: 1}, false},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: StopLimit, Amount: 1, Price: 100}, false},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: UnknownOrderType, Amount: 1}, false},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: Limit, Amount: 1, Price: 100, TimeInForce: "GTD"}, false},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: Market, Amount: 1, PostOnly: true}, false},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: Limit, Amount: 1, Price: 100, PostOnly: true, TimeInForce: IOC}, false},
+		// Well formed but not supported by the exchange
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: Stop, Amount: 1, TriggerPrice: 100}, false},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: Limit, Amount: 1, Price: 100, PostOnly: true}, false},
+		{OrderSubmission{CurrencyPair: p, OrderSide: Buy, OrderType: Limit, Amount: 1, Price: 100, TimeInForce: FOK}, false},
+	}
+
+	for x := range tests {
+		err := tests[x].order.Validate(supported)
+		if tests[x].valid && err != nil {
+			t.Errorf("Test %d failed - Validate() unexpected error: %s", x, err)
+		}
+		if !tests[x].valid && err == nil {
+			t.Errorf("Test %d failed - Validate() expected error", x)
+		}
+	}
+}
+
+func TestGetRequiredFeatures(t *testing.T) {
+	order := OrderSubmission{
+		OrderType:   ImmediateOrCancel,
+		TimeInForce: FOK,
+		PostOnly:    true,
+		ReduceOnly:  true,
+		HiddenOrder: true,
+	}
+	expected := LimitOrderSupport | ImmediateOrCancelOrderSupport | FillOrKillOrderSupport |
+		PostOnlyOrderSupport | ReduceOnlyOrderSupport | HiddenOrderSupport
+	if features := order.GetRequiredFeatures(); features != expected {
+		t.Errorf("Expected: %d, Received: %d", expected, features)
+	}
+}
+
+func TestTradeHistoryRequestValidate(t *testing.T) {
+	request := TradeHistoryRequest{}
+	if err := request.Validate(); err != ErrTradeHistoryPairIsEmpty {
+		t.Errorf("Expected: %v, Received: %v", ErrTradeHistoryPairIsEmpty, err)
+	}
+
+	request.CurrencyPair = pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+	if err := request.Validate(); err != nil {
+		t.Errorf("Expected: nil, Received: %v", err)
+	}
+
+	request.StartTime = time.Unix(110, 0)
+	request.EndTime = time.Unix(100, 0)
+	if err := request.Validate(); err != ErrTradeHistoryInvalidTimeRange {
+		t.Errorf("Expected: %v, Received: %v", ErrTradeHistoryInvalidTimeRange, err)
+	}
+}
+
+func TestSortTradeHistory(t *testing.T) {
+	trades := []TradeHistory{
+		{TID: "3", Timestamp: time.Unix(102, 0)},
+		{TID: "1", Timestamp: time.Unix(100, 0)},
+		{TID: "2", Timestamp: time.Unix(101, 0)},
+		{TID: "1", Timestamp: time.Unix(100, 0)},
+		{Timestamp: time.Unix(101, 0)},
+		{Timestamp: time.Unix(101, 0)},
+	}
+
+	SortTradeHistory(&trades)
+	if len(trades) != 5 {
+		t.Fatalf("Test failed - expected 5 trades, received %v", len(trades))
+	}
+	if trades[0].TID != "1" || trades[1].TID != "2" || trades[4].TID != "3" {
+		t.Errorf("Test failed - unexpected trade order %+v", trades)
+	}
+}
+
+func TestFilterTradeHistoryByTimeRange(t *testing.T) {
+	trades := []TradeHistory{
+		{Timestamp: time.Unix(100, 0)},
+		{Timestamp: time.Unix(110, 0)},
+		{Timestamp: time.Unix(111, 0)},
+	}
+
+	FilterTradeHistoryByTimeRange(&trades, time.Unix(0, 0), time.Unix(111, 0))
+	if len(trades) != 2 {
+		t.Errorf("Test failed - expected 2 trades, received %v", len(trades))
+	}
+
+	FilterTradeHistoryByTimeRange(&trades, time.Unix(105, 0), time.Time{})
+	if len(trades) != 1 {
+		t.Errorf("Test failed - expected 1 trade, received %v", len(trades))
+	}
+
+	FilterTradeHistoryByTimeRange(&trades, time.Time{}, time.Time{})
+	if len(trades) != 1 {
+		t.Errorf("Test failed - expected 1 trade, received %v", len(trades))
+	}
+}
+
+func TestPaginateTradeHistory(t *testing.T) {
+	request := TradeHistoryRequest{
+		CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.USD),
+		StartTime:    time.Unix(100, 0),
+		EndTime:      time.Unix(125, 0),
+	}
+
+	var cursors []string
+	fetch := func(cursor string) ([]TradeHistory, string, error) {
+		cursors = append(cursors, cursor)
+		from, _ := strconv.ParseInt(cursor, 10, 64)
+		var trades []TradeHistory
+		for x := from; x < from+10; x++ {
+			trades = append(trades, TradeHistory{
+				TID:       strconv.FormatInt(x, 10),
+				Timestamp: time.Unix(x, 0),
+			})
+		}
+		// Overlap pages by a single trade to ensure duplicates are removed
+		return trades, strconv.FormatInt(from+9, 10), nil
+	}
+
+	trades, err := PaginateTradeHistory(request, "95", fetch)
+	if err != nil {
+		t.Fatalf("Test failed - PaginateTradeHistory() error: %s", err)
+	}
+	if len(cursors) != 4 {
+		t.Errorf("Test failed - expected 4 pages, received %v", len(cursors))
+	}
+	if len(trades) != 25 || trades[0].TID != "100" || trades[24].TID != "124" {
+		t.Errorf("Test failed - unexpected trades %+v", trades)
+	}
+
+	cursors = nil
+	_, err = PaginateTradeHistory(TradeHistoryRequest{}, "0", func(cursor string) ([]TradeHistory, string, error) {
+		cursors = append(cursors, cursor)
+		if len(cursors) > 1 {
+			return nil, cursor, nil
+		}
+		return []TradeHistory{{TID: "1"}}, "1", nil
+	})
+	if err != nil {
+		t.Fatalf("Test failed - PaginateTradeHistory() error: %s", err)
+	}
+	if len(cursors) != 2 {
+		t.Errorf("Test failed - expected 2 pages, received %v", len(cursors))
+	}
+
+	_, err = PaginateTradeHistory(request, "", func(cursor string) ([]TradeHistory, string, error) {
+		return nil, "", errors.New("exchange unavailable")
+	})
+	if err == nil {
+		t.Error("Test failed - expected PaginateTradeHistory() error")
+	}
+}
@@ -3,6 +3,9 @@ package exchange
 import (
 	"errors"
 	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,6 +27,19 @@ const (
 	websocketRestablishConnection = 1 * time.Second
 )
 
+// vars related to websocket channel subscriptions
+var (
+	ErrWebsocketSubscriptionsNotSupported = errors.New("exchange_websocket.go error - channel subscriptions not supported")
+)
+
+// vars related to websocket orderbook validation
+var (
+	ErrOrderbookSequenceGap        = errors.New("exchange_websocket.go error - orderbook update sequence gap detected")
+	ErrOrderbookUpdateOutdated     = errors.New("exchange_websocket.go error - orderbook update is older than the local orderbook")
+	ErrOrderbookChecksumMismatch   = errors.New("exchange_websocket.go error - orderbook checksum mismatch")
+	ErrOrderbookResyncNotSupported = errors.New("exchange_websocket.go error - orderbook resync not supported")
+)
+
 // WebsocketInit initialises the websocket struct
 func (e *Base) WebsocketInit() {
 	e.Websocket = &Websocket{
@@ -72,10 +88,19 @@ type Websocket struct {
 	exchangeName string
 	enabled      bool
 	init         bool
-	connected    bool
 	connector    func() error
 	m            sync.Mutex
 
+	connected       bool
+	connectionMutex sync.RWMutex
+
+	subscriber         func(channelToSubscribe WebsocketChannelSubscription) error
+	unsubscriber       func(channelToUnsubscribe WebsocketChannelSubscription) error
+	subscribedChannels []WebsocketChannelSubscription
+	subscriptionLock   sync.Mutex
+
+	orderbookFetcher func(p pair.CurrencyPair, assetType string) (orderbook.Base, error)
+
 	// Connected denotes a channel switch for diversion of request flow
 	Connected chan struct{}
 
@@ -109,7 +134,7 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 	wg.Done() // Makes sure we are unlocking after we add to waitgroup
 
 	defer func() {
-		if w.connected {
+		if w.IsConnected() {
 			w.Disconnected <- struct{}{}
 		}
 		w.Wg.Done()
@@ -125,19 +150,19 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 			return
 
 		case <-w.TrafficAlert: // Resets timer on traffic
-			if !w.connected {
+			if !w.IsConnected() {
 				w.Connected <- struct{}{}
-				w.connected = true
+				w.setConnected(true)
 			}
 
 			trafficTimer.Reset(WebsocketTrafficLimitTime)
 
 		case <-trafficTimer.C: // Falls through when timer runs out
 			newtimer := time.NewTimer(10 * time.Second) // New secondary timer set
-			if w.connected {
+			if w.IsConnected() {
 				// If connected divert traffic to rest
 				w.Disconnected <- struct{}{}
-				w.connected = false
+				w.setConnected(false)
 			}
 
 			select {
@@ -150,10 +175,10 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 
 			case <-w.TrafficAlert: // If in this time response traffic comes through
 				trafficTimer.Reset(WebsocketTrafficLimitTime)
-				if !w.connected {
+				if !w.IsConnected() {
 					// If not connected divert traffic from REST to websocket
 					w.Connected <- struct{}{}
-					w.connected = true
+					w.setConnected(true)
 				}
 			}
 		}
@@ -171,7 +196,7 @@ func (w *Websocket) Connect() error {
 			w.GetName())
 	}
 
-	if w.connected {
+	if w.IsConnected() {
 		return errors.New("exchange_websocket.go error - already connected, cannot connect again")
 	}
 
@@ -188,9 +213,24 @@ func (w *Websocket) Connect() error {
 			err)
 	}
 
+	// The connection is marked as established while the subscription lock is
+	// held so channels subscribed during the resubscription are either
+	// resubscribed here or sent by SubscribeToChannels
+	if w.SupportsChannelSubscriptions() {
+		w.subscriptionLock.Lock()
+		w.setConnected(true)
+		err = w.resubscribeToAllChannels()
+		w.subscriptionLock.Unlock()
+		if err != nil {
+			w.setConnected(false)
+			return fmt.Errorf("exchange_websocket.go subscription error %s",
+				err)
+		}
+	}
+
 	// Divert for incoming websocket traffic
 	w.Connected <- struct{}{}
-	w.connected = true
+	w.setConnected(true)
 
 	return nil
 }
@@ -205,7 +245,7 @@ func (w *Websocket) Shutdown() error {
 		w.m.Unlock()
 	}()
 
-	if !w.connected {
+	if !w.IsConnected() {
 		return errors.New("exchange_websocket.go error - System not connected to shut down")
 	}
 
@@ -220,7 +260,7 @@ func (w *Websocket) Shutdown() error {
 
 	select {
 	case <-c:
-		w.connected = false
+		w.setConnected(false)
 		return nil
 	case <-timer.C:
 		return fmt.Errorf("%s - Websocket routines failed to shutdown",
@@ -256,13 +296,13 @@ func (w *Websocket) SetEnabled(enabled bool) error {
 
 	if !w.init {
 		if enabled {
-			if w.connected {
+			if w.IsConnected() {
 				return nil
 			}
 			return w.Connect()
 		}
 
-		if !w.connected {
+		if !w.IsConnected() {
 			return nil
 		}
 		return w.Shutdown()
@@ -284,7 +324,7 @@ func (w *Websocket) SetProxyAddress(URL string) error {
 	w.proxyAddr = URL
 
 	if !w.init && w.enabled {
-		if w.connected {
+		if w.IsConnected() {
 			err := w.Shutdown()
 			if err != nil {
 				return err
@@ -326,14 +366,334 @@ func (w *Websocket) GetName() string {
 	return w.exchangeName
 }
 
+// IsConnected returns whether the websocket is connected
+func (w *Websocket) IsConnected() bool {
+	w.connectionMutex.RLock()
+	defer w.connectionMutex.RUnlock()
+	return w.connected
+}
+
+// setConnected sets whether the websocket is connected
+func (w *Websocket) setConnected(connected bool) {
+	w.connectionMutex.Lock()
+	w.connected = connected
+	w.connectionMutex.Unlock()
+}
+
+// SetChannelSubscriber sets the package defined function which sends a single
+// channel subscription to the exchange
+func (w *Websocket) SetChannelSubscriber(subscriber func(channelToSubscribe WebsocketChannelSubscription) error) {
+	w.subscriber = subscriber
+}
+
+// SetChannelUnsubscriber sets the package defined function which removes a
+// single channel subscription from the exchange
+func (w *Websocket) SetChannelUnsubscriber(unsubscriber func(channelToUnsubscribe WebsocketChannelSubscription) error) {
+	w.unsubscriber = unsubscriber
+}
+
+// SupportsChannelSubscriptions returns whether the exchange websocket can
+// subscribe and unsubscribe channels at runtime
+func (w *Websocket) SupportsChannelSubscriptions() bool {
+	return w.subscriber != nil && w.unsubscriber != nil
+}
+
+// SubscribeToChannels tracks the supplied channel subscriptions and sends any
+// which are not already subscribed to the exchange when connected. When not
+// connected the subscriptions are sent on the next connection
+func (w *Websocket) SubscribeToChannels(channelsToSubscribe []WebsocketChannelSubscription) error {
+	if !w.SupportsChannelSubscriptions() {
+		return ErrWebsocketSubscriptionsNotSupported
+	}
+
+	w.subscriptionLock.Lock()
+	defer w.subscriptionLock.Unlock()
+
+	for i := range channelsToSubscribe {
+		if w.isSubscribed(channelsToSubscribe[i]) {
+			continue
+		}
+
+		if w.IsConnected() {
+			err := w.subscriber(channelsToSubscribe[i])
+			if err != nil {
+				return err
+			}
+		}
+		w.subscribedChannels = append(w.subscribedChannels, channelsToSubscribe[i])
+	}
+	return nil
+}
+
+// UnsubscribeChannels stops tracking the supplied channel subscriptions and
+// removes them from the exchange when connected
+func (w *Websocket) UnsubscribeChannels(channelsToUnsubscribe []WebsocketChannelSubscription) error {
+	if !w.SupportsChannelSubscriptions() {
+		return ErrWebsocketSubscriptionsNotSupported
+	}
+
+	w.subscriptionLock.Lock()
+	defer w.subscriptionLock.Unlock()
+
+	for i := range channelsToUnsubscribe {
+		for j := range w.subscribedChannels {
+			if !w.subscribedChannels[j].Equal(channelsToUnsubscribe[i]) {
+				continue
+			}
+
+			if w.IsConnected() {
+				err := w.unsubscriber(channelsToUnsubscribe[i])
+				if err != nil {
+					return err
+				}
+			}
+			w.subscribedChannels = append(w.subscribedChannels[:j],
+				w.subscribedChannels[j+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// ResubscribeToAllChannels sends every tracked channel subscription to the
+// exchange, used after a connection has been (re)established
+func (w *Websocket) ResubscribeToAllChannels() error {
+	if !w.SupportsChannelSubscriptions() {
+		return ErrWebsocketSubscriptionsNotSupported
+	}
+
+	w.subscriptionLock.Lock()
+	defer w.subscriptionLock.Unlock()
+	return w.resubscribeToAllChannels()
+}
+
+// resubscribeToAllChannels sends every tracked channel subscription to the
+// exchange, the subscription lock must be held by the caller
+func (w *Websocket) resubscribeToAllChannels() error {
+	for i := range w.subscribedChannels {
+		err := w.subscriber(w.subscribedChannels[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSubscriptions returns a copy of the tracked channel subscriptions
+func (w *Websocket) GetSubscriptions() []WebsocketChannelSubscription {
+	w.subscriptionLock.Lock()
+	defer w.subscriptionLock.Unlock()
+
+	subscriptions := make([]WebsocketChannelSubscription, len(w.subscribedChannels))
+	copy(subscriptions, w.subscribedChannels)
+	return subscriptions
+}
+
+// isSubscribed returns whether the channel subscription is already tracked,
+// the subscription lock must be held by the caller
+func (w *Websocket) isSubscribed(channelToCheck WebsocketChannelSubscription) bool {
+	for i := range w.subscribedChannels {
+		if w.subscribedChannels[i].Equal(channelToCheck) {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal returns whether two channel subscriptions are for the same channel,
+// currency pair and asset type
+func (w *WebsocketChannelSubscription) Equal(subscription WebsocketChannelSubscription) bool {
+	return w.Channel == subscription.Channel &&
+		w.Asset == subscription.Asset &&
+		w.Currency.Equal(subscription.Currency, true)
+}
+
+// GenerateSubscriptions returns a channel subscription for every websocket
+// channel listed in the exchange features for each supplied currency pair and
+// exchange asset type
+func (e *Base) GenerateSubscriptions(pairs []pair.CurrencyPair) []WebsocketChannelSubscription {
+	var subscriptions []WebsocketChannelSubscription
+	for _, channel := range e.Features.Subscriptions {
+		for _, assetType := range e.AssetTypes {
+			for x := range pairs {
+				subscriptions = append(subscriptions, WebsocketChannelSubscription{
+					Channel:  channel,
+					Currency: pairs[x],
+					Asset:    assetType,
+				})
+			}
+		}
+	}
+	return subscriptions
+}
+
+// SetOrderbookFetcher sets the function used to fetch a REST orderbook snapshot
+// when a local orderbook fails validation, usually the exchange's
+// UpdateOrderbook
+func (w *Websocket) SetOrderbookFetcher(fetcher func(p pair.CurrencyPair, assetType string) (orderbook.Base, error)) {
+	w.orderbookFetcher = fetcher
+}
+
+// ResyncOrderbook invalidates the local orderbook, reloads it from a fresh REST
+// snapshot and sends a resync event to the data handler
+func (w *Websocket) ResyncOrderbook(p pair.CurrencyPair, assetType string, reason error) error {
+	w.Orderbook.Invalidate(p, assetType)
+
+	if w.orderbookFetcher == nil {
+		return ErrOrderbookResyncNotSupported
+	}
+
+	newOrderbook, err := w.orderbookFetcher(p, assetType)
+	if err != nil {
+		return fmt.Errorf("exchange_websocket.go %s orderbook resync error %s",
+			w.GetName(), err)
+	}
+
+	newOrderbook.Pair = p
+	newOrderbook.CurrencyPair = p.Pair().String()
+	newOrderbook.AssetType = assetType
+	newOrderbook.LastUpdated = time.Now()
+
+	err = w.Orderbook.LoadSnapshot(newOrderbook, w.GetName())
+	if err != nil {
+		return err
+	}
+
+	w.DataHandler <- WebsocketOrderbookResync{
+		Pair:     p,
+		Asset:    assetType,
+		Exchange: w.GetName(),
+		Reason:   reason.Error(),
+	}
+	return nil
+}
+
+// CalculateOrderbookChecksum returns the CRC32 checksum of the top depth price
+// levels of an orderbook as published by exchanges such as Bitfinex and OKEx.
+// Bid and ask levels are interleaved, formatted by formatLevel and joined with
+// colons
+func CalculateOrderbookChecksum(ob orderbook.Base, depth int, formatLevel func(item orderbook.Item, isAsk bool) string) uint32 {
+	bids := append([]orderbook.Item(nil), ob.Bids...)
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+
+	asks := append([]orderbook.Item(nil), ob.Asks...)
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+
+	var levels []string
+	for i := 0; i < depth; i++ {
+		if i < len(bids) {
+			levels = append(levels, formatLevel(bids[i], false))
+		}
+		if i < len(asks) {
+			levels = append(levels, formatLevel(asks[i], true))
+		}
+	}
+	return crc32.ChecksumIEEE([]byte(strings.Join(levels, ":")))
+}
+
 // WebsocketOrderbookLocal defines a local cache of orderbooks for amending,
 // appending and deleting changes and updates the main store in orderbook.go
 type WebsocketOrderbookLocal struct {
 	ob          []orderbook.Base
+	sequences   map[string]int64
 	lastUpdated time.Time
 	m           sync.Mutex
 }
 
+// sequenceKey returns the key of an orderbook in the sequence map
+func sequenceKey(p pair.CurrencyPair, assetType string) string {
+	return assetType + p.FirstCurrency.Upper().String() + p.SecondCurrency.Upper().String()
+}
+
+// SetSequence stores the sequence number of the orderbook snapshot or update
+// last applied to the local cache
+func (w *WebsocketOrderbookLocal) SetSequence(p pair.CurrencyPair, assetType string, sequence int64) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.sequences == nil {
+		w.sequences = make(map[string]int64)
+	}
+	w.sequences[sequenceKey(p, assetType)] = sequence
+}
+
+// ValidateSequence checks that an update covering the sequence range first to
+// last follows on from the last applied sequence and stores last when it does.
+// Outdated updates return ErrOrderbookUpdateOutdated and should be dropped, a
+// gap returns ErrOrderbookSequenceGap and the orderbook should be resynced.
+// Exchanges publishing a single sequence number per update pass it as both
+// first and last
+func (w *WebsocketOrderbookLocal) ValidateSequence(p pair.CurrencyPair, assetType string, first, last int64) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.sequences == nil {
+		w.sequences = make(map[string]int64)
+	}
+
+	key := sequenceKey(p, assetType)
+	if sequence, ok := w.sequences[key]; ok {
+		if last <= sequence {
+			return ErrOrderbookUpdateOutdated
+		}
+		if first > sequence+1 {
+			return ErrOrderbookSequenceGap
+		}
+	}
+
+	w.sequences[key] = last
+	return nil
+}
+
+// VerifyChecksum compares a checksum published by an exchange against the
+// checksum calculated from the local orderbook
+func (w *WebsocketOrderbookLocal) VerifyChecksum(p pair.CurrencyPair, assetType string, checksum uint32, calculate func(ob orderbook.Base) uint32) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	for i := range w.ob {
+		if w.ob[i].Pair == p && w.ob[i].AssetType == assetType {
+			if calculate(w.ob[i]) != checksum {
+				return ErrOrderbookChecksumMismatch
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("exchange.go WebsocketOrderbookLocal VerifyChecksum() - orderbook.Base could not be found for CurrencyPair: %s AssetType: %s",
+		p.Pair().String(),
+		assetType)
+}
+
+// HasOrderbook returns whether a snapshot has been loaded into the local cache
+// for the currency pair and asset type
+func (w *WebsocketOrderbookLocal) HasOrderbook(p pair.CurrencyPair, assetType string) bool {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	for i := range w.ob {
+		if w.ob[i].Pair == p && w.ob[i].AssetType == assetType {
+			return true
+		}
+	}
+	return false
+}
+
+// Invalidate removes an orderbook and its sequence from the local cache so a
+// new snapshot can be loaded
+func (w *WebsocketOrderbookLocal) Invalidate(p pair.CurrencyPair, assetType string) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	for i := range w.ob {
+		if w.ob[i].Pair == p && w.ob[i].AssetType == assetType {
+			w.ob = append(w.ob[:i], w.ob[i+1:]...)
+			break
+		}
+	}
+	delete(w.sequences, sequenceKey(p, assetType))
+}
+
 // Update updates a local cache using bid targets and ask targets then updates
 // main cache in orderbook.go
 // Volume == 0; deletion at price target
@@ -384,7 +744,7 @@ func (w *WebsocketOrderbookLocal) Update(bidTargets, askTargets []orderbook.Item
 				if orderbookAddress.Bids[y].Price == bidTargets[x].Price {
 					if bidTargets[x].Amount == 0 {
 						// Delete
-						orderbookAddress.Asks = append(orderbookAddress.Bids[:y],
+						orderbookAddress.Bids = append(orderbookAddress.Bids[:y],
 							orderbookAddress.Bids[y+1:]...)
 						return
 					}
@@ -550,6 +910,7 @@ func (w *WebsocketOrderbookLocal) UpdateUsingID(bidTargets, askTargets []orderbo
 func (w *WebsocketOrderbookLocal) FlushCache() {
 	w.m.Lock()
 	w.ob = nil
+	w.sequences = nil
 	w.m.Unlock()
 }
 
@@ -559,6 +920,23 @@ type WebsocketResponse struct {
 	Raw  []byte
 }
 
+// WebsocketOrderbookResync defines a websocket event in which a local orderbook
+// failed validation and was reloaded from a REST snapshot
+type WebsocketOrderbookResync struct {
+	Pair     pair.CurrencyPair
+	Asset    string
+	Exchange string
+	Reason   string
+}
+
+// WebsocketChannelSubscription defines a websocket channel subscription for a
+// currency pair and asset type
+type WebsocketChannelSubscription struct {
+	Channel  string
+	Currency pair.CurrencyPair
+	Asset    string
+}
+
 // WebsocketOrderbookUpdate defines a websocket event in which the orderbook
 // has been updated in the orderbook package
 type WebsocketOrderbookUpdate struct {
@@ -609,6 +987,53 @@ type KlineData struct {
 	Volume     float64
 }
 
+// OrderUpdate defines a websocket event in which the state of an order placed
+// by an authenticated account has changed. Fields which are not reported by
+// the exchange are left at their zero value
+type OrderUpdate struct {
+	Timestamp       time.Time
+	Exchange        string
+	AssetType       string
+	Pair            pair.CurrencyPair
+	OrderID         string
+	ClientOrderID   string
+	OrderSide       OrderSide
+	OrderType       OrderType
+	Status          OrderStatus
+	Price           float64
+	Amount          float64
+	ExecutedAmount  float64
+	RemainingAmount float64
+}
+
+// FillEvent defines a websocket event in which an order placed by an
+// authenticated account has been (partially) filled
+type FillEvent struct {
+	Timestamp   time.Time
+	Exchange    string
+	AssetType   string
+	Pair        pair.CurrencyPair
+	OrderID     string
+	TradeID     string
+	OrderSide   OrderSide
+	Price       float64
+	Amount      float64
+	Fee         float64
+	FeeCurrency string
+	IsMaker     bool
+}
+
+// BalanceUpdate defines a websocket event in which the balance of a currency
+// held by an authenticated account has changed. Total includes Hold, the
+// balance reserved by open orders
+type BalanceUpdate struct {
+	Timestamp time.Time
+	Exchange  string
+	Currency  string
+	Total     float64
+	Hold      float64
+}
+
 // WebsocketPositionUpdated reflects a change in orders/contracts on an exchange
 type WebsocketPositionUpdated struct {
 	Timestamp time.Time
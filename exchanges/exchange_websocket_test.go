@@ -1,6 +1,9 @@
 package exchange
 
 import (
+	"fmt"
+	"hash/crc32"
+	"sync"
 	"testing"
 	"time"
 
@@ -309,3 +312,260 @@ func TestUpdate(t *testing.T) {
 		t.Error("test failed - OrderbookUpdate error", err)
 	}
 }
+
+func TestWebsocketChannelSubscriptions(t *testing.T) {
+	b := Base{
+		Features:   Features{Subscriptions: []string{TickerChannel, OrderbookChannel}},
+		AssetTypes: []string{"SPOT"},
+	}
+	b.WebsocketInit()
+
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	ltcusd := pair.NewCurrencyPair("LTC", "USD")
+	ethusd := pair.NewCurrencyPair("ETH", "USD")
+
+	subscriptions := b.GenerateSubscriptions([]pair.CurrencyPair{btcusd, ltcusd})
+	if len(subscriptions) != 4 {
+		t.Fatalf("test failed - expected 4 subscriptions, received %d", len(subscriptions))
+	}
+
+	err := b.Websocket.SubscribeToChannels(subscriptions)
+	if err != ErrWebsocketSubscriptionsNotSupported {
+		t.Errorf("test failed - expected %v, received %v", ErrWebsocketSubscriptionsNotSupported, err)
+	}
+
+	var subscribed, unsubscribed []WebsocketChannelSubscription
+	b.Websocket.SetChannelSubscriber(func(channel WebsocketChannelSubscription) error {
+		subscribed = append(subscribed, channel)
+		return nil
+	})
+	b.Websocket.SetChannelUnsubscriber(func(channel WebsocketChannelSubscription) error {
+		unsubscribed = append(unsubscribed, channel)
+		return nil
+	})
+
+	// Subscriptions are only tracked while disconnected
+	err = b.Websocket.SubscribeToChannels(subscriptions)
+	if err != nil {
+		t.Fatal("test failed - SubscribeToChannels() error", err)
+	}
+	if len(subscribed) != 0 || len(b.Websocket.GetSubscriptions()) != 4 {
+		t.Errorf("test failed - expected 0 sent and 4 tracked subscriptions, received %d and %d",
+			len(subscribed), len(b.Websocket.GetSubscriptions()))
+	}
+
+	err = b.Websocket.ResubscribeToAllChannels()
+	if err != nil {
+		t.Fatal("test failed - ResubscribeToAllChannels() error", err)
+	}
+	if len(subscribed) != 4 {
+		t.Errorf("test failed - expected 4 sent subscriptions, received %d", len(subscribed))
+	}
+
+	b.Websocket.setConnected(true)
+	subscribed = nil
+	err = b.Websocket.SubscribeToChannels(b.GenerateSubscriptions([]pair.CurrencyPair{btcusd, ethusd}))
+	if err != nil {
+		t.Fatal("test failed - SubscribeToChannels() error", err)
+	}
+	if len(subscribed) != 2 || len(b.Websocket.GetSubscriptions()) != 6 {
+		t.Errorf("test failed - expected 2 sent and 6 tracked subscriptions, received %d and %d",
+			len(subscribed), len(b.Websocket.GetSubscriptions()))
+	}
+
+	err = b.Websocket.UnsubscribeChannels(b.GenerateSubscriptions([]pair.CurrencyPair{ltcusd}))
+	if err != nil {
+		t.Fatal("test failed - UnsubscribeChannels() error", err)
+	}
+	if len(unsubscribed) != 2 || len(b.Websocket.GetSubscriptions()) != 4 {
+		t.Errorf("test failed - expected 2 removed and 4 tracked subscriptions, received %d and %d",
+			len(unsubscribed), len(b.Websocket.GetSubscriptions()))
+	}
+
+	for _, s := range b.Websocket.GetSubscriptions() {
+		if s.Currency.Equal(ltcusd, true) {
+			t.Errorf("test failed - unsubscribed channel %+v still tracked", s)
+		}
+	}
+}
+
+func TestWebsocketConnectResubscribes(t *testing.T) {
+	b := Base{
+		Features:   Features{Subscriptions: []string{TickerChannel}},
+		AssetTypes: []string{"SPOT"},
+	}
+	b.WebsocketInit()
+	err := b.WebsocketSetup(func() error { return nil }, "testName", true, "", "")
+	if err != nil {
+		t.Fatal("test failed - WebsocketSetup() error", err)
+	}
+
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	ethusd := pair.NewCurrencyPair("ETH", "USD")
+
+	// A channel subscribed while the connection resubscribes must be sent
+	// once the connection is established
+	var wg sync.WaitGroup
+	var m sync.Mutex
+	var subscribed []WebsocketChannelSubscription
+	b.Websocket.SetChannelSubscriber(func(channel WebsocketChannelSubscription) error {
+		m.Lock()
+		defer m.Unlock()
+		if len(subscribed) == 0 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := b.Websocket.SubscribeToChannels(b.GenerateSubscriptions([]pair.CurrencyPair{ethusd}))
+				if err != nil {
+					t.Error("test failed - SubscribeToChannels() error", err)
+				}
+			}()
+		}
+		subscribed = append(subscribed, channel)
+		return nil
+	})
+	b.Websocket.SetChannelUnsubscriber(func(channel WebsocketChannelSubscription) error {
+		return nil
+	})
+	err = b.Websocket.SubscribeToChannels(b.GenerateSubscriptions([]pair.CurrencyPair{btcusd}))
+	if err != nil {
+		t.Fatal("test failed - SubscribeToChannels() error", err)
+	}
+
+	err = b.Websocket.Connect()
+	if err != nil {
+		t.Fatal("test failed - Connect() error", err)
+	}
+	wg.Wait()
+	<-b.Websocket.Connected
+
+	m.Lock()
+	if len(subscribed) != 2 || !subscribed[1].Currency.Equal(ethusd, true) {
+		t.Errorf("test failed - expected 2 sent subscriptions, received %+v", subscribed)
+	}
+	m.Unlock()
+
+	err = b.Websocket.Shutdown()
+	if err != nil {
+		t.Error("test failed - Shutdown() error", err)
+	}
+}
+
+func TestValidateSequence(t *testing.T) {
+	var w WebsocketOrderbookLocal
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	// Unknown sequences are stored from the first update
+	if err := w.ValidateSequence(p, "SPOT", 5, 10); err != nil {
+		t.Error("test failed - ValidateSequence() error", err)
+	}
+
+	tests := []struct {
+		first, last int64
+		expected    error
+	}{
+		{9, 10, ErrOrderbookUpdateOutdated},
+		{8, 11, nil},
+		{12, 12, nil},
+		{14, 15, ErrOrderbookSequenceGap},
+		{13, 13, nil},
+	}
+
+	for _, test := range tests {
+		if err := w.ValidateSequence(p, "SPOT", test.first, test.last); err != test.expected {
+			t.Errorf("test failed - sequence %d-%d expected %v, received %v",
+				test.first, test.last, test.expected, err)
+		}
+	}
+
+	w.SetSequence(p, "SPOT", 100)
+	if err := w.ValidateSequence(p, "SPOT", 14, 14); err != ErrOrderbookUpdateOutdated {
+		t.Errorf("test failed - expected %v, received %v", ErrOrderbookUpdateOutdated, err)
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	var w WebsocketOrderbookLocal
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	format := func(item orderbook.Item, isAsk bool) string {
+		return fmt.Sprintf("%v:%v", item.Price, item.Amount)
+	}
+	calculate := func(ob orderbook.Base) uint32 {
+		return CalculateOrderbookChecksum(ob, 2, format)
+	}
+
+	err := w.LoadSnapshot(orderbook.Base{
+		Pair:      p,
+		AssetType: "SPOT",
+		Bids:      []orderbook.Item{{Price: 98, Amount: 2}, {Price: 99, Amount: 1}, {Price: 97, Amount: 3}},
+		Asks:      []orderbook.Item{{Price: 101, Amount: 4}, {Price: 100, Amount: 5}},
+	}, "test")
+	if err != nil {
+		t.Fatal("test failed - LoadSnapshot() error", err)
+	}
+
+	if !w.HasOrderbook(p, "SPOT") || w.HasOrderbook(p, "FUTURES") {
+		t.Error("test failed - HasOrderbook() unexpected result")
+	}
+
+	expected := crc32.ChecksumIEEE([]byte("99:1:100:5:98:2:101:4"))
+	if err = w.VerifyChecksum(p, "SPOT", expected, calculate); err != nil {
+		t.Error("test failed - VerifyChecksum() error", err)
+	}
+
+	if err = w.VerifyChecksum(p, "SPOT", expected+1, calculate); err != ErrOrderbookChecksumMismatch {
+		t.Errorf("test failed - expected %v, received %v", ErrOrderbookChecksumMismatch, err)
+	}
+
+	w.Invalidate(p, "SPOT")
+	if w.HasOrderbook(p, "SPOT") {
+		t.Error("test failed - HasOrderbook() expected invalidated orderbook to be removed")
+	}
+	if err = w.VerifyChecksum(p, "SPOT", expected, calculate); err == nil {
+		t.Error("test failed - expected error verifying an invalidated orderbook")
+	}
+}
+
+func TestResyncOrderbook(t *testing.T) {
+	var b Base
+	b.WebsocketInit()
+	b.Websocket.DataHandler = make(chan interface{}, 1)
+	b.Websocket.SetExchangeName("test")
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	err := b.Websocket.ResyncOrderbook(p, "SPOT", ErrOrderbookSequenceGap)
+	if err != ErrOrderbookResyncNotSupported {
+		t.Errorf("test failed - expected %v, received %v", ErrOrderbookResyncNotSupported, err)
+	}
+
+	snapshot := orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 100, Amount: 1}},
+	}
+	b.Websocket.SetOrderbookFetcher(func(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+		return snapshot, nil
+	})
+
+	stale := snapshot
+	stale.Pair = p
+	stale.AssetType = "SPOT"
+	err = b.Websocket.Orderbook.LoadSnapshot(stale, "test")
+	if err != nil {
+		t.Fatal("test failed - LoadSnapshot() error", err)
+	}
+
+	err = b.Websocket.ResyncOrderbook(p, "SPOT", ErrOrderbookChecksumMismatch)
+	if err != nil {
+		t.Fatal("test failed - ResyncOrderbook() error", err)
+	}
+
+	resync, ok := (<-b.Websocket.DataHandler).(WebsocketOrderbookResync)
+	if !ok {
+		t.Fatal("test failed - expected a WebsocketOrderbookResync event")
+	}
+	if resync.Exchange != "test" || resync.Pair != p ||
+		resync.Reason != ErrOrderbookChecksumMismatch.Error() {
+		t.Errorf("test failed - unexpected resync event %+v", resync)
+	}
+}
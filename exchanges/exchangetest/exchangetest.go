@@ -0,0 +1,412 @@
+// Package exchangetest provides an in-memory exchange.IBotExchange for testing
+// packages which wrap or trade through exchanges
+package exchangetest
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Order statuses reported by the exchange
+const (
+	StatusOpen      = "open"
+	StatusFilled    = "filled"
+	StatusCancelled = "cancelled"
+)
+
+// Exchange is an in-memory implementation of exchange.IBotExchange. Submitted
+// limit orders stay active until filled or cancelled, market orders and every
+// order when FillOrders is set are filled in full on submission. Orders which
+// are no longer active are moved to the order history. Market data is read
+// from the ticker and orderbook caches under the exchange name.
+//
+// Every order function can be replaced by setting its matching hook, the
+// remaining exchange functions return common.ErrNotYetImplemented
+type Exchange struct {
+	Name                    string
+	Pairs                   []pair.CurrencyPair
+	Features                exchange.Features
+	OrderSubmissionFeatures uint32
+	MakerFee, TakerFee      float64
+	// Fee is charged on the purchase value of a trade by GetFeeByType
+	Fee float64
+	// Balances are reported by GetAccountInfo keyed by currency
+	Balances map[string]float64
+	// Active and History hold the open and completed orders on the exchange,
+	// they can be seeded before the exchange is used
+	Active  []exchange.OrderDetail
+	History []exchange.OrderDetail
+	// FillOrders fills every submitted order in full on submission
+	FillOrders bool
+	// SubmitErr and CancelErr are returned by SubmitOrder and CancelOrder
+	// when set
+	SubmitErr error
+	CancelErr error
+
+	SubmitOrderFunc        func(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error)
+	ModifyOrderFunc        func(action exchange.ModifyOrder) (string, error)
+	CancelAllOrdersFunc    func(orders exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error)
+	GetOrderHistoryFunc    func(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error)
+	GetAccountInfoFunc     func() (exchange.AccountInfo, error)
+	GetFeeByTypeFunc       func(feeBuilder exchange.FeeBuilder) (float64, error)
+	GetHistoricCandlesFunc func(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error)
+
+	submitted []exchange.OrderSubmission
+	cancelled []string
+	mtx       sync.Mutex
+}
+
+var _ exchange.IBotExchange = (*Exchange)(nil)
+
+// New returns an in-memory exchange trading the supplied pairs
+func New(name string, pairs ...pair.CurrencyPair) *Exchange {
+	return &Exchange{Name: name, Pairs: pairs}
+}
+
+// Setup is a no-op for the test exchange
+func (e *Exchange) Setup(exch config.ExchangeConfig) {}
+
+// Start is a no-op for the test exchange
+func (e *Exchange) Start(wg *sync.WaitGroup) {}
+
+// SetDefaults is a no-op for the test exchange
+func (e *Exchange) SetDefaults() {}
+
+// GetName returns the exchange name
+func (e *Exchange) GetName() string {
+	return e.Name
+}
+
+// IsEnabled always returns true
+func (e *Exchange) IsEnabled() bool {
+	return true
+}
+
+// SetEnabled is a no-op for the test exchange
+func (e *Exchange) SetEnabled(enabled bool) {}
+
+// GetTickerPrice returns the cached ticker for the exchange
+func (e *Exchange) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	return ticker.GetTicker(e.Name, p, assetType)
+}
+
+// UpdateTicker returns the cached ticker for the exchange
+func (e *Exchange) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	return ticker.GetTicker(e.Name, p, assetType)
+}
+
+// GetOrderbookEx returns the cached orderbook for the exchange
+func (e *Exchange) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	return orderbook.GetOrderbook(e.Name, p, assetType)
+}
+
+// UpdateOrderbook returns the cached orderbook for the exchange
+func (e *Exchange) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	return orderbook.GetOrderbook(e.Name, p, assetType)
+}
+
+// GetEnabledCurrencies returns the exchange pairs
+func (e *Exchange) GetEnabledCurrencies() []pair.CurrencyPair {
+	return e.Pairs
+}
+
+// GetAvailableCurrencies returns the exchange pairs
+func (e *Exchange) GetAvailableCurrencies() []pair.CurrencyPair {
+	return e.Pairs
+}
+
+// GetAssetTypes returns the spot asset type
+func (e *Exchange) GetAssetTypes() []string {
+	return []string{ticker.Spot}
+}
+
+// GetAccountInfo returns the exchange balances
+func (e *Exchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	if e.GetAccountInfoFunc != nil {
+		return e.GetAccountInfoFunc()
+	}
+	info := exchange.AccountInfo{ExchangeName: e.Name}
+	for c, v := range e.Balances {
+		info.Currencies = append(info.Currencies, exchange.AccountCurrencyInfo{
+			CurrencyName: c,
+			TotalValue:   v,
+		})
+	}
+	return info, nil
+}
+
+// GetAuthenticatedAPISupport always returns true
+func (e *Exchange) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+// SetCurrencies replaces the exchange pairs
+func (e *Exchange) SetCurrencies(pairs []pair.CurrencyPair, enabledPairs bool) error {
+	e.Pairs = pairs
+	return nil
+}
+
+// GetExchangeHistory is not implemented by the test exchange
+func (e *Exchange) GetExchangeHistory(req exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrNotYetImplemented
+}
+
+// GetHistoricCandles returns candles from GetHistoricCandlesFunc
+func (e *Exchange) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	if e.GetHistoricCandlesFunc != nil {
+		return e.GetHistoricCandlesFunc(p, assetType, interval, start, end)
+	}
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
+// SupportsAutoPairUpdates always returns false
+func (e *Exchange) SupportsAutoPairUpdates() bool {
+	return false
+}
+
+// GetLastPairsUpdateTime always returns zero
+func (e *Exchange) GetLastPairsUpdateTime() int64 {
+	return 0
+}
+
+// SupportsRESTTickerBatchUpdates always returns false
+func (e *Exchange) SupportsRESTTickerBatchUpdates() bool {
+	return false
+}
+
+// GetWithdrawPermissions always returns no permissions
+func (e *Exchange) GetWithdrawPermissions() uint32 {
+	return 0
+}
+
+// FormatWithdrawPermissions always returns an empty string
+func (e *Exchange) FormatWithdrawPermissions() string {
+	return ""
+}
+
+// SupportsWithdrawPermissions always returns false
+func (e *Exchange) SupportsWithdrawPermissions(permissions uint32) bool {
+	return false
+}
+
+// GetOrderSubmissionFeatures returns the exchange order submission features
+func (e *Exchange) GetOrderSubmissionFeatures() uint32 {
+	return e.OrderSubmissionFeatures
+}
+
+// FormatOrderSubmissionFeatures always returns an empty string
+func (e *Exchange) FormatOrderSubmissionFeatures() string {
+	return ""
+}
+
+// SupportsOrderSubmissionFeatures returns whether all of the supplied order
+// submission features are supported
+func (e *Exchange) SupportsOrderSubmissionFeatures(features uint32) bool {
+	return e.OrderSubmissionFeatures&features == features
+}
+
+// GetFeatures returns the exchange feature descriptor
+func (e *Exchange) GetFeatures() exchange.Features {
+	return e.Features
+}
+
+// GetMakerTakerFees returns the exchange maker and taker fees
+func (e *Exchange) GetMakerTakerFees() (maker, taker float64) {
+	return e.MakerFee, e.TakerFee
+}
+
+// GetFeeByType returns Fee charged on the purchase value of a trade
+func (e *Exchange) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
+	if e.GetFeeByTypeFunc != nil {
+		return e.GetFeeByTypeFunc(feeBuilder)
+	}
+	return e.Fee * feeBuilder.PurchasePrice * feeBuilder.Amount, nil
+}
+
+// GetFundingHistory is not implemented by the test exchange
+func (e *Exchange) GetFundingHistory() ([]exchange.FundHistory, error) {
+	return nil, common.ErrNotYetImplemented
+}
+
+// SubmitOrder records the order and adds it to the active orders, or the order
+// history when it is filled on submission
+func (e *Exchange) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	if e.SubmitOrderFunc != nil {
+		return e.SubmitOrderFunc(order)
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.SubmitErr != nil {
+		return exchange.SubmitOrderResponse{}, e.SubmitErr
+	}
+
+	e.submitted = append(e.submitted, *order)
+	detail := exchange.OrderDetail{
+		Exchange:        e.Name,
+		ID:              strconv.Itoa(len(e.submitted)),
+		CurrencyPair:    order.CurrencyPair,
+		OrderSide:       order.OrderSide,
+		OrderType:       order.OrderType,
+		OrderDate:       time.Now(),
+		Status:          StatusOpen,
+		Price:           order.Price,
+		Amount:          order.Amount,
+		RemainingAmount: order.Amount,
+	}
+	if e.FillOrders || order.OrderType == exchange.Market {
+		detail.Status = StatusFilled
+		detail.ExecutedAmount = order.Amount
+		detail.RemainingAmount = 0
+		e.History = append(e.History, detail)
+	} else {
+		e.Active = append(e.Active, detail)
+	}
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: detail.ID}, nil
+}
+
+// ModifyOrder returns the ID of the modified order
+func (e *Exchange) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if e.ModifyOrderFunc != nil {
+		return e.ModifyOrderFunc(action)
+	}
+	return action.OrderID, nil
+}
+
+// CancelOrder records the cancellation and moves an active order to the order
+// history
+func (e *Exchange) CancelOrder(order exchange.OrderCancellation) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.CancelErr != nil {
+		return e.CancelErr
+	}
+	e.cancelled = append(e.cancelled, order.OrderID)
+	e.complete(order.OrderID, StatusCancelled)
+	return nil
+}
+
+// CancelAllOrders cancels every active order
+func (e *Exchange) CancelAllOrders(orders exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if e.CancelAllOrdersFunc != nil {
+		return e.CancelAllOrdersFunc(orders)
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.CancelErr != nil {
+		return exchange.CancelAllOrdersResponse{}, e.CancelErr
+	}
+	for len(e.Active) > 0 {
+		e.cancelled = append(e.cancelled, e.Active[0].ID)
+		e.complete(e.Active[0].ID, StatusCancelled)
+	}
+	return exchange.CancelAllOrdersResponse{}, nil
+}
+
+// GetOrderInfo is not implemented by the test exchange
+func (e *Exchange) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	return exchange.OrderDetail{}, common.ErrNotYetImplemented
+}
+
+// GetActiveOrders returns the active orders matching the request
+func (e *Exchange) GetActiveOrders(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return exchange.FilterOrders(append([]exchange.OrderDetail(nil), e.Active...), req), nil
+}
+
+// GetOrderHistory returns the completed orders matching the request
+func (e *Exchange) GetOrderHistory(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if e.GetOrderHistoryFunc != nil {
+		return e.GetOrderHistoryFunc(req)
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return exchange.FilterOrders(append([]exchange.OrderDetail(nil), e.History...), req), nil
+}
+
+// GetDepositAddress is not implemented by the test exchange
+func (e *Exchange) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+	return "", common.ErrNotYetImplemented
+}
+
+// WithdrawCryptocurrencyFunds is not implemented by the test exchange
+func (e *Exchange) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrNotYetImplemented
+}
+
+// WithdrawFiatFunds is not implemented by the test exchange
+func (e *Exchange) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrNotYetImplemented
+}
+
+// GetWebsocket is not supported by the test exchange
+func (e *Exchange) GetWebsocket() (*exchange.Websocket, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// Fill sets the executed amount of an active order, an order which is
+// executed in full is moved to the order history
+func (e *Exchange) Fill(id string, executed float64) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	for x := range e.Active {
+		if e.Active[x].ID != id {
+			continue
+		}
+		e.Active[x].ExecutedAmount = executed
+		e.Active[x].RemainingAmount = e.Active[x].Amount - executed
+		if executed >= e.Active[x].Amount {
+			e.complete(id, StatusFilled)
+		}
+		return
+	}
+}
+
+// Expire moves an active order to the order history without filling it
+// further
+func (e *Exchange) Expire(id string) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.complete(id, StatusCancelled)
+}
+
+// Submitted returns every order submitted to the exchange
+func (e *Exchange) Submitted() []exchange.OrderSubmission {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return append([]exchange.OrderSubmission(nil), e.submitted...)
+}
+
+// Cancelled returns the IDs of every order cancelled on the exchange
+func (e *Exchange) Cancelled() []string {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return append([]string(nil), e.cancelled...)
+}
+
+// complete moves an active order to the order history with the supplied
+// status, the caller must hold the exchange lock
+func (e *Exchange) complete(id, status string) {
+	for x := range e.Active {
+		if e.Active[x].ID != id {
+			continue
+		}
+		detail := e.Active[x]
+		detail.Status = status
+		e.Active = append(e.Active[:x], e.Active[x+1:]...)
+		e.History = append(e.History, detail)
+		return
+	}
+}
@@ -0,0 +1,113 @@
+package exchangetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+var btcusd = pair.NewCurrencyPair("BTC", "USD")
+
+func submit(t *testing.T, e *Exchange, orderType exchange.OrderType) string {
+	resp, err := e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: btcusd,
+		OrderSide:    exchange.Buy,
+		OrderType:    orderType,
+		Price:        100,
+		Amount:       2,
+	})
+	if err != nil || !resp.IsOrderPlaced {
+		t.Fatalf("Test failed. SubmitOrder error: %v", err)
+	}
+	return resp.OrderID
+}
+
+func TestOrders(t *testing.T) {
+	e := New("Test", btcusd)
+
+	market := submit(t, e, exchange.Market)
+	limit := submit(t, e, exchange.Limit)
+	if market != "1" || limit != "2" || len(e.Submitted()) != 2 {
+		t.Fatalf("Test failed. Unexpected order IDs %s %s", market, limit)
+	}
+
+	active, _ := e.GetActiveOrders(exchange.GetOrdersRequest{})
+	history, _ := e.GetOrderHistory(exchange.GetOrdersRequest{})
+	if len(active) != 1 || active[0].ID != limit || len(history) != 1 ||
+		history[0].ExecutedAmount != 2 || history[0].Status != StatusFilled {
+		t.Fatalf("Test failed. Expected market order to fill got %+v %+v", active, history)
+	}
+
+	e.Fill(limit, 0.5)
+	if active, _ = e.GetActiveOrders(exchange.GetOrdersRequest{}); active[0].ExecutedAmount != 0.5 {
+		t.Errorf("Test failed. Expected partial fill got %+v", active)
+	}
+	e.Fill(limit, 2)
+	if active, _ = e.GetActiveOrders(exchange.GetOrdersRequest{}); len(active) != 0 {
+		t.Errorf("Test failed. Expected filled order to leave the book got %+v", active)
+	}
+
+	cancelled := submit(t, e, exchange.Limit)
+	if err := e.CancelOrder(exchange.OrderCancellation{OrderID: cancelled}); err != nil {
+		t.Fatalf("Test failed. CancelOrder error: %s", err)
+	}
+	expired := submit(t, e, exchange.Limit)
+	e.Expire(expired)
+
+	history, _ = e.GetOrderHistory(exchange.GetOrdersRequest{})
+	if len(history) != 4 || history[2].Status != StatusCancelled || history[3].ID != expired {
+		t.Errorf("Test failed. Unexpected order history %+v", history)
+	}
+	if c := e.Cancelled(); len(c) != 1 || c[0] != cancelled {
+		t.Errorf("Test failed. Unexpected cancellations %v", c)
+	}
+
+	e.SubmitErr = errors.New("rejected")
+	if _, err := e.SubmitOrder(&exchange.OrderSubmission{}); err != e.SubmitErr {
+		t.Errorf("Test failed. Expected %s got %v", e.SubmitErr, err)
+	}
+}
+
+func TestFeesAndBalances(t *testing.T) {
+	e := New("Test")
+	e.Fee = 0.001
+	e.Balances = map[string]float64{"BTC": 1}
+	e.OrderSubmissionFeatures = exchange.LimitOrderSupport
+
+	fee, err := e.GetFeeByType(exchange.FeeBuilder{PurchasePrice: 100, Amount: 2})
+	if err != nil || fee != 0.2 {
+		t.Errorf("Test failed. Expected fee 0.2 got %f %v", fee, err)
+	}
+	info, err := e.GetAccountInfo()
+	if err != nil || info.ExchangeName != "Test" || len(info.Currencies) != 1 ||
+		info.Currencies[0].TotalValue != 1 {
+		t.Errorf("Test failed. Unexpected account info %+v %v", info, err)
+	}
+	if !e.SupportsOrderSubmissionFeatures(exchange.LimitOrderSupport) ||
+		e.SupportsOrderSubmissionFeatures(exchange.LimitOrderSupport|exchange.MarketOrderSupport) {
+		t.Error("Test failed. Unexpected order submission feature support")
+	}
+}
+
+func TestGetOrderDetail(t *testing.T) {
+	e := New("Test", btcusd)
+	limit := submit(t, e, exchange.Limit)
+
+	detail, err := exchange.GetOrderDetail(e, limit, btcusd)
+	if err != nil || detail.ID != limit || detail.Status != StatusOpen {
+		t.Errorf("Test failed. Expected active order got %+v %v", detail, err)
+	}
+
+	// active orders are still searched when the order history fails
+	e.GetOrderHistoryFunc = func(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+		return nil, errors.New("history unavailable")
+	}
+	if detail, err = exchange.GetOrderDetail(e, limit, btcusd); err != nil || detail.ID != limit {
+		t.Errorf("Test failed. Expected active order got %+v %v", detail, err)
+	}
+	if _, err = exchange.GetOrderDetail(e, "100", btcusd); err == nil {
+		t.Error("Test failed. Expected error for unknown order")
+	}
+}
@@ -58,6 +58,15 @@ func (e *EXMO) SetDefaults() {
 	e.Verbose = false
 	e.RESTPollingDelay = 10
 	e.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithSetup
+	e.OrderSubmissionFeatures = exchange.MarketOrderSupport
+	e.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	e.RequestCurrencyPairFormat.Delimiter = "_"
 	e.RequestCurrencyPairFormat.Uppercase = true
 	e.RequestCurrencyPairFormat.Separator = ","
@@ -6,10 +6,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -153,7 +155,7 @@ func (e *EXMO) GetAccountInfo() (exchange.AccountInfo, error) {
 			if z == x {
 				avail, _ := strconv.ParseFloat(y, 64)
 				reserved, _ := strconv.ParseFloat(w, 64)
-				exchangeCurrency.TotalValue = avail + reserved
+				exchangeCurrency.TotalValue = avail
 				exchangeCurrency.Hold = reserved
 			}
 		}
@@ -169,21 +171,31 @@ func (e *EXMO) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (e *EXMO) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (e *EXMO) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (e *EXMO) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (e *EXMO) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (e *EXMO) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(e.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var oT string
-	if orderType == exchange.Limit {
+	if order.OrderType == exchange.Limit {
 		return submitOrderResponse, errors.New("Unsupported order type")
-	} else if orderType == exchange.Market {
-		if side == exchange.Buy {
+	} else if order.OrderType == exchange.Market {
+		if order.OrderSide == exchange.Buy {
 			oT = "market_buy"
 		} else {
 			oT = "market_sell"
@@ -192,7 +204,7 @@ func (e *EXMO) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderTy
 		return submitOrderResponse, errors.New("Unsupported order type")
 	}
 
-	response, err := e.CreateOrder(p.Pair().String(), oT, price, amount)
+	response, err := e.CreateOrder(order.CurrencyPair.Pair().String(), oT, order.Price, order.Amount)
 
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
@@ -248,6 +260,76 @@ func (e *EXMO) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (e *EXMO) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := e.GetOpenOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for _, order := range resp {
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        e.Name,
+			ID:              strconv.FormatInt(order.OrderID, 10),
+			CurrencyPair:    pair.NewCurrencyPairDelimiter(order.Pair, "_"),
+			OrderSide:       exchange.FormatOrderSide(order.Type),
+			OrderType:       exchange.Limit,
+			OrderDate:       time.Unix(order.Created, 0),
+			Price:           order.Price,
+			Amount:          order.Quantity,
+			RemainingAmount: order.Quantity,
+		})
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (e *EXMO) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = e.GetEnabledCurrencies()
+	}
+
+	pairs, err := exchange.GetAndFormatExchangeCurrencies(e.Name, currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := e.GetUserTrades(pairs.String(), "", "10000")
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for _, trades := range resp {
+		for i := range trades {
+			orderDate := time.Unix(trades[i].Date, 0)
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:       e.Name,
+				ID:             strconv.FormatInt(trades[i].OrderID, 10),
+				CurrencyPair:   pair.NewCurrencyPairDelimiter(trades[i].Pair, "_"),
+				OrderSide:      exchange.FormatOrderSide(trades[i].Type),
+				OrderDate:      orderDate,
+				Status:         "FILLED",
+				Price:          trades[i].Price,
+				Amount:         trades[i].Quantity,
+				ExecutedAmount: trades[i].Quantity,
+				Fills: []exchange.OrderFill{{
+					ID:        strconv.FormatInt(trades[i].TradeID, 10),
+					Timestamp: orderDate,
+					Price:     trades[i].Price,
+					Amount:    trades[i].Quantity,
+				}},
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (e *EXMO) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -49,6 +49,15 @@ func (g *Gateio) SetDefaults() {
 	g.Verbose = false
 	g.RESTPollingDelay = 10
 	g.APIWithdrawPermissions = exchange.AutoWithdrawCrypto
+	g.OrderSubmissionFeatures = exchange.LimitOrderSupport
+	g.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	g.RequestCurrencyPairFormat.Delimiter = "_"
 	g.RequestCurrencyPairFormat.Uppercase = false
 	g.ConfigCurrencyPairFormat.Delimiter = "_"
@@ -5,10 +5,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -146,7 +148,7 @@ func (g *Gateio) GetAccountInfo() (exchange.AccountInfo, error) {
 			var updated bool
 			for i := range balances {
 				if balances[i].CurrencyName == key {
-					balances[i].TotalValue = balances[i].Hold + availAmount
+					balances[i].TotalValue = availAmount
 					updated = true
 					break
 				}
@@ -174,28 +176,38 @@ func (g *Gateio) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (g *Gateio) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (g *Gateio) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (g *Gateio) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (g *Gateio) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (g *Gateio) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(g.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var orderTypeFormat SpotNewOrderRequestParamsType
 
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		orderTypeFormat = SpotNewOrderRequestParamsTypeBuy
 	} else {
 		orderTypeFormat = SpotNewOrderRequestParamsTypeSell
 	}
 
 	var spotNewOrderRequestParams = SpotNewOrderRequestParams{
-		Amount: amount,
-		Price:  price,
-		Symbol: p.Pair().String(),
+		Amount: order.Amount,
+		Price:  order.Price,
+		Symbol: order.CurrencyPair.Pair().String(),
 		Type:   orderTypeFormat,
 	}
 
@@ -261,6 +273,53 @@ func (g *Gateio) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (g *Gateio) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := g.GetOpenOrders("")
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for i := range resp.Orders {
+		var p pair.CurrencyPair
+		if common.StringContains(resp.Orders[i].CurrencyPair, "_") {
+			p = pair.NewCurrencyPairDelimiter(common.StringToUpper(resp.Orders[i].CurrencyPair), "_")
+		}
+
+		var orderDate time.Time
+		timestamp, err := strconv.ParseInt(resp.Orders[i].Timestamp, 10, 64)
+		if err == nil {
+			orderDate = time.Unix(timestamp, 0)
+		}
+
+		amount, _ := strconv.ParseFloat(resp.Orders[i].InitialAmount, 64)
+		remaining, _ := strconv.ParseFloat(resp.Orders[i].Amount, 64)
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        g.Name,
+			ID:              resp.Orders[i].OrderNumber,
+			CurrencyPair:    p,
+			OrderSide:       exchange.FormatOrderSide(resp.Orders[i].Type),
+			OrderType:       exchange.Limit,
+			OrderDate:       orderDate,
+			Status:          resp.Orders[i].Status,
+			Price:           resp.Orders[i].InitialRate,
+			Amount:          amount,
+			ExecutedAmount:  amount - remaining,
+			RemainingAmount: remaining,
+		})
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (g *Gateio) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return nil, common.ErrNotYetImplemented
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (g *Gateio) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -103,6 +103,15 @@ func (g *Gemini) SetDefaults() {
 	g.Verbose = false
 	g.RESTPollingDelay = 10
 	g.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission | exchange.AutoWithdrawCryptoWithSetup | exchange.WithdrawFiatViaWebsiteOnly
+	g.OrderSubmissionFeatures = exchange.LimitOrderSupport
+	g.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	g.RequestCurrencyPairFormat.Delimiter = ""
 	g.RequestCurrencyPairFormat.Uppercase = true
 	g.ConfigCurrencyPairFormat.Delimiter = ""
@@ -359,19 +368,10 @@ func (g *Gemini) GetOrderStatus(orderID int64) (Order, error) {
 
 // GetOrders returns active orders in the market
 func (g *Gemini) GetOrders() ([]Order, error) {
-	var response struct {
-		orders  []Order
-		Message string `json:"message"`
-	}
+	response := []Order{}
 
-	err := g.SendAuthenticatedHTTPRequest("POST", geminiOrders, nil, &response)
-	if err != nil {
-		return response.orders, err
-	}
-	if response.Message != "" {
-		return response.orders, errors.New(response.Message)
-	}
-	return response.orders, nil
+	return response,
+		g.SendAuthenticatedHTTPRequest("POST", geminiOrders, nil, &response)
 }
 
 // GetTradeHistory returns an array of trades that have been on the exchange
@@ -3,15 +3,17 @@ package gemini
 import (
 	"fmt"
 	"log"
-	"net/url"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"net/url"
 )
 
 // Start starts the Gemini go routine
@@ -50,14 +52,21 @@ func (g *Gemini) GetAccountInfo() (exchange.AccountInfo, error) {
 	if err != nil {
 		return response, err
 	}
+	response.Currencies = accountCurrencies(accountBalance)
+	return response, nil
+}
+
+// accountCurrencies converts the balances into available and held balances
+func accountCurrencies(accountBalance []Balance) []exchange.AccountCurrencyInfo {
+	var currencies []exchange.AccountCurrencyInfo
 	for i := 0; i < len(accountBalance); i++ {
 		var exchangeCurrency exchange.AccountCurrencyInfo
 		exchangeCurrency.CurrencyName = accountBalance[i].Currency
-		exchangeCurrency.TotalValue = accountBalance[i].Amount
-		exchangeCurrency.Hold = accountBalance[i].Available
-		response.Currencies = append(response.Currencies, exchangeCurrency)
+		exchangeCurrency.TotalValue = accountBalance[i].Available
+		exchangeCurrency.Hold = accountBalance[i].Amount - accountBalance[i].Available
+		currencies = append(currencies, exchangeCurrency)
 	}
-	return response, nil
+	return currencies
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -121,17 +130,27 @@ func (g *Gemini) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (g *Gemini) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (g *Gemini) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (g *Gemini) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (g *Gemini) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (g *Gemini) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	response, err := g.NewOrder(p.Pair().String(), amount, price, side.ToString(), orderType.ToString())
+	if err := order.Validate(g.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	response, err := g.NewOrder(order.CurrencyPair.Pair().String(), order.Amount, order.Price, order.OrderSide.ToString(), order.OrderType.ToString())
 
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
@@ -184,6 +203,89 @@ func (g *Gemini) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (g *Gemini) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := g.GetOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		var p pair.CurrencyPair
+		if len(resp[i].Symbol) == 6 {
+			p = pair.NewCurrencyPair(common.StringToUpper(resp[i].Symbol[0:3]),
+				common.StringToUpper(resp[i].Symbol[3:]))
+		}
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        g.Name,
+			ID:              strconv.FormatInt(resp[i].OrderID, 10),
+			ClientOrderID:   resp[i].ClientOrderID,
+			CurrencyPair:    p,
+			OrderSide:       exchange.FormatOrderSide(resp[i].Side),
+			OrderType:       exchange.FormatOrderType(resp[i].Type),
+			OrderDate:       time.Unix(0, resp[i].TimestampMS*int64(time.Millisecond)),
+			Price:           resp[i].Price,
+			Amount:          resp[i].OriginalAmount,
+			ExecutedAmount:  resp[i].ExecutedAmount,
+			RemainingAmount: resp[i].RemainingAmount,
+		})
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (g *Gemini) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = g.GetEnabledCurrencies()
+	}
+
+	var since int64
+	if !getOrdersRequest.StartTicks.IsZero() {
+		since = getOrdersRequest.StartTicks.Unix()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := g.GetTradeHistory(exchange.FormatExchangeCurrency(g.Name, currencies[x]).String(), since)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp {
+			tradeDate := time.Unix(0, resp[i].TimestampMS*int64(time.Millisecond))
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:       g.Name,
+				ID:             strconv.FormatInt(resp[i].OrderID, 10),
+				ClientOrderID:  resp[i].ClientOrderID,
+				CurrencyPair:   currencies[x],
+				OrderSide:      exchange.FormatOrderSide(resp[i].Type),
+				OrderDate:      tradeDate,
+				Status:         "FILLED",
+				Price:          resp[i].Price,
+				Amount:         resp[i].Amount,
+				ExecutedAmount: resp[i].Amount,
+				Fee:            resp[i].FeeAmount,
+				FeeCurrency:    resp[i].FeeCurrency,
+				Fills: []exchange.OrderFill{{
+					ID:          strconv.FormatInt(resp[i].TID, 10),
+					Timestamp:   tradeDate,
+					Price:       resp[i].Price,
+					Amount:      resp[i].Amount,
+					Fee:         resp[i].FeeAmount,
+					FeeCurrency: resp[i].FeeCurrency,
+				}},
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (g *Gemini) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
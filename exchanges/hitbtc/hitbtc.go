@@ -61,6 +61,16 @@ func (h *HitBTC) SetDefaults() {
 	h.Verbose = false
 	h.RESTPollingDelay = 10
 	h.APIWithdrawPermissions = exchange.AutoWithdrawCrypto
+	h.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+	h.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true, Websocket: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.TickerChannel, exchange.OrderbookChannel},
+	}
 	h.RequestCurrencyPairFormat.Delimiter = ""
 	h.RequestCurrencyPairFormat.Uppercase = true
 	h.ConfigCurrencyPairFormat.Delimiter = "-"
@@ -121,6 +131,12 @@ func (h *HitBTC) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		h.Websocket.SetChannelSubscriber(h.Subscribe)
+		h.Websocket.SetChannelUnsubscriber(h.Unsubscribe)
+		err = h.Websocket.SubscribeToChannels(h.GenerateSubscriptions(h.GetEnabledCurrencies()))
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 }
 
@@ -3,6 +3,7 @@ package hitbtc
 import (
 	"testing"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
@@ -199,7 +200,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.DGD,
 		SecondCurrency: symbol.BTC,
 	}
-	response, err := h.SubmitOrder(p, exchange.Buy, exchange.Market, 1, 10, "1234234")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       1,
+		Price:        10,
+		ClientID:     "1234234",
+	}
+	response, err := h.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -269,3 +278,57 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	h.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := h.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	h.SetDefaults()
+	TestSetup(t)
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := h.GetOrderHistory(getOrdersRequest)
+	if err != common.ErrNotYetImplemented {
+		t.Errorf("Expected '%v', received: '%v'", common.ErrNotYetImplemented, err)
+	}
+}
+
+func TestWsOrderReport(t *testing.T) {
+	raw := []byte(`{"jsonrpc":"2.0","method":"report","params":{"id":"4345697765","clientOrderId":"53b7cf917963464a811a4af426102c19","symbol":"ETHBTC","side":"sell","status":"partiallyFilled","type":"limit","timeInForce":"GTC","quantity":"0.013","price":"0.100000","cumQuantity":"0.005","createdAt":"2017-10-20T12:29:43.166Z","updatedAt":"2017-10-20T12:29:44.166Z","reportType":"trade","tradeQuantity":"0.005","tradePrice":"0.100000","tradeId":55051694,"tradeFee":"-0.000000005"}}`)
+
+	var report WsOrderReport
+	err := common.JSONDecode(raw, &report)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update := h.wsOrderUpdate(&report.Params)
+	if update.Status != exchange.PartiallyFilledOrderStatus || update.OrderSide != exchange.Sell ||
+		update.OrderType != exchange.Limit || update.Amount != 0.013 || update.ExecutedAmount != 0.005 {
+		t.Errorf("Unexpected order update %+v", update)
+	}
+
+	fill := h.wsFillEvent(&report.Params)
+	if fill.TradeID != "55051694" || fill.Amount != 0.005 || fill.Price != 0.1 ||
+		fill.OrderID != "4345697765" {
+		t.Errorf("Unexpected fill %+v", fill)
+	}
+}
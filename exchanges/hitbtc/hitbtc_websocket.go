@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -46,63 +47,92 @@ func (h *HitBTC) WsConnect() error {
 	go h.WsReadData()
 	go h.WsHandleData()
 
-	err = h.WsSubscribe()
-	if err != nil {
-		return err
+	if h.AuthenticatedAPISupport {
+		err = h.wsLogin()
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// WsSubscribe subscribes to the relevant channels
-func (h *HitBTC) WsSubscribe() error {
-	enabledPairs := h.GetEnabledCurrencies()
-	for _, p := range enabledPairs {
-		pF := exchange.FormatExchangeCurrency(h.GetName(), p)
-
-		tickerSubReq, err := common.JSONEncode(WsNotification{
-			JSONRPCVersion: rpcVersion,
-			Method:         "subscribeTicker",
-			Params:         params{Symbol: pF.String()},
-		})
-		if err != nil {
-			return err
-		}
+// wsLogin authenticates the websocket connection and subscribes to the order
+// reports of the account
+func (h *HitBTC) wsLogin() error {
+	nonce := strconv.FormatInt(time.Now().UnixNano(), 10)
+	hmac := common.GetHMAC(common.HashSHA256, []byte(nonce), []byte(h.APISecret))
+
+	login, err := common.JSONEncode(WsRequest{
+		Method: "login",
+		Params: WsLoginParams{
+			Algo:      "HS256",
+			PKey:      h.APIKey,
+			Nonce:     nonce,
+			Signature: common.HexEncodeToString(hmac),
+		},
+		ID: "login",
+	})
+	if err != nil {
+		return err
+	}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, tickerSubReq)
-		if err != nil {
-			return nil
-		}
+	err = h.WebsocketConn.WriteMessage(websocket.TextMessage, login)
+	if err != nil {
+		return err
+	}
 
-		orderbookSubReq, err := common.JSONEncode(WsNotification{
-			JSONRPCVersion: rpcVersion,
-			Method:         "subscribeOrderbook",
-			Params:         params{Symbol: pF.String()},
-		})
-		if err != nil {
-			return err
-		}
+	reports, err := common.JSONEncode(WsRequest{
+		Method: "subscribeReports",
+		Params: struct{}{},
+		ID:     "subscribeReports",
+	})
+	if err != nil {
+		return err
+	}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, orderbookSubReq)
-		if err != nil {
-			return nil
-		}
+	return h.WebsocketConn.WriteMessage(websocket.TextMessage, reports)
+}
 
-		tradeSubReq, err := common.JSONEncode(WsNotification{
-			JSONRPCVersion: rpcVersion,
-			Method:         "subscribeTrades",
-			Params:         params{Symbol: pF.String()},
-		})
-		if err != nil {
-			return err
-		}
+// wsChannelMethods maps the generic websocket channels to HitBTC subscription
+// method suffixes
+var wsChannelMethods = map[string]string{
+	exchange.TickerChannel:    "Ticker",
+	exchange.OrderbookChannel: "Orderbook",
+	exchange.TradeChannel:     "Trades",
+}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, tradeSubReq)
-		if err != nil {
-			return nil
-		}
+// Subscribe sends a websocket message to receive data from the channel
+func (h *HitBTC) Subscribe(channelToSubscribe exchange.WebsocketChannelSubscription) error {
+	return h.wsSendChannelRequest("subscribe", channelToSubscribe)
+}
+
+// Unsubscribe sends a websocket message to stop receiving data from the
+// channel
+func (h *HitBTC) Unsubscribe(channelToUnsubscribe exchange.WebsocketChannelSubscription) error {
+	return h.wsSendChannelRequest("unsubscribe", channelToUnsubscribe)
+}
+
+// wsSendChannelRequest sends a subscribe or unsubscribe notification for a
+// channel subscription
+func (h *HitBTC) wsSendChannelRequest(action string, channel exchange.WebsocketChannelSubscription) error {
+	method, ok := wsChannelMethods[channel.Channel]
+	if !ok {
+		return fmt.Errorf("hitbtc_websocket.go error - unsupported channel %s",
+			channel.Channel)
 	}
-	return nil
+
+	pF := exchange.FormatExchangeCurrency(h.GetName(), channel.Currency)
+	req, err := common.JSONEncode(WsNotification{
+		JSONRPCVersion: rpcVersion,
+		Method:         action + method,
+		Params:         params{Symbol: pF.String()},
+	})
+	if err != nil {
+		return err
+	}
+
+	return h.WebsocketConn.WriteMessage(websocket.TextMessage, req)
 }
 
 // WsReadData reads from the websocket connection
@@ -221,6 +251,29 @@ func (h *HitBTC) WsHandleData() {
 				if err != nil {
 					log.Fatal(err)
 				}
+
+			case "activeOrders":
+				var activeOrders WsActiveOrders
+				err := common.JSONDecode(resp.Raw, &activeOrders)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				for i := range activeOrders.Params {
+					h.Websocket.DataHandler <- h.wsOrderUpdate(&activeOrders.Params[i])
+				}
+
+			case "report":
+				var report WsOrderReport
+				err := common.JSONDecode(resp.Raw, &report)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				if report.Params.ReportType == "trade" {
+					h.Websocket.DataHandler <- h.wsFillEvent(&report.Params)
+				}
+				h.Websocket.DataHandler <- h.wsOrderUpdate(&report.Params)
 			}
 		}
 	}
@@ -296,6 +349,51 @@ func (h *HitBTC) WsProcessOrderbookUpdate(ob WsOrderbook) error {
 	return nil
 }
 
+// wsOrderUpdate converts an order report into an order update
+func (h *HitBTC) wsOrderUpdate(report *WsReport) exchange.OrderUpdate {
+	return exchange.OrderUpdate{
+		Timestamp:       parseWsTime(report.UpdatedAt),
+		Exchange:        h.GetName(),
+		AssetType:       "SPOT",
+		Pair:            pair.NewCurrencyPairFromString(report.Symbol),
+		OrderID:         report.ID,
+		ClientOrderID:   report.ClientOrderID,
+		OrderSide:       exchange.FormatOrderSide(report.Side),
+		OrderType:       exchange.FormatOrderType(report.Type),
+		Status:          exchange.FormatOrderStatus(report.Status),
+		Price:           report.Price,
+		Amount:          report.Quantity,
+		ExecutedAmount:  report.CumQuantity,
+		RemainingAmount: report.Quantity - report.CumQuantity,
+	}
+}
+
+// wsFillEvent converts a trade report into a fill event
+func (h *HitBTC) wsFillEvent(report *WsReport) exchange.FillEvent {
+	return exchange.FillEvent{
+		Timestamp: parseWsTime(report.UpdatedAt),
+		Exchange:  h.GetName(),
+		AssetType: "SPOT",
+		Pair:      pair.NewCurrencyPairFromString(report.Symbol),
+		OrderID:   report.ID,
+		TradeID:   strconv.FormatInt(report.TradeID, 10),
+		OrderSide: exchange.FormatOrderSide(report.Side),
+		Price:     report.TradePrice,
+		Amount:    report.TradeQuantity,
+		Fee:       report.TradeFee,
+	}
+}
+
+// parseWsTime parses an RFC3339 websocket timestamp, returning the current
+// time if it is missing
+func parseWsTime(t string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return time.Now()
+	}
+	return parsed
+}
+
 type capture struct {
 	Method string `json:"method"`
 	Result bool   `json:"result"`
@@ -370,3 +468,42 @@ type WsTrade struct {
 		Symbol string `json:"symbol"`
 	} `json:"params"`
 }
+
+// WsLoginParams defines the params of a websocket login request
+type WsLoginParams struct {
+	Algo      string `json:"algo"`
+	PKey      string `json:"pKey"`
+	Nonce     string `json:"nonce"`
+	Signature string `json:"signature"`
+}
+
+// WsReport defines the state of an order placed by the authenticated account
+type WsReport struct {
+	ID            string  `json:"id"`
+	ClientOrderID string  `json:"clientOrderId"`
+	Symbol        string  `json:"symbol"`
+	Side          string  `json:"side"`
+	Status        string  `json:"status"`
+	Type          string  `json:"type"`
+	TimeInForce   string  `json:"timeInForce"`
+	Quantity      float64 `json:"quantity,string"`
+	Price         float64 `json:"price,string"`
+	CumQuantity   float64 `json:"cumQuantity,string"`
+	CreatedAt     string  `json:"createdAt"`
+	UpdatedAt     string  `json:"updatedAt"`
+	ReportType    string  `json:"reportType"`
+	TradeQuantity float64 `json:"tradeQuantity,string"`
+	TradePrice    float64 `json:"tradePrice,string"`
+	TradeID       int64   `json:"tradeId"`
+	TradeFee      float64 `json:"tradeFee,string"`
+}
+
+// WsActiveOrders defines the active orders sent after subscribing to reports
+type WsActiveOrders struct {
+	Params []WsReport `json:"params"`
+}
+
+// WsOrderReport defines an order report notification
+type WsOrderReport struct {
+	Params WsReport `json:"params"`
+}
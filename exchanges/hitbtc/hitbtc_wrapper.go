@@ -5,10 +5,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -148,17 +150,27 @@ func (h *HitBTC) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (h *HitBTC) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (h *HitBTC) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (h *HitBTC) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (h *HitBTC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (h *HitBTC) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	response, err := h.PlaceOrder(p.Pair().String(), price, amount, common.StringToLower(orderType.ToString()), common.StringToLower(side.ToString()))
+	if err := order.Validate(h.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	response, err := h.PlaceOrder(order.CurrencyPair.Pair().String(), order.Price, order.Amount, common.StringToLower(order.OrderType.ToString()), common.StringToLower(order.OrderSide.ToString()))
 
 	if response.OrderNumber > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response.OrderNumber)
@@ -213,6 +225,47 @@ func (h *HitBTC) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (h *HitBTC) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = h.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := h.GetActiveorders(exchange.FormatExchangeCurrency(h.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp {
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:        h.Name,
+				ID:              strconv.FormatInt(resp[i].ID, 10),
+				ClientOrderID:   resp[i].ClientOrderID,
+				CurrencyPair:    currencies[x],
+				OrderSide:       exchange.FormatOrderSide(resp[i].Side),
+				OrderType:       exchange.FormatOrderType(resp[i].Type),
+				OrderDate:       resp[i].CreatedAt,
+				Status:          resp[i].Status,
+				Price:           resp[i].Price,
+				Amount:          resp[i].Quantity,
+				ExecutedAmount:  resp[i].CumQuantity,
+				RemainingAmount: resp[i].Quantity - resp[i].CumQuantity,
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (h *HitBTC) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return nil, common.ErrNotYetImplemented
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (h *HitBTC) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
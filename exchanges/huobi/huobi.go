@@ -77,6 +77,18 @@ func (h *HUOBI) SetDefaults() {
 	h.Verbose = false
 	h.RESTPollingDelay = 10
 	h.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithSetup
+	h.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+	h.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		TradeFetching:     exchange.FeatureSupport{Websocket: true},
+		KlineFetching:     exchange.FeatureSupport{Websocket: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.OrderbookChannel, exchange.TradeChannel, exchange.KlineChannel},
+	}
 	h.RequestCurrencyPairFormat.Delimiter = ""
 	h.RequestCurrencyPairFormat.Uppercase = false
 	h.ConfigCurrencyPairFormat.Delimiter = "-"
@@ -15,6 +15,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 )
 
 // Please supply you own test keys here for due diligence testing.
@@ -420,12 +421,14 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.BTC,
 		SecondCurrency: symbol.USDT,
 	}
-	accounts, err := h.GetAccounts()
-	if err != nil {
-		t.Errorf("Failed to get accounts. Err: %s", err)
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Amount:       1,
+		Price:        10,
 	}
-
-	response, err := h.SubmitOrder(p, exchange.Buy, exchange.Limit, 1, 10, strconv.FormatInt(accounts[0].ID, 10))
+	response, err := h.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -509,3 +512,84 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	h.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := h.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	h.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := h.GetOrderHistory(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get order history: %s", err)
+	}
+}
+
+func TestWsProcessOrderbook(t *testing.T) {
+	var hb HUOBI
+	hb.Name = "Huobi"
+	hb.WebsocketInit()
+	hb.Websocket.DataHandler = make(chan interface{}, 3)
+	p := pair.NewCurrencyPairFromString("btcusdt")
+
+	depth := func(version int64, bid float64) WsDepth {
+		var d WsDepth
+		d.Tick.Version = version
+		d.Tick.Timestamp = version
+		d.Tick.Bids = []interface{}{[]interface{}{bid, 2.0}}
+		d.Tick.Asks = []interface{}{[]interface{}{101.0, 3.0}}
+		return d
+	}
+
+	for _, d := range []WsDepth{depth(10, 100), depth(15, 99), depth(12, 98)} {
+		err := hb.WsProcessOrderbook(d, "btcusdt")
+		if err != nil {
+			t.Fatal("Test failed - WsProcessOrderbook() error", err)
+		}
+	}
+
+	if len(hb.Websocket.DataHandler) != 2 {
+		t.Errorf("Test failed - WsProcessOrderbook() expected the outdated snapshot to be dropped, received %d updates",
+			len(hb.Websocket.DataHandler))
+	}
+
+	err := hb.Websocket.Orderbook.VerifyChecksum(p, "SPOT", 1, func(ob orderbook.Base) uint32 {
+		if len(ob.Bids) != 1 || ob.Bids[0].Price != 99 || ob.Bids[0].Amount != 2 {
+			t.Errorf("Test failed - WsProcessOrderbook() unexpected bids %+v", ob.Bids)
+		}
+		return 1
+	})
+	if err != nil {
+		t.Error("Test failed - WsProcessOrderbook() orderbook not loaded", err)
+	}
+
+	d := depth(20, 100)
+	d.Tick.Bids = []interface{}{[]interface{}{"100"}}
+	if hb.WsProcessOrderbook(d, "btcusdt") == nil {
+		t.Error("Test failed - WsProcessOrderbook() expected error parsing invalid level")
+	}
+}
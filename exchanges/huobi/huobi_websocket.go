@@ -147,7 +147,11 @@ func (h *HUOBI) WsHandleData() {
 
 				data := common.SplitStrings(depth.Channel, ".")
 
-				h.WsProcessOrderbook(depth, data[1])
+				err = h.WsProcessOrderbook(depth, data[1])
+				if err != nil {
+					h.Websocket.DataHandler <- fmt.Errorf("huobi_websocket.go orderbook error: %s",
+						err)
+				}
 
 			case common.StringContains(init.Channel, "kline"):
 				var kline WsKline
@@ -190,35 +194,53 @@ func (h *HUOBI) WsHandleData() {
 	}
 }
 
-// WsProcessOrderbook processes new orderbook data
+// WsProcessOrderbook processes new orderbook data. Huobi pushes a full
+// snapshot of the orderbook on every update and does not publish a checksum,
+// so snapshots are validated against their version and any snapshot older
+// than the one already loaded is dropped
 func (h *HUOBI) WsProcessOrderbook(ob WsDepth, symbol string) error {
-	var bids []orderbook.Item
-	for _, data := range ob.Tick.Bids {
-		bidLevel := data.([]interface{})
-		bids = append(bids, orderbook.Item{Price: bidLevel[0].(float64),
-			Amount: bidLevel[0].(float64)})
+	bids, err := parseDepthLevels(ob.Tick.Bids)
+	if err != nil {
+		return err
 	}
 
-	var asks []orderbook.Item
-	for _, data := range ob.Tick.Asks {
-		askLevel := data.([]interface{})
-		asks = append(asks, orderbook.Item{Price: askLevel[0].(float64),
-			Amount: askLevel[0].(float64)})
+	asks, err := parseDepthLevels(ob.Tick.Asks)
+	if err != nil {
+		return err
 	}
 
 	p := pair.NewCurrencyPairFromString(symbol)
 
+	version := ob.Tick.Version
+	if version != 0 {
+		err = h.Websocket.Orderbook.ValidateSequence(p, "SPOT", version, version)
+		switch err {
+		case nil, exchange.ErrOrderbookSequenceGap:
+			// Versions are not contiguous and a snapshot replaces the whole
+			// orderbook, so a gap loses no updates
+		case exchange.ErrOrderbookUpdateOutdated:
+			return nil
+		default:
+			return err
+		}
+	}
+
 	var newOrderbook orderbook.Base
 	newOrderbook.Asks = asks
 	newOrderbook.Bids = bids
 	newOrderbook.CurrencyPair = symbol
-	newOrderbook.LastUpdated = time.Now()
+	newOrderbook.LastUpdated = time.Unix(0, ob.Tick.Timestamp*int64(time.Millisecond))
 	newOrderbook.Pair = p
+	newOrderbook.AssetType = "SPOT"
 
-	err := h.Websocket.Orderbook.LoadSnapshot(newOrderbook, h.GetName())
+	h.Websocket.Orderbook.Invalidate(p, "SPOT")
+	err = h.Websocket.Orderbook.LoadSnapshot(newOrderbook, h.GetName())
 	if err != nil {
 		return err
 	}
+	if version != 0 {
+		h.Websocket.Orderbook.SetSequence(p, "SPOT", version)
+	}
 
 	h.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
 		Pair:     p,
@@ -229,6 +251,30 @@ func (h *HUOBI) WsProcessOrderbook(ob WsDepth, symbol string) error {
 	return nil
 }
 
+// parseDepthLevels converts the price and amount pairs of depth levels into
+// orderbook items
+func parseDepthLevels(levels []interface{}) ([]orderbook.Item, error) {
+	var items []orderbook.Item
+	for x := range levels {
+		level, ok := levels[x].([]interface{})
+		if !ok || len(level) < 2 {
+			return nil, fmt.Errorf("huobi_websocket.go invalid depth level %v", levels[x])
+		}
+
+		price, ok := level[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf("huobi_websocket.go invalid depth price %v", level[0])
+		}
+
+		amount, ok := level[1].(float64)
+		if !ok {
+			return nil, fmt.Errorf("huobi_websocket.go invalid depth amount %v", level[1])
+		}
+		items = append(items, orderbook.Item{Price: price, Amount: amount})
+	}
+	return items, nil
+}
+
 // WsSubscribe susbcribes to the current websocket streams based on the enabled
 // pair
 func (h *HUOBI) WsSubscribe() error {
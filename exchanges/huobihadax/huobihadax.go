@@ -69,6 +69,15 @@ func (h *HUOBIHADAX) SetDefaults() {
 	h.Verbose = false
 	h.RESTPollingDelay = 10
 	h.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithSetup
+	h.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+	h.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	h.RequestCurrencyPairFormat.Delimiter = ""
 	h.RequestCurrencyPairFormat.Uppercase = false
 	h.ConfigCurrencyPairFormat.Delimiter = "-"
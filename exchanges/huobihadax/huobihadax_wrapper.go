@@ -6,10 +6,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -178,7 +180,7 @@ func (h *HUOBIHADAX) GetAccountInfo() (exchange.AccountInfo, error) {
 	for key, data := range currencyData {
 		balances = append(balances, exchange.AccountCurrencyInfo{
 			CurrencyName: key,
-			TotalValue:   data.Avail + data.Hold,
+			TotalValue:   data.Avail,
 			Hold:         data.Hold,
 		})
 	}
@@ -194,39 +196,54 @@ func (h *HUOBIHADAX) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (h *HUOBIHADAX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (h *HUOBIHADAX) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (h *HUOBIHADAX) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (h *HUOBIHADAX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (h *HUOBIHADAX) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	accountID, err := strconv.ParseInt(clientID, 0, 64)
+	if err := order.Validate(h.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	account, err := h.GetAccountID()
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	accountID, err := strconv.ParseInt(account, 10, 64)
 	if err != nil {
 		return submitOrderResponse, err
 	}
 
 	var formattedType SpotNewOrderRequestParamsType
 	var params = SpotNewOrderRequestParams{
-		Amount:    amount,
+		Amount:    order.Amount,
 		Source:    "api",
-		Symbol:    common.StringToLower(p.Pair().String()),
+		Symbol:    common.StringToLower(order.CurrencyPair.Pair().String()),
 		AccountID: int(accountID),
 	}
 
-	if side == exchange.Buy && orderType == exchange.Market {
+	if order.OrderSide == exchange.Buy && order.OrderType == exchange.Market {
 		formattedType = SpotNewOrderRequestTypeBuyMarket
-	} else if side == exchange.Sell && orderType == exchange.Market {
+	} else if order.OrderSide == exchange.Sell && order.OrderType == exchange.Market {
 		formattedType = SpotNewOrderRequestTypeSellMarket
-	} else if side == exchange.Buy && orderType == exchange.Limit {
+	} else if order.OrderSide == exchange.Buy && order.OrderType == exchange.Limit {
 		formattedType = SpotNewOrderRequestTypeBuyLimit
-		params.Price = price
-	} else if side == exchange.Sell && orderType == exchange.Limit {
+		params.Price = order.Price
+	} else if order.OrderSide == exchange.Sell && order.OrderType == exchange.Limit {
 		formattedType = SpotNewOrderRequestTypeSellLimit
-		params.Price = price
+		params.Price = order.Price
 	} else {
 		return submitOrderResponse, errors.New("Unsupported order type")
 	}
@@ -290,6 +307,104 @@ func (h *HUOBIHADAX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (h *HUOBIHADAX) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	accountID, err := h.GetAccountID()
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = h.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := h.GetOpenOrders(accountID,
+			exchange.FormatExchangeCurrency(h.Name, currencies[x]).String(),
+			"",
+			500)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, h.formatOrders(currencies[x], resp)...)
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (h *HUOBIHADAX) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = h.GetEnabledCurrencies()
+	}
+
+	var start, end string
+	if !getOrdersRequest.StartTicks.IsZero() {
+		start = getOrdersRequest.StartTicks.Format("2006-01-02")
+	}
+	if !getOrdersRequest.EndTicks.IsZero() {
+		end = getOrdersRequest.EndTicks.Format("2006-01-02")
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := h.GetOrders(exchange.FormatExchangeCurrency(h.Name, currencies[x]).String(),
+			"",
+			start,
+			end,
+			"partial-canceled,filled,canceled",
+			"",
+			"",
+			"")
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, h.formatOrders(currencies[x], resp)...)
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrders converts Huobi Hadax orders to the exchange.OrderDetail type
+func (h *HUOBIHADAX) formatOrders(p pair.CurrencyPair, resp []OrderInfo) []exchange.OrderDetail {
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		var side exchange.OrderSide
+		var orderType exchange.OrderType
+		orderTypes := common.SplitStrings(resp[i].Type, "-")
+		if len(orderTypes) == 2 {
+			side = exchange.FormatOrderSide(orderTypes[0])
+			orderType = exchange.FormatOrderType(orderTypes[1])
+		}
+
+		amount, _ := strconv.ParseFloat(resp[i].Amount, 64)
+		price, _ := strconv.ParseFloat(resp[i].Price, 64)
+		executed, _ := strconv.ParseFloat(resp[i].FieldAmount, 64)
+		fee, _ := strconv.ParseFloat(resp[i].FieldFees, 64)
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        h.Name,
+			AccountID:       strconv.Itoa(resp[i].AccountID),
+			ID:              strconv.Itoa(resp[i].ID),
+			CurrencyPair:    p,
+			OrderSide:       side,
+			OrderType:       orderType,
+			OrderDate:       time.Unix(0, resp[i].CreatedAt*int64(time.Millisecond)),
+			Status:          resp[i].State,
+			Price:           price,
+			Amount:          amount,
+			ExecutedAmount:  executed,
+			RemainingAmount: amount - executed,
+			Fee:             fee,
+		})
+	}
+	return orders
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (h *HUOBIHADAX) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -3,15 +3,17 @@ package itbit
 import (
 	"fmt"
 	"log"
-	"net/url"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"net/url"
 )
 
 // Start starts the ItBit go routine
@@ -131,7 +133,7 @@ func (i *ItBit) GetAccountInfo() (exchange.AccountInfo, error) {
 				amounts[cb.Currency] = &balance{}
 			}
 
-			amounts[cb.Currency].TotalValue += cb.TotalBalance
+			amounts[cb.Currency].TotalValue += cb.AvailableBalance
 			amounts[cb.Currency].Hold += cb.TotalBalance - cb.AvailableBalance
 		}
 	}
@@ -156,16 +158,26 @@ func (i *ItBit) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (i *ItBit) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (i *ItBit) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (i *ItBit) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (i *ItBit) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (i *ItBit) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(i.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var wallet string
 
 	wallets, err := i.GetWallets(nil)
@@ -176,17 +188,17 @@ func (i *ItBit) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderT
 	// Determine what wallet ID to use if there is any actual available currency to make the trade!
 	for _, i := range wallets {
 		for j := range i.Balances {
-			if i.Balances[j].Currency == p.FirstCurrency.String() && i.Balances[j].AvailableBalance >= amount {
+			if i.Balances[j].Currency == order.CurrencyPair.FirstCurrency.String() && i.Balances[j].AvailableBalance >= order.Amount {
 				wallet = i.ID
 			}
 		}
 	}
 
 	if wallet == "" {
-		return submitOrderResponse, fmt.Errorf("No wallet found with currency: %s with amount >= %v", p.FirstCurrency.String(), amount)
+		return submitOrderResponse, fmt.Errorf("No wallet found with currency: %s with amount >= %v", order.CurrencyPair.FirstCurrency.String(), order.Amount)
 	}
 
-	response, err := i.PlaceOrder(wallet, side.ToString(), orderType.ToString(), p.FirstCurrency.String(), amount, price, p.Pair().String(), "")
+	response, err := i.PlaceOrder(wallet, order.OrderSide.ToString(), order.OrderType.ToString(), order.CurrencyPair.FirstCurrency.String(), order.Amount, order.Price, order.CurrencyPair.Pair().String(), "")
 
 	if response.ID != "" {
 		submitOrderResponse.OrderID = response.ID
@@ -236,6 +248,74 @@ func (i *ItBit) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (i *ItBit) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	orders, err := i.getOrdersByStatus("open")
+	if err != nil {
+		return nil, err
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (i *ItBit) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	var orders []exchange.OrderDetail
+	for _, status := range []string{"filled", "cancelled", "rejected"} {
+		resp, err := i.getOrdersByStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, resp...)
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// getOrdersByStatus retrieves orders matching the supplied status across all
+// wallets and converts them to the exchange.OrderDetail type
+func (i *ItBit) getOrdersByStatus(status string) ([]exchange.OrderDetail, error) {
+	wallets, err := i.GetWallets(url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for _, wallet := range wallets {
+		resp, err := i.GetOrders(wallet.ID, "", status, 0, 50)
+		if err != nil {
+			return nil, err
+		}
+
+		for x := range resp {
+			var p pair.CurrencyPair
+			if len(resp[x].Instrument) == 6 {
+				p = pair.NewCurrencyPair(resp[x].Instrument[0:3], resp[x].Instrument[3:])
+			}
+
+			orderDate, _ := time.Parse(time.RFC3339, resp[x].CreatedTime)
+
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:        i.Name,
+				AccountID:       resp[x].WalletID,
+				ID:              resp[x].ID,
+				ClientOrderID:   resp[x].ClientOrderIdentifier,
+				CurrencyPair:    p,
+				OrderSide:       exchange.FormatOrderSide(resp[x].Side),
+				OrderType:       exchange.FormatOrderType(resp[x].Type),
+				OrderDate:       orderDate,
+				Status:          resp[x].Status,
+				Price:           resp[x].Price,
+				Amount:          resp[x].Amount,
+				ExecutedAmount:  resp[x].AmountFilled,
+				RemainingAmount: resp[x].Amount - resp[x].AmountFilled,
+			})
+		}
+	}
+	return orders, nil
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (i *ItBit) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -0,0 +1,223 @@
+package kline
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Interval defines the time period covered by a single candle
+type Interval time.Duration
+
+// Supported candle intervals
+const (
+	OneMin     = Interval(time.Minute)
+	ThreeMin   = 3 * OneMin
+	FiveMin    = 5 * OneMin
+	FifteenMin = 15 * OneMin
+	ThirtyMin  = 30 * OneMin
+	OneHour    = Interval(time.Hour)
+	TwoHour    = 2 * OneHour
+	FourHour   = 4 * OneHour
+	SixHour    = 6 * OneHour
+	EightHour  = 8 * OneHour
+	TwelveHour = 12 * OneHour
+	OneDay     = 24 * OneHour
+	ThreeDay   = 3 * OneDay
+	OneWeek    = 7 * OneDay
+)
+
+// vars related to candle fetching
+var (
+	ErrInvalidInterval     = errors.New("candle interval must be at least one minute")
+	ErrInvalidTimeRange    = errors.New("candle start time must be before end time")
+	ErrUnsupportedInterval = errors.New("candle interval is not supported and can not be resampled from a supported interval")
+	ErrNoFetchFunc         = errors.New("candle fetch function not set")
+)
+
+// Candle holds the open, high, low, close and volume of a single interval
+// starting at Time
+type Candle struct {
+	Time   time.Time `json:"time"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"`
+}
+
+// Item holds the candles of an exchange currency pair and asset type
+type Item struct {
+	Exchange  string            `json:"exchange"`
+	Pair      pair.CurrencyPair `json:"pair"`
+	AssetType string            `json:"assetType"`
+	Interval  Interval          `json:"interval"`
+	Candles   []Candle          `json:"candles"`
+}
+
+// FetchFunc retrieves candles from an exchange for an interval natively
+// supported by the exchange and a time range no longer than the exchange's
+// request limit
+type FetchFunc func(interval Interval, start, end time.Time) ([]Candle, error)
+
+// Fetcher retrieves candles for any interval and time range, paginating over
+// exchange request limits and resampling intervals the exchange does not
+// support
+type Fetcher struct {
+	// SupportedIntervals are the intervals natively supported by the exchange
+	SupportedIntervals []Interval
+	// Limit is the maximum number of candles returned per request, 0 if the
+	// exchange does not limit the number of candles returned
+	Limit int
+	Fetch FetchFunc
+}
+
+// Duration returns the interval as a time.Duration
+func (i Interval) Duration() time.Duration {
+	return time.Duration(i)
+}
+
+// String returns the short form of the interval, e.g. 1m, 4h, 1d or 1w
+func (i Interval) String() string {
+	switch {
+	case i >= OneWeek && i%OneWeek == 0:
+		return fmt.Sprintf("%dw", i/OneWeek)
+	case i >= OneDay && i%OneDay == 0:
+		return fmt.Sprintf("%dd", i/OneDay)
+	case i >= OneHour && i%OneHour == 0:
+		return fmt.Sprintf("%dh", i/OneHour)
+	case i >= OneMin && i%OneMin == 0:
+		return fmt.Sprintf("%dm", i/OneMin)
+	}
+	return time.Duration(i).String()
+}
+
+// Minutes returns the number of whole minutes in the interval
+func (i Interval) Minutes() int64 {
+	return int64(i / OneMin)
+}
+
+// Seconds returns the number of whole seconds in the interval
+func (i Interval) Seconds() int64 {
+	return int64(time.Duration(i) / time.Second)
+}
+
+// GetNativeInterval returns the interval to request from an exchange in order
+// to build candles for the requested interval. If the interval is not
+// supported the largest supported interval it can be resampled from is
+// returned
+func (f *Fetcher) GetNativeInterval(interval Interval) (Interval, error) {
+	if interval < OneMin {
+		return 0, ErrInvalidInterval
+	}
+
+	var native Interval
+	for _, supported := range f.SupportedIntervals {
+		if supported == interval {
+			return supported, nil
+		}
+		if supported < interval && interval%supported == 0 && supported > native {
+			native = supported
+		}
+	}
+
+	if native == 0 {
+		return 0, ErrUnsupportedInterval
+	}
+	return native, nil
+}
+
+// GetCandles returns the candles for the interval between start and end
+func (f *Fetcher) GetCandles(interval Interval, start, end time.Time) ([]Candle, error) {
+	if f.Fetch == nil {
+		return nil, ErrNoFetchFunc
+	}
+
+	if !start.Before(end) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	native, err := f.GetNativeInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	start = start.UTC().Truncate(interval.Duration())
+	end = end.UTC()
+
+	window := end.Sub(start)
+	if f.Limit > 0 {
+		window = time.Duration(f.Limit) * native.Duration()
+	}
+
+	var candles []Candle
+	for windowStart := start; windowStart.Before(end); windowStart = windowStart.Add(window) {
+		windowEnd := windowStart.Add(window)
+		if windowEnd.After(end) {
+			windowEnd = end
+		}
+
+		resp, err := f.Fetch(native, windowStart, windowEnd)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, resp...)
+	}
+
+	candles = Filter(candles, start, end)
+	if native != interval {
+		candles = Resample(candles, interval)
+	}
+	return candles, nil
+}
+
+// Filter returns the candles starting between start and end sorted by time
+// with any duplicate candles removed
+func Filter(candles []Candle, start, end time.Time) []Candle {
+	sort.SliceStable(candles, func(i, j int) bool {
+		return candles[i].Time.Before(candles[j].Time)
+	})
+
+	var filtered []Candle
+	for x := range candles {
+		if candles[x].Time.Before(start) || !candles[x].Time.Before(end) {
+			continue
+		}
+		if len(filtered) > 0 && filtered[len(filtered)-1].Time.Equal(candles[x].Time) {
+			// Keep the latest candle received for the same time
+			filtered[len(filtered)-1] = candles[x]
+			continue
+		}
+		filtered = append(filtered, candles[x])
+	}
+	return filtered
+}
+
+// Resample combines time sorted candles of a smaller interval into candles of
+// the supplied interval
+func Resample(candles []Candle, interval Interval) []Candle {
+	var resampled []Candle
+	for x := range candles {
+		bucket := candles[x].Time.UTC().Truncate(interval.Duration())
+		if len(resampled) == 0 || !resampled[len(resampled)-1].Time.Equal(bucket) {
+			c := candles[x]
+			c.Time = bucket
+			resampled = append(resampled, c)
+			continue
+		}
+
+		c := &resampled[len(resampled)-1]
+		if candles[x].High > c.High {
+			c.High = candles[x].High
+		}
+		if candles[x].Low < c.Low {
+			c.Low = candles[x].Low
+		}
+		c.Close = candles[x].Close
+		c.Volume += candles[x].Volume
+	}
+	return resampled
+}
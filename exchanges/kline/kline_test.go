@@ -0,0 +1,164 @@
+package kline
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIntervalString(t *testing.T) {
+	t.Parallel()
+	tests := map[Interval]string{
+		OneMin:                     "1m",
+		FifteenMin:                 "15m",
+		OneHour:                    "1h",
+		FourHour:                   "4h",
+		OneDay:                     "1d",
+		ThreeDay:                   "3d",
+		OneWeek:                    "1w",
+		Interval(90 * time.Minute): "90m",
+	}
+
+	for interval, expected := range tests {
+		if interval.String() != expected {
+			t.Errorf("Test failed. Expected %s, received %s", expected, interval.String())
+		}
+	}
+
+	if OneHour.Minutes() != 60 || OneHour.Seconds() != 3600 {
+		t.Error("Test failed. Minutes() or Seconds() returned an unexpected value")
+	}
+}
+
+func TestGetNativeInterval(t *testing.T) {
+	t.Parallel()
+	f := Fetcher{SupportedIntervals: []Interval{OneMin, FiveMin, OneHour, OneDay}}
+
+	tests := []struct {
+		interval Interval
+		expected Interval
+		err      error
+	}{
+		{OneMin, OneMin, nil},
+		{FifteenMin, FiveMin, nil},
+		{FourHour, OneHour, nil},
+		{OneWeek, OneDay, nil},
+		{Interval(time.Second), 0, ErrInvalidInterval},
+	}
+
+	for _, test := range tests {
+		native, err := f.GetNativeInterval(test.interval)
+		if err != test.err {
+			t.Errorf("Test failed. %s expected error %v, received %v", test.interval, test.err, err)
+		}
+		if native != test.expected {
+			t.Errorf("Test failed. %s expected native interval %s, received %s",
+				test.interval, test.expected, native)
+		}
+	}
+
+	f.SupportedIntervals = []Interval{FiveMin}
+	if _, err := f.GetNativeInterval(ThreeMin); err != ErrUnsupportedInterval {
+		t.Errorf("Test failed. Expected %v, received %v", ErrUnsupportedInterval, err)
+	}
+}
+
+func TestResample(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	var candles []Candle
+	for x := 0; x < 6; x++ {
+		price := float64(x + 1)
+		candles = append(candles, Candle{
+			Time:   start.Add(time.Duration(x) * time.Minute),
+			Open:   price,
+			High:   price + 1,
+			Low:    price - 1,
+			Close:  price + 0.5,
+			Volume: 1,
+		})
+	}
+
+	resampled := Resample(candles, ThreeMin)
+	if len(resampled) != 2 {
+		t.Fatalf("Test failed. Expected 2 candles, received %d", len(resampled))
+	}
+
+	expected := Candle{Time: start, Open: 1, High: 4, Low: 0, Close: 3.5, Volume: 3}
+	if resampled[0] != expected {
+		t.Errorf("Test failed. Expected %+v, received %+v", expected, resampled[0])
+	}
+	if !resampled[1].Time.Equal(start.Add(3*time.Minute)) || resampled[1].Open != 4 {
+		t.Errorf("Test failed. Unexpected second candle %+v", resampled[1])
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := []Candle{
+		{Time: start.Add(2 * time.Minute), Close: 3},
+		{Time: start.Add(-time.Minute), Close: 0},
+		{Time: start, Close: 1},
+		{Time: start.Add(time.Minute), Close: 2},
+		{Time: start.Add(time.Minute), Close: 2.5},
+		{Time: start.Add(3 * time.Minute), Close: 4},
+	}
+
+	filtered := Filter(candles, start, start.Add(3*time.Minute))
+	if len(filtered) != 3 {
+		t.Fatalf("Test failed. Expected 3 candles, received %d", len(filtered))
+	}
+	if filtered[0].Close != 1 || filtered[1].Close != 2.5 || filtered[2].Close != 3 {
+		t.Errorf("Test failed. Unexpected filtered candles %+v", filtered)
+	}
+}
+
+func TestGetCandles(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	var requests int
+	f := Fetcher{
+		SupportedIntervals: []Interval{OneMin, FiveMin},
+		Limit:              5,
+		Fetch: func(interval Interval, s, e time.Time) ([]Candle, error) {
+			requests++
+			if e.Sub(s) > 5*interval.Duration() {
+				t.Errorf("Test failed. Request window %s exceeds limit", e.Sub(s))
+			}
+			var candles []Candle
+			for c := s; c.Before(e); c = c.Add(interval.Duration()) {
+				candles = append(candles, Candle{Time: c, Open: 1, High: 2, Low: 1, Close: 2, Volume: 1})
+			}
+			return candles, nil
+		},
+	}
+
+	candles, err := f.GetCandles(FifteenMin, start, end)
+	if err != nil {
+		t.Fatal("Test failed. GetCandles() error", err)
+	}
+	if requests != 3 {
+		t.Errorf("Test failed. Expected 3 requests, received %d", requests)
+	}
+	if len(candles) != 4 {
+		t.Fatalf("Test failed. Expected 4 candles, received %d", len(candles))
+	}
+	if candles[0].Volume != 3 || !candles[3].Time.Equal(start.Add(45*time.Minute)) {
+		t.Errorf("Test failed. Unexpected candles %+v", candles)
+	}
+
+	if _, err = f.GetCandles(FifteenMin, end, start); err != ErrInvalidTimeRange {
+		t.Errorf("Test failed. Expected %v, received %v", ErrInvalidTimeRange, err)
+	}
+
+	fetchErr := errors.New("exchange unavailable")
+	f.Fetch = func(interval Interval, s, e time.Time) ([]Candle, error) {
+		return nil, fetchErr
+	}
+	if _, err = f.GetCandles(OneMin, start, end); err != fetchErr {
+		t.Errorf("Test failed. Expected %v, received %v", fetchErr, err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -44,8 +45,24 @@ const (
 
 	krakenAuthRate   = 0
 	krakenUnauthRate = 0
+
+	// krakenOHLCLimit is the maximum number of candles returned per request
+	krakenOHLCLimit = 720
 )
 
+// krakenOHLCIntervals are the candle intervals supported by the OHLC endpoint
+var krakenOHLCIntervals = []kline.Interval{
+	kline.OneMin,
+	kline.FiveMin,
+	kline.FifteenMin,
+	kline.ThirtyMin,
+	kline.OneHour,
+	kline.FourHour,
+	kline.OneDay,
+	kline.OneWeek,
+	15 * kline.OneDay,
+}
+
 // Kraken is the overarching type across the alphapoint package
 type Kraken struct {
 	exchange.Base
@@ -61,6 +78,19 @@ func (k *Kraken) SetDefaults() {
 	k.Verbose = false
 	k.RESTPollingDelay = 10
 	k.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithSetup | exchange.WithdrawCryptoWith2FA | exchange.AutoWithdrawFiatWithSetup | exchange.WithdrawFiatWith2FA
+	k.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport |
+		exchange.StopOrderSupport | exchange.StopLimitOrderSupport | exchange.TrailingStopOrderSupport |
+		exchange.PostOnlyOrderSupport
+	k.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		TradeFetching:     exchange.FeatureSupport{REST: true},
+		KlineFetching:     exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	k.RequestCurrencyPairFormat.Delimiter = ""
 	k.RequestCurrencyPairFormat.Uppercase = true
 	k.RequestCurrencyPairFormat.Separator = ","
@@ -243,10 +273,18 @@ func (k *Kraken) GetTickers(pairList string) (Tickers, error) {
 	return tickers, nil
 }
 
-// GetOHLC returns an array of open high low close values of a currency pair
-func (k *Kraken) GetOHLC(symbol string) ([]OpenHighLowClose, error) {
+// GetOHLC returns an array of open high low close values of a currency pair.
+// interval is the candle period in minutes and since returns candles after the
+// supplied unix time, both are optional
+func (k *Kraken) GetOHLC(symbol string, interval, since int64) ([]OpenHighLowClose, error) {
 	values := url.Values{}
 	values.Set("pair", symbol)
+	if interval != 0 {
+		values.Set("interval", strconv.FormatInt(interval, 10))
+	}
+	if since != 0 {
+		values.Set("since", strconv.FormatInt(since, 10))
+	}
 
 	type Response struct {
 		Error []interface{}          `json:"error"`
@@ -267,7 +305,17 @@ func (k *Kraken) GetOHLC(symbol string) ([]OpenHighLowClose, error) {
 		return OHLC, fmt.Errorf("GetOHLC error: %s", result.Error)
 	}
 
-	for _, y := range result.Data[symbol].([]interface{}) {
+	// The result is keyed by Kraken's internal pair name which can differ from
+	// the requested symbol, e.g. XBTUSD returns XXBTZUSD
+	var data []interface{}
+	for key, value := range result.Data {
+		if key == "last" {
+			continue
+		}
+		data, _ = value.([]interface{})
+	}
+
+	for _, y := range data {
 		o := OpenHighLowClose{}
 		for i, x := range y.([]interface{}) {
 			switch i {
@@ -353,25 +401,51 @@ func (k *Kraken) GetDepth(symbol string) (Orderbook, error) {
 	return orderBook, nil
 }
 
-// GetTrades returns current trades on Kraken
-func (k *Kraken) GetTrades(symbol string) ([]RecentTrades, error) {
+// GetTrades returns trades on Kraken since the supplied cursor, an empty
+// cursor returns the most recent trades. The cursor of the next page of trades
+// is returned alongside the trades
+func (k *Kraken) GetTrades(symbol, since string) ([]RecentTrades, string, error) {
 	values := url.Values{}
 	values.Set("pair", symbol)
+	if since != "" {
+		values.Set("since", since)
+	}
+
+	type Response struct {
+		Error []interface{}          `json:"error"`
+		Data  map[string]interface{} `json:"result"`
+	}
 
 	var recentTrades []RecentTrades
-	var result interface{}
+	var result Response
 
 	path := fmt.Sprintf("%s/%s/public/%s?%s", k.APIUrl, krakenAPIVersion, krakenTrades, values.Encode())
 
 	err := k.SendHTTPRequest(path, &result)
 	if err != nil {
-		return recentTrades, err
+		return recentTrades, "", err
 	}
 
-	data := result.(map[string]interface{})
-	tradeInfo := data["result"].(map[string]interface{})
+	if len(result.Error) != 0 {
+		return recentTrades, "", fmt.Errorf("GetTrades error: %s", result.Error)
+	}
+
+	var last string
+	var data []interface{}
+	for key, value := range result.Data {
+		if key == "last" {
+			switch l := value.(type) {
+			case string:
+				last = l
+			case float64:
+				last = strconv.FormatFloat(l, 'f', -1, 64)
+			}
+			continue
+		}
+		data, _ = value.([]interface{})
+	}
 
-	for _, x := range tradeInfo[symbol].([]interface{}) {
+	for _, x := range data {
 		r := RecentTrades{}
 		for i, y := range x.([]interface{}) {
 			switch i {
@@ -387,11 +461,14 @@ func (k *Kraken) GetTrades(symbol string) ([]RecentTrades, error) {
 				r.MarketOrLimit = y.(string)
 			case 5:
 				r.Miscellaneous = y.(string)
+			case 6:
+				tradeID, _ := y.(float64)
+				r.TradeID = int64(tradeID)
 			}
 		}
 		recentTrades = append(recentTrades, r)
 	}
-	return recentTrades, nil
+	return recentTrades, last, nil
 }
 
 // GetSpread returns the full spread on Kraken
@@ -2,11 +2,13 @@ package kraken
 
 import (
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 )
 
 var k Kraken
@@ -81,7 +83,7 @@ func TestGetTickers(t *testing.T) {
 
 func TestGetOHLC(t *testing.T) {
 	t.Parallel()
-	_, err := k.GetOHLC("BCHEUR")
+	_, err := k.GetOHLC("BCHEUR", 0, 0)
 	if err != nil {
 		t.Error("Test Failed - GetOHLC() error", err)
 	}
@@ -97,7 +99,7 @@ func TestGetDepth(t *testing.T) {
 
 func TestGetTrades(t *testing.T) {
 	t.Parallel()
-	_, err := k.GetTrades("BCHEUR")
+	_, _, err := k.GetTrades("BCHEUR", "")
 	if err != nil {
 		t.Error("Test Failed - GetTrades() error", err)
 	}
@@ -354,7 +356,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.XBT,
 		SecondCurrency: symbol.CAD,
 	}
-	response, err := k.SubmitOrder(p, exchange.Buy, exchange.Market, 1, 10, "hi")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       1,
+		Price:        10,
+		ClientID:     "hi",
+	}
+	response, err := k.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -438,3 +448,84 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	k.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := k.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	k.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := k.GetOrderHistory(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get order history: %s", err)
+	}
+}
+
+func TestGetHistoricCandles(t *testing.T) {
+	k.SetDefaults()
+	TestSetup(t)
+
+	end := time.Now()
+	start := end.Add(-30 * 24 * time.Hour)
+	item, err := k.GetHistoricCandles(pair.NewCurrencyPair(symbol.XBT, symbol.USD), "SPOT", kline.OneDay, start, end)
+	if err != nil {
+		t.Errorf("Could not get historic candles: %s", err)
+	}
+	if err == nil && len(item.Candles) == 0 {
+		t.Error("Expected historic candles to be returned")
+	}
+}
+
+func TestGetExchangeHistory(t *testing.T) {
+	k.SetDefaults()
+	TestSetup(t)
+
+	end := time.Now()
+	start := end.Add(-time.Hour)
+	trades, err := k.GetExchangeHistory(exchange.TradeHistoryRequest{
+		CurrencyPair: pair.NewCurrencyPair(symbol.XBT, symbol.USD),
+		AssetType:    "SPOT",
+		StartTime:    start,
+		EndTime:      end,
+	})
+	if err != nil {
+		t.Errorf("Could not get exchange history: %s", err)
+	}
+	for x := range trades {
+		if trades[x].Timestamp.Before(start) || !trades[x].Timestamp.Before(end) {
+			t.Errorf("Trade %+v outside of requested time range", trades[x])
+		}
+	}
+
+	_, err = k.GetExchangeHistory(exchange.TradeHistoryRequest{
+		CurrencyPair: pair.NewCurrencyPair(symbol.XBT, symbol.USD),
+		FromTradeID:  "1337",
+	})
+	if err == nil {
+		t.Error("Expected error when fetching from a trade ID")
+	}
+}
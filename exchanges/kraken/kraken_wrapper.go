@@ -1,13 +1,17 @@
 package kraken
 
 import (
+	"errors"
 	"log"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -168,19 +172,122 @@ func (k *Kraken) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (k *Kraken) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
-	var resp []exchange.TradeHistory
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (k *Kraken) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
+	if err := tradeHistoryRequest.Validate(); err != nil {
+		return nil, err
+	}
+
+	if tradeHistoryRequest.FromTradeID != "" {
+		return nil, errors.New("kraken trade history can only be fetched from a start time")
+	}
+
+	// Kraken's since cursor is a nanosecond timestamp
+	var since string
+	if !tradeHistoryRequest.StartTime.IsZero() {
+		since = strconv.FormatInt(tradeHistoryRequest.StartTime.UnixNano(), 10)
+	}
+
+	symbol := exchange.FormatExchangeCurrency(k.GetName(), tradeHistoryRequest.CurrencyPair).String()
+	return exchange.PaginateTradeHistory(tradeHistoryRequest, since, func(cursor string) ([]exchange.TradeHistory, string, error) {
+		resp, last, err := k.GetTrades(symbol, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var trades []exchange.TradeHistory
+		for x := range resp {
+			var tid string
+			if resp[x].TradeID != 0 {
+				tid = strconv.FormatInt(resp[x].TradeID, 10)
+			}
+
+			trades = append(trades, exchange.TradeHistory{
+				Exchange:     k.GetName(),
+				TID:          tid,
+				CurrencyPair: tradeHistoryRequest.CurrencyPair,
+				AssetType:    tradeHistoryRequest.AssetType,
+				Side:         exchange.FormatOrderSide(resp[x].BuyOrSell),
+				Price:        resp[x].Price,
+				Amount:       resp[x].Volume,
+				Timestamp:    time.Unix(0, int64(resp[x].Time*float64(time.Second))),
+			})
+		}
+		return trades, last, nil
+	})
+}
+
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (k *Kraken) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	symbol := exchange.FormatExchangeCurrency(k.GetName(), p).String()
+	fetcher := kline.Fetcher{
+		SupportedIntervals: krakenOHLCIntervals,
+		Limit:              krakenOHLCLimit,
+		Fetch: func(interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+			// since is exclusive, step back one second to include the
+			// candle opening at start
+			resp, err := k.GetOHLC(symbol, interval.Minutes(), start.Unix()-1)
+			if err != nil {
+				return nil, err
+			}
+
+			var candles []kline.Candle
+			for x := range resp {
+				candles = append(candles, kline.Candle{
+					Time:   time.Unix(int64(resp[x].Time), 0),
+					Open:   resp[x].Open,
+					High:   resp[x].High,
+					Low:    resp[x].Low,
+					Close:  resp[x].Close,
+					Volume: resp[x].Volume,
+				})
+			}
+			return candles, nil
+		},
+	}
+
+	candles, err := fetcher.GetCandles(interval, start, end)
+	if err != nil {
+		return kline.Item{}, err
+	}
 
-	return resp, common.ErrNotYetImplemented
+	return kline.Item{
+		Exchange:  k.GetName(),
+		Pair:      p,
+		AssetType: assetType,
+		Interval:  interval,
+		Candles:   candles,
+	}, nil
 }
 
 // SubmitOrder submits a new order
-func (k *Kraken) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (k *Kraken) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(k.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var args = AddOrderOptions{}
+	if order.PostOnly {
+		args.Oflags = "post"
+	}
+
+	orderType := common.StringToLower(order.OrderType.ToString())
+	price, price2 := order.Price, 0.0
+	switch order.OrderType {
+	case exchange.Stop:
+		orderType = "stop-loss"
+		price = order.TriggerPrice
+	case exchange.StopLimit:
+		orderType = "stop-loss-limit"
+		price, price2 = order.TriggerPrice, order.Price
+	case exchange.TrailingStop:
+		orderType = "trailing-stop"
+		price = order.TrailingAmount
+	}
 
-	response, err := k.AddOrder(p.Pair().String(), side.ToString(), orderType.ToString(), amount, price, 0, 0, args)
+	response, err := k.AddOrder(order.CurrencyPair.Pair().String(), order.OrderSide.ToString(), orderType, order.Amount, price, price2, 0, args)
 
 	if len(response.TransactionIds) > 0 {
 		submitOrderResponse.OrderID = strings.Join(response.TransactionIds, ", ")
@@ -235,6 +342,66 @@ func (k *Kraken) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (k *Kraken) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := k.GetOpenOrders(OrderInfoOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	orders := k.formatOrders(resp.Open)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (k *Kraken) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	var req GetClosedOrdersOptions
+	if !getOrdersRequest.StartTicks.IsZero() {
+		req.Start = strconv.FormatInt(getOrdersRequest.StartTicks.Unix(), 10)
+	}
+
+	if !getOrdersRequest.EndTicks.IsZero() {
+		req.End = strconv.FormatInt(getOrdersRequest.EndTicks.Unix(), 10)
+	}
+
+	resp, err := k.GetClosedOrders(req)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := k.formatOrders(resp.Closed)
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrders converts Kraken orders to the exchange.OrderDetail type
+func (k *Kraken) formatOrders(resp map[string]OrderInfo) []exchange.OrderDetail {
+	var orders []exchange.OrderDetail
+	for orderID, order := range resp {
+		var p pair.CurrencyPair
+		if len(order.Descr.Pair) >= 6 {
+			p = pair.NewCurrencyPairFromString(order.Descr.Pair)
+		}
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        k.Name,
+			ID:              orderID,
+			ClientOrderID:   strconv.FormatInt(int64(order.UserRef), 10),
+			CurrencyPair:    p,
+			OrderSide:       exchange.FormatOrderSide(order.Descr.Type),
+			OrderType:       exchange.FormatOrderType(order.Descr.OrderType),
+			OrderDate:       time.Unix(int64(order.OpenTm), 0),
+			Status:          order.Status,
+			Price:           order.Descr.Price,
+			Amount:          order.Vol,
+			ExecutedAmount:  order.VolExec,
+			RemainingAmount: order.Vol - order.VolExec,
+			Fee:             order.Fee,
+		})
+	}
+	return orders
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (k *Kraken) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -5,10 +5,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -131,18 +133,28 @@ func (l *LakeBTC) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (l *LakeBTC) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (l *LakeBTC) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (l *LakeBTC) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (l *LakeBTC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (l *LakeBTC) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	isBuyOrder := side == exchange.Buy
-	response, err := l.Trade(isBuyOrder, amount, price, common.StringToLower(p.Pair().String()))
+	if err := order.Validate(l.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	isBuyOrder := order.OrderSide == exchange.Buy
+	response, err := l.Trade(isBuyOrder, order.Amount, order.Price, common.StringToLower(order.CurrencyPair.Pair().String()))
 
 	if response.ID > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response.ID)
@@ -198,6 +210,84 @@ func (l *LakeBTC) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (l *LakeBTC) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := l.GetOpenOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		var p pair.CurrencyPair
+		if len(resp[i].Symbol) == 6 {
+			p = pair.NewCurrencyPair(common.StringToUpper(resp[i].Symbol[0:3]),
+				common.StringToUpper(resp[i].Symbol[3:]))
+		}
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        l.Name,
+			ID:              strconv.FormatInt(resp[i].ID, 10),
+			CurrencyPair:    p,
+			OrderSide:       exchange.FormatOrderSide(resp[i].Type),
+			OrderType:       exchange.Limit,
+			OrderDate:       time.Unix(resp[i].At, 0),
+			Price:           resp[i].Price,
+			Amount:          resp[i].Amount,
+			RemainingAmount: resp[i].Amount,
+		})
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (l *LakeBTC) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	var since int64
+	if !getOrdersRequest.StartTicks.IsZero() {
+		since = getOrdersRequest.StartTicks.Unix()
+	}
+
+	resp, err := l.GetTrades(since)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		var p pair.CurrencyPair
+		if len(resp[i].Symbol) == 6 {
+			p = pair.NewCurrencyPair(common.StringToUpper(resp[i].Symbol[0:3]),
+				common.StringToUpper(resp[i].Symbol[3:]))
+		}
+
+		var price float64
+		if resp[i].Amount != 0 {
+			price = resp[i].Total / resp[i].Amount
+		}
+
+		tradeDate := time.Unix(resp[i].At, 0)
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:       l.Name,
+			CurrencyPair:   p,
+			OrderSide:      exchange.FormatOrderSide(resp[i].Type),
+			OrderDate:      tradeDate,
+			Status:         "FILLED",
+			Price:          price,
+			Amount:         resp[i].Amount,
+			ExecutedAmount: resp[i].Amount,
+			Fills: []exchange.OrderFill{{
+				Timestamp: tradeDate,
+				Price:     price,
+				Amount:    resp[i].Amount,
+			}},
+		})
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (l *LakeBTC) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
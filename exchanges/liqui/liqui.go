@@ -52,6 +52,15 @@ func (l *Liqui) SetDefaults() {
 	l.RESTPollingDelay = 10
 	l.Ticker = make(map[string]Ticker)
 	l.APIWithdrawPermissions = exchange.NoAPIWithdrawalMethods
+	l.OrderSubmissionFeatures = exchange.LimitOrderSupport
+	l.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	l.RequestCurrencyPairFormat.Delimiter = "_"
 	l.RequestCurrencyPairFormat.Uppercase = false
 	l.RequestCurrencyPairFormat.Separator = "-"
@@ -207,8 +216,8 @@ func (l *Liqui) Trade(pair, orderType string, amount, price float64) (float64, e
 	return result.OrderID, l.SendAuthenticatedHTTPRequest(liquiTrade, req, &result)
 }
 
-// GetActiveOrders returns the list of your active orders.
-func (l *Liqui) GetActiveOrders(pair string) (map[string]ActiveOrders, error) {
+// GetOpenOrders returns the list of your active orders.
+func (l *Liqui) GetOpenOrders(pair string) (map[string]ActiveOrders, error) {
 	result := make(map[string]ActiveOrders)
 
 	req := url.Values{}
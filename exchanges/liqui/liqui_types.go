@@ -66,10 +66,10 @@ type AccountInfo struct {
 // ActiveOrders holds active order information
 type ActiveOrders struct {
 	Pair             string  `json:"pair"`
-	Type             string  `json:"sell"`
+	Type             string  `json:"type"`
 	Amount           float64 `json:"amount"`
 	Rate             float64 `json:"rate"`
-	TimestampCreated float64 `json:"time_created"`
+	TimestampCreated float64 `json:"timestamp_created"`
 	Status           int     `json:"status"`
 	Success          int     `json:"success"`
 	Error            string  `json:"error"`
@@ -78,11 +78,11 @@ type ActiveOrders struct {
 // OrderInfo holds specific order information
 type OrderInfo struct {
 	Pair             string  `json:"pair"`
-	Type             string  `json:"sell"`
+	Type             string  `json:"type"`
 	StartAmount      float64 `json:"start_amount"`
 	Amount           float64 `json:"amount"`
 	Rate             float64 `json:"rate"`
-	TimestampCreated float64 `json:"time_created"`
+	TimestampCreated float64 `json:"timestamp_created"`
 	Status           int     `json:"status"`
 	Success          int     `json:"success"`
 	Error            string  `json:"error"`
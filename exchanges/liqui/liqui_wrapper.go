@@ -5,12 +5,15 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"net/url"
 )
 
 // Start starts the Liqui go routine
@@ -141,17 +144,27 @@ func (l *Liqui) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (l *Liqui) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (l *Liqui) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (l *Liqui) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (l *Liqui) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (l *Liqui) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	response, err := l.Trade(p.Pair().String(), fmt.Sprintf("%s", orderType), amount, price)
+	if err := order.Validate(l.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	response, err := l.Trade(order.CurrencyPair.Pair().String(), common.StringToLower(order.OrderSide.ToString()), order.Amount, order.Price)
 
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
@@ -187,7 +200,7 @@ func (l *Liqui) CancelAllOrders(orderCancellation exchange.OrderCancellation) (e
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
-	activeOrders, err := l.GetActiveOrders("")
+	activeOrders, err := l.GetOpenOrders("")
 	if err != nil {
 		return cancelAllOrdersResponse, err
 	}
@@ -213,6 +226,96 @@ func (l *Liqui) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (l *Liqui) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = l.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := l.GetOpenOrders(exchange.FormatExchangeCurrency(l.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+
+		for orderID, order := range resp {
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:        l.Name,
+				ID:              orderID,
+				CurrencyPair:    currencies[x],
+				OrderSide:       exchange.FormatOrderSide(order.Type),
+				OrderType:       exchange.Limit,
+				OrderDate:       time.Unix(int64(order.TimestampCreated), 0),
+				Status:          strconv.Itoa(order.Status),
+				Price:           order.Rate,
+				Amount:          order.Amount,
+				RemainingAmount: order.Amount,
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (l *Liqui) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = l.GetEnabledCurrencies()
+	}
+
+	var since, end string
+	if !getOrdersRequest.StartTicks.IsZero() {
+		since = strconv.FormatInt(getOrdersRequest.StartTicks.Unix(), 10)
+	}
+	if !getOrdersRequest.EndTicks.IsZero() {
+		end = strconv.FormatInt(getOrdersRequest.EndTicks.Unix(), 10)
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		vals := url.Values{}
+		if since != "" {
+			vals.Set("since", since)
+		}
+		if end != "" {
+			vals.Set("end", end)
+		}
+
+		resp, err := l.GetTradeHistory(vals, exchange.FormatExchangeCurrency(l.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+
+		for tradeID, trade := range resp {
+			tradeDate := time.Unix(int64(trade.Timestamp), 0)
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:       l.Name,
+				ID:             strconv.FormatFloat(trade.OrderID, 'f', -1, 64),
+				CurrencyPair:   currencies[x],
+				OrderSide:      exchange.FormatOrderSide(trade.Type),
+				OrderType:      exchange.Limit,
+				OrderDate:      tradeDate,
+				Status:         "FILLED",
+				Price:          trade.Rate,
+				Amount:         trade.Amount,
+				ExecutedAmount: trade.Amount,
+				Fills: []exchange.OrderFill{{
+					ID:        tradeID,
+					Timestamp: tradeDate,
+					Price:     trade.Rate,
+					Amount:    trade.Amount,
+				}},
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (l *Liqui) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
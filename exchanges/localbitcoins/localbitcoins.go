@@ -118,6 +118,15 @@ func (l *LocalBitcoins) SetDefaults() {
 	l.Verbose = false
 	l.RESTPollingDelay = 10
 	l.APIWithdrawPermissions = exchange.WithdrawCryptoViaWebsiteOnly
+	l.OrderSubmissionFeatures = exchange.LimitOrderSupport
+	l.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	l.RequestCurrencyPairFormat.Delimiter = ""
 	l.RequestCurrencyPairFormat.Uppercase = true
 	l.ConfigCurrencyPairFormat.Delimiter = ""
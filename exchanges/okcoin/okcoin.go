@@ -102,6 +102,17 @@ func (o *OKCoin) SetDefaults() {
 	o.RESTPollingDelay = 10
 	o.AssetTypes = []string{ticker.Spot}
 	o.APIWithdrawPermissions = exchange.AutoWithdrawCrypto | exchange.WithdrawFiatViaWebsiteOnly
+	o.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+	o.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true, Websocket: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		KlineFetching:     exchange.FeatureSupport{Websocket: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.TickerChannel, exchange.OrderbookChannel, exchange.KlineChannel},
+	}
 	o.SupportsAutoPairUpdating = false
 	o.SupportsRESTTickerBatching = false
 	o.WebsocketInit()
@@ -529,8 +540,8 @@ func (o *OKCoin) GetOrderInfoBatch(orderID []int64, symbol string) ([]OrderInfo,
 	return result.Orders, nil
 }
 
-// GetOrderHistory returns a history of orders
-func (o *OKCoin) GetOrderHistory(pageLength, currentPage int64, status, symbol string) (OrderHistory, error) {
+// GetOrderHistoryForCurrency returns a history of orders
+func (o *OKCoin) GetOrderHistoryForCurrency(pageLength, currentPage int64, status, symbol string) (OrderHistory, error) {
 	v := url.Values{}
 	v.Set("symbol", symbol)
 	v.Set("status", status)
@@ -6,10 +6,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -185,25 +187,35 @@ func (o *OKCoin) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (o *OKCoin) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (o *OKCoin) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (o *OKCoin) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (o *OKCoin) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (o *OKCoin) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(o.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var oT string
-	if orderType == exchange.Limit {
-		if side == exchange.Buy {
+	if order.OrderType == exchange.Limit {
+		if order.OrderSide == exchange.Buy {
 			oT = "buy"
 		} else {
 			oT = "sell"
 		}
-	} else if orderType == exchange.Market {
-		if side == exchange.Buy {
+	} else if order.OrderType == exchange.Market {
+		if order.OrderSide == exchange.Buy {
 			oT = "buy_market"
 		} else {
 			oT = "sell_market"
@@ -212,7 +224,7 @@ func (o *OKCoin) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, order
 		return submitOrderResponse, errors.New("Unsupported order type")
 	}
 
-	response, err := o.Trade(amount, price, p.Pair().String(), oT)
+	response, err := o.Trade(order.Amount, order.Price, order.CurrencyPair.Pair().String(), oT)
 
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
@@ -282,6 +294,73 @@ func (o *OKCoin) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (o *OKCoin) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = o.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := o.GetOrderInformation(-1, exchange.FormatExchangeCurrency(o.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, o.formatOrders(currencies[x], resp)...)
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (o *OKCoin) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = o.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := o.GetOrderHistoryForCurrency(200, 1, "1", exchange.FormatExchangeCurrency(o.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, o.formatOrders(currencies[x], resp.Orders)...)
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// formatOrders converts OKCoin orders to the exchange.OrderDetail type
+func (o *OKCoin) formatOrders(p pair.CurrencyPair, resp []OrderInfo) []exchange.OrderDetail {
+	var orders []exchange.OrderDetail
+	for i := range resp {
+		side := exchange.FormatOrderSide(resp[i].Type)
+		orderType := exchange.Limit
+		if common.StringContains(resp[i].Type, "market") {
+			orderType = exchange.Market
+			side = exchange.FormatOrderSide(common.SplitStrings(resp[i].Type, "_")[0])
+		}
+
+		orders = append(orders, exchange.OrderDetail{
+			Exchange:        o.Name,
+			ID:              strconv.FormatInt(resp[i].OrderID, 10),
+			CurrencyPair:    p,
+			OrderSide:       side,
+			OrderType:       orderType,
+			OrderDate:       time.Unix(0, resp[i].Created*int64(time.Millisecond)),
+			Status:          strconv.Itoa(resp[i].Status),
+			Price:           resp[i].Price,
+			Amount:          resp[i].Amount,
+			ExecutedAmount:  resp[i].DealAmount,
+			RemainingAmount: resp[i].Amount - resp[i].DealAmount,
+		})
+	}
+	return orders
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (o *OKCoin) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -107,6 +107,18 @@ func (o *OKEX) SetDefaults() {
 	o.Verbose = false
 	o.RESTPollingDelay = 10
 	o.APIWithdrawPermissions = exchange.AutoWithdrawCrypto
+	o.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport
+	o.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true, Websocket: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		TradeFetching:     exchange.FeatureSupport{Websocket: true},
+		KlineFetching:     exchange.FeatureSupport{Websocket: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.TickerChannel, exchange.OrderbookChannel, exchange.TradeChannel, exchange.KlineChannel},
+	}
 	o.RequestCurrencyPairFormat.Delimiter = "_"
 	o.RequestCurrencyPairFormat.Uppercase = false
 	o.ConfigCurrencyPairFormat.Delimiter = "_"
@@ -167,6 +179,7 @@ func (o *OKEX) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		o.Websocket.SetOrderbookFetcher(o.UpdateOrderbook)
 	}
 }
 
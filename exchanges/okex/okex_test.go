@@ -1,12 +1,15 @@
 package okex
 
 import (
+	"hash/crc32"
 	"testing"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 )
 
 var o OKEX
@@ -421,7 +424,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.BTC,
 		SecondCurrency: symbol.EUR,
 	}
-	response, err := o.SubmitOrder(p, exchange.Buy, exchange.Market, 1, 10, "hi")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       1,
+		Price:        10,
+		ClientID:     "hi",
+	}
+	response, err := o.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -505,3 +516,111 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	o.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := o.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	o.SetDefaults()
+	TestSetup(t)
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := o.GetOrderHistory(getOrdersRequest)
+	if err != common.ErrNotYetImplemented {
+		t.Errorf("Expected '%v', received: '%v'", common.ErrNotYetImplemented, err)
+	}
+}
+
+func TestWsProcessOrderbook(t *testing.T) {
+	var ok OKEX
+	ok.Name = "OKEX"
+	ok.WebsocketInit()
+	ok.Websocket.DataHandler = make(chan interface{}, 1)
+	ok.Websocket.SetExchangeName(ok.Name)
+	p := pair.NewCurrencyPairFromString("btc_usdt")
+
+	checksum := func(levels string) int32 {
+		return int32(crc32.ChecksumIEEE([]byte(levels)))
+	}
+
+	err := ok.WsProcessOrderbook(DepthStreamData{
+		Bids:      [][]string{{"100", "1"}, {"99", "2"}},
+		Asks:      [][]string{{"101", "1.5"}},
+		Timestamp: 1000,
+		Checksum:  checksum("100:1:101:1.5:99:2"),
+	}, p, "SPOT")
+	if err != nil {
+		t.Fatal("Test Failed - WsProcessOrderbook() snapshot error", err)
+	}
+
+	err = ok.WsProcessOrderbook(DepthStreamData{
+		Bids:      [][]string{{"100", "0"}},
+		Asks:      [][]string{{"102", "3"}},
+		Timestamp: 2000,
+		Checksum:  checksum("99:2:101:1.5:102:3"),
+	}, p, "SPOT")
+	if err != nil {
+		t.Fatal("Test Failed - WsProcessOrderbook() update error", err)
+	}
+
+	snapshot := orderbook.Base{
+		Bids: []orderbook.Item{{Price: 98, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 103, Amount: 1}},
+	}
+	ok.Websocket.SetOrderbookFetcher(func(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+		return snapshot, nil
+	})
+
+	err = ok.WsProcessOrderbook(DepthStreamData{
+		Bids:      [][]string{{"99", "4"}},
+		Timestamp: 3000,
+		Checksum:  checksum("99:2:101:1.5:102:3"),
+	}, p, "SPOT")
+	if err != nil {
+		t.Fatal("Test Failed - WsProcessOrderbook() resync error", err)
+	}
+
+	if _, resynced := (<-ok.Websocket.DataHandler).(exchange.WebsocketOrderbookResync); !resynced {
+		t.Error("Test Failed - WsProcessOrderbook() expected a resync event on checksum mismatch")
+	}
+
+	expected := uint32(checksum("98:1:103:1"))
+	err = ok.Websocket.Orderbook.VerifyChecksum(p, "SPOT", expected, calculateOrderbookChecksum)
+	if err != nil {
+		t.Error("Test Failed - WsProcessOrderbook() expected orderbook to be reloaded", err)
+	}
+
+	err = ok.WsProcessOrderbook(DepthStreamData{
+		Bids:      [][]string{{"bad", "1"}},
+		Timestamp: 4000,
+	}, p, "SPOT")
+	if err == nil {
+		t.Error("Test Failed - WsProcessOrderbook() expected error parsing invalid level")
+	}
+}
+
+func TestAccountCurrencies(t *testing.T) {
+	t.Parallel()
+	currencies := accountCurrencies([]FullBalance{{Currency: "btc", Available: 1.5, Hold: 0.5}})
+	if len(currencies) != 1 || currencies[0].TotalValue != 1.5 || currencies[0].Hold != 0.5 {
+		t.Errorf("Test failed - OKEX accountCurrencies() expected available balance 1.5 and hold 0.5 got %+v", currencies)
+	}
+}
@@ -83,6 +83,7 @@ type DepthStreamData struct {
 	Asks      [][]string `json:"asks"`
 	Bids      [][]string `json:"bids"`
 	Timestamp float64    `json:"timestamp"`
+	Checksum  int32      `json:"checksum"`
 }
 
 // ContractDepth response depth
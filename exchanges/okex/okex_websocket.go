@@ -17,10 +17,15 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 )
 
 const (
 	okexDefaultWebsocketURL = "wss://real.okex.com:10440/websocket/okexapi"
+
+	// okexWebsocketChecksumDepth is the number of price levels on each side
+	// of the orderbook included in depth checksums
+	okexWebsocketChecksumDepth = 25
 )
 
 func (o *OKEX) writeToWebsocket(message string) error {
@@ -303,6 +308,15 @@ func (o *OKEX) WsHandleData() {
 						log.Fatal("OKEX Depth Decode Error:", err)
 					}
 
+					err = o.WsProcessOrderbook(depth,
+						pair.NewCurrencyPairFromString(newPair),
+						common.StringToUpper(assetType))
+					if err != nil {
+						o.Websocket.DataHandler <- fmt.Errorf("okex_websocket.go orderbook error: %s",
+							err)
+						continue
+					}
+
 					o.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
 						Exchange: o.GetName(),
 						Asset:    assetType,
@@ -314,6 +328,86 @@ func (o *OKEX) WsHandleData() {
 	}
 }
 
+// WsProcessOrderbook applies a depth push to the local orderbook. The first
+// push after subscribing is a full snapshot, later pushes only hold the price
+// levels which changed. The result is validated against the checksum
+// published with the push and the orderbook is resynced on a mismatch
+func (o *OKEX) WsProcessOrderbook(depth DepthStreamData, p pair.CurrencyPair, assetType string) error {
+	bids, err := parseDepthLevels(depth.Bids)
+	if err != nil {
+		return err
+	}
+
+	asks, err := parseDepthLevels(depth.Asks)
+	if err != nil {
+		return err
+	}
+
+	updated := time.Unix(0, int64(depth.Timestamp)*int64(time.Millisecond))
+	if !o.Websocket.Orderbook.HasOrderbook(p, assetType) {
+		err = o.Websocket.Orderbook.LoadSnapshot(orderbook.Base{
+			Bids:         bids,
+			Asks:         asks,
+			Pair:         p,
+			CurrencyPair: p.Pair().String(),
+			AssetType:    assetType,
+			LastUpdated:  updated,
+		}, o.GetName())
+	} else if len(bids) > 0 || len(asks) > 0 {
+		err = o.Websocket.Orderbook.Update(bids, asks, p, updated, o.GetName(), assetType)
+	}
+	if err != nil {
+		return err
+	}
+
+	if depth.Checksum == 0 {
+		return nil
+	}
+
+	err = o.Websocket.Orderbook.VerifyChecksum(p,
+		assetType,
+		uint32(depth.Checksum),
+		calculateOrderbookChecksum)
+	if err == exchange.ErrOrderbookChecksumMismatch {
+		return o.Websocket.ResyncOrderbook(p, assetType, err)
+	}
+	return err
+}
+
+// parseDepthLevels converts the price and amount strings of depth levels into
+// orderbook items
+func parseDepthLevels(levels [][]string) ([]orderbook.Item, error) {
+	var items []orderbook.Item
+	for x := range levels {
+		if len(levels[x]) < 2 {
+			return nil, fmt.Errorf("okex_websocket.go invalid depth level %v", levels[x])
+		}
+
+		price, err := strconv.ParseFloat(levels[x][0], 64)
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := strconv.ParseFloat(levels[x][1], 64)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, orderbook.Item{Price: price, Amount: amount})
+	}
+	return items, nil
+}
+
+// calculateOrderbookChecksum returns the OKEx checksum of an orderbook, each
+// level is represented by its price and amount
+func calculateOrderbookChecksum(ob orderbook.Base) uint32 {
+	return exchange.CalculateOrderbookChecksum(ob,
+		okexWebsocketChecksumDepth,
+		func(item orderbook.Item, isAsk bool) string {
+			return strconv.FormatFloat(item.Price, 'f', -1, 64) + ":" +
+				strconv.FormatFloat(item.Amount, 'f', -1, 64)
+		})
+}
+
 // ErrorResponse defines an error response type from the websocket connection
 type ErrorResponse struct {
 	Result    bool   `json:"result"`
@@ -6,10 +6,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -157,18 +159,23 @@ func (o *OKEX) GetAccountInfo() (exchange.AccountInfo, error) {
 		return info, err
 	}
 
+	info.ExchangeName = o.GetName()
+	info.Currencies = accountCurrencies(bal)
+	return info, nil
+}
+
+// accountCurrencies converts the wallet balances into available and held
+// balances
+func accountCurrencies(bal []FullBalance) []exchange.AccountCurrencyInfo {
 	var balances []exchange.AccountCurrencyInfo
 	for _, data := range bal {
 		balances = append(balances, exchange.AccountCurrencyInfo{
 			CurrencyName: data.Currency,
-			TotalValue:   data.Available + data.Hold,
+			TotalValue:   data.Available,
 			Hold:         data.Hold,
 		})
 	}
-
-	info.ExchangeName = o.GetName()
-	info.Currencies = balances
-	return info, nil
+	return balances
 }
 
 // GetFundingHistory returns funding history, deposits and
@@ -178,26 +185,36 @@ func (o *OKEX) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (o *OKEX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (o *OKEX) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (o *OKEX) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (o *OKEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (o *OKEX) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(o.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var oT SpotNewOrderRequestType
 
-	if orderType == exchange.Limit {
-		if side == exchange.Buy {
+	if order.OrderType == exchange.Limit {
+		if order.OrderSide == exchange.Buy {
 			oT = SpotNewOrderRequestTypeBuy
 		} else {
 			oT = SpotNewOrderRequestTypeSell
 		}
-	} else if orderType == exchange.Market {
-		if side == exchange.Buy {
+	} else if order.OrderType == exchange.Market {
+		if order.OrderSide == exchange.Buy {
 			oT = SpotNewOrderRequestTypeBuyMarket
 		} else {
 			oT = SpotNewOrderRequestTypeSellMarket
@@ -207,9 +224,9 @@ func (o *OKEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderTy
 	}
 
 	var params = SpotNewOrderRequestParams{
-		Amount: amount,
-		Price:  price,
-		Symbol: p.Pair().String(),
+		Amount: order.Amount,
+		Price:  order.Price,
+		Symbol: order.CurrencyPair.Pair().String(),
 		Type:   oT,
 	}
 
@@ -281,6 +298,45 @@ func (o *OKEX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (o *OKEX) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = o.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := o.GetTokenOrders(exchange.FormatExchangeCurrency(o.Name, currencies[x]).String(), -1)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Orders {
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:        o.Name,
+				ID:              strconv.FormatInt(resp.Orders[i].OrderID, 10),
+				CurrencyPair:    currencies[x],
+				OrderSide:       exchange.FormatOrderSide(resp.Orders[i].Type),
+				OrderType:       exchange.Limit,
+				Status:          strconv.FormatInt(resp.Orders[i].Status, 10),
+				Price:           float64(resp.Orders[i].Price),
+				Amount:          resp.Orders[i].Amount,
+				ExecutedAmount:  float64(resp.Orders[i].DealAmount),
+				RemainingAmount: resp.Orders[i].Amount - float64(resp.Orders[i].DealAmount),
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (o *OKEX) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return nil, common.ErrNotYetImplemented
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (o *OKEX) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -0,0 +1,352 @@
+package orderbook
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Side is the direction of a simulated market order
+type Side string
+
+// Market order sides, buys fill against the asks and sells against the bids
+const (
+	Buy  Side = "BUY"
+	Sell Side = "SELL"
+)
+
+const (
+	// spreadSamples is the number of recent spreads each stored orderbook
+	// keeps for its spread statistics
+	spreadSamples = 1000
+	bpsMultiplier = 10000
+)
+
+// vars related to orderbook analytics
+var (
+	ErrNoBids                = errors.New("orderbook has no bids")
+	ErrNoAsks                = errors.New("orderbook has no asks")
+	ErrInvalidAmount         = errors.New("amount must be above zero")
+	ErrInvalidSide           = errors.New("side must be buy or sell")
+	ErrInsufficientLiquidity = errors.New("orderbook depth is insufficient to fill the amount")
+	ErrNoSpreadSamples       = errors.New("no spread samples recorded for orderbook")
+)
+
+// Spread holds the top of an orderbook
+type Spread struct {
+	Bid       float64 `json:"bid"`
+	Ask       float64 `json:"ask"`
+	Mid       float64 `json:"mid"`
+	Spread    float64 `json:"spread"`
+	SpreadBps float64 `json:"spreadBps"`
+}
+
+// SpreadStats holds statistics of the spread in basis points over the most
+// recent orderbook updates
+type SpreadStats struct {
+	Samples int     `json:"samples"`
+	Last    float64 `json:"last"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Mean    float64 `json:"mean"`
+	StdDev  float64 `json:"stdDev"`
+}
+
+// Fill is the result of simulating a market order against an orderbook
+type Fill struct {
+	Side      Side    `json:"side"`
+	Requested float64 `json:"requested"`
+	// Amount is less than Requested when the orderbook is not deep enough
+	Amount float64 `json:"amount"`
+	Value  float64 `json:"value"`
+	// AveragePrice is the volume weighted average price of the fill
+	AveragePrice float64 `json:"averagePrice"`
+	WorstPrice   float64 `json:"worstPrice"`
+	MidPrice     float64 `json:"midPrice"`
+	// Slippage is how far the average price is from the mid price against
+	// the order, in price and basis points
+	Slippage    float64 `json:"slippage"`
+	SlippageBps float64 `json:"slippageBps"`
+}
+
+// Liquidity holds the amount and value resting within a distance of the mid
+// price
+type Liquidity struct {
+	Bps       float64 `json:"bps"`
+	MidPrice  float64 `json:"midPrice"`
+	BidAmount float64 `json:"bidAmount"`
+	BidValue  float64 `json:"bidValue"`
+	AskAmount float64 `json:"askAmount"`
+	AskValue  float64 `json:"askValue"`
+}
+
+// Analytics summarises an orderbook
+type Analytics struct {
+	Exchange    string            `json:"exchange"`
+	Pair        pair.CurrencyPair `json:"pair"`
+	AssetType   string            `json:"assetType"`
+	Spread      Spread            `json:"spread"`
+	SpreadStats SpreadStats       `json:"spreadStats"`
+	Imbalance   float64           `json:"imbalance"`
+	Liquidity   []Liquidity       `json:"liquidity"`
+}
+
+// spreadHistory is a ring of the most recent spreads of a stored orderbook
+type spreadHistory struct {
+	samples []float64
+	next    int
+}
+
+// sortedBids returns the bids ordered from the best price
+func (o *Base) sortedBids() []Item {
+	bids := make([]Item, 0, len(o.Bids))
+	for _, b := range o.Bids {
+		if b.Price > 0 && b.Amount > 0 {
+			bids = append(bids, b)
+		}
+	}
+	sort.SliceStable(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	return bids
+}
+
+// sortedAsks returns the asks ordered from the best price
+func (o *Base) sortedAsks() []Item {
+	asks := make([]Item, 0, len(o.Asks))
+	for _, a := range o.Asks {
+		if a.Price > 0 && a.Amount > 0 {
+			asks = append(asks, a)
+		}
+	}
+	sort.SliceStable(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+	return asks
+}
+
+// GetSpread returns the best bid and ask with the spread between them
+func (o *Base) GetSpread() (Spread, error) {
+	bids := o.sortedBids()
+	if len(bids) == 0 {
+		return Spread{}, ErrNoBids
+	}
+	asks := o.sortedAsks()
+	if len(asks) == 0 {
+		return Spread{}, ErrNoAsks
+	}
+
+	s := Spread{
+		Bid: bids[0].Price,
+		Ask: asks[0].Price,
+		Mid: (bids[0].Price + asks[0].Price) / 2,
+	}
+	s.Spread = s.Ask - s.Bid
+	s.SpreadBps = s.Spread / s.Mid * bpsMultiplier
+	return s, nil
+}
+
+// GetMidPrice returns the price halfway between the best bid and ask
+func (o *Base) GetMidPrice() (float64, error) {
+	s, err := o.GetSpread()
+	return s.Mid, err
+}
+
+// SimulateFill walks the orderbook to fill a market order of amount, returning
+// its volume weighted average price and slippage against the mid price. When
+// the orderbook is too shallow the partial fill is returned with
+// ErrInsufficientLiquidity
+func (o *Base) SimulateFill(side Side, amount float64) (Fill, error) {
+	if amount <= 0 {
+		return Fill{}, ErrInvalidAmount
+	}
+	var levels []Item
+	switch side {
+	case Buy:
+		levels = o.sortedAsks()
+	case Sell:
+		levels = o.sortedBids()
+	default:
+		return Fill{}, ErrInvalidSide
+	}
+	mid, err := o.GetMidPrice()
+	if err != nil {
+		return Fill{}, err
+	}
+
+	f := Fill{Side: side, Requested: amount, MidPrice: mid}
+	for _, l := range levels {
+		qty := math.Min(l.Amount, amount-f.Amount)
+		f.Amount += qty
+		f.Value += qty * l.Price
+		f.WorstPrice = l.Price
+		if f.Amount >= amount {
+			break
+		}
+	}
+	f.AveragePrice = f.Value / f.Amount
+	f.Slippage = f.AveragePrice - mid
+	if side == Sell {
+		f.Slippage = -f.Slippage
+	}
+	f.SlippageBps = f.Slippage / mid * bpsMultiplier
+
+	if f.Amount < amount {
+		return f, ErrInsufficientLiquidity
+	}
+	return f, nil
+}
+
+// GetFillPrice returns the volume weighted average price of a market order of
+// amount
+func (o *Base) GetFillPrice(side Side, amount float64) (float64, error) {
+	f, err := o.SimulateFill(side, amount)
+	if err != nil {
+		return 0, err
+	}
+	return f.AveragePrice, nil
+}
+
+// GetSlippage returns the slippage in basis points against the mid price of a
+// market order of amount
+func (o *Base) GetSlippage(side Side, amount float64) (float64, error) {
+	f, err := o.SimulateFill(side, amount)
+	if err != nil {
+		return 0, err
+	}
+	return f.SlippageBps, nil
+}
+
+// GetLiquidity returns the bids and asks resting within bps basis points of
+// the mid price
+func (o *Base) GetLiquidity(bps float64) (Liquidity, error) {
+	mid, err := o.GetMidPrice()
+	if err != nil {
+		return Liquidity{}, err
+	}
+
+	l := Liquidity{Bps: bps, MidPrice: mid}
+	low := mid * (1 - bps/bpsMultiplier)
+	high := mid * (1 + bps/bpsMultiplier)
+	for _, b := range o.sortedBids() {
+		if b.Price < low {
+			break
+		}
+		l.BidAmount += b.Amount
+		l.BidValue += b.Amount * b.Price
+	}
+	for _, a := range o.sortedAsks() {
+		if a.Price > high {
+			break
+		}
+		l.AskAmount += a.Amount
+		l.AskValue += a.Amount * a.Price
+	}
+	return l, nil
+}
+
+// GetImbalance returns the imbalance between the bid and ask amounts within
+// bps basis points of the mid price, from 1 when there are only bids to -1
+// when there are only asks. A bps of zero uses the whole orderbook
+func (o *Base) GetImbalance(bps float64) (float64, error) {
+	var bidAmount, askAmount float64
+	if bps > 0 {
+		l, err := o.GetLiquidity(bps)
+		if err != nil {
+			return 0, err
+		}
+		bidAmount, askAmount = l.BidAmount, l.AskAmount
+	} else {
+		for _, b := range o.sortedBids() {
+			bidAmount += b.Amount
+		}
+		for _, a := range o.sortedAsks() {
+			askAmount += a.Amount
+		}
+	}
+	if bidAmount+askAmount == 0 {
+		return 0, nil
+	}
+	return (bidAmount - askAmount) / (bidAmount + askAmount), nil
+}
+
+// add records a spread sample, replacing the oldest once full
+func (h *spreadHistory) add(spreadBps float64) {
+	if len(h.samples) < spreadSamples {
+		h.samples = append(h.samples, spreadBps)
+		return
+	}
+	h.samples[h.next] = spreadBps
+	h.next = (h.next + 1) % spreadSamples
+}
+
+// stats returns the statistics of the recorded spreads
+func (h *spreadHistory) stats() (SpreadStats, error) {
+	if len(h.samples) == 0 {
+		return SpreadStats{}, ErrNoSpreadSamples
+	}
+	last := h.next - 1
+	if last < 0 {
+		last = len(h.samples) - 1
+	}
+	s := SpreadStats{
+		Samples: len(h.samples),
+		Last:    h.samples[last],
+		Min:     h.samples[0],
+		Max:     h.samples[0],
+	}
+	var sum float64
+	for _, v := range h.samples {
+		s.Min = math.Min(s.Min, v)
+		s.Max = math.Max(s.Max, v)
+		sum += v
+	}
+	s.Mean = sum / float64(len(h.samples))
+	var variance float64
+	for _, v := range h.samples {
+		variance += (v - s.Mean) * (v - s.Mean)
+	}
+	s.StdDev = math.Sqrt(variance / float64(len(h.samples)))
+	return s, nil
+}
+
+// GetSpreadStats returns statistics of the spread over the most recent updates
+// of a stored orderbook
+func GetSpreadStats(exchange string, p pair.CurrencyPair, orderbookType string) (SpreadStats, error) {
+	b, ok := books.get(newKey(exchange, p, orderbookType))
+	if !ok {
+		return SpreadStats{}, errors.New(ErrOrderbookForExchangeNotFound)
+	}
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.spreads.stats()
+}
+
+// GetAnalytics returns the spread, spread statistics, whole book imbalance and
+// liquidity within each of the bps distances of a stored orderbook
+func GetAnalytics(exchange string, p pair.CurrencyPair, orderbookType string, bps ...float64) (Analytics, error) {
+	ob, err := GetOrderbook(exchange, p, orderbookType)
+	if err != nil {
+		return Analytics{}, err
+	}
+
+	a := Analytics{Exchange: exchange, Pair: p, AssetType: orderbookType}
+	a.Spread, err = ob.GetSpread()
+	if err != nil {
+		return a, err
+	}
+	a.SpreadStats, err = GetSpreadStats(exchange, p, orderbookType)
+	if err != nil {
+		return a, err
+	}
+	a.Imbalance, err = ob.GetImbalance(0)
+	if err != nil {
+		return a, err
+	}
+	for _, distance := range bps {
+		l, err := ob.GetLiquidity(distance)
+		if err != nil {
+			return a, err
+		}
+		a.Liquidity = append(a.Liquidity, l)
+	}
+	return a, nil
+}
@@ -0,0 +1,135 @@
+package orderbook
+
+import (
+	"math"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+func isClose(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// testBase returns an unsorted orderbook with a mid price of 100
+func testBase() Base {
+	return Base{
+		Bids: []Item{{Price: 98, Amount: 2}, {Price: 99, Amount: 1}, {Price: 90, Amount: 10}},
+		Asks: []Item{{Price: 102, Amount: 3}, {Price: 101, Amount: 1}, {Price: 0, Amount: 5}},
+	}
+}
+
+func TestGetSpread(t *testing.T) {
+	t.Parallel()
+	b := testBase()
+	s, err := b.GetSpread()
+	if err != nil {
+		t.Fatalf("Test failed. GetSpread error: %s", err)
+	}
+	if s.Bid != 99 || s.Ask != 101 || s.Mid != 100 || s.Spread != 2 || !isClose(s.SpreadBps, 200) {
+		t.Errorf("Test failed. Unexpected spread %+v", s)
+	}
+
+	b.Asks = nil
+	if _, err = b.GetSpread(); err != ErrNoAsks {
+		t.Errorf("Test failed. Expected %s got %v", ErrNoAsks, err)
+	}
+	b.Bids = nil
+	if _, err = b.GetMidPrice(); err != ErrNoBids {
+		t.Errorf("Test failed. Expected %s got %v", ErrNoBids, err)
+	}
+}
+
+func TestSimulateFill(t *testing.T) {
+	t.Parallel()
+	b := testBase()
+
+	f, err := b.SimulateFill(Buy, 2)
+	if err != nil {
+		t.Fatalf("Test failed. SimulateFill error: %s", err)
+	}
+	if f.Amount != 2 || f.Value != 203 || f.AveragePrice != 101.5 || f.WorstPrice != 102 ||
+		!isClose(f.Slippage, 1.5) || !isClose(f.SlippageBps, 150) {
+		t.Errorf("Test failed. Unexpected buy fill %+v", f)
+	}
+
+	price, err := b.GetFillPrice(Sell, 3)
+	if err != nil || !isClose(price, 295.0/3) {
+		t.Errorf("Test failed. Expected sell fill price of %f got %f %v", 295.0/3, price, err)
+	}
+	slippage, err := b.GetSlippage(Sell, 3)
+	if err != nil || !isClose(slippage, (100-295.0/3)/100*10000) {
+		t.Errorf("Test failed. Unexpected sell slippage %f %v", slippage, err)
+	}
+
+	f, err = b.SimulateFill(Buy, 5)
+	if err != ErrInsufficientLiquidity || f.Amount != 4 {
+		t.Errorf("Test failed. Expected partial fill with %s got %+v %v", ErrInsufficientLiquidity, f, err)
+	}
+	if _, err = b.SimulateFill(Buy, 0); err != ErrInvalidAmount {
+		t.Errorf("Test failed. Expected %s got %v", ErrInvalidAmount, err)
+	}
+	if _, err = b.SimulateFill("HOLD", 1); err != ErrInvalidSide {
+		t.Errorf("Test failed. Expected %s got %v", ErrInvalidSide, err)
+	}
+}
+
+func TestGetLiquidityAndImbalance(t *testing.T) {
+	t.Parallel()
+	b := testBase()
+
+	l, err := b.GetLiquidity(200)
+	if err != nil {
+		t.Fatalf("Test failed. GetLiquidity error: %s", err)
+	}
+	if l.BidAmount != 3 || l.BidValue != 295 || l.AskAmount != 4 || l.AskValue != 407 {
+		t.Errorf("Test failed. Unexpected liquidity %+v", l)
+	}
+
+	imbalance, err := b.GetImbalance(200)
+	if err != nil || !isClose(imbalance, -1.0/7) {
+		t.Errorf("Test failed. Expected imbalance within 200 bps of %f got %f %v", -1.0/7, imbalance, err)
+	}
+	imbalance, err = b.GetImbalance(0)
+	if err != nil || !isClose(imbalance, 9.0/17) {
+		t.Errorf("Test failed. Expected whole book imbalance of %f got %f %v", 9.0/17, imbalance, err)
+	}
+}
+
+func TestGetAnalytics(t *testing.T) {
+	t.Parallel()
+	p := pair.NewCurrencyPair("BTC", "USD")
+	if _, err := GetSpreadStats("AnalyticsTest", p, Spot); err == nil {
+		t.Error("Test failed. Expected error for missing orderbook")
+	}
+
+	for _, ask := range []float64{101, 103, 102} {
+		ProcessOrderbook("AnalyticsTest", p, Base{
+			Bids: []Item{{Price: 99, Amount: 1}},
+			Asks: []Item{{Price: ask, Amount: 1}},
+		}, Spot)
+	}
+
+	a, err := GetAnalytics("AnalyticsTest", p, Spot, 50, 500)
+	if err != nil {
+		t.Fatalf("Test failed. GetAnalytics error: %s", err)
+	}
+	if a.Spread.Ask != 102 || a.Imbalance != 0 || len(a.Liquidity) != 2 ||
+		a.Liquidity[0].AskAmount != 0 || a.Liquidity[1].AskAmount != 1 {
+		t.Errorf("Test failed. Unexpected analytics %+v", a)
+	}
+
+	stats := a.SpreadStats
+	if stats.Samples != 3 || !isClose(stats.Last, a.Spread.SpreadBps) ||
+		!isClose(stats.Min, 200) || !isClose(stats.Max, 4.0/101*10000) || stats.Mean <= stats.Min || stats.StdDev <= 0 {
+		t.Errorf("Test failed. Unexpected spread stats %+v", stats)
+	}
+
+	var h spreadHistory
+	for i := 0; i < spreadSamples+5; i++ {
+		h.add(float64(i))
+	}
+	if stats, _ = h.stats(); stats.Samples != spreadSamples || stats.Min != 5 || stats.Last != spreadSamples+4 {
+		t.Errorf("Test failed. Expected oldest spreads to be replaced got %+v", stats)
+	}
+}
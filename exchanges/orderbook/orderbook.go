@@ -13,14 +13,18 @@ const (
 	ErrOrderbookForExchangeNotFound = "Ticker for exchange does not exist."
 	ErrPrimaryCurrencyNotFound      = "Error primary currency for orderbook not found."
 	ErrSecondaryCurrencyNotFound    = "Error secondary currency for orderbook not found."
+	ErrAssetTypeNotFound            = "Error asset type for orderbook not found."
 
 	Spot = "SPOT"
+
+	// subscriptionBuffer is the number of updates a subscriber can fall behind
+	// by before further updates are dropped for that subscriber
+	subscriptionBuffer = 100
 )
 
 // Vars for the orderbook package
 var (
-	Orderbooks []Orderbook
-	m          sync.Mutex
+	books = newStore()
 )
 
 // Item stores the amount and price values
@@ -46,6 +50,74 @@ type Orderbook struct {
 	ExchangeName string
 }
 
+// Update is sent to subscribers whenever a stored orderbook is processed
+type Update struct {
+	Exchange  string
+	Pair      pair.CurrencyPair
+	AssetType string
+	Orderbook Base
+}
+
+// Subscription receives orderbook updates matching its filter on C. Updates
+// are dropped rather than blocking ProcessOrderbook if C is not drained
+type Subscription struct {
+	C         chan Update
+	id        int64
+	exchange  string
+	pair      pair.CurrencyPair
+	assetType string
+}
+
+// key uniquely identifies a stored orderbook
+type key struct {
+	exchange  string
+	first     pair.CurrencyItem
+	second    pair.CurrencyItem
+	assetType string
+}
+
+// book holds a single orderbook guarded by its own lock so updates to one
+// book do not contend with reads of another
+type book struct {
+	mtx     sync.RWMutex
+	base    Base
+	spreads spreadHistory
+}
+
+// store holds every processed orderbook keyed by exchange, pair and asset
+type store struct {
+	mtx       sync.RWMutex
+	books     map[key]*book
+	subMtx    sync.RWMutex
+	subs      map[int64]*Subscription
+	nextSubID int64
+}
+
+func newStore() *store {
+	return &store{
+		books: make(map[key]*book),
+		subs:  make(map[int64]*Subscription),
+	}
+}
+
+func newKey(exchange string, p pair.CurrencyPair, assetType string) key {
+	return key{
+		exchange:  exchange,
+		first:     p.FirstCurrency.Upper(),
+		second:    p.SecondCurrency.Upper(),
+		assetType: assetType,
+	}
+}
+
+// copyBase returns a deep copy of an orderbook so callers cannot mutate the
+// stored bids and asks
+func copyBase(b Base) Base {
+	c := b
+	c.Bids = append([]Item(nil), b.Bids...)
+	c.Asks = append([]Item(nil), b.Asks...)
+	return c
+}
+
 // CalculateTotalBids returns the total amount of bids and the total orderbook
 // bids value
 func (o *Base) CalculateTotalBids() (float64, float64) {
@@ -77,12 +149,135 @@ func (o *Base) Update(Bids, Asks []Item) {
 	o.LastUpdated = time.Now()
 }
 
-// GetOrderbook checks and returns the orderbook given an exchange name and
-// currency pair if it exists
+// get returns the stored book for a key if it exists
+func (s *store) get(k key) (*book, bool) {
+	s.mtx.RLock()
+	b, ok := s.books[k]
+	s.mtx.RUnlock()
+	return b, ok
+}
+
+// set stores a copy of the supplied orderbook, creating the book if required,
+// and records its spread
+func (s *store) set(k key, ob Base) {
+	b, ok := s.get(k)
+	if !ok {
+		s.mtx.Lock()
+		b, ok = s.books[k]
+		if !ok {
+			b = &book{}
+			s.books[k] = b
+		}
+		s.mtx.Unlock()
+	}
+
+	b.mtx.Lock()
+	b.base = copyBase(ob)
+	if spread, err := b.base.GetSpread(); err == nil {
+		b.spreads.add(spread.SpreadBps)
+	}
+	b.mtx.Unlock()
+}
+
+// snapshot returns a copy of every stored book matching the filter function
+func (s *store) snapshot(match func(k key) bool) []Base {
+	s.mtx.RLock()
+	var matched []*book
+	for k, b := range s.books {
+		if match(k) {
+			matched = append(matched, b)
+		}
+	}
+	s.mtx.RUnlock()
+
+	result := make([]Base, 0, len(matched))
+	for _, b := range matched {
+		b.mtx.RLock()
+		result = append(result, copyBase(b.base))
+		b.mtx.RUnlock()
+	}
+	return result
+}
+
+// notify sends an update to every matching subscriber without blocking
+func (s *store) notify(u Update) {
+	s.subMtx.RLock()
+	defer s.subMtx.RUnlock()
+	for _, sub := range s.subs {
+		if !sub.matches(u) {
+			continue
+		}
+		select {
+		case sub.C <- Update{
+			Exchange:  u.Exchange,
+			Pair:      u.Pair,
+			AssetType: u.AssetType,
+			Orderbook: copyBase(u.Orderbook),
+		}:
+		default:
+		}
+	}
+}
+
+// matches returns whether an update satisfies the subscription filter, empty
+// filter fields match everything
+func (s *Subscription) matches(u Update) bool {
+	if s.exchange != "" && s.exchange != u.Exchange {
+		return false
+	}
+	if !s.pair.Empty() && !s.pair.Equal(u.Pair, true) {
+		return false
+	}
+	if s.assetType != "" && s.assetType != u.AssetType {
+		return false
+	}
+	return true
+}
+
+// Subscribe returns a subscription which receives a copy of every orderbook
+// processed for the exchange, pair and asset type. Empty exchange, pair or
+// asset type values match all orderbooks
+func Subscribe(exchangeName string, p pair.CurrencyPair, assetType string) *Subscription {
+	books.subMtx.Lock()
+	defer books.subMtx.Unlock()
+	books.nextSubID++
+	sub := &Subscription{
+		C:         make(chan Update, subscriptionBuffer),
+		id:        books.nextSubID,
+		exchange:  exchangeName,
+		pair:      p,
+		assetType: assetType,
+	}
+	books.subs[sub.id] = sub
+	return sub
+}
+
+// Unsubscribe stops and closes a subscription
+func Unsubscribe(sub *Subscription) {
+	if sub == nil {
+		return
+	}
+	books.subMtx.Lock()
+	defer books.subMtx.Unlock()
+	if _, ok := books.subs[sub.id]; !ok {
+		return
+	}
+	delete(books.subs, sub.id)
+	close(sub.C)
+}
+
+// GetOrderbook checks and returns a copy of the orderbook given an exchange
+// name and currency pair if it exists
 func GetOrderbook(exchange string, p pair.CurrencyPair, orderbookType string) (Base, error) {
-	orderbook, err := GetOrderbookByExchange(exchange)
-	if err != nil {
-		return Base{}, err
+	b, ok := books.get(newKey(exchange, p, orderbookType))
+	if ok {
+		b.mtx.RLock()
+		defer b.mtx.RUnlock()
+		return copyBase(b.base), nil
+	}
+
+	if !exchangeExists(exchange) {
+		return Base{}, errors.New(ErrOrderbookForExchangeNotFound)
 	}
 
 	if !FirstCurrencyExists(exchange, p.FirstCurrency) {
@@ -93,31 +288,76 @@ func GetOrderbook(exchange string, p pair.CurrencyPair, orderbookType string) (B
 		return Base{}, errors.New(ErrSecondaryCurrencyNotFound)
 	}
 
-	return orderbook.Orderbook[p.FirstCurrency][p.SecondCurrency][orderbookType], nil
+	return Base{}, errors.New(ErrAssetTypeNotFound)
+}
+
+// GetOrderbooks returns a copy of every stored orderbook for an exchange. An
+// empty exchange name returns the orderbooks for all exchanges
+func GetOrderbooks(exchange string) []Base {
+	return books.snapshot(func(k key) bool {
+		return exchange == "" || k.exchange == exchange
+	})
 }
 
-// GetOrderbookByExchange returns an exchange orderbook
+// GetExchanges returns the names of all exchanges with stored orderbooks
+func GetExchanges() []string {
+	books.mtx.RLock()
+	defer books.mtx.RUnlock()
+	seen := make(map[string]bool)
+	var exchanges []string
+	for k := range books.books {
+		if !seen[k.exchange] {
+			seen[k.exchange] = true
+			exchanges = append(exchanges, k.exchange)
+		}
+	}
+	return exchanges
+}
+
+// GetOrderbookByExchange returns a copy of all orderbooks for an exchange
 func GetOrderbookByExchange(exchange string) (*Orderbook, error) {
-	m.Lock()
-	defer m.Unlock()
-	for _, y := range Orderbooks {
-		if y.ExchangeName == exchange {
-			return &y, nil
+	bases := GetOrderbooks(exchange)
+	if len(bases) == 0 {
+		return nil, errors.New(ErrOrderbookForExchangeNotFound)
+	}
+
+	orderbook := Orderbook{
+		ExchangeName: exchange,
+		Orderbook:    make(map[pair.CurrencyItem]map[pair.CurrencyItem]map[string]Base),
+	}
+	for x := range bases {
+		first := bases[x].Pair.FirstCurrency.Upper()
+		second := bases[x].Pair.SecondCurrency.Upper()
+		if _, ok := orderbook.Orderbook[first]; !ok {
+			orderbook.Orderbook[first] = make(map[pair.CurrencyItem]map[string]Base)
+		}
+		if _, ok := orderbook.Orderbook[first][second]; !ok {
+			orderbook.Orderbook[first][second] = make(map[string]Base)
+		}
+		orderbook.Orderbook[first][second][bases[x].AssetType] = bases[x]
+	}
+	return &orderbook, nil
+}
+
+func exchangeExists(exchange string) bool {
+	books.mtx.RLock()
+	defer books.mtx.RUnlock()
+	for k := range books.books {
+		if k.exchange == exchange {
+			return true
 		}
 	}
-	return nil, errors.New(ErrOrderbookForExchangeNotFound)
+	return false
 }
 
 // FirstCurrencyExists checks to see if the first currency of the orderbook map
 // exists
 func FirstCurrencyExists(exchange string, currency pair.CurrencyItem) bool {
-	m.Lock()
-	defer m.Unlock()
-	for _, y := range Orderbooks {
-		if y.ExchangeName == exchange {
-			if _, ok := y.Orderbook[currency]; ok {
-				return true
-			}
+	books.mtx.RLock()
+	defer books.mtx.RUnlock()
+	for k := range books.books {
+		if k.exchange == exchange && k.first == currency.Upper() {
+			return true
 		}
 	}
 	return false
@@ -126,15 +366,13 @@ func FirstCurrencyExists(exchange string, currency pair.CurrencyItem) bool {
 // SecondCurrencyExists checks to see if the second currency of the orderbook
 // map exists
 func SecondCurrencyExists(exchange string, p pair.CurrencyPair) bool {
-	m.Lock()
-	defer m.Unlock()
-	for _, y := range Orderbooks {
-		if y.ExchangeName == exchange {
-			if _, ok := y.Orderbook[p.FirstCurrency]; ok {
-				if _, ok := y.Orderbook[p.FirstCurrency][p.SecondCurrency]; ok {
-					return true
-				}
-			}
+	books.mtx.RLock()
+	defer books.mtx.RUnlock()
+	for k := range books.books {
+		if k.exchange == exchange &&
+			k.first == p.FirstCurrency.Upper() &&
+			k.second == p.SecondCurrency.Upper() {
+			return true
 		}
 	}
 	return false
@@ -142,8 +380,9 @@ func SecondCurrencyExists(exchange string, p pair.CurrencyPair) bool {
 
 // CreateNewOrderbook creates a new orderbook
 func CreateNewOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew Base, orderbookType string) Orderbook {
-	m.Lock()
-	defer m.Unlock()
+	orderbookNew.AssetType = orderbookType
+	books.set(newKey(exchangeName, p, orderbookType), orderbookNew)
+
 	orderbook := Orderbook{}
 	orderbook.ExchangeName = exchangeName
 	orderbook.Orderbook = make(map[pair.CurrencyItem]map[pair.CurrencyItem]map[string]Base)
@@ -152,12 +391,11 @@ func CreateNewOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew B
 	b[orderbookType] = orderbookNew
 	a[p.SecondCurrency] = b
 	orderbook.Orderbook[p.FirstCurrency] = a
-	Orderbooks = append(Orderbooks, orderbook)
 	return orderbook
 }
 
-// ProcessOrderbook processes incoming orderbooks, creating or updating the
-// Orderbook list
+// ProcessOrderbook processes incoming orderbooks, storing them and notifying
+// any subscribers
 func ProcessOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew Base, orderbookType string) {
 	if orderbookNew.Pair.Pair() == "" {
 		// set Pair if not set
@@ -165,27 +403,13 @@ func ProcessOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew Bas
 	}
 	orderbookNew.CurrencyPair = p.Pair().String()
 	orderbookNew.LastUpdated = time.Now()
+	orderbookNew.AssetType = orderbookType
 
-	orderbook, err := GetOrderbookByExchange(exchangeName)
-	if err != nil {
-		CreateNewOrderbook(exchangeName, p, orderbookNew, orderbookType)
-		return
-	}
-
-	if FirstCurrencyExists(exchangeName, p.FirstCurrency) {
-		m.Lock()
-		a := make(map[string]Base)
-		a[orderbookType] = orderbookNew
-		orderbook.Orderbook[p.FirstCurrency][p.SecondCurrency] = a
-		m.Unlock()
-		return
-	}
-
-	m.Lock()
-	a := make(map[pair.CurrencyItem]map[string]Base)
-	b := make(map[string]Base)
-	b[orderbookType] = orderbookNew
-	a[p.SecondCurrency] = b
-	orderbook.Orderbook[p.FirstCurrency] = a
-	m.Unlock()
+	books.set(newKey(exchangeName, p, orderbookType), orderbookNew)
+	books.notify(Update{
+		Exchange:  exchangeName,
+		Pair:      p,
+		AssetType: orderbookType,
+		Orderbook: orderbookNew,
+	})
 }
@@ -210,7 +210,7 @@ func TestCreateNewOrderbook(t *testing.T) {
 }
 
 func TestProcessOrderbook(t *testing.T) {
-	Orderbooks = []Orderbook{}
+	books = newStore()
 	currency := pair.NewCurrencyPair("BTC", "USD")
 	base := Base{
 		Pair:         currency,
@@ -328,3 +328,82 @@ func TestProcessOrderbook(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestGetOrderbookReturnsCopy(t *testing.T) {
+	currency := pair.NewCurrencyPair("LTC", "USD")
+	base := Base{
+		Pair: currency,
+		Asks: []Item{{Price: 100, Amount: 10}},
+		Bids: []Item{{Price: 90, Amount: 10}},
+	}
+	ProcessOrderbook("CopyExchange", currency, base, Spot)
+
+	result, err := GetOrderbook("CopyExchange", currency, Spot)
+	if err != nil {
+		t.Fatalf("Test failed. TestGetOrderbookReturnsCopy error: %s", err)
+	}
+	result.Asks[0].Price = 1
+
+	result, err = GetOrderbook("CopyExchange", currency, Spot)
+	if err != nil {
+		t.Fatalf("Test failed. TestGetOrderbookReturnsCopy error: %s", err)
+	}
+	if result.Asks[0].Price != 100 {
+		t.Error("Test failed. TestGetOrderbookReturnsCopy stored orderbook was mutated")
+	}
+
+	_, err = GetOrderbook("CopyExchange", currency, "futures")
+	if err == nil {
+		t.Error("Test failed. TestGetOrderbookReturnsCopy retrieved non-existent asset type")
+	}
+
+	if len(GetOrderbooks("CopyExchange")) != 1 {
+		t.Error("Test failed. TestGetOrderbookReturnsCopy expected one orderbook")
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	currency := pair.NewCurrencyPair("ETH", "USD")
+	sub := Subscribe("SubExchange", currency, Spot)
+	all := Subscribe("", pair.CurrencyPair{}, "")
+	defer Unsubscribe(all)
+
+	base := Base{
+		Asks: []Item{{Price: 100, Amount: 10}},
+		Bids: []Item{{Price: 90, Amount: 10}},
+	}
+	ProcessOrderbook("OtherExchange", currency, base, Spot)
+	ProcessOrderbook("SubExchange", currency, base, Spot)
+
+	select {
+	case u := <-sub.C:
+		if u.Exchange != "SubExchange" || !u.Pair.Equal(currency, true) ||
+			u.Orderbook.Asks[0].Price != 100 {
+			t.Errorf("Test failed. TestSubscribe received unexpected update %v", u)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Test failed. TestSubscribe did not receive an update")
+	}
+
+	select {
+	case u := <-sub.C:
+		t.Errorf("Test failed. TestSubscribe received unexpected update %v", u)
+	default:
+	}
+
+	var received int
+	for received < 2 {
+		select {
+		case <-all.C:
+			received++
+		case <-time.After(time.Second):
+			t.Fatal("Test failed. TestSubscribe wildcard subscription missed updates")
+		}
+	}
+
+	Unsubscribe(sub)
+	if _, ok := <-sub.C; ok {
+		t.Error("Test failed. TestSubscribe expected channel to be closed")
+	}
+	ProcessOrderbook("SubExchange", currency, base, Spot)
+}
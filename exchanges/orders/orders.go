@@ -1,69 +1,657 @@
 package orders
 
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
 const (
-	limitOrder = iota
-	marketOrder
+	ordersFile = "orders.json"
 )
 
-// Orders variable holds an array of pointers to order structs
-var Orders []*Order
+// vars related to the order manager
+var (
+	ErrOrderNotFound = errors.New("order not found")
+)
 
-// Order struct holds order values
-type Order struct {
-	OrderID  int
-	Exchange string
-	Type     int
-	Amount   float64
-	Price    float64
+// IsOpen returns whether or not the status can still change on the exchange
+func (s Status) IsOpen() bool {
+	return s == New || s == PartiallyFilled
 }
 
-// NewOrder creates a new order and returns a an orderID
-func NewOrder(Exchange string, amount, price float64) int {
-	order := &Order{}
-	if len(Orders) == 0 {
-		order.OrderID = 0
-	} else {
-		order.OrderID = len(Orders)
+// NewManager returns a new order manager which persists to the supplied data
+// directory
+func NewManager(dataDir string) *Manager {
+	return &Manager{
+		filePath: dataDir + common.GetOSPathSlash() + ordersFile,
 	}
+}
 
-	order.Exchange = Exchange
-	order.Amount = amount
-	order.Price = price
-	Orders = append(Orders, order)
-	return order.OrderID
+// GetFilePath returns the path of the file orders are persisted to
+func (m *Manager) GetFilePath() string {
+	return m.filePath
 }
 
-// DeleteOrder deletes orders by ID and returns state
-func DeleteOrder(orderID int) bool {
-	for i := range Orders {
-		if Orders[i].OrderID == orderID {
-			Orders = append(Orders[:i], Orders[i+1:]...)
-			return true
+// Load reads any previously persisted orders from disk
+func (m *Manager) Load() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, err := os.Stat(m.filePath); os.IsNotExist(err) {
+		return nil
+	}
+
+	data, err := common.ReadFile(m.filePath)
+	if err != nil {
+		return err
+	}
+
+	var s store
+	err = common.JSONDecode(data, &s)
+	if err != nil {
+		return err
+	}
+
+	m.Orders = s.Orders
+	m.LastID = s.LastID
+	// Fills before the restart have already been published, executed amounts
+	// reported by the exchange beyond these are published when reconciled
+	for x := range m.Orders {
+		m.Orders[x].ReportedAmount = m.Orders[x].ExecutedAmount
+	}
+	return nil
+}
+
+// Save persists all tracked orders to disk
+func (m *Manager) Save() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.save()
+}
+
+func (m *Manager) save() error {
+	var orders []*Order
+	for x := range m.Orders {
+		if !m.Orders[x].PaperTrading {
+			orders = append(orders, m.Orders[x])
 		}
 	}
-	return false
+
+	data, err := common.JSONEncode(store{LastID: m.LastID, Orders: orders})
+	if err != nil {
+		return err
+	}
+	return common.WriteFile(m.filePath, data)
+}
+
+// saveOrLog persists the tracked orders and logs any failure, order actions
+// should not fail because the state could not be written to disk
+func (m *Manager) saveOrLog() {
+	err := m.save()
+	if err != nil {
+		log.Printf("Order manager: unable to save orders to %s. Err: %s", m.filePath, err)
+	}
+}
+
+// Wrap returns an exchange which records all submitted, modified and
+// cancelled orders in the order manager
+func (m *Manager) Wrap(exch exchange.IBotExchange) exchange.IBotExchange {
+	return &Exchange{IBotExchange: exch, manager: m}
 }
 
-// GetOrdersByExchange returns order pointer grouped by exchange
-func GetOrdersByExchange(exchange string) []*Order {
-	orders := []*Order{}
-	for i := range Orders {
-		if Orders[i].Exchange == exchange {
-			orders = append(orders, Orders[i])
+// SetPaperTrading marks an exchange as paper trading. Its orders are tracked
+// separately from any orders previously placed on the live exchange, are not
+// persisted to disk and are not reconciled
+func (m *Manager) SetPaperTrading(exchName string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.paperTrading == nil {
+		m.paperTrading = make(map[string]bool)
+	}
+	m.paperTrading[common.StringToLower(exchName)] = true
+}
+
+// isPaperTrading returns whether an exchange's orders are simulated
+func (m *Manager) isPaperTrading(exchName string) bool {
+	return m.paperTrading[common.StringToLower(exchName)]
+}
+
+// SetFillHandler sets the function called with the fills of exchanges which
+// do not deliver fill events. These fills are derived from increases in the
+// executed amount of tracked orders
+func (m *Manager) SetFillHandler(handler func(fill exchange.FillEvent)) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.fillHandler = handler
+}
+
+// ProcessFill applies a fill event delivered by an exchange to a tracked
+// order. Fills for the order are no longer derived from its executed amount
+func (m *Manager) ProcessFill(fill exchange.FillEvent) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.fillStreams == nil {
+		m.fillStreams = make(map[string]bool)
+	}
+	m.fillStreams[common.StringToLower(fill.Exchange)] = true
+
+	o := m.getOrder(fill.Exchange, fill.OrderID)
+	if o == nil {
+		o = m.updateOrder(exchange.OrderDetail{
+			Exchange:     fill.Exchange,
+			ID:           fill.OrderID,
+			CurrencyPair: fill.Pair,
+			OrderSide:    fill.OrderSide,
+			OrderDate:    fill.Timestamp,
+		}, true)
+	}
+
+	o.ReportedAmount += fill.Amount
+	if o.ReportedAmount > o.ExecutedAmount {
+		o.ExecutedAmount = o.ReportedAmount
+		if o.Status.IsOpen() {
+			o.Status = PartiallyFilled
+			if o.Amount > 0 && o.ExecutedAmount >= o.Amount {
+				o.Status = Filled
+			}
 		}
 	}
-	if len(orders) > 0 {
-		return orders
+	o.UpdatedAt = time.Now()
+	m.saveOrLog()
+}
+
+// queueFill queues a fill for any executed amount of an order not yet
+// published, the lock must be held by the caller
+func (m *Manager) queueFill(o *Order) {
+	if o.ExecutedAmount <= o.ReportedAmount ||
+		m.fillStreams[common.StringToLower(o.Exchange)] {
+		return
+	}
+	m.fills = append(m.fills, exchange.FillEvent{
+		Timestamp: time.Now(),
+		Exchange:  o.Exchange,
+		Pair:      o.CurrencyPair,
+		OrderID:   o.ExchangeOrderID,
+		OrderSide: o.Side,
+		Price:     o.Price,
+		Amount:    o.ExecutedAmount - o.ReportedAmount,
+	})
+	o.ReportedAmount = o.ExecutedAmount
+}
+
+// publishFills sends the queued fills to the fill handler, it must be called
+// without the lock held so the handler may use the manager
+func (m *Manager) publishFills() {
+	m.mtx.Lock()
+	fills := m.fills
+	m.fills = nil
+	handler := m.fillHandler
+	m.mtx.Unlock()
+
+	if handler == nil {
+		return
+	}
+	for x := range fills {
+		handler(fills[x])
+	}
+}
+
+// GetOrders returns a copy of all tracked orders
+func (m *Manager) GetOrders() []Order {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var orders []Order
+	for x := range m.Orders {
+		orders = append(orders, *m.Orders[x])
+	}
+	return orders
+}
+
+// GetOrdersByExchange returns a copy of all tracked orders for an exchange
+func (m *Manager) GetOrdersByExchange(exchName string) []Order {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var orders []Order
+	for x := range m.Orders {
+		if common.StringToLower(m.Orders[x].Exchange) == common.StringToLower(exchName) {
+			orders = append(orders, *m.Orders[x])
+		}
+	}
+	return orders
+}
+
+// GetOpenOrders returns a copy of all tracked orders for an exchange which
+// are yet to be filled or cancelled. An empty exchange name matches all
+// exchanges
+func (m *Manager) GetOpenOrders(exchName string) []Order {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var orders []Order
+	for x := range m.Orders {
+		if exchName != "" &&
+			common.StringToLower(m.Orders[x].Exchange) != common.StringToLower(exchName) {
+			continue
+		}
+		if m.Orders[x].Status.IsOpen() {
+			orders = append(orders, *m.Orders[x])
+		}
+	}
+	return orders
+}
+
+// GetOrderByID returns a copy of a tracked order by its internal ID
+func (m *Manager) GetOrderByID(id int64) (Order, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for x := range m.Orders {
+		if m.Orders[x].ID == id {
+			return *m.Orders[x], nil
+		}
+	}
+	return Order{}, ErrOrderNotFound
+}
+
+// GetOrderByExchangeOrderID returns a copy of a tracked order by the ID
+// assigned to it by the exchange
+func (m *Manager) GetOrderByExchangeOrderID(exchName, orderID string) (Order, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	o := m.getOrder(exchName, orderID)
+	if o == nil {
+		return Order{}, ErrOrderNotFound
+	}
+	return *o, nil
+}
+
+func (m *Manager) getOrder(exchName, orderID string) *Order {
+	paperTrading := m.isPaperTrading(exchName)
+	for x := range m.Orders {
+		if m.Orders[x].ExchangeOrderID == orderID &&
+			m.Orders[x].PaperTrading == paperTrading &&
+			common.StringToLower(m.Orders[x].Exchange) == common.StringToLower(exchName) {
+			return m.Orders[x]
+		}
 	}
 	return nil
 }
 
-// GetOrderByOrderID returns order pointer by ID
-func GetOrderByOrderID(orderID int) *Order {
-	for i := range Orders {
-		if Orders[i].OrderID == orderID {
-			return Orders[i]
+func (m *Manager) add(o *Order) {
+	o.PaperTrading = m.isPaperTrading(o.Exchange)
+	m.LastID++
+	o.ID = m.LastID
+	o.CreatedAt = time.Now()
+	o.UpdatedAt = o.CreatedAt
+	m.Orders = append(m.Orders, o)
+}
+
+// UpdateOrder applies the state reported by an exchange to a tracked order.
+// Orders which are not yet tracked are added to the manager
+func (m *Manager) UpdateOrder(detail exchange.OrderDetail) {
+	defer m.publishFills()
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.queueFill(m.updateOrder(detail, true))
+	m.saveOrLog()
+}
+
+func (m *Manager) updateOrder(detail exchange.OrderDetail, active bool) *Order {
+	o := m.getOrder(detail.Exchange, detail.ID)
+	if o == nil {
+		o = &Order{
+			Exchange:        detail.Exchange,
+			ExchangeOrderID: detail.ID,
+			ClientOrderID:   detail.ClientOrderID,
+			CurrencyPair:    detail.CurrencyPair,
+			Side:            detail.OrderSide,
+			Type:            detail.OrderType,
+			Price:           detail.Price,
+			Amount:          detail.Amount,
+			Status:          New,
+		}
+		m.add(o)
+		if !detail.OrderDate.IsZero() {
+			o.CreatedAt = detail.OrderDate
 		}
 	}
+
+	if detail.Amount > 0 {
+		o.Amount = detail.Amount
+	}
+	if detail.Price > 0 {
+		o.Price = detail.Price
+	}
+	if detail.ExecutedAmount > o.ExecutedAmount {
+		o.ExecutedAmount = detail.ExecutedAmount
+	}
+
+	switch {
+	case o.Amount > 0 && o.ExecutedAmount >= o.Amount:
+		o.Status = Filled
+	case !active:
+		o.Status = Cancelled
+	case o.ExecutedAmount > 0:
+		o.Status = PartiallyFilled
+	default:
+		o.Status = New
+	}
+	o.UpdatedAt = time.Now()
+	return o
+}
+
+// ProcessOrderUpdate applies an order update received over an authenticated
+// websocket connection to a tracked order. Orders which are not yet tracked
+// are added to the manager
+func (m *Manager) ProcessOrderUpdate(update exchange.OrderUpdate) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	executed := update.ExecutedAmount
+	if executed == 0 && update.Amount > 0 && update.RemainingAmount > 0 {
+		executed = update.Amount - update.RemainingAmount
+	}
+
+	active := update.Status != exchange.CancelledOrderStatus &&
+		update.Status != exchange.RejectedOrderStatus
+	o := m.updateOrder(exchange.OrderDetail{
+		Exchange:       update.Exchange,
+		ID:             update.OrderID,
+		ClientOrderID:  update.ClientOrderID,
+		CurrencyPair:   update.Pair,
+		OrderSide:      update.OrderSide,
+		OrderType:      update.OrderType,
+		OrderDate:      update.Timestamp,
+		Price:          update.Price,
+		Amount:         update.Amount,
+		ExecutedAmount: executed,
+	}, active)
+
+	switch update.Status {
+	case exchange.FilledOrderStatus:
+		o.Status = Filled
+	case exchange.RejectedOrderStatus:
+		o.Status = Rejected
+	}
+	m.saveOrLog()
+}
+
+// Reconcile retrieves the active orders and order history of an exchange and
+// updates the state of all tracked orders to match. Orders active on the
+// exchange which are not yet tracked are added to the manager. The manager is
+// not locked while the exchange is queried
+func (m *Manager) Reconcile(exch exchange.IBotExchange) error {
+	m.mtx.Lock()
+	paperTrading := m.isPaperTrading(exch.GetName())
+	m.mtx.Unlock()
+	if paperTrading {
+		return nil
+	}
+
+	activeOrders, err := exch.GetActiveOrders(exchange.GetOrdersRequest{})
+	if err != nil {
+		return err
+	}
+
+	defer m.publishFills()
+	missing, p := m.reconcileActive(exch.GetName(), activeOrders)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	history, err := exch.GetOrderHistory(exchange.GetOrdersRequest{Currencies: p})
+	if err != nil {
+		return fmt.Errorf("unable to resolve %d orders no longer active on %s: %s",
+			len(missing), exch.GetName(), err)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.reconcileHistory(exch.GetName(), missing, history)
+	m.saveOrLog()
+	return nil
+}
+
+// reconcileActive updates tracked orders from the active orders of an
+// exchange, returning the open orders which are no longer active and their
+// currency pairs
+func (m *Manager) reconcileActive(exchName string, activeOrders []exchange.OrderDetail) ([]*Order, []pair.CurrencyPair) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	active := make(map[string]bool)
+	for x := range activeOrders {
+		activeOrders[x].Exchange = exchName
+		active[activeOrders[x].ID] = true
+		tracked := m.getOrder(exchName, activeOrders[x].ID) != nil
+		o := m.updateOrder(activeOrders[x], true)
+		if !tracked {
+			// Orders placed outside of the bot were filled before being
+			// tracked
+			o.ReportedAmount = o.ExecutedAmount
+		}
+		m.queueFill(o)
+	}
+
+	var missing []*Order
+	var p []pair.CurrencyPair
+	for x := range m.Orders {
+		if common.StringToLower(m.Orders[x].Exchange) != common.StringToLower(exchName) {
+			continue
+		}
+		if m.Orders[x].Status.IsOpen() && !active[m.Orders[x].ExchangeOrderID] {
+			missing = append(missing, m.Orders[x])
+			if !pair.Contains(p, m.Orders[x].CurrencyPair, true) {
+				p = append(p, m.Orders[x].CurrencyPair)
+			}
+		}
+	}
+
+	m.saveOrLog()
+	return missing, p
+}
+
+// reconcileHistory resolves the final state of tracked orders which are no
+// longer active on the exchange from its order history. Orders which were
+// closed while the history was retrieved are skipped
+func (m *Manager) reconcileHistory(exchName string, missing []*Order, history []exchange.OrderDetail) {
+	// Some exchanges return order history per fill, total them per order and
+	// keep the last status reported
+	executed := make(map[string]float64)
+	status := make(map[string]exchange.OrderStatus)
+	found := make(map[string]bool)
+	for x := range history {
+		id := history[x].ID
+		found[id] = true
+		executed[id] += history[x].ExecutedAmount
+		if s := exchange.FormatOrderStatus(history[x].Status); s != exchange.UnknownOrderStatus {
+			status[id] = s
+		}
+	}
+
+	for x := range missing {
+		if !missing[x].Status.IsOpen() {
+			continue
+		}
+		id := missing[x].ExchangeOrderID
+		if !found[id] {
+			log.Printf("Order manager: %s order %s is no longer active but was not found in order history",
+				exchName, id)
+			continue
+		}
+		o := m.updateOrder(exchange.OrderDetail{
+			Exchange:       exchName,
+			ID:             id,
+			ExecutedAmount: executed[id],
+		}, true)
+
+		switch status[id] {
+		case exchange.FilledOrderStatus:
+			if o.ExecutedAmount < o.Amount {
+				o.ExecutedAmount = o.Amount
+			}
+			o.Status = Filled
+		case exchange.CancelledOrderStatus:
+			if o.Status != Filled {
+				o.Status = Cancelled
+			}
+		case exchange.RejectedOrderStatus:
+			o.Status = Rejected
+		default:
+			if o.Status != Filled {
+				log.Printf("Order manager: %s order %s is no longer active but its final status could not be determined from order history",
+					exchName, id)
+			}
+		}
+		m.queueFill(o)
+	}
+}
+
+// Stop stops the wrapped exchange if it runs background routines
+func (e *Exchange) Stop() {
+	if s, ok := e.IBotExchange.(exchange.Stopper); ok {
+		s.Stop()
+	}
+}
+
+// SubmitOrder submits an order to the wrapped exchange and records it
+func (e *Exchange) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	resp, err := e.IBotExchange.SubmitOrder(order)
+	if order == nil {
+		return resp, err
+	}
+
+	o := &Order{
+		Exchange:        e.GetName(),
+		ExchangeOrderID: resp.OrderID,
+		ClientOrderID:   order.ClientID,
+		CurrencyPair:    order.CurrencyPair,
+		Side:            order.OrderSide,
+		Type:            order.OrderType,
+		Price:           order.Price,
+		Amount:          order.Amount,
+		Status:          New,
+	}
+	if err != nil || !resp.IsOrderPlaced {
+		o.Status = Rejected
+		if err != nil {
+			o.Error = err.Error()
+		}
+	}
+
+	e.manager.mtx.Lock()
+	defer e.manager.mtx.Unlock()
+
+	// An order update for the order may have been received before the
+	// submission returned
+	if existing := e.manager.getOrder(o.Exchange, o.ExchangeOrderID); o.ExchangeOrderID != "" && existing != nil {
+		existing.ClientOrderID = o.ClientOrderID
+		existing.Type = o.Type
+		if existing.Price == 0 {
+			existing.Price = o.Price
+		}
+		if existing.Amount == 0 {
+			existing.Amount = o.Amount
+		}
+		e.manager.saveOrLog()
+		return resp, err
+	}
+
+	e.manager.add(o)
+	e.manager.saveOrLog()
+	return resp, err
+}
+
+// ModifyOrder modifies an order on the wrapped exchange and records the
+// changes
+func (e *Exchange) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	orderID, err := e.IBotExchange.ModifyOrder(action)
+	if err != nil {
+		return orderID, err
+	}
+
+	e.manager.mtx.Lock()
+	defer e.manager.mtx.Unlock()
+
+	o := e.manager.getOrder(e.GetName(), action.OrderID)
+	if o == nil {
+		return orderID, nil
+	}
+
+	if orderID != "" {
+		o.ExchangeOrderID = orderID
+	}
+	if action.Price > 0 {
+		o.Price = action.Price
+	}
+	if action.Amount > 0 {
+		o.Amount = action.Amount
+	}
+	o.UpdatedAt = time.Now()
+	e.manager.saveOrLog()
+
+	return orderID, nil
+}
+
+// CancelOrder cancels an order on the wrapped exchange and records the
+// cancellation
+func (e *Exchange) CancelOrder(order exchange.OrderCancellation) error {
+	err := e.IBotExchange.CancelOrder(order)
+	if err != nil {
+		return err
+	}
+
+	e.manager.mtx.Lock()
+	defer e.manager.mtx.Unlock()
+
+	o := e.manager.getOrder(e.GetName(), order.OrderID)
+	if o != nil && o.Status.IsOpen() {
+		o.Status = Cancelled
+		o.UpdatedAt = time.Now()
+		e.manager.saveOrLog()
+	}
 	return nil
 }
+
+// CancelAllOrders cancels all orders on the wrapped exchange and records the
+// cancellations. Orders which failed to cancel remain open
+func (e *Exchange) CancelAllOrders(orders exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	resp, err := e.IBotExchange.CancelAllOrders(orders)
+	if err != nil {
+		return resp, err
+	}
+
+	e.manager.mtx.Lock()
+	defer e.manager.mtx.Unlock()
+
+	for _, o := range e.manager.Orders {
+		if common.StringToLower(o.Exchange) != common.StringToLower(e.GetName()) ||
+			!o.Status.IsOpen() {
+			continue
+		}
+		if orders.CurrencyPair.FirstCurrency != "" &&
+			!o.CurrencyPair.Equal(orders.CurrencyPair, false) {
+			continue
+		}
+		if _, ok := resp.OrderStatus[o.ExchangeOrderID]; ok {
+			continue
+		}
+		o.Status = Cancelled
+		o.UpdatedAt = time.Now()
+	}
+	e.manager.saveOrLog()
+
+	return resp, nil
+}
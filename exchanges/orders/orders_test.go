@@ -1,37 +1,433 @@
 package orders
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
 )
 
-func TestNewOrder(t *testing.T) {
-	ID := NewOrder("ANX", 2000, 20.00)
-	if ID != 0 {
-		t.Error("Test Failed - Orders_test.go NewOrder() - Error")
+func setupManager(t *testing.T) (*Manager, string) {
+	dir, err := ioutil.TempDir("", "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewManager(dir), dir
+}
+
+func TestSubmitOrder(t *testing.T) {
+	m, dir := setupManager(t)
+	defer os.RemoveAll(dir)
+
+	e := exchangetest.New("Test")
+	exch := m.Wrap(e)
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+
+	_, err := exch.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Amount:       1,
+		Price:        100,
+	})
+	if err != nil {
+		t.Fatalf("Test failed - SubmitOrder() error: %s", err)
+	}
+
+	e.SubmitErr = errors.New("insufficient funds")
+	_, err = exch.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Amount:       1,
+		Price:        100,
+	})
+	if err == nil {
+		t.Error("Test failed - SubmitOrder() expected error")
+	}
+
+	orders := m.GetOrdersByExchange("test")
+	if len(orders) != 2 {
+		t.Fatalf("Test failed - expected 2 orders, received %d", len(orders))
+	}
+	if orders[0].Status != New || orders[0].ExchangeOrderID != "1" || orders[0].ID != 1 {
+		t.Errorf("Test failed - unexpected order %+v", orders[0])
+	}
+	if orders[1].Status != Rejected || orders[1].Error == "" {
+		t.Errorf("Test failed - unexpected order %+v", orders[1])
+	}
+}
+
+func TestModifyAndCancelOrder(t *testing.T) {
+	m, dir := setupManager(t)
+	defer os.RemoveAll(dir)
+
+	e := exchangetest.New("Test")
+	e.ModifyOrderFunc = func(action exchange.ModifyOrder) (string, error) {
+		return "4", nil
+	}
+	e.CancelAllOrdersFunc = func(orders exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+		return exchange.CancelAllOrdersResponse{
+			OrderStatus: map[string]string{"3": "Order could not be cancelled"},
+		}, nil
+	}
+	exch := m.Wrap(e)
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+
+	for i := 0; i < 3; i++ {
+		exch.SubmitOrder(&exchange.OrderSubmission{
+			CurrencyPair: p,
+			OrderSide:    exchange.Sell,
+			OrderType:    exchange.Limit,
+			Amount:       1,
+			Price:        100,
+		})
+	}
+
+	_, err := exch.ModifyOrder(exchange.ModifyOrder{OrderID: "1", Price: 200})
+	if err != nil {
+		t.Fatalf("Test failed - ModifyOrder() error: %s", err)
+	}
+	o, err := m.GetOrderByExchangeOrderID("Test", "4")
+	if err != nil {
+		t.Fatalf("Test failed - GetOrderByExchangeOrderID() error: %s", err)
+	}
+	if o.Price != 200 || o.Amount != 1 {
+		t.Errorf("Test failed - unexpected modified order %+v", o)
+	}
+
+	err = exch.CancelOrder(exchange.OrderCancellation{OrderID: "4"})
+	if err != nil {
+		t.Fatalf("Test failed - CancelOrder() error: %s", err)
+	}
+	o, _ = m.GetOrderByID(o.ID)
+	if o.Status != Cancelled {
+		t.Errorf("Test failed - expected cancelled status, received %s", o.Status)
+	}
+
+	_, err = exch.CancelAllOrders(exchange.OrderCancellation{CurrencyPair: p})
+	if err != nil {
+		t.Fatalf("Test failed - CancelAllOrders() error: %s", err)
+	}
+	open := m.GetOpenOrders("Test")
+	if len(open) != 1 || open[0].ExchangeOrderID != "3" {
+		t.Errorf("Test failed - expected order 3 to remain open, received %+v", open)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	m, dir := setupManager(t)
+	defer os.RemoveAll(dir)
+
+	exch := m.Wrap(exchangetest.New("Test"))
+	exch.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.USD),
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Amount:       1,
+		Price:        100,
+	})
+
+	loaded := NewManager(dir)
+	err := loaded.Load()
+	if err != nil {
+		t.Fatalf("Test failed - Load() error: %s", err)
+	}
+	orders := loaded.GetOrders()
+	if len(orders) != 1 || loaded.LastID != 1 {
+		t.Fatalf("Test failed - expected 1 persisted order, received %d", len(orders))
+	}
+	if orders[0].ExchangeOrderID != "1" ||
+		!orders[0].CurrencyPair.Equal(pair.NewCurrencyPair(symbol.BTC, symbol.USD), true) {
+		t.Errorf("Test failed - unexpected persisted order %+v", orders[0])
+	}
+
+	empty := NewManager(dir + "nonexistent")
+	if err = empty.Load(); err != nil {
+		t.Errorf("Test failed - Load() error on missing file: %s", err)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	m, dir := setupManager(t)
+	defer os.RemoveAll(dir)
+
+	e := exchangetest.New("Test")
+	exch := m.Wrap(e)
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+	for i := 0; i < 6; i++ {
+		exch.SubmitOrder(&exchange.OrderSubmission{
+			CurrencyPair: p,
+			OrderSide:    exchange.Buy,
+			OrderType:    exchange.Limit,
+			Amount:       2,
+			Price:        100,
+		})
+	}
+
+	e.Active = []exchange.OrderDetail{
+		{ID: "1", CurrencyPair: p, Amount: 2, ExecutedAmount: 1},
+		{ID: "7", CurrencyPair: p, Amount: 3, OrderSide: exchange.Sell},
 	}
-	ID = NewOrder("BATMAN", 400, 25.00)
-	if ID != 1 {
-		t.Error("Test Failed - Orders_test.go NewOrder() - Error")
+	e.History = []exchange.OrderDetail{
+		{ID: "2", CurrencyPair: p, ExecutedAmount: 1},
+		{ID: "2", CurrencyPair: p, ExecutedAmount: 1},
+		{ID: "3", CurrencyPair: p, Status: "canceled"},
+		{ID: "5", CurrencyPair: p, Status: "FILLED"},
+		{ID: "6", CurrencyPair: p},
+	}
+
+	err := m.Reconcile(e)
+	if err != nil {
+		t.Fatalf("Test failed - Reconcile() error: %s", err)
+	}
+
+	expected := map[string]Status{
+		"1": PartiallyFilled,
+		"2": Filled,
+		"3": Cancelled,
+		"4": New,
+		"5": Filled,
+		"6": New,
+		"7": New,
+	}
+	for id, status := range expected {
+		o, err := m.GetOrderByExchangeOrderID("Test", id)
+		if err != nil {
+			t.Errorf("Test failed - order %s not found", id)
+			continue
+		}
+		if o.Status != status {
+			t.Errorf("Test failed - order %s expected %s, received %s", id, status, o.Status)
+		}
+	}
+
+	o, _ := m.GetOrderByExchangeOrderID("Test", "5")
+	if o.ExecutedAmount != 2 {
+		t.Errorf("Test failed - order 5 expected executed amount 2, received %f", o.ExecutedAmount)
+	}
+}
+
+func TestReconcileUnlocked(t *testing.T) {
+	m, dir := setupManager(t)
+	defer os.RemoveAll(dir)
+
+	e := exchangetest.New("Test")
+	exch := m.Wrap(e)
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+	submission := exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Amount:       2,
+		Price:        100,
+	}
+	exch.SubmitOrder(&submission)
+	e.Active = nil
+
+	// orders submitted while the order history is retrieved must not wait
+	// for the manager
+	var submitted bool
+	e.GetOrderHistoryFunc = func(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+		done := make(chan struct{})
+		go func() {
+			order := submission
+			exch.SubmitOrder(&order)
+			close(done)
+		}()
+		select {
+		case <-done:
+			submitted = true
+		case <-time.After(time.Second):
+		}
+		return []exchange.OrderDetail{{ID: "1", CurrencyPair: p, Status: "FILLED"}}, nil
+	}
+
+	err := m.Reconcile(e)
+	if err != nil {
+		t.Fatalf("Test failed - Reconcile() error: %s", err)
+	}
+	if !submitted {
+		t.Error("Test failed - Reconcile() held the manager lock while retrieving order history")
+	}
+	if o, _ := m.GetOrderByExchangeOrderID("Test", "1"); o.Status != Filled {
+		t.Errorf("Test failed - order 1 expected %s, received %s", Filled, o.Status)
+	}
+	if o, _ := m.GetOrderByExchangeOrderID("Test", "2"); o.Status != New {
+		t.Errorf("Test failed - order 2 expected %s, received %s", New, o.Status)
 	}
 }
 
-func TestDeleteOrder(t *testing.T) {
-	if value := DeleteOrder(0); !value {
-		t.Error("Test Failed - Orders_test.go DeleteOrder() - Error")
+func TestProcessOrderUpdate(t *testing.T) {
+	m, dir := setupManager(t)
+	defer os.RemoveAll(dir)
+
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+	m.ProcessOrderUpdate(exchange.OrderUpdate{
+		Exchange:  "Test",
+		Pair:      p,
+		OrderID:   "1",
+		OrderSide: exchange.Buy,
+		OrderType: exchange.Limit,
+		Status:    exchange.NewOrderStatus,
+		Price:     100,
+		Amount:    2,
+	})
+	o, err := m.GetOrderByExchangeOrderID("Test", "1")
+	if err != nil {
+		t.Fatalf("Test failed - GetOrderByExchangeOrderID() error: %s", err)
+	}
+	if o.Status != New || o.Amount != 2 || o.Side != exchange.Buy {
+		t.Errorf("Test failed - unexpected order %+v", o)
+	}
+
+	m.ProcessOrderUpdate(exchange.OrderUpdate{
+		Exchange:        "Test",
+		OrderID:         "1",
+		Status:          exchange.PartiallyFilledOrderStatus,
+		Amount:          2,
+		RemainingAmount: 1.5,
+	})
+	o, _ = m.GetOrderByID(o.ID)
+	if o.Status != PartiallyFilled || o.ExecutedAmount != 0.5 {
+		t.Errorf("Test failed - unexpected order %+v", o)
 	}
-	if value := DeleteOrder(100); value {
-		t.Error("Test Failed - Orders_test.go DeleteOrder() - Error")
+
+	m.ProcessOrderUpdate(exchange.OrderUpdate{
+		Exchange: "Test",
+		OrderID:  "1",
+		Status:   exchange.CancelledOrderStatus,
+	})
+	o, _ = m.GetOrderByID(o.ID)
+	if o.Status != Cancelled || o.ExecutedAmount != 0.5 {
+		t.Errorf("Test failed - unexpected order %+v", o)
+	}
+
+	m.ProcessOrderUpdate(exchange.OrderUpdate{
+		Exchange: "Test",
+		OrderID:  "2",
+		Status:   exchange.RejectedOrderStatus,
+	})
+	o, _ = m.GetOrderByExchangeOrderID("Test", "2")
+	if o.Status != Rejected {
+		t.Errorf("Test failed - expected rejected status, received %s", o.Status)
 	}
 }
 
-func TestGetOrdersByExchange(t *testing.T) {
-	if value := GetOrdersByExchange("ANX"); len(value) != 0 {
-		t.Error("Test Failed - Orders_test.go GetOrdersByExchange() - Error")
+func TestPaperTrading(t *testing.T) {
+	m, dir := setupManager(t)
+	defer os.RemoveAll(dir)
+
+	m.SetPaperTrading("Test")
+	e := exchangetest.New("Test")
+	exch := m.Wrap(e)
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+
+	// the update for the paper order is published before the submission returns
+	m.ProcessOrderUpdate(exchange.OrderUpdate{
+		Exchange:  "Test",
+		Pair:      p,
+		OrderID:   "1",
+		OrderSide: exchange.Buy,
+		Status:    exchange.NewOrderStatus,
+		Amount:    1,
+	})
+	exch.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Amount:       1,
+		Price:        100,
+	})
+	orders := m.GetOrders()
+	if len(orders) != 1 || !orders[0].PaperTrading || orders[0].Price != 100 {
+		t.Fatalf("Test failed - expected 1 paper order, received %+v", orders)
+	}
+
+	loaded := NewManager(dir)
+	err := loaded.Load()
+	if err != nil {
+		t.Fatalf("Test failed - Load() error: %s", err)
+	}
+	if len(loaded.GetOrders()) != 0 {
+		t.Error("Test failed - paper orders should not be persisted")
+	}
+
+	e.Active = []exchange.OrderDetail{{ID: "2", CurrencyPair: p, Amount: 3}}
+	err = m.Reconcile(e)
+	if err != nil {
+		t.Fatalf("Test failed - Reconcile() error: %s", err)
+	}
+	if len(m.GetOrders()) != 1 {
+		t.Error("Test failed - paper exchanges should not be reconciled")
 	}
 }
 
-func TestGetOrderByOrderID(t *testing.T) {
-	if value := GetOrderByOrderID(69); value != nil {
-		t.Error("Test Failed - Orders_test.go GetOrdersByExchange() - Error")
+func TestFills(t *testing.T) {
+	m, dir := setupManager(t)
+	defer os.RemoveAll(dir)
+
+	var fills []exchange.FillEvent
+	m.SetFillHandler(func(fill exchange.FillEvent) {
+		fills = append(fills, fill)
+	})
+
+	e := exchangetest.New("Test")
+	exch := m.Wrap(e)
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+	exch.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: p,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Amount:       2,
+		Price:        100,
+	})
+
+	// fills are derived from the executed amounts reported by the exchange
+	e.Active = []exchange.OrderDetail{{ID: "1", CurrencyPair: p, Amount: 2, ExecutedAmount: 0.5}}
+	err := m.Reconcile(e)
+	if err != nil {
+		t.Fatalf("Test failed - Reconcile() error: %s", err)
+	}
+	e.Active = nil
+	e.History = []exchange.OrderDetail{{ID: "1", CurrencyPair: p, ExecutedAmount: 2, Status: "FILLED"}}
+	err = m.Reconcile(e)
+	if err != nil {
+		t.Fatalf("Test failed - Reconcile() error: %s", err)
+	}
+	if len(fills) != 2 || fills[0].Amount != 0.5 || fills[1].Amount != 1.5 ||
+		fills[1].OrderID != "1" || fills[1].Price != 100 || fills[1].OrderSide != exchange.Buy {
+		t.Fatalf("Test failed - unexpected fills %+v", fills)
+	}
+
+	// fills already published are not published again after a restart
+	loaded := NewManager(dir)
+	loaded.SetFillHandler(func(fill exchange.FillEvent) {
+		fills = append(fills, fill)
+	})
+	err = loaded.Load()
+	if err != nil {
+		t.Fatalf("Test failed - Load() error: %s", err)
+	}
+	loaded.UpdateOrder(exchange.OrderDetail{Exchange: "Test", ID: "1", ExecutedAmount: 2})
+	if len(fills) != 2 {
+		t.Errorf("Test failed - expected no further fills, received %d", len(fills))
+	}
+
+	// fills are not derived for exchanges which deliver fill events
+	loaded.ProcessFill(exchange.FillEvent{Exchange: "Test", OrderID: "2", Pair: p, Amount: 1})
+	loaded.UpdateOrder(exchange.OrderDetail{Exchange: "Test", ID: "2", Amount: 3, ExecutedAmount: 2})
+	if len(fills) != 2 {
+		t.Errorf("Test failed - expected no derived fills, received %d", len(fills))
+	}
+	o, _ := loaded.GetOrderByExchangeOrderID("Test", "2")
+	if o.ExecutedAmount != 2 || o.Status != PartiallyFilled {
+		t.Errorf("Test failed - unexpected order %+v", o)
 	}
 }
@@ -0,0 +1,72 @@
+package orders
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Status defines the lifecycle state of a tracked order
+type Status string
+
+// Order lifecycle states
+const (
+	New             Status = "NEW"
+	PartiallyFilled Status = "PARTIALLY_FILLED"
+	Filled          Status = "FILLED"
+	Cancelled       Status = "CANCELLED"
+	Rejected        Status = "REJECTED"
+)
+
+// Order holds an order placed by the bot and its current lifecycle state
+type Order struct {
+	ID              int64              `json:"id"`
+	Exchange        string             `json:"exchange"`
+	ExchangeOrderID string             `json:"exchangeOrderId"`
+	ClientOrderID   string             `json:"clientOrderId"`
+	CurrencyPair    pair.CurrencyPair  `json:"currencyPair"`
+	Side            exchange.OrderSide `json:"side"`
+	Type            exchange.OrderType `json:"type"`
+	Price           float64            `json:"price"`
+	Amount          float64            `json:"amount"`
+	ExecutedAmount  float64            `json:"executedAmount"`
+	Status          Status             `json:"status"`
+	Error           string             `json:"error,omitempty"`
+	PaperTrading    bool               `json:"paperTrading,omitempty"`
+	// ReportedAmount is the executed amount already published as fills
+	ReportedAmount float64   `json:"-"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// Manager tracks every order submitted, modified or cancelled through an
+// exchange wrapped by the manager and persists them to disk
+type Manager struct {
+	Orders   []*Order
+	LastID   int64
+	filePath string
+	// paperTrading holds the lower case names of exchanges whose orders are
+	// simulated, these orders are tracked in memory only
+	paperTrading map[string]bool
+	// fillStreams holds the lower case names of exchanges which deliver fill
+	// events, fills are not derived from order state for these exchanges
+	fillStreams map[string]bool
+	fillHandler func(fill exchange.FillEvent)
+	fills       []exchange.FillEvent
+	mtx         sync.Mutex
+}
+
+// Exchange wraps an exchange.IBotExchange so that all order actions are
+// recorded by the order manager
+type Exchange struct {
+	exchange.IBotExchange
+	manager *Manager
+}
+
+// store is the on disk format of the order manager
+type store struct {
+	LastID int64    `json:"lastId"`
+	Orders []*Order `json:"orders"`
+}
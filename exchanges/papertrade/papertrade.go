@@ -0,0 +1,721 @@
+package papertrade
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// vars related to paper trading
+var (
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrOrderNotOpen         = errors.New("order is not open")
+	ErrInsufficientFunds    = errors.New("insufficient funds")
+	ErrNoLiquidity          = errors.New("orderbook has no liquidity to fill against")
+	ErrPostOnlyWouldTake    = errors.New("post only order would immediately match")
+	ErrFillOrKillNotFilled  = errors.New("fill or kill order could not be completely filled")
+	ErrInvalidAmount        = errors.New("amount must be greater than zero")
+	ErrDepositsNotSupported = errors.New("deposits are not supported when paper trading")
+)
+
+// supportedFeatures are the order types and options the simulated matching
+// engine can fill
+const supportedFeatures = exchange.LimitOrderSupport |
+	exchange.MarketOrderSupport |
+	exchange.ImmediateOrCancelOrderSupport |
+	exchange.FillOrKillOrderSupport |
+	exchange.PostOnlyOrderSupport
+
+// New returns an exchange which simulates trading on exch using its live
+// orderbooks and the configured starting balances
+func New(exch exchange.IBotExchange, cfg config.PaperTradingConfig) *Exchange {
+	e := &Exchange{
+		IBotExchange: exch,
+		makerFee:     cfg.MakerFee,
+		takerFee:     cfg.TakerFee,
+		balances:     make(map[string]float64),
+		depth:        make(map[string]map[float64]float64),
+	}
+
+	if f, ok := exch.(feeGetter); ok {
+		maker, taker := f.GetMakerTakerFees()
+		if e.makerFee == 0 {
+			e.makerFee = maker
+		}
+		if e.takerFee == 0 {
+			e.takerFee = taker
+		}
+	}
+
+	for currency, balance := range cfg.StartingBalances {
+		e.balances[common.StringToUpper(currency)] = balance
+	}
+	return e
+}
+
+// Start starts the wrapped exchange and fills resting orders as its
+// orderbooks are updated
+func (e *Exchange) Start(wg *sync.WaitGroup) {
+	e.IBotExchange.Start(wg)
+
+	e.mtx.Lock()
+	if e.sub == nil {
+		e.sub = orderbook.Subscribe(e.GetName(), pair.CurrencyPair{}, orderbook.Spot)
+		go e.watchOrderbooks(e.sub)
+	}
+	e.mtx.Unlock()
+}
+
+// Stop stops filling resting orders against orderbook updates, it is called
+// when the exchange is unloaded or the bot shuts down
+func (e *Exchange) Stop() {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	orderbook.Unsubscribe(e.sub)
+	e.sub = nil
+	e.depth = make(map[string]map[float64]float64)
+}
+
+func (e *Exchange) watchOrderbooks(sub *orderbook.Subscription) {
+	for update := range sub.C {
+		e.mtx.Lock()
+		e.matchResting(update.Pair, update.Orderbook)
+		e.mtx.Unlock()
+		e.publish()
+	}
+}
+
+// SetEventHandler sets the function simulated order updates and fills are
+// published to, as exchange.OrderUpdate and exchange.FillEvent values in the
+// same form as authenticated websocket events
+func (e *Exchange) SetEventHandler(handler func(event interface{})) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.handler = handler
+}
+
+// queueOrderUpdate queues an order update for the current state of an order,
+// the lock must be held by the caller
+func (e *Exchange) queueOrderUpdate(o *exchange.OrderDetail) {
+	e.events = append(e.events, exchange.OrderUpdate{
+		Timestamp:       time.Now(),
+		Exchange:        o.Exchange,
+		AssetType:       orderbook.Spot,
+		Pair:            o.CurrencyPair,
+		OrderID:         o.ID,
+		ClientOrderID:   o.ClientOrderID,
+		OrderSide:       o.OrderSide,
+		OrderType:       o.OrderType,
+		Status:          exchange.OrderStatus(o.Status),
+		Price:           o.Price,
+		Amount:          o.Amount,
+		ExecutedAmount:  o.ExecutedAmount,
+		RemainingAmount: o.RemainingAmount,
+	})
+}
+
+// publish sends the queued events to the event handler, it must be called
+// without the lock held so the handler can use the exchange
+func (e *Exchange) publish() {
+	e.mtx.Lock()
+	events := e.events
+	e.events = nil
+	handler := e.handler
+	e.mtx.Unlock()
+
+	if handler == nil {
+		return
+	}
+	for _, event := range events {
+		handler(event)
+	}
+}
+
+// GetAuthenticatedAPISupport returns true as all authenticated functions are
+// simulated
+func (e *Exchange) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+// getOrderbook returns the cached orderbook for a pair, fetching it from the
+// exchange if it has not been cached yet
+func (e *Exchange) getOrderbook(p pair.CurrencyPair) (orderbook.Base, error) {
+	ob, err := orderbook.GetOrderbook(e.GetName(), p, orderbook.Spot)
+	if err == nil {
+		return ob, nil
+	}
+	return e.IBotExchange.UpdateOrderbook(p, orderbook.Spot)
+}
+
+// SubmitOrder simulates placing an order. The marketable part of the order is
+// filled as a taker against the live orderbook and the remainder of limit
+// orders rests until the orderbook trades through its price
+func (e *Exchange) SubmitOrder(s *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var resp exchange.SubmitOrderResponse
+	err := s.Validate(supportedFeatures)
+	if err != nil {
+		return resp, err
+	}
+
+	ob, err := e.getOrderbook(s.CurrencyPair)
+	if err != nil {
+		return resp, err
+	}
+
+	defer e.publish()
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	orderType := s.OrderType
+	if orderType == exchange.ImmediateOrCancel {
+		orderType = exchange.Limit
+	}
+	o := &exchange.OrderDetail{
+		Exchange:        e.GetName(),
+		ClientOrderID:   s.ClientID,
+		CurrencyPair:    s.CurrencyPair,
+		OrderSide:       s.OrderSide,
+		OrderType:       orderType,
+		OrderDate:       time.Now(),
+		Status:          exchange.NewOrderStatus.ToString(),
+		Price:           s.Price,
+		Amount:          s.Amount,
+		RemainingAmount: s.Amount,
+	}
+
+	levels := getLevels(ob, o.OrderSide)
+	marketable := crossingAmount(o, levels)
+	if orderType == exchange.Market && marketable == 0 {
+		return resp, ErrNoLiquidity
+	}
+	if s.PostOnly && marketable > 0 {
+		return resp, ErrPostOnlyWouldTake
+	}
+	if s.TimeInForce == exchange.FOK && marketable < o.Amount {
+		return resp, ErrFillOrKillNotFilled
+	}
+	if !e.canAfford(o, levels) {
+		return resp, ErrInsufficientFunds
+	}
+
+	e.lastID++
+	o.ID = strconv.FormatInt(e.lastID, 10)
+	e.orders = append(e.orders, o)
+
+	e.take(o, levels)
+	if isOpen(o) && (orderType == exchange.Market ||
+		s.OrderType == exchange.ImmediateOrCancel ||
+		s.TimeInForce == exchange.IOC) {
+		// only limit orders rest on the book
+		o.Status = exchange.CancelledOrderStatus.ToString()
+		e.queueOrderUpdate(o)
+	} else if o.ExecutedAmount == 0 {
+		e.queueOrderUpdate(o)
+	}
+
+	resp.IsOrderPlaced = true
+	resp.OrderID = o.ID
+	return resp, nil
+}
+
+// ModifyOrder simulates changing the price or amount of an open order
+func (e *Exchange) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	e.mtx.Lock()
+	o, err := e.getOrder(action.OrderID)
+	if err != nil {
+		e.mtx.Unlock()
+		return "", err
+	}
+	if !isOpen(o) {
+		e.mtx.Unlock()
+		return "", ErrOrderNotOpen
+	}
+	if action.Amount > 0 && action.Amount < o.ExecutedAmount {
+		e.mtx.Unlock()
+		return "", fmt.Errorf("amount %f is less than the executed amount %f",
+			action.Amount, o.ExecutedAmount)
+	}
+	p := o.CurrencyPair
+	e.mtx.Unlock()
+
+	ob, err := e.getOrderbook(p)
+	if err != nil {
+		return "", err
+	}
+
+	defer e.publish()
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	modified := *o
+	if action.Price > 0 {
+		modified.Price = action.Price
+	}
+	if action.Amount > 0 {
+		modified.Amount = action.Amount
+		modified.RemainingAmount = action.Amount - modified.ExecutedAmount
+	}
+
+	// release the balance held by the original order while checking the
+	// modified order can be afforded
+	o.Status = exchange.CancelledOrderStatus.ToString()
+	affordable := e.canAfford(&modified, nil)
+	o.Status = modified.Status
+	if !affordable {
+		return "", ErrInsufficientFunds
+	}
+
+	*o = modified
+	if o.RemainingAmount <= 0 {
+		o.Status = exchange.FilledOrderStatus.ToString()
+		e.queueOrderUpdate(o)
+		return o.ID, nil
+	}
+	e.queueOrderUpdate(o)
+	e.take(o, getLevels(ob, o.OrderSide))
+	return o.ID, nil
+}
+
+// CancelOrder simulates cancelling an open order
+func (e *Exchange) CancelOrder(order exchange.OrderCancellation) error {
+	defer e.publish()
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	o, err := e.getOrder(order.OrderID)
+	if err != nil {
+		return err
+	}
+	if !isOpen(o) {
+		return ErrOrderNotOpen
+	}
+	o.Status = exchange.CancelledOrderStatus.ToString()
+	e.queueOrderUpdate(o)
+	return nil
+}
+
+// CancelAllOrders simulates cancelling all open orders, optionally limited to
+// a currency pair and side
+func (e *Exchange) CancelAllOrders(orders exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	defer e.publish()
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	resp := exchange.CancelAllOrdersResponse{
+		OrderStatus: make(map[string]string),
+	}
+	for _, o := range e.orders {
+		if !isOpen(o) {
+			continue
+		}
+		if !orders.CurrencyPair.Empty() && !o.CurrencyPair.Equal(orders.CurrencyPair, true) {
+			continue
+		}
+		if orders.Side != "" && orders.Side != exchange.AnyOrderSide && orders.Side != o.OrderSide {
+			continue
+		}
+		o.Status = exchange.CancelledOrderStatus.ToString()
+		e.queueOrderUpdate(o)
+	}
+	return resp, nil
+}
+
+// GetOrderInfo returns a simulated order
+func (e *Exchange) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	o, err := e.getOrder(strconv.FormatInt(orderID, 10))
+	if err != nil {
+		return exchange.OrderDetail{}, err
+	}
+	return copyOrder(o), nil
+}
+
+// GetActiveOrders returns all open simulated orders matching the request
+func (e *Exchange) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return e.getOrders(getOrdersRequest, true), nil
+}
+
+// GetOrderHistory returns all filled and cancelled simulated orders matching
+// the request
+func (e *Exchange) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return e.getOrders(getOrdersRequest, false), nil
+}
+
+func (e *Exchange) getOrders(getOrdersRequest exchange.GetOrdersRequest, open bool) []exchange.OrderDetail {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	var orders []exchange.OrderDetail
+	for _, o := range e.orders {
+		if isOpen(o) == open {
+			orders = append(orders, copyOrder(o))
+		}
+	}
+	return exchange.FilterOrders(orders, getOrdersRequest)
+}
+
+// GetAccountInfo returns the simulated balances, Hold is the balance reserved
+// by open orders
+func (e *Exchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	holds := make(map[string]float64)
+	for _, o := range e.orders {
+		holds[heldCurrency(o)] += e.held(o)
+	}
+
+	var currencies []string
+	for currency := range e.balances {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	info := exchange.AccountInfo{ExchangeName: e.GetName()}
+	for _, currency := range currencies {
+		info.Currencies = append(info.Currencies, exchange.AccountCurrencyInfo{
+			CurrencyName: currency,
+			TotalValue:   e.balances[currency] - holds[currency],
+			Hold:         holds[currency],
+		})
+	}
+	return info, nil
+}
+
+// GetFeeByType returns the simulated fee for trades and defers to the
+// wrapped exchange for all other fee types
+func (e *Exchange) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
+	if feeBuilder.FeeType != exchange.CryptocurrencyTradeFee {
+		return e.IBotExchange.GetFeeByType(feeBuilder)
+	}
+	feeRate := e.takerFee
+	if feeBuilder.IsMaker {
+		feeRate = e.makerFee
+	}
+	return feeRate / 100 * feeBuilder.PurchasePrice * feeBuilder.Amount, nil
+}
+
+// GetFundingHistory returns the simulated withdrawals
+func (e *Exchange) GetFundingHistory() ([]exchange.FundHistory, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return append([]exchange.FundHistory(nil), e.withdrawals...), nil
+}
+
+// GetDepositAddress returns an error as deposits can not be simulated
+func (e *Exchange) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+	return "", ErrDepositsNotSupported
+}
+
+// WithdrawCryptocurrencyFunds simulates withdrawing a cryptocurrency to an
+// address by deducting it from the simulated balance
+func (e *Exchange) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+	return e.withdraw(cryptocurrency, amount, address, "cryptocurrency withdrawal")
+}
+
+// WithdrawFiatFunds simulates withdrawing fiat funds by deducting them from
+// the simulated balance
+func (e *Exchange) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	return e.withdraw(currency, amount, "", "fiat withdrawal")
+}
+
+func (e *Exchange) withdraw(currency pair.CurrencyItem, amount float64, address, description string) (string, error) {
+	if amount <= 0 {
+		return "", ErrInvalidAmount
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	c := currency.Upper().String()
+	if e.available(c) < amount {
+		return "", ErrInsufficientFunds
+	}
+	e.balances[c] -= amount
+
+	e.lastID++
+	e.withdrawals = append(e.withdrawals, exchange.FundHistory{
+		ExchangeName:    e.GetName(),
+		Status:          "COMPLETED",
+		TransferID:      e.lastID,
+		Description:     description,
+		Timestamp:       time.Now().Unix(),
+		Currency:        c,
+		Amount:          amount,
+		TransferType:    "withdrawal",
+		CryptoToAddress: address,
+	})
+	return strconv.FormatInt(e.lastID, 10), nil
+}
+
+func (e *Exchange) getOrder(orderID string) (*exchange.OrderDetail, error) {
+	for _, o := range e.orders {
+		if o.ID == orderID {
+			return o, nil
+		}
+	}
+	return nil, ErrOrderNotFound
+}
+
+func copyOrder(o *exchange.OrderDetail) exchange.OrderDetail {
+	c := *o
+	c.Fills = append([]exchange.OrderFill(nil), o.Fills...)
+	return c
+}
+
+func isOpen(o *exchange.OrderDetail) bool {
+	return o.Status == exchange.NewOrderStatus.ToString() ||
+		o.Status == exchange.PartiallyFilledOrderStatus.ToString()
+}
+
+// getLevels returns a copy of the orderbook side an order on the supplied side
+// would trade against
+func getLevels(ob orderbook.Base, side exchange.OrderSide) []orderbook.Item {
+	var levels []orderbook.Item
+	if side == exchange.Buy {
+		levels = append(levels, ob.Asks...)
+		sort.Slice(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+	} else {
+		levels = append(levels, ob.Bids...)
+		sort.Slice(levels, func(i, j int) bool { return levels[i].Price > levels[j].Price })
+	}
+	return levels
+}
+
+// crosses returns whether an order can trade at the supplied price
+func crosses(o *exchange.OrderDetail, price float64) bool {
+	if o.OrderType == exchange.Market {
+		return true
+	}
+	if o.OrderSide == exchange.Buy {
+		return price <= o.Price
+	}
+	return price >= o.Price
+}
+
+// crossingAmount returns the amount the order could immediately fill against
+// the supplied levels
+func crossingAmount(o *exchange.OrderDetail, levels []orderbook.Item) float64 {
+	var amount float64
+	for x := range levels {
+		if !crosses(o, levels[x].Price) {
+			break
+		}
+		amount += levels[x].Amount
+	}
+	return math.Min(amount, o.RemainingAmount)
+}
+
+// heldCurrency returns the currency reserved by an open order
+func heldCurrency(o *exchange.OrderDetail) string {
+	if o.OrderSide == exchange.Buy {
+		return o.CurrencyPair.SecondCurrency.Upper().String()
+	}
+	return o.CurrencyPair.FirstCurrency.Upper().String()
+}
+
+// held returns the balance reserved by an open limit order
+func (e *Exchange) held(o *exchange.OrderDetail) float64 {
+	if o.OrderType != exchange.Limit || !isOpen(o) {
+		return 0
+	}
+	if o.OrderSide == exchange.Sell {
+		return o.RemainingAmount
+	}
+	return o.Price * o.RemainingAmount * (1 + math.Max(e.makerFee, e.takerFee)/100)
+}
+
+// available returns the balance of a currency not reserved by open orders
+func (e *Exchange) available(currency string) float64 {
+	available := e.balances[currency]
+	for _, o := range e.orders {
+		if heldCurrency(o) == currency {
+			available -= e.held(o)
+		}
+	}
+	return available
+}
+
+// canAfford returns whether the available balance covers the full order at
+// its limit price, or for market orders the price of walking the levels
+func (e *Exchange) canAfford(o *exchange.OrderDetail, levels []orderbook.Item) bool {
+	available := e.available(heldCurrency(o))
+	if o.OrderSide == exchange.Sell {
+		return available >= o.RemainingAmount
+	}
+
+	if o.OrderType == exchange.Limit {
+		return available >= o.Price*o.RemainingAmount*(1+math.Max(e.makerFee, e.takerFee)/100)
+	}
+
+	var cost float64
+	remaining := o.RemainingAmount
+	for x := range levels {
+		amount := math.Min(remaining, levels[x].Amount)
+		cost += levels[x].Price * amount
+		remaining -= amount
+		if remaining <= 0 {
+			break
+		}
+	}
+	return available >= cost*(1+e.takerFee/100)
+}
+
+// take fills as much of an order as possible as a taker against the levels
+func (e *Exchange) take(o *exchange.OrderDetail, levels []orderbook.Item) {
+	for x := range levels {
+		if o.RemainingAmount <= 0 || !crosses(o, levels[x].Price) {
+			return
+		}
+		if e.fill(o, levels[x].Price, math.Min(o.RemainingAmount, levels[x].Amount), false) == 0 {
+			return
+		}
+	}
+}
+
+// matchResting fills resting limit orders for a pair as makers against an
+// updated orderbook. Only depth added since the previous update is matched and
+// it is consumed as orders fill, so liquidity is never filled against twice.
+// Orders are matched best price first, then oldest first
+func (e *Exchange) matchResting(p pair.CurrencyPair, ob orderbook.Base) {
+	for _, side := range []exchange.OrderSide{exchange.Buy, exchange.Sell} {
+		levels := e.newDepth(p, side, getLevels(ob, side))
+
+		var resting []*exchange.OrderDetail
+		for _, o := range e.orders {
+			if isOpen(o) && o.OrderType == exchange.Limit && o.OrderSide == side &&
+				o.CurrencyPair.Equal(p, true) {
+				resting = append(resting, o)
+			}
+		}
+		sort.SliceStable(resting, func(i, j int) bool {
+			if side == exchange.Buy {
+				return resting[i].Price > resting[j].Price
+			}
+			return resting[i].Price < resting[j].Price
+		})
+
+		for _, o := range resting {
+			for x := range levels {
+				if o.RemainingAmount <= 0 || !crosses(o, levels[x].Price) {
+					break
+				}
+				if levels[x].Amount <= 0 {
+					continue
+				}
+				filled := e.fill(o, o.Price, math.Min(o.RemainingAmount, levels[x].Amount), true)
+				if filled == 0 {
+					break
+				}
+				levels[x].Amount -= filled
+			}
+		}
+	}
+}
+
+// newDepth records the levels an order on the supplied side trades against
+// and returns them with the amount at each price reduced by the amount seen
+// at that price in the previous update
+func (e *Exchange) newDepth(p pair.CurrencyPair, side exchange.OrderSide, levels []orderbook.Item) []orderbook.Item {
+	key := common.StringToUpper(p.Pair().String()) + string(side)
+	previous := e.depth[key]
+	current := make(map[float64]float64, len(levels))
+	for x := range levels {
+		current[levels[x].Price] += levels[x].Amount
+		levels[x].Amount = math.Max(levels[x].Amount-previous[levels[x].Price], 0)
+	}
+	e.depth[key] = current
+	return levels
+}
+
+// fill executes part of an order, limited by the available balance, and
+// returns the amount filled
+func (e *Exchange) fill(o *exchange.OrderDetail, price, amount float64, maker bool) float64 {
+	if amount <= 0 || price <= 0 {
+		return 0
+	}
+
+	feeRate := e.takerFee / 100
+	if maker {
+		feeRate = e.makerFee / 100
+	}
+
+	base := o.CurrencyPair.FirstCurrency.Upper().String()
+	quote := o.CurrencyPair.SecondCurrency.Upper().String()
+
+	// the balance held by this order is available to fill it
+	if o.OrderSide == exchange.Buy {
+		available := e.available(quote) + e.held(o)
+		amount = math.Min(amount, available/(price*(1+feeRate)))
+	} else {
+		amount = math.Min(amount, e.available(base)+e.held(o))
+	}
+	if amount <= 0 {
+		return 0
+	}
+
+	notional := price * amount
+	fee := notional * feeRate
+	if o.OrderSide == exchange.Buy {
+		e.balances[quote] -= notional + fee
+		e.balances[base] += amount
+	} else {
+		e.balances[base] -= amount
+		e.balances[quote] += notional - fee
+	}
+
+	o.ExecutedAmount += amount
+	o.RemainingAmount -= amount
+	o.Fee += fee
+	o.FeeCurrency = quote
+	f := exchange.OrderFill{
+		ID:          strconv.Itoa(len(o.Fills) + 1),
+		Timestamp:   time.Now(),
+		Price:       price,
+		Amount:      amount,
+		Fee:         fee,
+		FeeCurrency: quote,
+		IsMaker:     maker,
+	}
+	o.Fills = append(o.Fills, f)
+
+	if o.RemainingAmount <= o.Amount*1e-12 {
+		o.RemainingAmount = 0
+		o.Status = exchange.FilledOrderStatus.ToString()
+	} else {
+		o.Status = exchange.PartiallyFilledOrderStatus.ToString()
+	}
+
+	log.Printf("%s paper trading: %s order %s filled %f %s @ %f",
+		o.Exchange, o.OrderSide, o.ID, amount, o.CurrencyPair.Pair(), price)
+
+	e.queueOrderUpdate(o)
+	e.events = append(e.events, exchange.FillEvent{
+		Timestamp:   f.Timestamp,
+		Exchange:    o.Exchange,
+		AssetType:   orderbook.Spot,
+		Pair:        o.CurrencyPair,
+		OrderID:     o.ID,
+		TradeID:     o.ID + "-" + f.ID,
+		OrderSide:   o.OrderSide,
+		Price:       price,
+		Amount:      amount,
+		Fee:         fee,
+		FeeCurrency: quote,
+		IsMaker:     maker,
+	})
+	return amount
+}
@@ -0,0 +1,354 @@
+package papertrade
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/exchanges/risk"
+)
+
+var testPair = pair.NewCurrencyPair("BTC", "USD")
+
+func newTestExchange(name string) *Exchange {
+	orderbook.ProcessOrderbook(name, testPair, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}, {Price: 98, Amount: 2}},
+		Asks: []orderbook.Item{{Price: 101, Amount: 1}, {Price: 102, Amount: 2}},
+	}, orderbook.Spot)
+
+	exch := exchangetest.New(name, testPair)
+	exch.MakerFee, exch.TakerFee = 0.1, 0.2
+	return New(exch, config.PaperTradingConfig{
+		Enabled:          true,
+		StartingBalances: map[string]float64{"usd": 1000, "BTC": 1},
+	})
+}
+
+func getBalance(t *testing.T, e *Exchange, currency string) (float64, float64) {
+	info, err := e.GetAccountInfo()
+	if err != nil {
+		t.Fatalf("Test failed. GetAccountInfo error: %s", err)
+	}
+	for _, c := range info.Currencies {
+		if c.CurrencyName == currency {
+			return c.TotalValue, c.Hold
+		}
+	}
+	return 0, 0
+}
+
+func TestNew(t *testing.T) {
+	e := newTestExchange("PaperNew")
+	if e.makerFee != 0.1 || e.takerFee != 0.2 {
+		t.Error("Test failed. New did not use the exchange fees")
+	}
+	fee, err := e.GetFeeByType(exchange.FeeBuilder{
+		FeeType:       exchange.CryptocurrencyTradeFee,
+		PurchasePrice: 100,
+		Amount:        2,
+	})
+	if err != nil || fee != 0.4 {
+		t.Errorf("Test failed. GetFeeByType expected taker fee 0.4 got %f %v", fee, err)
+	}
+	if !e.GetAuthenticatedAPISupport() {
+		t.Error("Test failed. New expected authenticated API support")
+	}
+	if total, _ := getBalance(t, e, "USD"); total != 1000 {
+		t.Errorf("Test failed. New expected USD balance 1000 got %f", total)
+	}
+}
+
+func TestSubmitMarketOrder(t *testing.T) {
+	e := newTestExchange("PaperMarket")
+	resp, err := e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: testPair,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       2,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. SubmitOrder error: %s", err)
+	}
+
+	id, _ := strconv.ParseInt(resp.OrderID, 10, 64)
+	o, err := e.GetOrderInfo(id)
+	if err != nil {
+		t.Fatalf("Test failed. GetOrderInfo error: %s", err)
+	}
+	if o.Status != exchange.FilledOrderStatus.ToString() || len(o.Fills) != 2 {
+		t.Errorf("Test failed. SubmitOrder unexpected order %v", o)
+	}
+
+	// 1 @ 101 + 1 @ 102 with a 0.2% taker fee
+	expected := 1000 - 203*1.002
+	if total, _ := getBalance(t, e, "USD"); total < expected-1e-9 || total > expected+1e-9 {
+		t.Errorf("Test failed. SubmitOrder expected USD balance %f got %f", expected, total)
+	}
+	if total, _ := getBalance(t, e, "BTC"); total != 3 {
+		t.Errorf("Test failed. SubmitOrder expected BTC balance 3 got %f", total)
+	}
+
+	_, err = e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: testPair,
+		OrderSide:    exchange.Sell,
+		OrderType:    exchange.Market,
+		Amount:       10,
+	})
+	if err != ErrInsufficientFunds {
+		t.Errorf("Test failed. SubmitOrder expected %s got %v", ErrInsufficientFunds, err)
+	}
+}
+
+func TestRestingLimitOrder(t *testing.T) {
+	e := newTestExchange("PaperLimit")
+	e.Start(nil)
+	defer e.Stop()
+
+	resp, err := e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: testPair,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Price:        100,
+		Amount:       2,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. SubmitOrder error: %s", err)
+	}
+
+	if _, hold := getBalance(t, e, "USD"); hold <= 200 {
+		t.Errorf("Test failed. SubmitOrder expected USD hold greater than 200 got %f", hold)
+	}
+
+	active, _ := e.GetActiveOrders(exchange.GetOrdersRequest{})
+	if len(active) != 1 {
+		t.Fatalf("Test failed. GetActiveOrders expected 1 order got %d", len(active))
+	}
+
+	_, err = e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: testPair,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Price:        101,
+		Amount:       1,
+		PostOnly:     true,
+	})
+	if err != ErrPostOnlyWouldTake {
+		t.Errorf("Test failed. SubmitOrder expected %s got %v", ErrPostOnlyWouldTake, err)
+	}
+
+	orderbook.ProcessOrderbook("PaperLimit", testPair, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 98, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 99.5, Amount: 5}},
+	}, orderbook.Spot)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		active, _ = e.GetActiveOrders(exchange.GetOrdersRequest{})
+		if len(active) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Test failed. Resting order was not filled by orderbook update")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	history, _ := e.GetOrderHistory(exchange.GetOrdersRequest{})
+	if len(history) != 1 || history[0].ID != resp.OrderID || history[0].Price != 100 ||
+		!history[0].Fills[0].IsMaker {
+		t.Errorf("Test failed. GetOrderHistory unexpected orders %v", history)
+	}
+}
+
+func TestModifyAndCancelOrder(t *testing.T) {
+	e := newTestExchange("PaperModify")
+	resp, err := e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: testPair,
+		OrderSide:    exchange.Sell,
+		OrderType:    exchange.Limit,
+		Price:        110,
+		Amount:       0.5,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. SubmitOrder error: %s", err)
+	}
+
+	_, err = e.ModifyOrder(exchange.ModifyOrder{OrderID: resp.OrderID, Amount: 2})
+	if err != ErrInsufficientFunds {
+		t.Errorf("Test failed. ModifyOrder expected %s got %v", ErrInsufficientFunds, err)
+	}
+
+	_, err = e.ModifyOrder(exchange.ModifyOrder{OrderID: resp.OrderID, Price: 105, Amount: 1})
+	if err != nil {
+		t.Fatalf("Test failed. ModifyOrder error: %s", err)
+	}
+	if _, hold := getBalance(t, e, "BTC"); hold != 1 {
+		t.Errorf("Test failed. ModifyOrder expected BTC hold 1 got %f", hold)
+	}
+
+	err = e.CancelOrder(exchange.OrderCancellation{OrderID: resp.OrderID})
+	if err != nil {
+		t.Fatalf("Test failed. CancelOrder error: %s", err)
+	}
+	err = e.CancelOrder(exchange.OrderCancellation{OrderID: resp.OrderID})
+	if err != ErrOrderNotOpen {
+		t.Errorf("Test failed. CancelOrder expected %s got %v", ErrOrderNotOpen, err)
+	}
+}
+
+func TestWithdraw(t *testing.T) {
+	e := newTestExchange("PaperWithdraw")
+	_, err := e.WithdrawCryptocurrencyFunds("address", "BTC", 2)
+	if err != ErrInsufficientFunds {
+		t.Errorf("Test failed. WithdrawCryptocurrencyFunds expected %s got %v", ErrInsufficientFunds, err)
+	}
+
+	_, err = e.WithdrawCryptocurrencyFunds("address", "btc", 0.5)
+	if err != nil {
+		t.Fatalf("Test failed. WithdrawCryptocurrencyFunds error: %s", err)
+	}
+	_, err = e.WithdrawFiatFunds("USD", 100)
+	if err != nil {
+		t.Fatalf("Test failed. WithdrawFiatFunds error: %s", err)
+	}
+
+	history, _ := e.GetFundingHistory()
+	if len(history) != 2 {
+		t.Errorf("Test failed. GetFundingHistory expected 2 withdrawals got %d", len(history))
+	}
+	if total, _ := getBalance(t, e, "BTC"); total != 0.5 {
+		t.Errorf("Test failed. Withdraw expected BTC balance 0.5 got %f", total)
+	}
+
+	if _, err = e.GetDepositAddress("BTC"); err != ErrDepositsNotSupported {
+		t.Errorf("Test failed. GetDepositAddress expected %s got %v", ErrDepositsNotSupported, err)
+	}
+}
+
+func TestMatchRestingConsumesLiquidity(t *testing.T) {
+	e := newTestExchange("PaperConsume")
+	for _, price := range []float64{100, 100.5} {
+		_, err := e.SubmitOrder(&exchange.OrderSubmission{
+			CurrencyPair: testPair,
+			OrderSide:    exchange.Buy,
+			OrderType:    exchange.Limit,
+			Price:        price,
+			Amount:       1,
+		})
+		if err != nil {
+			t.Fatalf("Test failed. SubmitOrder error: %s", err)
+		}
+	}
+
+	executed := func() (float64, float64) {
+		first, _ := e.GetOrderInfo(1)
+		second, _ := e.GetOrderInfo(2)
+		return first.ExecutedAmount, second.ExecutedAmount
+	}
+
+	// the best priced order fills first against the new depth
+	book := orderbook.Base{Asks: []orderbook.Item{{Price: 99.5, Amount: 1.5}}}
+	e.mtx.Lock()
+	e.matchResting(testPair, book)
+	e.mtx.Unlock()
+	if first, second := executed(); first != 0.5 || second != 1 {
+		t.Errorf("Test failed. matchResting expected 0.5 and 1 executed got %f and %f", first, second)
+	}
+
+	// unchanged depth has already been filled against
+	e.mtx.Lock()
+	e.matchResting(testPair, book)
+	e.mtx.Unlock()
+	if first, _ := executed(); first != 0.5 {
+		t.Errorf("Test failed. matchResting expected unchanged depth not to fill got %f", first)
+	}
+
+	book.Asks[0].Amount = 3
+	e.mtx.Lock()
+	e.matchResting(testPair, book)
+	e.mtx.Unlock()
+	if first, _ := executed(); first != 1 {
+		t.Errorf("Test failed. matchResting expected added depth to fill got %f", first)
+	}
+}
+
+func TestStopThroughWrappers(t *testing.T) {
+	e := newTestExchange("PaperStop")
+	e.Start(nil)
+
+	var exch exchange.IBotExchange = orders.NewManager("").Wrap(risk.NewManager("").Wrap(e, config.RiskLimitsConfig{}))
+	s, ok := exch.(exchange.Stopper)
+	if !ok {
+		t.Fatal("Test failed. Wrapped exchange does not implement Stopper")
+	}
+	s.Stop()
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.sub != nil {
+		t.Error("Test failed. Stop expected orderbook subscription to be removed")
+	}
+}
+
+func TestEventHandler(t *testing.T) {
+	e := newTestExchange("PaperEvents")
+	var events []interface{}
+	e.SetEventHandler(func(event interface{}) {
+		events = append(events, event)
+	})
+
+	_, err := e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: testPair,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       2,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. SubmitOrder error: %s", err)
+	}
+
+	var fills int
+	var last exchange.OrderUpdate
+	for _, event := range events {
+		switch ev := event.(type) {
+		case exchange.FillEvent:
+			fills++
+		case exchange.OrderUpdate:
+			last = ev
+		}
+	}
+	if fills != 2 {
+		t.Errorf("Test failed. SubmitOrder expected 2 fill events got %d", fills)
+	}
+	if last.Status != exchange.FilledOrderStatus || last.ExecutedAmount != 2 {
+		t.Errorf("Test failed. SubmitOrder unexpected order update %+v", last)
+	}
+
+	events = nil
+	resp, err := e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: testPair,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Limit,
+		Price:        90,
+		Amount:       1,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. SubmitOrder error: %s", err)
+	}
+	err = e.CancelOrder(exchange.OrderCancellation{OrderID: resp.OrderID})
+	if err != nil {
+		t.Fatalf("Test failed. CancelOrder error: %s", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Test failed. Expected 2 order updates got %d", len(events))
+	}
+	if u, ok := events[1].(exchange.OrderUpdate); !ok || u.Status != exchange.CancelledOrderStatus {
+		t.Errorf("Test failed. CancelOrder unexpected event %+v", events[1])
+	}
+}
@@ -0,0 +1,35 @@
+package papertrade
+
+import (
+	"sync"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// Exchange wraps an exchange.IBotExchange so that market data is served by
+// the real exchange while order, account and withdrawal functions are served
+// by a simulated matching engine
+type Exchange struct {
+	exchange.IBotExchange
+	makerFee    float64
+	takerFee    float64
+	balances    map[string]float64
+	orders      []*exchange.OrderDetail
+	withdrawals []exchange.FundHistory
+	lastID      int64
+	sub         *orderbook.Subscription
+	// depth is the amount at each price of the previous orderbook update,
+	// keyed by currency pair and the side of the orders trading against it
+	depth map[string]map[float64]float64
+	// events are the order updates and fills queued while the lock is held,
+	// they are published to handler once it is released
+	events  []interface{}
+	handler func(event interface{})
+	mtx     sync.Mutex
+}
+
+// feeGetter is implemented by exchanges which embed exchange.Base
+type feeGetter interface {
+	GetMakerTakerFees() (maker, taker float64)
+}
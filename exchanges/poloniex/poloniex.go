@@ -13,6 +13,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -51,8 +52,25 @@ const (
 
 	poloniexAuthRate   = 6
 	poloniexUnauthRate = 6
+
+	poloniexDateLayout = "2006-01-02 15:04:05"
+
+	// poloniexTradeHistoryLimit is the maximum number of trades returned per
+	// public trade history request
+	poloniexTradeHistoryLimit = 1000
 )
 
+// poloniexChartDataIntervals are the candle periods supported by the chart
+// data endpoint
+var poloniexChartDataIntervals = []kline.Interval{
+	kline.FiveMin,
+	kline.FifteenMin,
+	kline.ThirtyMin,
+	kline.TwoHour,
+	kline.FourHour,
+	kline.OneDay,
+}
+
 // Poloniex is the overarching type across the poloniex package
 type Poloniex struct {
 	exchange.Base
@@ -67,6 +85,20 @@ func (p *Poloniex) SetDefaults() {
 	p.Verbose = false
 	p.RESTPollingDelay = 10
 	p.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission
+	p.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.MarketOrderSupport |
+		exchange.ImmediateOrCancelOrderSupport | exchange.FillOrKillOrderSupport
+	p.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true, Websocket: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true, Websocket: true},
+		TradeFetching:     exchange.FeatureSupport{REST: true, Websocket: true},
+		KlineFetching:     exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		ModifyOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+		Subscriptions:     []string{exchange.TickerChannel, exchange.OrderbookChannel, exchange.TradeChannel},
+	}
 	p.RequestCurrencyPairFormat.Delimiter = "_"
 	p.RequestCurrencyPairFormat.Uppercase = true
 	p.ConfigCurrencyPairFormat.Delimiter = "_"
@@ -2,11 +2,13 @@ package poloniex
 
 import (
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 )
 
 var p Poloniex
@@ -217,7 +219,15 @@ func TestSubmitOrder(t *testing.T) {
 		FirstCurrency:  symbol.BTC,
 		SecondCurrency: symbol.LTC,
 	}
-	response, err := p.SubmitOrder(pair, exchange.Buy, exchange.Market, 1, 10, "hi")
+	var order = &exchange.OrderSubmission{
+		CurrencyPair: pair,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       1,
+		Price:        10,
+		ClientID:     "hi",
+	}
+	response, err := p.SubmitOrder(order)
 	if err != nil || !response.IsOrderPlaced {
 		t.Errorf("Order failed to be placed: %v", err)
 	}
@@ -287,3 +297,84 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test Failed - ModifyOrder() error")
 	}
 }
+
+func TestGetActiveOrders(t *testing.T) {
+	p.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := p.GetActiveOrders(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get open orders: %s", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	p.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var getOrdersRequest = exchange.GetOrdersRequest{
+		OrderType: exchange.AnyOrderType,
+	}
+
+	_, err := p.GetOrderHistory(getOrdersRequest)
+	if err != nil {
+		t.Errorf("Could not get order history: %s", err)
+	}
+}
+
+func TestGetHistoricCandles(t *testing.T) {
+	p.SetDefaults()
+	TestSetup(t)
+
+	end := time.Now()
+	start := end.Add(-2 * 24 * time.Hour)
+	item, err := p.GetHistoricCandles(pair.NewCurrencyPair(symbol.BTC, symbol.LTC), "SPOT", kline.OneHour, start, end)
+	if err != nil {
+		t.Errorf("Could not get historic candles: %s", err)
+	}
+	if err == nil && len(item.Candles) == 0 {
+		t.Error("Expected historic candles to be returned")
+	}
+}
+
+func TestGetExchangeHistory(t *testing.T) {
+	p.SetDefaults()
+	TestSetup(t)
+
+	end := time.Now()
+	start := end.Add(-time.Hour)
+	trades, err := p.GetExchangeHistory(exchange.TradeHistoryRequest{
+		CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.LTC),
+		AssetType:    "SPOT",
+		StartTime:    start,
+		EndTime:      end,
+	})
+	if err != nil {
+		t.Errorf("Could not get exchange history: %s", err)
+	}
+	for x := range trades {
+		if trades[x].Timestamp.Before(start) || !trades[x].Timestamp.Before(end) {
+			t.Errorf("Trade %+v outside of requested time range", trades[x])
+		}
+	}
+
+	_, err = p.GetExchangeHistory(exchange.TradeHistoryRequest{
+		CurrencyPair: pair.NewCurrencyPair(symbol.BTC, symbol.LTC),
+		FromTradeID:  "1337",
+	})
+	if err == nil {
+		t.Error("Expected error when fetching from a trade ID")
+	}
+}
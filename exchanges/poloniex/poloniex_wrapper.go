@@ -1,14 +1,17 @@
 package poloniex
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -148,19 +151,121 @@ func (p *Poloniex) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (p *Poloniex) GetExchangeHistory(currencyPair pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
-	var resp []exchange.TradeHistory
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (p *Poloniex) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
+	if err := tradeHistoryRequest.Validate(); err != nil {
+		return nil, err
+	}
+
+	if tradeHistoryRequest.FromTradeID != "" {
+		return nil, errors.New("poloniex trade history can only be fetched from a start time")
+	}
+
+	// Poloniex returns the newest trades of a time range first, so the range
+	// is paged backwards with the end time in unix seconds as the cursor.
+	// Without a start time only the most recent trades are returned
+	var start, end string
+	if !tradeHistoryRequest.StartTime.IsZero() {
+		start = strconv.FormatInt(tradeHistoryRequest.StartTime.Unix(), 10)
+		end = strconv.FormatInt(time.Now().Unix(), 10)
+		if !tradeHistoryRequest.EndTime.IsZero() {
+			end = strconv.FormatInt(tradeHistoryRequest.EndTime.Unix(), 10)
+		}
+	}
+
+	symbol := exchange.FormatExchangeCurrency(p.Name, tradeHistoryRequest.CurrencyPair).String()
+	return exchange.PaginateTradeHistory(tradeHistoryRequest, end, func(cursor string) ([]exchange.TradeHistory, string, error) {
+		resp, err := p.GetTradeHistory(symbol, start, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var trades []exchange.TradeHistory
+		for x := range resp {
+			tradeDate, err := time.Parse(poloniexDateLayout, resp[x].Date)
+			if err != nil {
+				return nil, "", err
+			}
+
+			trades = append(trades, exchange.TradeHistory{
+				Exchange:     p.Name,
+				TID:          strconv.FormatInt(resp[x].TradeID, 10),
+				CurrencyPair: tradeHistoryRequest.CurrencyPair,
+				AssetType:    tradeHistoryRequest.AssetType,
+				Side:         exchange.FormatOrderSide(resp[x].Type),
+				Price:        resp[x].Rate,
+				Amount:       resp[x].Amount,
+				Timestamp:    tradeDate,
+			})
+		}
+
+		// trades sharing the oldest timestamp are fetched again and removed
+		// as duplicates
+		if start == "" || len(trades) < poloniexTradeHistoryLimit {
+			return trades, "", nil
+		}
+		return trades, strconv.FormatInt(trades[len(trades)-1].Timestamp.Unix(), 10), nil
+	})
+}
+
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (p *Poloniex) GetHistoricCandles(currencyPair pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	symbol := exchange.FormatExchangeCurrency(p.Name, currencyPair).String()
+	fetcher := kline.Fetcher{
+		SupportedIntervals: poloniexChartDataIntervals,
+		Fetch: func(interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+			resp, err := p.GetChartData(symbol,
+				strconv.FormatInt(start.Unix(), 10),
+				strconv.FormatInt(end.Unix(), 10),
+				strconv.FormatInt(interval.Seconds(), 10))
+			if err != nil {
+				return nil, err
+			}
+
+			var candles []kline.Candle
+			for x := range resp {
+				if resp[x].Error != "" {
+					return nil, errors.New(resp[x].Error)
+				}
+				candles = append(candles, kline.Candle{
+					Time:   time.Unix(int64(resp[x].Date), 0),
+					Open:   resp[x].Open,
+					High:   resp[x].High,
+					Low:    resp[x].Low,
+					Close:  resp[x].Close,
+					Volume: resp[x].Volume,
+				})
+			}
+			return candles, nil
+		},
+	}
 
-	return resp, common.ErrNotYetImplemented
+	candles, err := fetcher.GetCandles(interval, start, end)
+	if err != nil {
+		return kline.Item{}, err
+	}
+
+	return kline.Item{
+		Exchange:  p.Name,
+		Pair:      currencyPair,
+		AssetType: assetType,
+		Interval:  interval,
+		Candles:   candles,
+	}, nil
 }
 
 // SubmitOrder submits a new order
-func (p *Poloniex) SubmitOrder(currencyPair pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (p *Poloniex) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	fillOrKill := orderType == exchange.Market
-	isBuyOrder := side == exchange.Buy
-	response, err := p.PlaceOrder(currencyPair.Pair().String(), price, amount, false, fillOrKill, isBuyOrder)
+	if err := order.Validate(p.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	immediate := order.OrderType == exchange.ImmediateOrCancel || order.TimeInForce == exchange.IOC
+	fillOrKill := order.OrderType == exchange.Market || order.TimeInForce == exchange.FOK
+	isBuyOrder := order.OrderSide == exchange.Buy
+	response, err := p.PlaceOrder(order.CurrencyPair.Pair().String(), order.Price, order.Amount, immediate, fillOrKill, isBuyOrder)
 
 	if response.OrderNumber > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response.OrderNumber)
@@ -234,6 +339,108 @@ func (p *Poloniex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (p *Poloniex) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	resp, err := p.GetOpenOrdersForAllCurrencies()
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for currencyPair, openOrders := range resp.Data {
+		symbol := pair.NewCurrencyPairDelimiter(currencyPair, p.ConfigCurrencyPairFormat.Delimiter)
+		for i := range openOrders {
+			orderDate, err := time.Parse(poloniexDateLayout, openOrders[i].Date)
+			if err != nil {
+				log.Printf("%s unable to parse time for order %d: %s", p.Name, openOrders[i].OrderNumber, err)
+			}
+
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:        p.Name,
+				ID:              strconv.FormatInt(openOrders[i].OrderNumber, 10),
+				CurrencyPair:    symbol,
+				OrderSide:       exchange.FormatOrderSide(openOrders[i].Type),
+				OrderType:       exchange.Limit,
+				OrderDate:       orderDate,
+				Price:           openOrders[i].Rate,
+				Amount:          openOrders[i].Amount,
+				RemainingAmount: openOrders[i].Amount,
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (p *Poloniex) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	var start, end string
+	if !getOrdersRequest.StartTicks.IsZero() {
+		start = strconv.FormatInt(getOrdersRequest.StartTicks.Unix(), 10)
+	}
+	if !getOrdersRequest.EndTicks.IsZero() {
+		end = strconv.FormatInt(getOrdersRequest.EndTicks.Unix(), 10)
+	}
+
+	resp, err := p.GetAuthenticatedTradeHistory("all", start, end, "")
+	if err != nil {
+		return nil, err
+	}
+
+	tradeHistory, ok := resp.(AuthenticatedTradeHistoryAll)
+	if !ok {
+		return nil, errors.New("unable to parse trade history response")
+	}
+
+	// Trade history is returned per fill, group the fills by order number.
+	// The original order amount is not included so the order status cannot
+	// be determined
+	var orders []exchange.OrderDetail
+	for currencyPair, trades := range tradeHistory.Data {
+		symbol := pair.NewCurrencyPairDelimiter(currencyPair, p.ConfigCurrencyPairFormat.Delimiter)
+		index := make(map[int64]int)
+		for i := range trades {
+			tradeDate, err := time.Parse(poloniexDateLayout, trades[i].Date)
+			if err != nil {
+				log.Printf("%s unable to parse time for trade %d: %s", p.Name, trades[i].TradeID, err)
+			}
+
+			x, ok := index[trades[i].OrderNumber]
+			if !ok {
+				x = len(orders)
+				index[trades[i].OrderNumber] = x
+				orders = append(orders, exchange.OrderDetail{
+					Exchange:     p.Name,
+					ID:           strconv.FormatInt(trades[i].OrderNumber, 10),
+					CurrencyPair: symbol,
+					OrderSide:    exchange.FormatOrderSide(trades[i].Type),
+					OrderType:    exchange.Limit,
+					OrderDate:    tradeDate,
+					Status:       exchange.UnknownOrderStatus.ToString(),
+				})
+			}
+
+			o := &orders[x]
+			if tradeDate.Before(o.OrderDate) {
+				o.OrderDate = tradeDate
+			}
+			if executed := o.ExecutedAmount + trades[i].Amount; executed > 0 {
+				o.Price = (o.Price*o.ExecutedAmount + trades[i].Rate*trades[i].Amount) / executed
+			}
+			o.ExecutedAmount += trades[i].Amount
+			o.Fills = append(o.Fills, exchange.OrderFill{
+				ID:        strconv.FormatInt(trades[i].TradeID, 10),
+				Timestamp: tradeDate,
+				Price:     trades[i].Rate,
+				Amount:    trades[i].Amount,
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (p *Poloniex) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -0,0 +1,552 @@
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	riskFile = "risk.json"
+
+	// maxViolations is the number of recent violations kept by the manager
+	maxViolations = 100
+)
+
+// vars related to risk controls
+var (
+	ErrKillSwitchActive         = errors.New("kill switch is active, new orders are disabled")
+	ErrMaxOrderNotionalExceeded = errors.New("order exceeds maximum notional")
+	ErrMaxPositionExceeded      = errors.New("order exceeds maximum position")
+	ErrMaxOpenOrdersExceeded    = errors.New("maximum open orders reached")
+	ErrPriceDeviationExceeded   = errors.New("order price deviates too far from last price")
+	ErrDailyLossLimitReached    = errors.New("daily loss limit reached")
+	ErrNoReferencePrice         = errors.New("no ticker price available to check order against")
+)
+
+// NewManager returns a risk manager with the kill switch disengaged which
+// persists the daily profit and loss to the supplied data directory. The
+// profit and loss is held in memory only if the data directory is empty
+func NewManager(dataDir string) *Manager {
+	m := &Manager{
+		exchanges: make(map[string]*Exchange),
+		loaded:    make(map[string]map[string]*pnl),
+	}
+	if dataDir != "" {
+		m.filePath = dataDir + common.GetOSPathSlash() + riskFile
+	}
+	return m
+}
+
+// GetFilePath returns the path of the file the daily profit and loss is
+// persisted to
+func (m *Manager) GetFilePath() string {
+	return m.filePath
+}
+
+// Load reads the previously persisted daily profit and loss from disk, it
+// must be called before exchanges are wrapped
+func (m *Manager) Load() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.filePath == "" {
+		return nil
+	}
+	if _, err := os.Stat(m.filePath); os.IsNotExist(err) {
+		return nil
+	}
+
+	data, err := common.ReadFile(m.filePath)
+	if err != nil {
+		return err
+	}
+
+	var s store
+	err = common.JSONDecode(data, &s)
+	if err != nil {
+		return err
+	}
+	for exchName, pl := range s.PnL {
+		m.loaded[common.StringToLower(exchName)] = pl
+	}
+	return nil
+}
+
+// save persists the profit and loss of every wrapped exchange to disk
+func (m *Manager) save() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.filePath == "" {
+		return nil
+	}
+
+	s := store{PnL: make(map[string]map[string]*pnl)}
+	for exchName, pl := range m.loaded {
+		s.PnL[exchName] = pl
+	}
+	for exchName, e := range m.exchanges {
+		e.mtx.Lock()
+		pl := make(map[string]*pnl)
+		for key, v := range e.pnl {
+			c := *v
+			pl[key] = &c
+		}
+		e.mtx.Unlock()
+		s.PnL[exchName] = pl
+	}
+
+	data, err := common.JSONEncode(s)
+	if err != nil {
+		return err
+	}
+	return common.WriteFile(m.filePath, data)
+}
+
+// SetComms sets where violations and kill switch changes are reported
+func (m *Manager) SetComms(n Notifier) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.notifier = n
+}
+
+// Wrap returns exch wrapped so that orders are checked against the limits
+// before being submitted. Pair overrides with an invalid currency pair are
+// ignored
+func (m *Manager) Wrap(exch exchange.IBotExchange, limits config.RiskLimitsConfig) exchange.IBotExchange {
+	e := &Exchange{
+		IBotExchange: exch,
+		manager:      m,
+		limits:       limits,
+		pnl:          make(map[string]*pnl),
+	}
+	for key, override := range limits.Pairs {
+		p, err := pair.ParseCurrencyPair(common.StringToUpper(key))
+		if err != nil {
+			log.Printf("Risk: %s ignoring pair limits: %s", exch.GetName(), err)
+			continue
+		}
+		e.pairLimits = append(e.pairLimits, pairLimits{pair: p, limits: override})
+	}
+
+	name := common.StringToLower(exch.GetName())
+	m.mtx.Lock()
+	if pl, ok := m.loaded[name]; ok {
+		e.pnl = pl
+		delete(m.loaded, name)
+	}
+	m.exchanges[name] = e
+	m.mtx.Unlock()
+	return e
+}
+
+// SetOuter sets the outermost wrapper of an exchange wrapped by the manager so
+// that kill switch cancellations pass through wrappers such as the order
+// manager
+func (m *Manager) SetOuter(exch exchange.IBotExchange) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	e, ok := m.exchanges[common.StringToLower(exch.GetName())]
+	if !ok {
+		return
+	}
+	e.outer = exch
+}
+
+// ActivateKillSwitch rejects all new orders on every wrapped exchange and
+// cancels their open orders
+func (m *Manager) ActivateKillSwitch(reason string) error {
+	m.mtx.Lock()
+	m.killSwitch = true
+	m.killReason = reason
+	var exchanges []exchange.IBotExchange
+	for _, e := range m.exchanges {
+		if e.outer != nil {
+			exchanges = append(exchanges, e.outer)
+			continue
+		}
+		exchanges = append(exchanges, e)
+	}
+	m.mtx.Unlock()
+
+	m.notify("KILL_SWITCH", fmt.Sprintf("Kill switch activated: %s", reason))
+
+	var errs []string
+	for x := range exchanges {
+		resp, err := exchanges[x].CancelAllOrders(exchange.OrderCancellation{})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", exchanges[x].GetName(), err))
+			continue
+		}
+		var ids []string
+		for id := range resp.OrderStatus {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			errs = append(errs, fmt.Sprintf("%s: order %s %s",
+				exchanges[x].GetName(), id, resp.OrderStatus[id]))
+		}
+	}
+	if len(errs) > 0 {
+		err := fmt.Errorf("failed to cancel orders. %s", common.JoinStrings(errs, ", "))
+		m.notify("KILL_SWITCH", err.Error())
+		return err
+	}
+	return nil
+}
+
+// ResetKillSwitch allows new orders to be submitted again
+func (m *Manager) ResetKillSwitch() {
+	m.mtx.Lock()
+	m.killSwitch = false
+	m.killReason = ""
+	m.mtx.Unlock()
+
+	m.notify("KILL_SWITCH", "Kill switch reset, orders are enabled")
+}
+
+// IsKillSwitchActive returns whether the kill switch is active and the reason
+// it was activated
+func (m *Manager) IsKillSwitchActive() (bool, string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.killSwitch, m.killReason
+}
+
+// GetViolations returns the most recent risk violations
+func (m *Manager) GetViolations() []Violation {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	violations := make([]Violation, len(m.violations))
+	copy(violations, m.violations)
+	return violations
+}
+
+// ProcessFill updates the realised profit and loss used by the daily loss
+// limit of the fill's exchange. Fills are delivered by authenticated websocket
+// streams, paper trading or derived by the order manager
+func (m *Manager) ProcessFill(fill exchange.FillEvent) {
+	m.mtx.Lock()
+	e, ok := m.exchanges[common.StringToLower(fill.Exchange)]
+	m.mtx.Unlock()
+	if !ok {
+		return
+	}
+	if fill.Price <= 0 {
+		log.Printf("Risk: %s %s fill for order %s has no price, excluded from profit and loss",
+			fill.Exchange, fill.Pair.Pair(), fill.OrderID)
+		return
+	}
+	e.processFill(fill)
+
+	err := m.save()
+	if err != nil {
+		log.Printf("Risk: unable to save profit and loss to %s. Err: %s", m.filePath, err)
+	}
+}
+
+// report records a violation and pushes it to the communication mediums
+func (m *Manager) report(v Violation) {
+	m.mtx.Lock()
+	m.violations = append(m.violations, v)
+	if len(m.violations) > maxViolations {
+		m.violations = m.violations[len(m.violations)-maxViolations:]
+	}
+	m.mtx.Unlock()
+
+	m.notify("RISK_VIOLATION", fmt.Sprintf("%s %s order rejected by %s: %s",
+		v.Exchange, v.Pair.Pair(), v.Rule, v.Reason))
+}
+
+func (m *Manager) notify(eventType, message string) {
+	log.Printf("Risk: %s", message)
+
+	m.mtx.Lock()
+	n := m.notifier
+	m.mtx.Unlock()
+	if n != nil {
+		n.PushEvent(base.Event{Type: eventType, TradeDetails: message})
+	}
+}
+
+// GetLimits returns the limits enforced on a currency pair, pair limits
+// override the non zero default limits
+func (e *Exchange) GetLimits(p pair.CurrencyPair) config.RiskLimits {
+	if !e.limits.Enabled {
+		return config.RiskLimits{}
+	}
+
+	limits := e.limits.Default
+
+	for x := range e.pairLimits {
+		if !e.pairLimits[x].pair.Equal(p, true) {
+			continue
+		}
+		override := e.pairLimits[x].limits
+		if override.MaxOrderNotional != 0 {
+			limits.MaxOrderNotional = override.MaxOrderNotional
+		}
+		if override.MaxPosition != 0 {
+			limits.MaxPosition = override.MaxPosition
+		}
+		if override.MaxOpenOrders != 0 {
+			limits.MaxOpenOrders = override.MaxOpenOrders
+		}
+		if override.MaxPriceDeviation != 0 {
+			limits.MaxPriceDeviation = override.MaxPriceDeviation
+		}
+		if override.DailyLossLimit != 0 {
+			limits.DailyLossLimit = override.DailyLossLimit
+		}
+		break
+	}
+	return limits
+}
+
+// GetDailyPnL returns the realised profit and loss of a currency pair for the
+// current UTC day
+func (e *Exchange) GetDailyPnL(p pair.CurrencyPair) float64 {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	pl, ok := e.pnl[pairKey(p)]
+	if !ok || !pl.Day.Equal(today()) {
+		return 0
+	}
+	return pl.Realised
+}
+
+// Stop stops the wrapped exchange if it runs background routines
+func (e *Exchange) Stop() {
+	if s, ok := e.IBotExchange.(exchange.Stopper); ok {
+		s.Stop()
+	}
+}
+
+// SubmitOrder checks the order against the risk limits before submitting it
+func (e *Exchange) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	if order == nil {
+		return exchange.SubmitOrderResponse{}, exchange.ErrOrderSubmissionIsNil
+	}
+
+	err := e.checkOrder(order.CurrencyPair, order.OrderSide, order.Price, order.Amount)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+	return e.IBotExchange.SubmitOrder(order)
+}
+
+// ModifyOrder checks the modified order against the risk limits before
+// submitting the modification
+func (e *Exchange) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	err := e.checkOrder(action.Currency, action.OrderSide, action.Price, action.Amount)
+	if err != nil {
+		return "", err
+	}
+	return e.IBotExchange.ModifyOrder(action)
+}
+
+// checkOrder returns an error and reports the violation if an order breaches
+// the kill switch or a risk limit
+func (e *Exchange) checkOrder(p pair.CurrencyPair, side exchange.OrderSide, price, amount float64) error {
+	rule, reason, err := e.check(p, side, price, amount)
+	if err == nil {
+		return nil
+	}
+
+	e.manager.report(Violation{
+		Time:     time.Now(),
+		Exchange: e.GetName(),
+		Pair:     p,
+		Rule:     rule,
+		Reason:   reason,
+	})
+	return err
+}
+
+func (e *Exchange) check(p pair.CurrencyPair, side exchange.OrderSide, price, amount float64) (Rule, string, error) {
+	if active, reason := e.manager.IsKillSwitchActive(); active {
+		return KillSwitchRule, reason, ErrKillSwitchActive
+	}
+
+	limits := e.GetLimits(p)
+
+	if limits.DailyLossLimit > 0 {
+		realised := e.GetDailyPnL(p)
+		if -realised >= limits.DailyLossLimit {
+			return DailyLossLimitRule,
+				fmt.Sprintf("realised loss %f reached limit %f", -realised, limits.DailyLossLimit),
+				ErrDailyLossLimitReached
+		}
+	}
+
+	if limits.MaxOrderNotional > 0 || limits.MaxPriceDeviation > 0 {
+		last, err := e.getLastPrice(p)
+		if err != nil {
+			if limits.MaxPriceDeviation > 0 {
+				return PriceBandRule, err.Error(), err
+			}
+			return MaxOrderNotionalRule, err.Error(), err
+		}
+
+		if limits.MaxPriceDeviation > 0 && price > 0 {
+			deviation := math.Abs(price-last) / last * 100
+			if deviation > limits.MaxPriceDeviation {
+				return PriceBandRule,
+					fmt.Sprintf("price %f deviates %.2f%% from last price %f, limit %.2f%%",
+						price, deviation, last, limits.MaxPriceDeviation),
+					ErrPriceDeviationExceeded
+			}
+		}
+
+		if price <= 0 {
+			price = last
+		}
+		if notional := price * amount; limits.MaxOrderNotional > 0 && notional > limits.MaxOrderNotional {
+			return MaxOrderNotionalRule,
+				fmt.Sprintf("notional %f exceeds limit %f", notional, limits.MaxOrderNotional),
+				ErrMaxOrderNotionalExceeded
+		}
+	}
+
+	if limits.MaxOpenOrders == 0 && (limits.MaxPosition == 0 || side != exchange.Buy) {
+		return "", "", nil
+	}
+
+	orders, err := e.IBotExchange.GetActiveOrders(exchange.GetOrdersRequest{
+		Currencies: []pair.CurrencyPair{p},
+	})
+	if err != nil {
+		return MaxOpenOrdersRule, err.Error(), err
+	}
+	var open []exchange.OrderDetail
+	for x := range orders {
+		if orders[x].CurrencyPair.Equal(p, true) {
+			open = append(open, orders[x])
+		}
+	}
+
+	if limits.MaxOpenOrders > 0 && len(open) >= limits.MaxOpenOrders {
+		return MaxOpenOrdersRule,
+			fmt.Sprintf("%d open orders, limit %d", len(open), limits.MaxOpenOrders),
+			ErrMaxOpenOrdersExceeded
+	}
+
+	if limits.MaxPosition > 0 && side == exchange.Buy {
+		held, err := e.getBalance(p.FirstCurrency.Upper().String())
+		if err != nil {
+			return MaxPositionRule, err.Error(), err
+		}
+		position := held + amount
+		for x := range open {
+			if open[x].OrderSide == exchange.Buy {
+				position += open[x].Amount - open[x].ExecutedAmount
+			}
+		}
+		if position > limits.MaxPosition {
+			return MaxPositionRule,
+				fmt.Sprintf("position %f exceeds limit %f", position, limits.MaxPosition),
+				ErrMaxPositionExceeded
+		}
+	}
+	return "", "", nil
+}
+
+// getLastPrice returns the last cached ticker price for a currency pair
+func (e *Exchange) getLastPrice(p pair.CurrencyPair) (float64, error) {
+	t, err := ticker.GetTicker(e.GetName(), p, ticker.Spot)
+	if err != nil || t.Last <= 0 {
+		return 0, ErrNoReferencePrice
+	}
+	return t.Last, nil
+}
+
+// getBalance returns the total account balance of a currency
+func (e *Exchange) getBalance(currency string) (float64, error) {
+	info, err := e.IBotExchange.GetAccountInfo()
+	if err != nil {
+		return 0, err
+	}
+	for x := range info.Currencies {
+		if common.StringToUpper(info.Currencies[x].CurrencyName) == currency {
+			return info.Currencies[x].TotalValue + info.Currencies[x].Hold, nil
+		}
+	}
+	return 0, nil
+}
+
+// processFill updates the position and realised profit and loss of the fill's
+// currency pair using the average entry price
+func (e *Exchange) processFill(fill exchange.FillEvent) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	pl, ok := e.pnl[pairKey(fill.Pair)]
+	if !ok {
+		pl = &pnl{}
+		e.pnl[pairKey(fill.Pair)] = pl
+	}
+	if day := today(); !pl.Day.Equal(day) {
+		pl.Day = day
+		pl.Realised = 0
+	}
+
+	amount := fill.Amount
+	if fill.OrderSide == exchange.Sell {
+		amount = -amount
+	}
+
+	// closing part or all of the position realises profit or loss
+	if pl.Position != 0 && (pl.Position > 0) != (amount > 0) {
+		closed := math.Min(math.Abs(amount), math.Abs(pl.Position))
+		if pl.Position > 0 {
+			pl.Realised += (fill.Price - pl.AvgPrice) * closed
+		} else {
+			pl.Realised += (pl.AvgPrice - fill.Price) * closed
+		}
+	}
+
+	position := pl.Position + amount
+	switch {
+	case position == 0:
+		pl.AvgPrice = 0
+	case pl.Position == 0 || (pl.Position > 0) != (position > 0):
+		// opened or flipped the position
+		pl.AvgPrice = fill.Price
+	case math.Abs(position) > math.Abs(pl.Position):
+		pl.AvgPrice = (pl.AvgPrice*math.Abs(pl.Position) + fill.Price*math.Abs(amount)) /
+			math.Abs(position)
+	}
+	pl.Position = position
+
+	switch common.StringToUpper(fill.FeeCurrency) {
+	case "", fill.Pair.SecondCurrency.Upper().String():
+		pl.Realised -= fill.Fee
+	case fill.Pair.FirstCurrency.Upper().String():
+		pl.Realised -= fill.Fee * fill.Price
+	}
+}
+
+// pairKey returns a key for a currency pair regardless of its delimiter
+func pairKey(p pair.CurrencyPair) string {
+	return p.FirstCurrency.Upper().String() + p.SecondCurrency.Upper().String()
+}
+
+// today returns the start of the current UTC day
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,303 @@
+package risk
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+var testPair = pair.NewCurrencyPair("BTC", "USD")
+
+// outerExchange stands in for a wrapper placed around the risk exchange
+type outerExchange struct {
+	exchange.IBotExchange
+	cancelled int
+}
+
+func (o *outerExchange) CancelAllOrders(orders exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	o.cancelled++
+	return o.IBotExchange.CancelAllOrders(orders)
+}
+
+// testNotifier records the events pushed to it
+type testNotifier struct {
+	events []base.Event
+	mtx    sync.Mutex
+}
+
+func (n *testNotifier) PushEvent(event base.Event) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.events = append(n.events, event)
+}
+
+func newTestExchange(name string, limits config.RiskLimitsConfig) (*Manager, *exchangetest.Exchange, *Exchange, *testNotifier) {
+	ticker.ProcessTicker(name, testPair, ticker.Price{Last: 100}, ticker.Spot)
+	m := NewManager("")
+	n := &testNotifier{}
+	m.SetComms(n)
+	t := exchangetest.New(name, testPair)
+	return m, t, m.Wrap(t, limits).(*Exchange), n
+}
+
+func submit(e *Exchange, side exchange.OrderSide, price, amount float64) error {
+	orderType := exchange.Limit
+	if price == 0 {
+		orderType = exchange.Market
+	}
+	_, err := e.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: testPair,
+		OrderSide:    side,
+		OrderType:    orderType,
+		Price:        price,
+		Amount:       amount,
+	})
+	return err
+}
+
+func TestGetLimits(t *testing.T) {
+	_, _, e, _ := newTestExchange("RiskLimits", config.RiskLimitsConfig{
+		Enabled: true,
+		Default: config.RiskLimits{MaxOrderNotional: 1000, MaxOpenOrders: 5},
+		Pairs: map[string]config.RiskLimits{
+			"BTC-USD": {MaxOrderNotional: 500},
+			"BTC":     {MaxOrderNotional: 1},
+		},
+	})
+
+	limits := e.GetLimits(testPair)
+	if limits.MaxOrderNotional != 500 || limits.MaxOpenOrders != 5 {
+		t.Errorf("Test failed. GetLimits unexpected result %+v", limits)
+	}
+
+	limits = e.GetLimits(pair.NewCurrencyPair("LTC", "USD"))
+	if limits.MaxOrderNotional != 1000 {
+		t.Errorf("Test failed. GetLimits expected default limits got %+v", limits)
+	}
+
+	e.limits.Enabled = false
+	if e.GetLimits(testPair) != (config.RiskLimits{}) {
+		t.Error("Test failed. GetLimits expected no limits when disabled")
+	}
+}
+
+func TestOrderLimits(t *testing.T) {
+	m, exch, e, n := newTestExchange("RiskOrders", config.RiskLimitsConfig{
+		Enabled: true,
+		Default: config.RiskLimits{
+			MaxOrderNotional:  1000,
+			MaxPriceDeviation: 5,
+			MaxOpenOrders:     2,
+			MaxPosition:       5,
+		},
+	})
+
+	if err := submit(e, exchange.Buy, 100, 1); err != nil {
+		t.Fatalf("Test failed. SubmitOrder error: %s", err)
+	}
+	if err := submit(e, exchange.Buy, 0, 11); err != ErrMaxOrderNotionalExceeded {
+		t.Errorf("Test failed. Expected %s got %v", ErrMaxOrderNotionalExceeded, err)
+	}
+	if err := submit(e, exchange.Buy, 106, 1); err != ErrPriceDeviationExceeded {
+		t.Errorf("Test failed. Expected %s got %v", ErrPriceDeviationExceeded, err)
+	}
+
+	exch.Balances = map[string]float64{"BTC": 3}
+	exch.Active = []exchange.OrderDetail{
+		{CurrencyPair: testPair, OrderSide: exchange.Buy, Amount: 2, ExecutedAmount: 0.5},
+	}
+	if err := submit(e, exchange.Buy, 100, 1); err != ErrMaxPositionExceeded {
+		t.Errorf("Test failed. Expected %s got %v", ErrMaxPositionExceeded, err)
+	}
+	if err := submit(e, exchange.Sell, 100, 1); err != nil {
+		t.Errorf("Test failed. Expected sell to reduce position got %v", err)
+	}
+
+	exch.Active = append(exch.Active, exchange.OrderDetail{CurrencyPair: testPair})
+	if err := submit(e, exchange.Sell, 100, 1); err != ErrMaxOpenOrdersExceeded {
+		t.Errorf("Test failed. Expected %s got %v", ErrMaxOpenOrdersExceeded, err)
+	}
+
+	_, err := e.ModifyOrder(exchange.ModifyOrder{Currency: testPair, Price: 50, Amount: 1})
+	if err != ErrPriceDeviationExceeded {
+		t.Errorf("Test failed. Expected %s got %v", ErrPriceDeviationExceeded, err)
+	}
+
+	if len(exch.Submitted()) != 2 {
+		t.Errorf("Test failed. Expected 2 orders to reach the exchange got %d", len(exch.Submitted()))
+	}
+	if len(m.GetViolations()) != 5 || len(n.events) != 5 {
+		t.Errorf("Test failed. Expected 5 violations reported got %d %d",
+			len(m.GetViolations()), len(n.events))
+	}
+	if v := m.GetViolations()[0]; v.Rule != MaxOrderNotionalRule || v.Exchange != "RiskOrders" {
+		t.Errorf("Test failed. Unexpected violation %+v", v)
+	}
+}
+
+func TestNoReferencePrice(t *testing.T) {
+	m := NewManager("")
+	e := m.Wrap(exchangetest.New("RiskNoPrice"), config.RiskLimitsConfig{
+		Enabled: true,
+		Default: config.RiskLimits{MaxOrderNotional: 1000},
+	}).(*Exchange)
+
+	if err := submit(e, exchange.Buy, 0, 1); err != ErrNoReferencePrice {
+		t.Errorf("Test failed. Expected %s got %v", ErrNoReferencePrice, err)
+	}
+}
+
+func TestKillSwitch(t *testing.T) {
+	m, exch, e, n := newTestExchange("RiskKill", config.RiskLimitsConfig{})
+	var cancelled int
+	var failed map[string]string
+	exch.CancelAllOrdersFunc = func(orders exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+		cancelled++
+		return exchange.CancelAllOrdersResponse{OrderStatus: failed}, exch.CancelErr
+	}
+
+	err := m.ActivateKillSwitch("test")
+	if err != nil {
+		t.Fatalf("Test failed. ActivateKillSwitch error: %s", err)
+	}
+	if cancelled != 1 {
+		t.Error("Test failed. ActivateKillSwitch expected orders to be cancelled")
+	}
+	if active, reason := m.IsKillSwitchActive(); !active || reason != "test" {
+		t.Error("Test failed. Expected kill switch to be active")
+	}
+	if err = submit(e, exchange.Buy, 100, 1); err != ErrKillSwitchActive {
+		t.Errorf("Test failed. Expected %s got %v", ErrKillSwitchActive, err)
+	}
+
+	m.ResetKillSwitch()
+	if err = submit(e, exchange.Buy, 100, 1); err != nil {
+		t.Errorf("Test failed. SubmitOrder error after reset: %s", err)
+	}
+
+	exch.CancelErr = errors.New("cancel failed")
+	if m.ActivateKillSwitch("test") == nil {
+		t.Error("Test failed. ActivateKillSwitch expected cancel error")
+	}
+	if len(n.events) == 0 || n.events[0].Type != "KILL_SWITCH" {
+		t.Error("Test failed. Expected kill switch to be reported")
+	}
+
+	exch.CancelErr = nil
+	failed = map[string]string{"42": "order not found"}
+	err = m.ActivateKillSwitch("test")
+	if err == nil || !strings.Contains(err.Error(), "order 42 order not found") {
+		t.Errorf("Test failed. ActivateKillSwitch expected order failure got %v", err)
+	}
+	if last := n.events[len(n.events)-1]; !strings.Contains(last.TradeDetails, "order 42") {
+		t.Error("Test failed. Expected order failure to be reported")
+	}
+
+	failed = nil
+	outer := &outerExchange{IBotExchange: e}
+	m.SetOuter(outer)
+	if err = m.ActivateKillSwitch("test"); err != nil {
+		t.Fatalf("Test failed. ActivateKillSwitch error: %s", err)
+	}
+	if outer.cancelled != 1 {
+		t.Error("Test failed. Expected orders to be cancelled through the outer exchange")
+	}
+}
+
+func TestDailyLossLimit(t *testing.T) {
+	m, _, e, _ := newTestExchange("RiskLoss", config.RiskLimitsConfig{
+		Enabled: true,
+		Default: config.RiskLimits{DailyLossLimit: 50},
+	})
+
+	fill := func(side exchange.OrderSide, price, amount float64) {
+		m.ProcessFill(exchange.FillEvent{
+			Timestamp:   time.Now(),
+			Exchange:    "riskloss",
+			Pair:        pair.NewCurrencyPairDelimiter("BTC-USD", "-"),
+			OrderSide:   side,
+			Price:       price,
+			Amount:      amount,
+			Fee:         1,
+			FeeCurrency: "USD",
+		})
+	}
+
+	fill(exchange.Buy, 100, 1)
+	fill(exchange.Buy, 200, 1)
+	fill(exchange.Sell, 130, 1)
+	// average entry of 150 so selling at 130 realises -20 less 3 in fees
+	if pnl := e.GetDailyPnL(testPair); pnl != -23 {
+		t.Errorf("Test failed. Expected daily PnL -23 got %f", pnl)
+	}
+	if err := submit(e, exchange.Buy, 100, 1); err != nil {
+		t.Errorf("Test failed. SubmitOrder error: %s", err)
+	}
+
+	fill(exchange.Sell, 120, 2)
+	// closes the remaining 1 for -30 and opens a short at 120
+	if pnl := e.GetDailyPnL(testPair); pnl != -54 {
+		t.Errorf("Test failed. Expected daily PnL -54 got %f", pnl)
+	}
+	if err := submit(e, exchange.Buy, 100, 1); err != ErrDailyLossLimitReached {
+		t.Errorf("Test failed. Expected %s got %v", ErrDailyLossLimitReached, err)
+	}
+
+	fill(exchange.Buy, 100, 1)
+	if pnl := e.GetDailyPnL(testPair); pnl != -35 {
+		t.Errorf("Test failed. Expected daily PnL -35 got %f", pnl)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "risk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := NewManager(dir)
+	m.Wrap(exchangetest.New("RiskPersist", testPair), config.RiskLimitsConfig{})
+	m.ProcessFill(exchange.FillEvent{
+		Exchange:  "RiskPersist",
+		Pair:      testPair,
+		OrderSide: exchange.Buy,
+		Price:     100,
+		Amount:    1,
+	})
+	m.ProcessFill(exchange.FillEvent{
+		Exchange:  "RiskPersist",
+		Pair:      testPair,
+		OrderSide: exchange.Sell,
+		Price:     90,
+		Amount:    1,
+	})
+	// fills without a price are excluded
+	m.ProcessFill(exchange.FillEvent{
+		Exchange:  "RiskPersist",
+		Pair:      testPair,
+		OrderSide: exchange.Sell,
+		Amount:    1,
+	})
+
+	loaded := NewManager(dir)
+	err = loaded.Load()
+	if err != nil {
+		t.Fatalf("Test failed. Load error: %s", err)
+	}
+	e := loaded.Wrap(exchangetest.New("RiskPersist", testPair), config.RiskLimitsConfig{}).(*Exchange)
+	if pnl := e.GetDailyPnL(testPair); pnl != -10 {
+		t.Errorf("Test failed. Expected persisted daily PnL -10 got %f", pnl)
+	}
+}
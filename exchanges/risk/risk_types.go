@@ -0,0 +1,91 @@
+package risk
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Rule names the risk control which rejected an order
+type Rule string
+
+// Risk rules
+const (
+	KillSwitchRule       Rule = "KILL_SWITCH"
+	MaxOrderNotionalRule Rule = "MAX_ORDER_NOTIONAL"
+	MaxPositionRule      Rule = "MAX_POSITION"
+	MaxOpenOrdersRule    Rule = "MAX_OPEN_ORDERS"
+	PriceBandRule        Rule = "PRICE_BAND"
+	DailyLossLimitRule   Rule = "DAILY_LOSS_LIMIT"
+)
+
+// Violation holds an order rejected by a risk control
+type Violation struct {
+	Time     time.Time         `json:"time"`
+	Exchange string            `json:"exchange"`
+	Pair     pair.CurrencyPair `json:"pair"`
+	Rule     Rule              `json:"rule"`
+	Reason   string            `json:"reason"`
+}
+
+// Notifier is implemented by communications.Communications and receives risk
+// violations and kill switch changes
+type Notifier interface {
+	PushEvent(event base.Event)
+}
+
+// Manager enforces risk limits on every exchange wrapped by it and holds the
+// global kill switch
+type Manager struct {
+	filePath  string
+	exchanges map[string]*Exchange
+	// loaded holds the persisted profit and loss of each exchange until the
+	// exchange is wrapped
+	loaded     map[string]map[string]*pnl
+	notifier   Notifier
+	killSwitch bool
+	killReason string
+	violations []Violation
+	mtx        sync.Mutex
+}
+
+// Exchange wraps an exchange.IBotExchange so that orders are checked against
+// the risk limits before being submitted
+type Exchange struct {
+	exchange.IBotExchange
+	manager *Manager
+	limits  config.RiskLimitsConfig
+	// pairLimits holds the pair overrides of limits parsed when the exchange
+	// is wrapped
+	pairLimits []pairLimits
+	pnl        map[string]*pnl
+	// outer is the outermost wrapper of the exchange which the kill switch
+	// cancels orders through
+	outer exchange.IBotExchange
+	mtx   sync.Mutex
+}
+
+// pairLimits holds the limits overriding the defaults for a currency pair
+type pairLimits struct {
+	pair   pair.CurrencyPair
+	limits config.RiskLimits
+}
+
+// pnl tracks the position and realised profit and loss of a currency pair
+// from its fills
+type pnl struct {
+	Day      time.Time `json:"day"`
+	Position float64   `json:"position"`
+	AvgPrice float64   `json:"avgPrice"`
+	Realised float64   `json:"realised"`
+}
+
+// store is the on disk format of the risk manager, the profit and loss of
+// each currency pair keyed by exchange
+type store struct {
+	PnL map[string]map[string]*pnl `json:"pnl"`
+}
@@ -17,12 +17,20 @@ const (
 	ErrSecondaryCurrencyNotFound = "Error secondary currency for ticker not found."
 
 	Spot = "SPOT"
+
+	// subscriptionBuffer is the number of updates a subscriber can fall behind
+	// before further updates are dropped
+	subscriptionBuffer = 100
 )
 
 // Vars for the ticker package
 var (
 	Tickers []Ticker
 	m       sync.Mutex
+
+	subs      = make(map[int64]*Subscription)
+	nextSubID int64
+	subMtx    sync.RWMutex
 )
 
 // Price struct stores the currency pair and pricing information
@@ -39,6 +47,24 @@ type Price struct {
 	PriceATH     float64           `json:"PriceATH"`
 }
 
+// Update is sent to subscribers whenever a ticker is processed
+type Update struct {
+	Exchange  string
+	Pair      pair.CurrencyPair
+	AssetType string
+	Price     Price
+}
+
+// Subscription receives ticker updates matching its filter on C. Updates are
+// dropped rather than blocking ProcessTicker if C is not drained
+type Subscription struct {
+	C         chan Update
+	id        int64
+	exchange  string
+	pair      pair.CurrencyPair
+	assetType string
+}
+
 // Ticker struct holds the ticker information for a currency pair and type
 type Ticker struct {
 	Price        map[pair.CurrencyItem]map[pair.CurrencyItem]map[string]Price
@@ -147,8 +173,70 @@ func CreateNewTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price,
 	return ticker
 }
 
+// Subscribe returns a subscription which receives every ticker processed for
+// the exchange, pair and asset type. Empty exchange, pair or asset type values
+// match all tickers
+func Subscribe(exchangeName string, p pair.CurrencyPair, assetType string) *Subscription {
+	subMtx.Lock()
+	defer subMtx.Unlock()
+	nextSubID++
+	sub := &Subscription{
+		C:         make(chan Update, subscriptionBuffer),
+		id:        nextSubID,
+		exchange:  exchangeName,
+		pair:      p,
+		assetType: assetType,
+	}
+	subs[sub.id] = sub
+	return sub
+}
+
+// Unsubscribe stops and closes a subscription
+func Unsubscribe(sub *Subscription) {
+	if sub == nil {
+		return
+	}
+	subMtx.Lock()
+	defer subMtx.Unlock()
+	if _, ok := subs[sub.id]; !ok {
+		return
+	}
+	delete(subs, sub.id)
+	close(sub.C)
+}
+
+// notify sends an update to every matching subscriber without blocking
+func notify(u Update) {
+	subMtx.RLock()
+	defer subMtx.RUnlock()
+	for _, sub := range subs {
+		if !sub.matches(u) {
+			continue
+		}
+		select {
+		case sub.C <- u:
+		default:
+		}
+	}
+}
+
+// matches returns whether an update satisfies the subscription filter, empty
+// filter fields match everything
+func (s *Subscription) matches(u Update) bool {
+	if s.exchange != "" && s.exchange != u.Exchange {
+		return false
+	}
+	if !s.pair.Empty() && !s.pair.Equal(u.Pair, true) {
+		return false
+	}
+	if s.assetType != "" && s.assetType != u.AssetType {
+		return false
+	}
+	return true
+}
+
 // ProcessTicker processes incoming tickers, creating or updating the Tickers
-// list
+// list and notifying any subscribers
 func ProcessTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price, tickerType string) {
 	if tickerNew.Pair.Pair() == "" {
 		// set Pair if not set
@@ -158,6 +246,13 @@ func ProcessTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price, ti
 	tickerNew.CurrencyPair = p.Pair().String()
 	tickerNew.LastUpdated = time.Now()
 
+	defer notify(Update{
+		Exchange:  exchangeName,
+		Pair:      p,
+		AssetType: tickerType,
+		Price:     tickerNew,
+	})
+
 	ticker, err := GetTickerByExchange(exchangeName)
 	if err != nil {
 		CreateNewTicker(exchangeName, p, tickerNew, tickerType)
@@ -333,3 +333,44 @@ func TestProcessTicker(t *testing.T) { //non-appending function to tickers
 	wg.Wait()
 
 }
+
+func TestSubscribe(t *testing.T) {
+	currency := pair.NewCurrencyPair("ETH", "USD")
+	sub := Subscribe("SubExchange", currency, Spot)
+	all := Subscribe("", pair.CurrencyPair{}, "")
+	defer Unsubscribe(all)
+
+	ProcessTicker("OtherExchange", currency, Price{Last: 50}, Spot)
+	ProcessTicker("SubExchange", currency, Price{Last: 100}, Spot)
+
+	select {
+	case u := <-sub.C:
+		if u.Exchange != "SubExchange" || !u.Pair.Equal(currency, true) || u.Price.Last != 100 {
+			t.Errorf("Test failed. TestSubscribe received unexpected update %v", u)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Test failed. TestSubscribe did not receive an update")
+	}
+
+	select {
+	case u := <-sub.C:
+		t.Errorf("Test failed. TestSubscribe received unexpected update %v", u)
+	default:
+	}
+
+	var received int
+	for received < 2 {
+		select {
+		case <-all.C:
+			received++
+		case <-time.After(time.Second):
+			t.Fatal("Test failed. TestSubscribe wildcard subscription missed updates")
+		}
+	}
+
+	Unsubscribe(sub)
+	if _, ok := <-sub.C; ok {
+		t.Error("Test failed. TestSubscribe expected channel to be closed")
+	}
+	ProcessTicker("SubExchange", currency, Price{Last: 100}, Spot)
+}
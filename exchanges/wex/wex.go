@@ -57,6 +57,15 @@ func (w *WEX) SetDefaults() {
 	w.RESTPollingDelay = 10
 	w.Ticker = make(map[string]Ticker)
 	w.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission
+	w.OrderSubmissionFeatures = exchange.LimitOrderSupport
+	w.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	w.RequestCurrencyPairFormat.Delimiter = "_"
 	w.RequestCurrencyPairFormat.Uppercase = false
 	w.RequestCurrencyPairFormat.Separator = "-"
@@ -188,8 +197,8 @@ func (w *WEX) GetAccountInformation() (AccountInfo, error) {
 	return result, nil
 }
 
-// GetActiveOrders returns the active orders for a specific currency
-func (w *WEX) GetActiveOrders(pair string) (map[string]ActiveOrders, error) {
+// GetOpenOrders returns the active orders for a specific currency
+func (w *WEX) GetOpenOrders(pair string) (map[string]ActiveOrders, error) {
 	req := url.Values{}
 	req.Add("pair", pair)
 
@@ -5,10 +5,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -151,17 +153,27 @@ func (w *WEX) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (w *WEX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (w *WEX) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (w *WEX) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (w *WEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (w *WEX) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	response, err := w.Trade(common.StringToLower(p.Pair().String()), common.StringToLower(side.ToString()), amount, price)
+	if err := order.Validate(w.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	response, err := w.Trade(common.StringToLower(order.CurrencyPair.Pair().String()), common.StringToLower(order.OrderSide.ToString()), order.Amount, order.Price)
 
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
@@ -200,7 +212,7 @@ func (w *WEX) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exc
 	var allActiveOrders map[string]ActiveOrders
 
 	for _, pair := range w.EnabledPairs {
-		activeOrders, err := w.GetActiveOrders(pair)
+		activeOrders, err := w.GetOpenOrders(pair)
 		if err != nil {
 			return cancelAllOrdersResponse, err
 		}
@@ -231,6 +243,88 @@ func (w *WEX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (w *WEX) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = w.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := w.GetOpenOrders(exchange.FormatExchangeCurrency(w.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+
+		for orderID, order := range resp {
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:        w.Name,
+				ID:              orderID,
+				CurrencyPair:    currencies[x],
+				OrderSide:       exchange.FormatOrderSide(order.Type),
+				OrderType:       exchange.Limit,
+				OrderDate:       time.Unix(int64(order.TimestampCreated), 0),
+				Status:          strconv.Itoa(order.Status),
+				Price:           order.Rate,
+				Amount:          order.Amount,
+				RemainingAmount: order.Amount,
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (w *WEX) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = w.GetEnabledCurrencies()
+	}
+
+	var since, end string
+	if !getOrdersRequest.StartTicks.IsZero() {
+		since = strconv.FormatInt(getOrdersRequest.StartTicks.Unix(), 10)
+	}
+	if !getOrdersRequest.EndTicks.IsZero() {
+		end = strconv.FormatInt(getOrdersRequest.EndTicks.Unix(), 10)
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := w.GetTradeHistory(0, 1000, 0, "DESC", since, end, exchange.FormatExchangeCurrency(w.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+
+		for tradeID, trade := range resp {
+			tradeDate := time.Unix(int64(trade.Timestamp), 0)
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:       w.Name,
+				ID:             strconv.FormatFloat(trade.OrderID, 'f', -1, 64),
+				CurrencyPair:   currencies[x],
+				OrderSide:      exchange.FormatOrderSide(trade.Type),
+				OrderType:      exchange.Limit,
+				OrderDate:      tradeDate,
+				Status:         "FILLED",
+				Price:          trade.Rate,
+				Amount:         trade.Amount,
+				ExecutedAmount: trade.Amount,
+				Fills: []exchange.OrderFill{{
+					ID:        tradeID,
+					Timestamp: tradeDate,
+					Price:     trade.Rate,
+					Amount:    trade.Amount,
+				}},
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (w *WEX) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -5,10 +5,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -109,21 +111,27 @@ func (y *Yobit) GetAccountInfo() (exchange.AccountInfo, error) {
 		return response, err
 	}
 
+	response.Currencies = accountCurrencies(accountBalance)
+	return response, nil
+}
+
+// accountCurrencies converts the funds including and excluding open orders
+// into available and held balances
+func accountCurrencies(accountBalance AccountInfo) []exchange.AccountCurrencyInfo {
+	var currencies []exchange.AccountCurrencyInfo
 	for x, y := range accountBalance.FundsInclOrders {
 		var exchangeCurrency exchange.AccountCurrencyInfo
 		exchangeCurrency.CurrencyName = common.StringToUpper(x)
 		exchangeCurrency.TotalValue = y
 		exchangeCurrency.Hold = 0
-		for z, w := range accountBalance.Funds {
-			if z == x {
-				exchangeCurrency.Hold = y - w
-			}
+		if w, ok := accountBalance.Funds[x]; ok {
+			exchangeCurrency.TotalValue = w
+			exchangeCurrency.Hold = y - w
 		}
 
-		response.Currencies = append(response.Currencies, exchangeCurrency)
+		currencies = append(currencies, exchangeCurrency)
 	}
-
-	return response, nil
+	return currencies
 }
 
 // GetFundingHistory returns funding history, deposits and
@@ -133,17 +141,27 @@ func (y *Yobit) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (y *Yobit) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (y *Yobit) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (y *Yobit) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (y *Yobit) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (y *Yobit) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
-	response, err := y.Trade(p.Pair().String(), orderType.ToString(), amount, price)
+	if err := order.Validate(y.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
+	response, err := y.Trade(order.CurrencyPair.Pair().String(), common.StringToLower(order.OrderSide.ToString()), order.Amount, order.Price)
 
 	if response > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
@@ -181,7 +199,7 @@ func (y *Yobit) CancelAllOrders(orderCancellation exchange.OrderCancellation) (e
 	var allActiveOrders []map[string]ActiveOrders
 
 	for _, pair := range y.EnabledPairs {
-		activeOrdersForPair, err := y.GetActiveOrders(pair)
+		activeOrdersForPair, err := y.GetOpenOrders(pair)
 		if err != nil {
 			return cancelAllOrdersResponse, err
 		}
@@ -212,6 +230,88 @@ func (y *Yobit) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (y *Yobit) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = y.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := y.GetOpenOrders(exchange.FormatExchangeCurrency(y.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+
+		for orderID, order := range resp {
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:        y.Name,
+				ID:              orderID,
+				CurrencyPair:    currencies[x],
+				OrderSide:       exchange.FormatOrderSide(order.Type),
+				OrderType:       exchange.Limit,
+				OrderDate:       time.Unix(int64(order.TimestampCreated), 0),
+				Status:          strconv.Itoa(order.Status),
+				Price:           order.Rate,
+				Amount:          order.Amount,
+				RemainingAmount: order.Amount,
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (y *Yobit) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = y.GetEnabledCurrencies()
+	}
+
+	var since, end string
+	if !getOrdersRequest.StartTicks.IsZero() {
+		since = strconv.FormatInt(getOrdersRequest.StartTicks.Unix(), 10)
+	}
+	if !getOrdersRequest.EndTicks.IsZero() {
+		end = strconv.FormatInt(getOrdersRequest.EndTicks.Unix(), 10)
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		resp, err := y.GetTradeHistory(0, 1000, 0, "DESC", since, end, exchange.FormatExchangeCurrency(y.Name, currencies[x]).String())
+		if err != nil {
+			return nil, err
+		}
+
+		for tradeID, trade := range resp {
+			tradeDate := time.Unix(int64(trade.Timestamp), 0)
+			orders = append(orders, exchange.OrderDetail{
+				Exchange:       y.Name,
+				ID:             strconv.FormatFloat(trade.OrderID, 'f', -1, 64),
+				CurrencyPair:   currencies[x],
+				OrderSide:      exchange.FormatOrderSide(trade.Type),
+				OrderType:      exchange.Limit,
+				OrderDate:      tradeDate,
+				Status:         "FILLED",
+				Price:          trade.Rate,
+				Amount:         trade.Amount,
+				ExecutedAmount: trade.Amount,
+				Fills: []exchange.OrderFill{{
+					ID:        tradeID,
+					Timestamp: tradeDate,
+					Price:     trade.Rate,
+					Amount:    trade.Amount,
+				}},
+			})
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (y *Yobit) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -34,6 +34,10 @@ const (
 
 	zbAuthRate   = 100
 	zbUnauthRate = 100
+
+	// zbUnfinishedOrdersPageSize is the maximum page size accepted by
+	// getUnfinishedOrdersIgnoreTradeType
+	zbUnfinishedOrdersPageSize = 10
 )
 
 // ZB is the overarching type across this package
@@ -51,6 +55,15 @@ func (z *ZB) SetDefaults() {
 	z.Verbose = false
 	z.RESTPollingDelay = 10
 	z.APIWithdrawPermissions = exchange.AutoWithdrawCrypto
+	z.OrderSubmissionFeatures = exchange.LimitOrderSupport
+	z.Features = exchange.Features{
+		TickerFetching:    exchange.FeatureSupport{REST: true},
+		OrderbookFetching: exchange.FeatureSupport{REST: true},
+		SubmitOrder:       exchange.FeatureSupport{REST: true},
+		CancelOrder:       exchange.FeatureSupport{REST: true},
+		AccountInfo:       exchange.FeatureSupport{REST: true},
+		FeeFetching:       exchange.FeatureSupport{REST: true},
+	}
 	z.RequestCurrencyPairFormat.Delimiter = "_"
 	z.RequestCurrencyPairFormat.Uppercase = false
 	z.ConfigCurrencyPairFormat.Delimiter = "_"
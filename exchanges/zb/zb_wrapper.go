@@ -5,10 +5,12 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -124,28 +126,33 @@ func (z *ZB) GetAccountInfo() (exchange.AccountInfo, error) {
 		return info, err
 	}
 
+	info.ExchangeName = z.GetName()
+	info.Currencies, err = accountCurrencies(bal)
+	return info, err
+}
+
+// accountCurrencies converts the account coins into available and frozen
+// balances
+func accountCurrencies(bal AccountsResponse) ([]exchange.AccountCurrencyInfo, error) {
 	var balances []exchange.AccountCurrencyInfo
 	for _, data := range bal.Result.Coins {
 		hold, err := strconv.ParseFloat(data.Freez, 64)
 		if err != nil {
-			return info, err
+			return nil, err
 		}
 
 		avail, err := strconv.ParseFloat(data.Available, 64)
 		if err != nil {
-			return info, err
+			return nil, err
 		}
 
 		balances = append(balances, exchange.AccountCurrencyInfo{
 			CurrencyName: data.EnName,
-			TotalValue:   hold + avail,
+			TotalValue:   avail,
 			Hold:         hold,
 		})
 	}
-
-	info.ExchangeName = z.GetName()
-	info.Currencies = balances
-	return info, nil
+	return balances, nil
 }
 
 // GetFundingHistory returns funding history, deposits and
@@ -155,28 +162,38 @@ func (z *ZB) GetFundingHistory() ([]exchange.FundHistory, error) {
 	return fundHistory, common.ErrFunctionNotSupported
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
-func (z *ZB) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+// GetExchangeHistory returns historic trade data within the timeframe provided.
+func (z *ZB) GetExchangeHistory(tradeHistoryRequest exchange.TradeHistoryRequest) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
 	return resp, common.ErrNotYetImplemented
 }
 
+// GetHistoricCandles returns candles between a time period for a set time
+// interval
+func (z *ZB) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+	return kline.Item{}, common.ErrNotYetImplemented
+}
+
 // SubmitOrder submits a new order
-func (z *ZB) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+func (z *ZB) SubmitOrder(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
 	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := order.Validate(z.GetOrderSubmissionFeatures()); err != nil {
+		return submitOrderResponse, err
+	}
+
 	var oT SpotNewOrderRequestParamsType
 
-	if side == exchange.Buy {
+	if order.OrderSide == exchange.Buy {
 		oT = SpotNewOrderRequestParamsTypeBuy
 	} else {
 		oT = SpotNewOrderRequestParamsTypeSell
 	}
 
 	var params = SpotNewOrderRequestParams{
-		Amount: amount,
-		Price:  price,
-		Symbol: common.StringToLower(p.Pair().String()),
+		Amount: order.Amount,
+		Price:  order.Price,
+		Symbol: common.StringToLower(order.CurrencyPair.Pair().String()),
 		Type:   oT,
 	}
 	response, err := z.SpotNewOrder(params)
@@ -242,6 +259,61 @@ func (z *ZB) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves any orders that are active/open
+func (z *ZB) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	currencies := getOrdersRequest.Currencies
+	if len(currencies) == 0 {
+		currencies = z.GetEnabledCurrencies()
+	}
+
+	var orders []exchange.OrderDetail
+	for x := range currencies {
+		symbol := exchange.FormatExchangeCurrency(z.Name, currencies[x]).String()
+		for page := 1; ; page++ {
+			resp, err := z.GetUnfinishedOrdersIgnoreTradeType(symbol,
+				strconv.Itoa(page),
+				strconv.Itoa(zbUnfinishedOrdersPageSize))
+			if err != nil {
+				return nil, err
+			}
+
+			for i := range resp {
+				side := exchange.Sell
+				if resp[i].Type == 1 {
+					side = exchange.Buy
+				}
+
+				orders = append(orders, exchange.OrderDetail{
+					Exchange:        z.Name,
+					ID:              strconv.FormatInt(resp[i].ID, 10),
+					CurrencyPair:    currencies[x],
+					OrderSide:       side,
+					OrderType:       exchange.Limit,
+					OrderDate:       time.Unix(0, int64(resp[i].TradeDate)*int64(time.Millisecond)),
+					Status:          strconv.Itoa(resp[i].Status),
+					Price:           float64(resp[i].Price),
+					Amount:          resp[i].TotalAmount,
+					ExecutedAmount:  float64(resp[i].TradeAmount),
+					RemainingAmount: resp[i].TotalAmount - float64(resp[i].TradeAmount),
+				})
+			}
+
+			// A short page means there are no further open orders
+			if len(resp) < zbUnfinishedOrdersPageSize {
+				break
+			}
+		}
+	}
+
+	return exchange.FilterOrders(orders, getOrdersRequest), nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (z *ZB) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return nil, common.ErrNotYetImplemented
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (z *ZB) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
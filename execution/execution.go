@@ -0,0 +1,526 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	defaultInterval = time.Second * 5
+	// vwapLookbackDays is the number of previous days the VWAP volume profile
+	// is built from
+	vwapLookbackDays = 5
+	// dust is the amount below which an execution is treated as filled
+	dust = 1e-8
+)
+
+// minInterval is the shortest time allowed between child orders
+var minInterval = time.Second
+
+// vars related to execution algorithms
+var (
+	ErrExchangeNotFound      = errors.New("exchange not found")
+	ErrInvalidAmount         = errors.New("order amount must be above zero")
+	ErrInvalidSide           = errors.New("order side must be buy or sell")
+	ErrUnknownAlgorithm      = errors.New("unknown execution algorithm")
+	ErrInvalidSchedule       = errors.New("duration and slices must be above zero and give slices of at least one second")
+	ErrInvalidInterval       = errors.New("interval must be at least one second")
+	ErrDisplayAmountRequired = errors.New("display amount must be above zero")
+	ErrLimitPriceRequired    = errors.New("limit price must be set")
+	ErrInvalidParticipation  = errors.New("participation must be above zero and at most 100 percent")
+	ErrExecutionNotFound     = errors.New("execution not found")
+	ErrExecutionNotRunning   = errors.New("execution is not running")
+)
+
+// NewManager returns an execution manager for the exchanges
+func NewManager(exchanges []exchange.IBotExchange) *Manager {
+	return &Manager{
+		exchanges:  exchanges,
+		executions: make(map[string]*execution),
+	}
+}
+
+// Start validates a request and starts executing it in the background,
+// returning the ID used to track and cancel it
+func (m *Manager) Start(req Request) (string, error) {
+	if req.Amount <= 0 {
+		return "", ErrInvalidAmount
+	}
+	if req.Side != exchange.Buy && req.Side != exchange.Sell {
+		return "", ErrInvalidSide
+	}
+
+	every := req.Interval
+	if every <= 0 {
+		every = defaultInterval
+	}
+	if every < minInterval {
+		return "", ErrInvalidInterval
+	}
+	switch req.Algorithm {
+	case TWAP, VWAP:
+		if req.Duration <= 0 || req.Slices <= 0 ||
+			req.Duration/time.Duration(req.Slices) < minInterval {
+			return "", ErrInvalidSchedule
+		}
+		every = req.Duration / time.Duration(req.Slices)
+	case Iceberg:
+		if req.DisplayAmount <= 0 {
+			return "", ErrDisplayAmountRequired
+		}
+		if req.LimitPrice <= 0 {
+			return "", ErrLimitPriceRequired
+		}
+	case POV:
+		if req.Participation <= 0 || req.Participation > 100 {
+			return "", ErrInvalidParticipation
+		}
+	default:
+		return "", ErrUnknownAlgorithm
+	}
+
+	exch := m.getExchange(req.Exchange)
+	if exch == nil {
+		return "", ErrExchangeNotFound
+	}
+
+	e := &execution{
+		req:     req,
+		exch:    exch,
+		every:   every,
+		status:  Running,
+		started: time.Now(),
+		cancel:  make(chan struct{}),
+	}
+	switch req.Algorithm {
+	case TWAP:
+		e.schedule = cumulative(make([]float64, req.Slices))
+	case VWAP:
+		e.schedule = volumeSchedule(exch, req, e.started)
+	}
+
+	m.mtx.Lock()
+	m.nextID++
+	e.seq = m.nextID
+	e.id = strconv.FormatInt(e.seq, 10)
+	m.executions[e.id] = e
+	m.wg.Add(1)
+	m.mtx.Unlock()
+
+	log.Printf("Execution %s started: %s", e.id, e.req.String())
+	go m.run(e)
+	return e.id, nil
+}
+
+// Cancel stops a running execution, cancelling its open child order
+func (m *Manager) Cancel(id string) error {
+	m.mtx.Lock()
+	e, ok := m.executions[id]
+	m.mtx.Unlock()
+	if !ok {
+		return ErrExecutionNotFound
+	}
+	if !e.stop() {
+		return ErrExecutionNotRunning
+	}
+	return nil
+}
+
+// Get returns the progress of an execution
+func (m *Manager) Get(id string) (Progress, error) {
+	m.mtx.Lock()
+	e, ok := m.executions[id]
+	m.mtx.Unlock()
+	if !ok {
+		return Progress{}, ErrExecutionNotFound
+	}
+	return e.progress(), nil
+}
+
+// GetAll returns the progress of every execution, oldest first
+func (m *Manager) GetAll() []Progress {
+	m.mtx.Lock()
+	executions := make([]*execution, 0, len(m.executions))
+	for _, e := range m.executions {
+		executions = append(executions, e)
+	}
+	m.mtx.Unlock()
+
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].seq < executions[j].seq
+	})
+	result := make([]Progress, 0, len(executions))
+	for _, e := range executions {
+		result = append(result, e.progress())
+	}
+	return result
+}
+
+// ProcessTrade adds the volume of a trade from the live trade stream to the
+// running POV executions of its exchange currency pair
+func (m *Manager) ProcessTrade(trade exchange.TradeData) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, e := range m.executions {
+		if e.req.Algorithm != POV ||
+			common.StringToLower(e.exch.GetName()) != common.StringToLower(trade.Exchange) ||
+			!e.req.Pair.Equal(trade.CurrencyPair, true) {
+			continue
+		}
+		e.mtx.Lock()
+		if e.status == Running {
+			e.traded += trade.Amount
+		}
+		e.mtx.Unlock()
+	}
+}
+
+// Stop cancels every running execution and waits for them to finish
+func (m *Manager) Stop() {
+	m.mtx.Lock()
+	for _, e := range m.executions {
+		e.stop()
+	}
+	m.mtx.Unlock()
+	m.wg.Wait()
+}
+
+// getExchange returns the loaded exchange by name
+func (m *Manager) getExchange(name string) exchange.IBotExchange {
+	for _, exch := range m.exchanges {
+		if common.StringToLower(exch.GetName()) == common.StringToLower(name) {
+			return exch
+		}
+	}
+	return nil
+}
+
+// run places the child orders of an execution until it completes, fails or
+// is cancelled
+func (m *Manager) run(e *execution) {
+	defer m.wg.Done()
+	tick := time.NewTicker(e.every)
+	defer tick.Stop()
+
+	for slice := 0; ; slice++ {
+		done, err := e.step(slice)
+		if err != nil {
+			e.settle(true)
+			e.finish(Failed, err)
+			return
+		}
+		if done {
+			e.finish(Completed, nil)
+			return
+		}
+
+		select {
+		case <-e.cancel:
+			if err = e.settle(true); err != nil {
+				err = fmt.Errorf("failed to cancel child order: %s", err)
+			}
+			e.finish(Cancelled, err)
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+// step places the next child order of an execution and reports whether the
+// execution is complete
+func (e *execution) step(slice int) (bool, error) {
+	switch e.req.Algorithm {
+	case TWAP, VWAP:
+		// an unfilled limit order is cancelled and its remainder carried into
+		// the next slice. Anything left unfilled after the last slice is not
+		// executed
+		if err := e.settle(true); err != nil {
+			return false, err
+		}
+		if slice >= len(e.schedule) {
+			return true, nil
+		}
+		if err := e.place(e.req.Amount*e.schedule[slice] - e.filled()); err != nil {
+			return false, err
+		}
+		return slice == len(e.schedule)-1 && !e.hasOpenChild(), nil
+
+	case Iceberg:
+		if err := e.settle(false); err != nil {
+			return false, err
+		}
+		if e.hasOpenChild() {
+			return false, nil
+		}
+		remaining := e.req.Amount - e.filled()
+		if remaining <= dust {
+			return true, nil
+		}
+		return false, e.place(math.Min(e.req.DisplayAmount, remaining))
+
+	case POV:
+		if err := e.settle(true); err != nil {
+			return false, err
+		}
+		if e.req.Amount-e.filled() <= dust {
+			return true, nil
+		}
+		e.mtx.Lock()
+		target := math.Min(e.req.Amount, e.traded*e.req.Participation/100)
+		e.mtx.Unlock()
+		return false, e.place(target - e.filled())
+	}
+	return false, ErrUnknownAlgorithm
+}
+
+// place submits a child order. Market orders are settled once placed so that
+// their filled amount is the amount reported by the exchange
+func (e *execution) place(amount float64) error {
+	if amount <= dust {
+		return nil
+	}
+	orderType := exchange.Market
+	if e.req.LimitPrice > 0 {
+		orderType = exchange.Limit
+	}
+	resp, err := e.exch.SubmitOrder(&exchange.OrderSubmission{
+		CurrencyPair: e.req.Pair,
+		OrderSide:    e.req.Side,
+		OrderType:    orderType,
+		Price:        e.req.LimitPrice,
+		Amount:       amount,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.IsOrderPlaced {
+		return fmt.Errorf("child order of %f was not placed", amount)
+	}
+
+	e.mtx.Lock()
+	e.children = append(e.children, Child{
+		OrderID: resp.OrderID,
+		Time:    time.Now(),
+		Amount:  amount,
+		Open:    true,
+	})
+	e.mtx.Unlock()
+	if orderType == exchange.Market {
+		return e.settle(false)
+	}
+	return nil
+}
+
+// settle updates the filled amount of the open child order from the active
+// orders of the exchange, cancelling it if requested. The filled amount of a
+// child order no longer active is looked up on the exchange, as it may have
+// been rejected, cancelled or expired
+func (e *execution) settle(cancel bool) error {
+	e.mtx.Lock()
+	index := len(e.children) - 1
+	if index < 0 || !e.children[index].Open {
+		e.mtx.Unlock()
+		return nil
+	}
+	child := e.children[index]
+	e.mtx.Unlock()
+
+	orders, err := e.exch.GetActiveOrders(exchange.GetOrdersRequest{
+		Currencies: []pair.CurrencyPair{e.req.Pair},
+	})
+	if err != nil {
+		return err
+	}
+	var filled float64
+	open := false
+	for i := range orders {
+		if orders[i].ID == child.OrderID {
+			filled, open = orders[i].ExecutedAmount, true
+			break
+		}
+	}
+	if open && cancel {
+		err = e.exch.CancelOrder(exchange.OrderCancellation{
+			OrderID:      child.OrderID,
+			CurrencyPair: e.req.Pair,
+			Side:         e.req.Side,
+		})
+		if err != nil {
+			return err
+		}
+		open = false
+		// the order may have filled further before it was cancelled
+		if executed, err := e.executed(child.OrderID); err == nil {
+			filled = executed
+		}
+	} else if !open {
+		filled, err = e.executed(child.OrderID)
+		if err != nil {
+			return fmt.Errorf("unable to determine fill of child order %s: %s",
+				child.OrderID, err)
+		}
+	}
+
+	e.mtx.Lock()
+	e.children[index].Filled = filled
+	e.children[index].Open = open
+	e.mtx.Unlock()
+	return nil
+}
+
+// executed returns the executed amount of an order which is no longer active
+// from its order history, active orders or order info on the exchange
+func (e *execution) executed(orderID string) (float64, error) {
+	detail, err := exchange.GetOrderDetail(e.exch, orderID, e.req.Pair)
+	if err != nil {
+		return 0, err
+	}
+	return detail.ExecutedAmount, nil
+}
+
+// stop signals a running execution to cancel, returning false if it is not
+// running or already cancelling
+func (e *execution) stop() bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.status != Running || e.cancelled {
+		return false
+	}
+	e.cancelled = true
+	close(e.cancel)
+	return true
+}
+
+// finish records the final status of an execution
+func (e *execution) finish(status Status, err error) {
+	e.mtx.Lock()
+	e.status = status
+	e.ended = time.Now()
+	if err != nil {
+		e.err = err.Error()
+	}
+	e.mtx.Unlock()
+
+	if err != nil {
+		log.Printf("Execution %s %s: %s", e.id, status, err)
+		return
+	}
+	log.Printf("Execution %s %s, filled %f of %f", e.id, status, e.filled(), e.req.Amount)
+}
+
+// filled returns the amount filled by the child orders
+func (e *execution) filled() float64 {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	var filled float64
+	for i := range e.children {
+		filled += e.children[i].Filled
+	}
+	return filled
+}
+
+// hasOpenChild returns whether the last child order is still open
+func (e *execution) hasOpenChild() bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return len(e.children) > 0 && e.children[len(e.children)-1].Open
+}
+
+// progress returns a snapshot of the execution
+func (e *execution) progress() Progress {
+	filled := e.filled()
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	children := make([]Child, len(e.children))
+	copy(children, e.children)
+	return Progress{
+		ID:        e.id,
+		Request:   e.req,
+		Status:    e.status,
+		Filled:    filled,
+		Remaining: math.Max(e.req.Amount-filled, 0),
+		Children:  children,
+		Started:   e.started,
+		Ended:     e.ended,
+		Error:     e.err,
+	}
+}
+
+// volumeSchedule returns the cumulative fraction of the amount to fill after
+// each slice, weighted by the volume traded during the same window on each of
+// the previous vwapLookbackDays days. Equal slices are used when no historic
+// volume is available
+func volumeSchedule(exch exchange.IBotExchange, req Request, start time.Time) []float64 {
+	weights := make([]float64, req.Slices)
+	length := req.Duration / time.Duration(req.Slices)
+	interval := candleInterval(length)
+	for day := 1; day <= vwapLookbackDays; day++ {
+		from := start.Add(-time.Duration(day) * time.Hour * 24)
+		item, err := exch.GetHistoricCandles(req.Pair, ticker.Spot, interval, from, from.Add(req.Duration))
+		if err != nil {
+			continue
+		}
+		for _, c := range item.Candles {
+			offset := c.Time.Sub(from)
+			if offset < 0 || offset >= req.Duration {
+				continue
+			}
+			index := int(offset / length)
+			if index >= req.Slices {
+				index = req.Slices - 1
+			}
+			weights[index] += c.Volume
+		}
+	}
+	return cumulative(weights)
+}
+
+// candleInterval returns the largest candle interval, of at least one minute,
+// which fits within a slice
+func candleInterval(length time.Duration) kline.Interval {
+	interval := kline.OneMin
+	for _, i := range []kline.Interval{kline.FiveMin, kline.FifteenMin, kline.OneHour} {
+		if i.Duration() <= length {
+			interval = i
+		}
+	}
+	return interval
+}
+
+// cumulative converts slice weights into cumulative fractions of the total,
+// using equal weights when the total is zero
+func cumulative(weights []float64) []float64 {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	schedule := make([]float64, len(weights))
+	var sum float64
+	for i := range weights {
+		if total > 0 {
+			sum += weights[i] / total
+		} else {
+			sum = float64(i+1) / float64(len(weights))
+		}
+		schedule[i] = sum
+	}
+	schedule[len(schedule)-1] = 1
+	return schedule
+}
+
+// String returns a summary of the request
+func (r Request) String() string {
+	return fmt.Sprintf("%s %s %f %s on %s", r.Algorithm, r.Side, r.Amount,
+		r.Pair.Pair().String(), r.Exchange)
+}
@@ -0,0 +1,385 @@
+package execution
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+)
+
+var btcusd = pair.NewCurrencyPair("BTC", "USD")
+
+func init() {
+	minInterval = time.Millisecond
+}
+
+func newTestExchange() *exchangetest.Exchange {
+	return exchangetest.New("ExecutionTest", btcusd)
+}
+
+// fillAll completes an active order on the exchange
+func fillAll(exch *exchangetest.Exchange, id string) {
+	index, _ := strconv.Atoi(id)
+	exch.Fill(id, exch.Submitted()[index-1].Amount)
+}
+
+func isClose(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// waitFor polls an execution until check passes
+func waitFor(t *testing.T, m *Manager, id string, check func(Progress) bool) Progress {
+	deadline := time.Now().Add(time.Second * 5)
+	for {
+		p, err := m.Get(id)
+		if err != nil {
+			t.Fatalf("Test failed. Get error: %s", err)
+		}
+		if check(p) {
+			return p
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Test failed. Timed out waiting for execution %+v", p)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func finished(p Progress) bool {
+	return p.Status != Running
+}
+
+func TestStartErrors(t *testing.T) {
+	m := NewManager([]exchange.IBotExchange{newTestExchange()})
+	valid := Request{Exchange: "executiontest", Pair: btcusd, Side: exchange.Buy, Amount: 1}
+
+	tests := []struct {
+		modify func(*Request)
+		err    error
+	}{
+		{func(r *Request) { r.Algorithm = TWAP; r.Amount = 0 }, ErrInvalidAmount},
+		{func(r *Request) { r.Algorithm = TWAP; r.Side = "" }, ErrInvalidSide},
+		{func(r *Request) { r.Algorithm = "SNIPER" }, ErrUnknownAlgorithm},
+		{func(r *Request) { r.Algorithm = VWAP; r.Duration = time.Minute }, ErrInvalidSchedule},
+		{func(r *Request) { r.Algorithm = TWAP; r.Duration = time.Nanosecond; r.Slices = 2 }, ErrInvalidSchedule},
+		{func(r *Request) { r.Algorithm = POV; r.Participation = 5; r.Interval = time.Nanosecond }, ErrInvalidInterval},
+		{func(r *Request) { r.Algorithm = Iceberg; r.LimitPrice = 100 }, ErrDisplayAmountRequired},
+		{func(r *Request) { r.Algorithm = Iceberg; r.DisplayAmount = 0.1 }, ErrLimitPriceRequired},
+		{func(r *Request) { r.Algorithm = POV; r.Participation = 101 }, ErrInvalidParticipation},
+		{func(r *Request) { r.Algorithm = POV; r.Participation = 5; r.Exchange = "missing" }, ErrExchangeNotFound},
+	}
+	for i, test := range tests {
+		req := valid
+		test.modify(&req)
+		if _, err := m.Start(req); err != test.err {
+			t.Errorf("Test failed. Test %d expected %s got %v", i, test.err, err)
+		}
+	}
+
+	if _, err := m.Get("1"); err != ErrExecutionNotFound {
+		t.Errorf("Test failed. Expected %s got %v", ErrExecutionNotFound, err)
+	}
+	if err := m.Cancel("1"); err != ErrExecutionNotFound {
+		t.Errorf("Test failed. Expected %s got %v", ErrExecutionNotFound, err)
+	}
+}
+
+func TestTWAP(t *testing.T) {
+	exch := newTestExchange()
+	m := NewManager([]exchange.IBotExchange{exch})
+	id, err := m.Start(Request{
+		Algorithm: TWAP,
+		Exchange:  "ExecutionTest",
+		Pair:      btcusd,
+		Side:      exchange.Sell,
+		Amount:    1.5,
+		Duration:  time.Millisecond * 15,
+		Slices:    3,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+
+	p := waitFor(t, m, id, finished)
+	if p.Status != Completed || !isClose(p.Filled, 1.5) || p.Remaining != 0 || len(p.Children) != 3 {
+		t.Errorf("Test failed. Unexpected progress %+v", p)
+	}
+	for _, o := range exch.Submitted() {
+		if o.OrderType != exchange.Market || o.OrderSide != exchange.Sell || !isClose(o.Amount, 0.5) {
+			t.Errorf("Test failed. Unexpected child order %+v", o)
+		}
+	}
+	if err = m.Cancel(id); err != ErrExecutionNotRunning {
+		t.Errorf("Test failed. Expected %s got %v", ErrExecutionNotRunning, err)
+	}
+}
+
+func TestMarketChildFills(t *testing.T) {
+	exch := newTestExchange()
+	// market child orders are only partially filled by the exchange
+	exch.SubmitOrderFunc = func(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+		id := strconv.Itoa(len(exch.History) + 1)
+		exch.History = append(exch.History, exchange.OrderDetail{
+			ID:             id,
+			CurrencyPair:   btcusd,
+			Amount:         order.Amount,
+			ExecutedAmount: order.Amount / 2,
+			Status:         exchangetest.StatusCancelled,
+		})
+		return exchange.SubmitOrderResponse{OrderID: id, IsOrderPlaced: true}, nil
+	}
+	m := NewManager([]exchange.IBotExchange{exch})
+	req := Request{
+		Algorithm: TWAP,
+		Exchange:  "ExecutionTest",
+		Pair:      btcusd,
+		Side:      exchange.Buy,
+		Amount:    1,
+		Duration:  time.Millisecond * 2,
+		Slices:    2,
+	}
+	id, err := m.Start(req)
+	if err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+	p := waitFor(t, m, id, finished)
+	if p.Status != Completed || !isClose(p.Filled, 0.625) || !isClose(p.Remaining, 0.375) ||
+		!isClose(p.Children[0].Filled, 0.25) || !isClose(p.Children[1].Amount, 0.75) {
+		t.Errorf("Test failed. Expected reported fills got %+v", p)
+	}
+
+	// an execution fails when the fill of a market child cannot be found
+	exch.GetOrderHistoryFunc = func(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+		return nil, nil
+	}
+	if id, err = m.Start(req); err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+	if p = waitFor(t, m, id, finished); p.Status != Failed || p.Filled != 0 {
+		t.Errorf("Test failed. Expected failed execution got %+v", p)
+	}
+}
+
+func TestTWAPCarriesUnfilledLimit(t *testing.T) {
+	exch := newTestExchange()
+	m := NewManager([]exchange.IBotExchange{exch})
+	id, err := m.Start(Request{
+		Algorithm:  TWAP,
+		Exchange:   "ExecutionTest",
+		Pair:       btcusd,
+		Side:       exchange.Buy,
+		Amount:     1,
+		LimitPrice: 100,
+		Duration:   time.Hour,
+		Slices:     2,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+	waitFor(t, m, id, func(p Progress) bool { return len(p.Children) == 1 })
+
+	// the first slice is partially filled when the execution is cancelled
+	exch.Fill("1", 0.2)
+	if err = m.Cancel(id); err != nil {
+		t.Fatalf("Test failed. Cancel error: %s", err)
+	}
+	p := waitFor(t, m, id, finished)
+	if p.Status != Cancelled || !isClose(p.Filled, 0.2) || !isClose(p.Remaining, 0.8) || p.Children[0].Open {
+		t.Errorf("Test failed. Unexpected progress %+v", p)
+	}
+	if cancelled := exch.Cancelled(); len(cancelled) != 1 || cancelled[0] != "1" {
+		t.Errorf("Test failed. Expected open child order to be cancelled got %v", cancelled)
+	}
+
+	s := &execution{
+		req:      Request{Algorithm: TWAP, Pair: btcusd, Amount: 1, LimitPrice: 100},
+		exch:     exch,
+		schedule: []float64{0.5, 1},
+	}
+	if done, err := s.step(0); done || err != nil {
+		t.Fatalf("Test failed. Unexpected first step %v %v", done, err)
+	}
+	exch.Fill("2", 0.1)
+	if done, err := s.step(1); done || err != nil {
+		t.Fatalf("Test failed. Unexpected second step %v %v", done, err)
+	}
+	if o := exch.Submitted(); !isClose(o[len(o)-1].Amount, 0.9) {
+		t.Errorf("Test failed. Expected unfilled amount to be carried got %f", o[len(o)-1].Amount)
+	}
+	if done, err := s.step(2); !done || err != nil {
+		t.Errorf("Test failed. Expected execution to complete after last slice %v %v", done, err)
+	}
+}
+
+func TestVolumeSchedule(t *testing.T) {
+	var candleErr error
+	candles := []kline.Candle{
+		{Time: time.Time{}, Volume: 1},
+		{Time: time.Time{}.Add(time.Minute), Volume: 2},
+		{Time: time.Time{}.Add(time.Minute * 3), Volume: 5},
+		{Time: time.Time{}.Add(time.Minute * 4), Volume: 100},
+	}
+	exch := newTestExchange()
+	exch.GetHistoricCandlesFunc = func(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) (kline.Item, error) {
+		if candleErr != nil {
+			return kline.Item{}, candleErr
+		}
+		var item kline.Item
+		for _, c := range candles {
+			c.Time = start.Add(c.Time.Sub(time.Time{}))
+			item.Candles = append(item.Candles, c)
+		}
+		return item, nil
+	}
+	req := Request{Pair: btcusd, Duration: time.Minute * 4, Slices: 2}
+
+	// the final candle falls outside of the window
+	schedule := volumeSchedule(exch, req, time.Now())
+	if len(schedule) != 2 || !isClose(schedule[0], 3.0/8) || schedule[1] != 1 {
+		t.Errorf("Test failed. Unexpected volume schedule %v", schedule)
+	}
+
+	candleErr = errors.New("candles unavailable")
+	schedule = volumeSchedule(exch, req, time.Now())
+	if len(schedule) != 2 || schedule[0] != 0.5 || schedule[1] != 1 {
+		t.Errorf("Test failed. Expected equal slices without volume got %v", schedule)
+	}
+
+	if candleInterval(time.Second) != kline.OneMin || candleInterval(time.Minute*20) != kline.FifteenMin {
+		t.Error("Test failed. Unexpected candle interval")
+	}
+}
+
+func TestIceberg(t *testing.T) {
+	exch := newTestExchange()
+	m := NewManager([]exchange.IBotExchange{exch})
+	id, err := m.Start(Request{
+		Algorithm:     Iceberg,
+		Exchange:      "ExecutionTest",
+		Pair:          btcusd,
+		Side:          exchange.Buy,
+		Amount:        1,
+		LimitPrice:    100,
+		DisplayAmount: 0.4,
+		Interval:      time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		waitFor(t, m, id, func(p Progress) bool { return len(p.Children) == i })
+		if o := exch.Submitted(); len(o) != i {
+			t.Fatalf("Test failed. Expected a single open child order got %d", len(o))
+		}
+		fillAll(exch, strconv.Itoa(i))
+	}
+
+	p := waitFor(t, m, id, finished)
+	if p.Status != Completed || !isClose(p.Filled, 1) || !isClose(p.Children[2].Amount, 0.2) {
+		t.Errorf("Test failed. Unexpected progress %+v", p)
+	}
+}
+
+func TestIcebergExpiredChild(t *testing.T) {
+	exch := newTestExchange()
+	m := NewManager([]exchange.IBotExchange{exch})
+	id, err := m.Start(Request{
+		Algorithm:     Iceberg,
+		Exchange:      "ExecutionTest",
+		Pair:          btcusd,
+		Side:          exchange.Buy,
+		Amount:        0.4,
+		LimitPrice:    100,
+		DisplayAmount: 0.4,
+		Interval:      time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+
+	// a child order which leaves the book partially filled is replaced with
+	// its remainder rather than counted as filled
+	waitFor(t, m, id, func(p Progress) bool { return len(p.Children) == 1 })
+	exch.Fill("1", 0.1)
+	exch.Expire("1")
+	p := waitFor(t, m, id, func(p Progress) bool { return len(p.Children) == 2 })
+	if p.Status != Running || !isClose(p.Filled, 0.1) || !isClose(p.Children[1].Amount, 0.3) {
+		t.Errorf("Test failed. Unexpected progress %+v", p)
+	}
+
+	if err = m.Cancel(id); err != nil {
+		t.Fatalf("Test failed. Cancel error: %s", err)
+	}
+	if p = waitFor(t, m, id, finished); p.Status != Cancelled || !isClose(p.Filled, 0.1) {
+		t.Errorf("Test failed. Unexpected progress %+v", p)
+	}
+}
+
+func TestPOV(t *testing.T) {
+	exch := newTestExchange()
+	m := NewManager([]exchange.IBotExchange{exch})
+	id, err := m.Start(Request{
+		Algorithm:     POV,
+		Exchange:      "ExecutionTest",
+		Pair:          btcusd,
+		Side:          exchange.Buy,
+		Amount:        1,
+		Participation: 5,
+		Interval:      time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+
+	m.ProcessTrade(exchange.TradeData{Exchange: "ExecutionTest", CurrencyPair: pair.NewCurrencyPair("LTC", "USD"), Amount: 100})
+	m.ProcessTrade(exchange.TradeData{Exchange: "ExecutionTest", CurrencyPair: btcusd, Amount: 10})
+	p := waitFor(t, m, id, func(p Progress) bool { return len(p.Children) == 1 })
+	if !isClose(p.Filled, 0.5) {
+		t.Errorf("Test failed. Expected child order to track participation got %+v", p)
+	}
+
+	m.ProcessTrade(exchange.TradeData{Exchange: "executiontest", CurrencyPair: btcusd, Amount: 30})
+	p = waitFor(t, m, id, finished)
+	if p.Status != Completed || !isClose(p.Filled, 1) || !isClose(p.Children[1].Amount, 0.5) {
+		t.Errorf("Test failed. Expected participation to be capped at amount got %+v", p)
+	}
+}
+
+func TestFailedAndStop(t *testing.T) {
+	exch := newTestExchange()
+	exch.SubmitErr = errors.New("rejected")
+	m := NewManager([]exchange.IBotExchange{exch})
+	req := Request{
+		Algorithm: TWAP,
+		Exchange:  "ExecutionTest",
+		Pair:      btcusd,
+		Side:      exchange.Buy,
+		Amount:    1,
+		Duration:  time.Hour,
+		Slices:    2,
+	}
+	id, err := m.Start(req)
+	if err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+	if p := waitFor(t, m, id, finished); p.Status != Failed || p.Error != "rejected" {
+		t.Errorf("Test failed. Expected failed execution got %+v", p)
+	}
+
+	exch.SubmitErr = nil
+	if _, err = m.Start(req); err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+	m.Stop()
+	all := m.GetAll()
+	if len(all) != 2 || all[0].ID != id || all[1].Status != Cancelled {
+		t.Errorf("Test failed. Expected stop to cancel running executions got %+v", all)
+	}
+}
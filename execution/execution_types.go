@@ -0,0 +1,118 @@
+package execution
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Algorithm is the method used to slice a parent order into child orders
+type Algorithm string
+
+// Execution algorithms
+const (
+	// TWAP splits the parent order into equal child orders spread evenly over
+	// a duration
+	TWAP Algorithm = "TWAP"
+	// VWAP splits the parent order over a duration in proportion to the
+	// volume historically traded during each slice
+	VWAP Algorithm = "VWAP"
+	// Iceberg places a single child order of the display amount at a time,
+	// replacing it once filled
+	Iceberg Algorithm = "ICEBERG"
+	// POV sizes child orders to track a percentage of the volume traded
+	// since the execution started
+	POV Algorithm = "POV"
+)
+
+// Status is the state of an execution
+type Status string
+
+// Execution statuses
+const (
+	Running   Status = "RUNNING"
+	Completed Status = "COMPLETED"
+	Cancelled Status = "CANCELLED"
+	Failed    Status = "FAILED"
+)
+
+// Request is a parent order to be sliced into child orders
+type Request struct {
+	Algorithm Algorithm          `json:"algorithm"`
+	Exchange  string             `json:"exchange"`
+	Pair      pair.CurrencyPair  `json:"pair"`
+	Side      exchange.OrderSide `json:"side"`
+	Amount    float64            `json:"amount"`
+	// LimitPrice places child orders as limit orders at the price, child
+	// orders are market orders when zero. Unfilled limit orders are cancelled
+	// before the next TWAP, VWAP or POV child order is placed
+	LimitPrice float64 `json:"limitPrice,omitempty"`
+	// Duration is the time TWAP and VWAP orders are spread over
+	Duration time.Duration `json:"duration,omitempty"`
+	// Slices is the number of child orders TWAP and VWAP orders are split
+	// into
+	Slices int `json:"slices,omitempty"`
+	// DisplayAmount is the size of each iceberg child order
+	DisplayAmount float64 `json:"displayAmount,omitempty"`
+	// Participation is the percentage of traded volume POV orders target
+	Participation float64 `json:"participation,omitempty"`
+	// Interval is how often iceberg and POV orders check their child order
+	// and the traded volume
+	Interval time.Duration `json:"interval,omitempty"`
+}
+
+// Child is an order placed by an execution
+type Child struct {
+	OrderID string    `json:"orderId"`
+	Time    time.Time `json:"time"`
+	Amount  float64   `json:"amount"`
+	Filled  float64   `json:"filled"`
+	Open    bool      `json:"open"`
+}
+
+// Progress is a snapshot of an execution
+type Progress struct {
+	ID        string    `json:"id"`
+	Request   Request   `json:"request"`
+	Status    Status    `json:"status"`
+	Filled    float64   `json:"filled"`
+	Remaining float64   `json:"remaining"`
+	Children  []Child   `json:"children"`
+	Started   time.Time `json:"started"`
+	Ended     time.Time `json:"ended,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Manager runs executions against its exchanges
+type Manager struct {
+	exchanges  []exchange.IBotExchange
+	executions map[string]*execution
+	nextID     int64
+	wg         sync.WaitGroup
+	mtx        sync.Mutex
+}
+
+// execution is a running or finished parent order
+type execution struct {
+	seq   int64
+	id    string
+	req   Request
+	exch  exchange.IBotExchange
+	every time.Duration
+	// schedule is the cumulative fraction of the amount to be filled after
+	// each TWAP or VWAP slice
+	schedule []float64
+	status   Status
+	children []Child
+	traded   float64
+	started  time.Time
+	ended    time.Time
+	err      string
+	// cancel is closed to stop the execution, cancelled records that it has
+	// been closed
+	cancel    chan struct{}
+	cancelled bool
+	mtx       sync.Mutex
+}
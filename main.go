@@ -11,27 +11,44 @@ import (
 	"strconv"
 	"syscall"
 
+	"github.com/thrasher-/gocryptotrader/arbitrage"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/communications"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
 	"github.com/thrasher-/gocryptotrader/currency/forexprovider"
+	"github.com/thrasher-/gocryptotrader/events"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/conditional"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/exchanges/risk"
+	"github.com/thrasher-/gocryptotrader/execution"
+	"github.com/thrasher-/gocryptotrader/orderrouter"
 	"github.com/thrasher-/gocryptotrader/portfolio"
+	"github.com/thrasher-/gocryptotrader/recorder"
+	"github.com/thrasher-/gocryptotrader/strategy"
 )
 
 // Bot contains configuration, portfolio, exchange & ticker data and is the
 // overarching type across this code base.
 type Bot struct {
-	config     *config.Config
-	portfolio  *portfolio.Base
-	exchanges  []exchange.IBotExchange
-	comms      *communications.Communications
-	shutdown   chan bool
-	dryRun     bool
-	configFile string
-	dataDir    string
-	logFile    string
+	config       *config.Config
+	portfolio    *portfolio.Base
+	exchanges    []exchange.IBotExchange
+	comms        *communications.Communications
+	orderManager *orders.Manager
+	conditional  *conditional.Manager
+	risk         *risk.Manager
+	recorder     *recorder.Recorder
+	strategies   *strategy.Runtime
+	arbitrage    *arbitrage.Scanner
+	orderRouter  *orderrouter.Router
+	execution    *execution.Manager
+	shutdown     chan bool
+	dryRun       bool
+	configFile   string
+	dataDir      string
+	logFile      string
 }
 
 const banner = `
@@ -107,14 +124,64 @@ func main() {
 	common.HTTPClient = common.NewHTTPClientWithTimeout(bot.config.GlobalHTTPTimeout)
 	log.Printf("Global HTTP request timeout: %v.\n", common.HTTPClient.Timeout)
 
+	bot.orderManager = orders.NewManager(bot.dataDir)
+	bot.orderManager.SetFillHandler(processFill)
+	err = bot.orderManager.Load()
+	if err != nil {
+		log.Fatalf("Failed to load orders from %s. Err: %s", bot.orderManager.GetFilePath(), err)
+	}
+	log.Printf("Loaded %d orders from %s.\n", len(bot.orderManager.GetOrders()), bot.orderManager.GetFilePath())
+
+	bot.risk = risk.NewManager(bot.dataDir)
+	err = bot.risk.Load()
+	if err != nil {
+		log.Fatalf("Failed to load risk profit and loss from %s. Err: %s", bot.risk.GetFilePath(), err)
+	}
+
+	if bot.config.Recorder.Enabled {
+		bot.recorder = recorder.New(bot.dataDir, bot.config.GetRecorderConfig(), bot.config.Exchanges)
+		log.Printf("Market data recorder enabled. Using directory: %s.\n", bot.recorder.GetDirectory())
+	} else {
+		log.Println("Market data recorder disabled.")
+	}
+
 	SetupExchanges()
 	if len(bot.exchanges) == 0 {
 		log.Fatalf("No exchanges were able to be loaded. Exiting")
 	}
 
+	bot.orderRouter = orderrouter.New(bot.exchanges, IsRelatablePairs, currency.ConvertCurrency)
+	bot.execution = execution.NewManager(bot.exchanges)
+
+	bot.conditional = conditional.NewManager(bot.dataDir, bot.exchanges)
+	err = bot.conditional.Load()
+	if err != nil {
+		log.Fatalf("Failed to load conditional orders from %s. Err: %s", bot.conditional.GetFilePath(), err)
+	}
+	log.Printf("Loaded %d conditional orders from %s.\n", len(bot.conditional.GetOrders()), bot.conditional.GetFilePath())
+
+	err = events.Load(bot.dataDir)
+	if err != nil {
+		log.Fatalf("Failed to load events from %s. Err: %s", events.GetFilePath(), err)
+	}
+	log.Printf("Loaded %d events from %s.\n", len(events.GetEvents()), events.GetFilePath())
+
+	log.Println("Reconciling orders with exchanges..")
+	ReconcileOrders()
+
 	log.Println("Starting communication mediums..")
 	bot.comms = communications.NewComm(bot.config.GetCommunicationsConfig())
 	bot.comms.GetEnabledCommunicationMediums()
+	bot.comms.SetExchanges(bot.exchanges)
+	bot.risk.SetComms(bot.comms)
+	bot.conditional.SetComms(bot.comms)
+	err = bot.conditional.Start()
+	if err != nil {
+		log.Fatalf("Failed to start conditional orders. Err: %s", err)
+	}
+	events.SetComms(bot.comms)
+	events.SetExchanges(bot.exchanges)
+	events.Start()
 
 	log.Printf("Fiat display currency: %s.", bot.config.Currency.FiatDisplayCurrency)
 	currency.BaseCurrency = bot.config.Currency.FiatDisplayCurrency
@@ -157,8 +224,44 @@ func main() {
 		log.Println("HTTP RESTful Webserver support disabled.")
 	}
 
+	bot.strategies, err = strategy.New(bot.config.GetStrategiesConfig(), bot.exchanges)
+	if err != nil {
+		log.Fatalf("Failed to load strategies. Err: %s", err)
+	}
+	if names := bot.strategies.GetStrategies(); len(names) > 0 {
+		log.Printf("Starting strategies: %s.\n", common.JoinStrings(names, ", "))
+		err = bot.strategies.Start()
+		if err != nil {
+			log.Fatalf("Failed to start strategies. Err: %s", err)
+		}
+	} else {
+		log.Println("No strategies enabled.")
+	}
+
+	if bot.config.Arbitrage.Enabled || bot.config.Arbitrage.Triangular.Enabled {
+		bot.arbitrage = arbitrage.New(bot.config.GetArbitrageConfig(), bot.exchanges,
+			IsRelatablePairs, currency.ConvertCurrency)
+		bot.arbitrage.SetComms(bot.comms)
+		if bot.config.Webserver.Enabled {
+			bot.arbitrage.SetHandler(func(o []arbitrage.Opportunity) {
+				relayWebsocketEvent(o, "arbitrage_opportunities", "", "")
+			})
+			bot.arbitrage.SetTriangularHandler(func(o []arbitrage.TriangularOpportunity) {
+				relayWebsocketEvent(o, "triangular_arbitrage_opportunities", "", "")
+			})
+		}
+		err = bot.arbitrage.Start()
+		if err != nil {
+			log.Fatalf("Failed to start arbitrage scanner. Err: %s", err)
+		}
+		log.Println("Arbitrage scanner started.")
+	} else {
+		log.Println("Arbitrage scanner disabled.")
+	}
+
 	go portfolio.StartPortfolioWatcher()
 
+	go OrderReconcilerRoutine()
 	go TickerUpdaterRoutine()
 	go OrderbookUpdaterRoutine()
 	go WebsocketRoutine(*verbosity)
@@ -219,6 +322,63 @@ func Shutdown() {
 		}
 	}
 
+	if bot.strategies != nil {
+		bot.strategies.Stop()
+		log.Println("Strategies stopped.")
+	}
+
+	if bot.arbitrage != nil {
+		bot.arbitrage.Stop()
+		log.Println("Arbitrage scanner stopped.")
+	}
+
+	if bot.execution != nil {
+		bot.execution.Stop()
+		log.Println("Order executions stopped.")
+	}
+
+	for x := range bot.exchanges {
+		if s, ok := bot.exchanges[x].(exchange.Stopper); ok {
+			s.Stop()
+		}
+	}
+
+	events.Stop()
+	err := events.Save()
+	if err != nil {
+		log.Printf("Unable to save events. Err: %s", err)
+	} else {
+		log.Println("Events saved successfully.")
+	}
+
+	if bot.conditional != nil {
+		bot.conditional.Stop()
+		err := bot.conditional.Save()
+		if err != nil {
+			log.Printf("Unable to save conditional orders. Err: %s", err)
+		} else {
+			log.Println("Conditional orders saved successfully.")
+		}
+	}
+
+	if bot.orderManager != nil {
+		err := bot.orderManager.Save()
+		if err != nil {
+			log.Printf("Unable to save orders. Err: %s", err)
+		} else {
+			log.Println("Orders saved successfully.")
+		}
+	}
+
+	if bot.recorder != nil {
+		err := bot.recorder.Close()
+		if err != nil {
+			log.Printf("Unable to close market data recorder. Err: %s", err)
+		} else {
+			log.Println("Market data recordings saved successfully.")
+		}
+	}
+
 	log.Println("Exiting.")
 
 	if logFileHandle != nil {
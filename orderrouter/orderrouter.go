@@ -0,0 +1,375 @@
+package orderrouter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// vars related to the order router
+var (
+	ErrInvalidAmount   = errors.New("order amount must be above zero")
+	ErrInvalidSide     = errors.New("order side must be buy or sell")
+	ErrNoLiquidity     = errors.New("no liquidity available to route order")
+	ErrNoOrdersPlaced  = errors.New("no child orders were placed")
+	ErrNoOrdersFilled  = errors.New("no child orders were filled")
+	ErrNoExchangeMatch = errors.New("no loaded exchanges match the requested exchanges")
+)
+
+// New returns a router for the exchanges. relatable decides which currency
+// pairs can fill a request and convert normalises differing fiat quote
+// currencies
+func New(exchanges []exchange.IBotExchange, relatable RelatableFunc, convert ConvertFunc) *Router {
+	return &Router{
+		exchanges: exchanges,
+		relatable: relatable,
+		convert:   convert,
+	}
+}
+
+// Plan builds a consolidated orderbook from the orderbook cache of every
+// exchange trading the requested pair and splits the request across the best
+// levels after fees, limited by the balances available on each exchange. No
+// orders are submitted
+func (r *Router) Plan(req Request) (Report, error) {
+	if req.Amount <= 0 {
+		return Report{}, ErrInvalidAmount
+	}
+	if req.Side != exchange.Buy && req.Side != exchange.Sell {
+		return Report{}, ErrInvalidSide
+	}
+
+	levels, budgets, err := r.getLevels(req)
+	if err != nil {
+		return Report{}, err
+	}
+	sort.SliceStable(levels, func(i, j int) bool {
+		if req.Side == exchange.Buy {
+			return levels[i].effective < levels[j].effective
+		}
+		return levels[i].effective > levels[j].effective
+	})
+
+	report := Report{Pair: req.Pair, Side: req.Side, Requested: req.Amount}
+	children := make(map[*market]int)
+	remaining := req.Amount
+	for i := range levels {
+		if remaining <= 0 {
+			break
+		}
+		l := &levels[i]
+		m := l.market
+		converted := l.price * m.rate
+		if req.LimitPrice > 0 &&
+			((req.Side == exchange.Buy && converted > req.LimitPrice) ||
+				(req.Side == exchange.Sell && converted < req.LimitPrice)) {
+			continue
+		}
+
+		budgetKey := m.exchange.GetName() + m.spend
+		qty := l.amount
+		if remaining < qty {
+			qty = remaining
+		}
+		if req.Side == exchange.Buy {
+			if affordable := budgets[budgetKey] / (l.price * (1 + m.fee)); affordable < qty {
+				qty = affordable
+			}
+			budgets[budgetKey] -= qty * l.price * (1 + m.fee)
+		} else {
+			if budgets[budgetKey] < qty {
+				qty = budgets[budgetKey]
+			}
+			budgets[budgetKey] -= qty
+		}
+		if qty <= 0 {
+			continue
+		}
+		remaining -= qty
+
+		index, ok := children[m]
+		if !ok {
+			index = len(report.Children)
+			children[m] = index
+			report.Children = append(report.Children, ChildOrder{
+				Exchange: m.exchange.GetName(),
+				Pair:     m.pair,
+				Side:     req.Side,
+				rate:     m.rate,
+				fee:      m.fee,
+				ioc:      m.ioc,
+			})
+		}
+		c := &report.Children[index]
+		c.Price = l.price
+		c.Amount += qty
+		c.Value += qty * converted
+		c.Fee += qty * converted * m.fee
+	}
+
+	if len(report.Children) == 0 {
+		return report, ErrNoLiquidity
+	}
+	report.total(false)
+	return report, nil
+}
+
+// Execute plans a request and submits its child orders as immediate or
+// cancel limit orders, or as limit orders which are cancelled once submitted
+// on exchanges without immediate or cancel support. The executed amount and
+// price of each placed child order is then fetched from the exchange, the
+// report totals only include these fills while the planned totals include
+// every child order
+func (r *Router) Execute(req Request) (Report, error) {
+	report, err := r.Plan(req)
+	if err != nil {
+		return report, err
+	}
+
+	exchanges := make(map[string]exchange.IBotExchange)
+	for _, exch := range r.exchanges {
+		exchanges[exch.GetName()] = exch
+	}
+
+	var wg sync.WaitGroup
+	for i := range report.Children {
+		wg.Add(1)
+		go func(c *ChildOrder) {
+			defer wg.Done()
+			c.execute(exchanges[c.Exchange])
+		}(&report.Children[i])
+	}
+	wg.Wait()
+
+	report.total(true)
+	placed := false
+	for i := range report.Children {
+		placed = placed || report.Children[i].Placed
+	}
+	if !placed {
+		return report, ErrNoOrdersPlaced
+	}
+	if report.Amount == 0 {
+		return report, ErrNoOrdersFilled
+	}
+	return report, nil
+}
+
+// execute submits a child order and sets its fill. Child orders on exchanges
+// without immediate or cancel support are cancelled straight after being
+// placed so that no part of them rests on the book
+func (c *ChildOrder) execute(exch exchange.IBotExchange) {
+	order := &exchange.OrderSubmission{
+		CurrencyPair: c.Pair,
+		OrderSide:    c.Side,
+		OrderType:    exchange.Limit,
+		Price:        c.Price,
+		Amount:       c.Amount,
+	}
+	if c.ioc {
+		order.TimeInForce = exchange.IOC
+	}
+	resp, err := exch.SubmitOrder(order)
+	if err != nil {
+		c.Error = err.Error()
+		return
+	}
+	c.Placed = resp.IsOrderPlaced
+	c.OrderID = resp.OrderID
+	if !c.Placed {
+		return
+	}
+
+	var cancelErr error
+	if !c.ioc {
+		cancelErr = exch.CancelOrder(exchange.OrderCancellation{
+			OrderID:      c.OrderID,
+			CurrencyPair: c.Pair,
+			Side:         c.Side,
+		})
+	}
+
+	err = c.getFill(exch)
+	if err != nil {
+		c.Error = fmt.Sprintf("unable to get fill: %s", err)
+		return
+	}
+	// an order which filled in full before the cancellation cannot be
+	// cancelled
+	if cancelErr != nil && c.Executed < c.Amount {
+		c.Error = fmt.Sprintf("unable to cancel child order, it may remain open: %s", cancelErr)
+	}
+}
+
+// getFill sets the executed amount, average price, value and fee of a placed
+// child order from the exchange's order history, active orders or order info
+func (c *ChildOrder) getFill(exch exchange.IBotExchange) error {
+	detail, err := exchange.GetOrderDetail(exch, c.OrderID, c.Pair)
+	if err != nil {
+		return err
+	}
+
+	c.Executed = detail.ExecutedAmount
+	if c.Executed <= 0 {
+		return nil
+	}
+	c.ExecutedPrice = detail.Price
+	var amount, value float64
+	for _, f := range detail.Fills {
+		amount += f.Amount
+		value += f.Amount * f.Price
+	}
+	if amount > 0 {
+		c.ExecutedPrice = value / amount
+	}
+	c.ExecutedValue = c.Executed * c.ExecutedPrice * c.rate
+	c.ExecutedFee = c.ExecutedValue * c.fee
+	return nil
+}
+
+// getLevels returns the orderbook levels on the side of the cached orderbooks
+// which fill the request and the balance available on each exchange keyed by
+// exchange name and currency
+func (r *Router) getLevels(req Request) ([]level, map[string]float64, error) {
+	var names []string
+	for _, name := range req.Exchanges {
+		names = append(names, common.StringToLower(name))
+	}
+
+	budgets := make(map[string]float64)
+	var levels []level
+	var matched bool
+	for _, exch := range r.exchanges {
+		if len(names) > 0 && !common.StringDataCompare(names, common.StringToLower(exch.GetName())) {
+			continue
+		}
+		matched = true
+		// child orders are limit orders so that they never fill beyond the
+		// planned levels
+		if !exch.SupportsOrderSubmissionFeatures(exchange.LimitOrderSupport) {
+			continue
+		}
+
+		info, err := exch.GetAccountInfo()
+		if err != nil {
+			continue
+		}
+		for _, c := range info.Currencies {
+			budgets[exch.GetName()+common.StringToUpper(c.CurrencyName)] += c.TotalValue
+		}
+
+		for _, p := range exch.GetEnabledCurrencies() {
+			m, ok := r.getMarket(exch, p, req)
+			if !ok {
+				continue
+			}
+			ob, err := orderbook.GetOrderbook(exch.GetName(), p, orderbook.Spot)
+			if err != nil {
+				continue
+			}
+			side := ob.Asks
+			if req.Side == exchange.Sell {
+				side = ob.Bids
+			}
+			for _, item := range side {
+				if item.Price <= 0 || item.Amount <= 0 {
+					continue
+				}
+				effective := item.Price * m.rate * (1 + m.fee)
+				if req.Side == exchange.Sell {
+					effective = item.Price * m.rate * (1 - m.fee)
+				}
+				levels = append(levels, level{
+					market:    m,
+					price:     item.Price,
+					amount:    item.Amount,
+					effective: effective,
+				})
+			}
+		}
+	}
+	if !matched {
+		return nil, nil, ErrNoExchangeMatch
+	}
+	if len(levels) == 0 {
+		return nil, nil, ErrNoLiquidity
+	}
+	return levels, budgets, nil
+}
+
+// getMarket returns the market for an exchange currency pair if it can fill
+// the request, along with its quote conversion rate and taker fee
+func (r *Router) getMarket(exch exchange.IBotExchange, p pair.CurrencyPair, req Request) (*market, bool) {
+	if p.FirstCurrency.Upper() == req.Pair.SecondCurrency.Upper() ||
+		!r.relatable(req.Pair, p, false) {
+		return nil, false
+	}
+
+	rate := 1.0
+	from := p.SecondCurrency.Upper().String()
+	to := req.Pair.SecondCurrency.Upper().String()
+	if from != to {
+		var err error
+		rate, err = r.convert(1, from, to)
+		if err != nil || rate <= 0 {
+			return nil, false
+		}
+	}
+
+	fee, err := exch.GetFeeByType(exchange.FeeBuilder{
+		FeeType:        exchange.CryptocurrencyTradeFee,
+		FirstCurrency:  p.FirstCurrency.String(),
+		SecondCurrency: p.SecondCurrency.String(),
+		Delimiter:      p.Delimiter,
+		PurchasePrice:  1,
+		Amount:         1,
+	})
+	if err != nil {
+		return nil, false
+	}
+
+	spend := p.SecondCurrency.Upper().String()
+	if req.Side == exchange.Sell {
+		spend = p.FirstCurrency.Upper().String()
+	}
+	return &market{
+		exchange: exch,
+		pair:     p,
+		rate:     rate,
+		fee:      fee,
+		spend:    spend,
+		ioc: exch.SupportsOrderSubmissionFeatures(exchange.LimitOrderSupport |
+			exchange.ImmediateOrCancelOrderSupport),
+	}, true
+}
+
+// total sums the planned child orders into the planned totals, and either
+// the fills of the child orders when executed is set or else the planned child
+// orders into the report totals
+func (r *Report) total(executed bool) {
+	r.Planned, r.PlannedValue, r.PlannedFees = 0, 0, 0
+	r.Amount, r.Value, r.Fees, r.AveragePrice = 0, 0, 0, 0
+	for i := range r.Children {
+		c := &r.Children[i]
+		r.Planned += c.Amount
+		r.PlannedValue += c.Value
+		r.PlannedFees += c.Fee
+		if executed {
+			r.Amount += c.Executed
+			r.Value += c.ExecutedValue
+			r.Fees += c.ExecutedFee
+		}
+	}
+	if !executed {
+		r.Amount, r.Value, r.Fees = r.Planned, r.PlannedValue, r.PlannedFees
+	}
+	if r.Amount > 0 {
+		r.AveragePrice = r.Value / r.Amount
+	}
+}
@@ -0,0 +1,245 @@
+package orderrouter
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+var (
+	btcusd = pair.NewCurrencyPair("BTC", "USD")
+	btceur = pair.NewCurrencyPair("BTC", "EUR")
+)
+
+// fillOrders reports the supplied fraction of every order submitted to the
+// exchange as filled one below its price
+func fillOrders(e *exchangetest.Exchange, fraction float64) {
+	e.GetOrderHistoryFunc = func(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+		var orders []exchange.OrderDetail
+		for i, o := range e.Submitted() {
+			executed := o.Amount * fraction
+			detail := exchange.OrderDetail{
+				ID:             strconv.Itoa(i + 1),
+				CurrencyPair:   o.CurrencyPair,
+				Price:          o.Price,
+				Amount:         o.Amount,
+				ExecutedAmount: executed,
+			}
+			if executed > 0 {
+				detail.Fills = []exchange.OrderFill{{Price: o.Price - 1, Amount: executed}}
+			}
+			orders = append(orders, detail)
+		}
+		return orders, nil
+	}
+}
+
+func relatable(p1, p2 pair.CurrencyPair, includeUSDT bool) bool {
+	return p1.FirstCurrency == p2.FirstCurrency
+}
+
+func convert(amount float64, from, to string) (float64, error) {
+	if from == "EUR" && to == "USD" {
+		return amount * 1.1, nil
+	}
+	return 0, errors.New("unsupported conversion")
+}
+
+func isClose(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// newTestRouter seeds the orderbook cache with BTCUSD asks of 100 and 103 and
+// BTCEUR asks of 92 and 93, converted at 1.1
+func newTestRouter(prefix string) (*Router, *exchangetest.Exchange, *exchangetest.Exchange) {
+	usd := exchangetest.New(prefix+"USD", btcusd)
+	usd.Fee = 0.001
+	usd.Balances = map[string]float64{"usd": 1000, "BTC": 0.4}
+	usd.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.ImmediateOrCancelOrderSupport
+	fillOrders(usd, 0)
+	eur := exchangetest.New(prefix+"EUR", btceur)
+	eur.Fee = 0.002
+	eur.Balances = map[string]float64{"EUR": 60, "BTC": 0.3}
+	eur.OrderSubmissionFeatures = exchange.LimitOrderSupport | exchange.ImmediateOrCancelOrderSupport
+	fillOrders(eur, 0)
+	orderbook.ProcessOrderbook(usd.Name, btcusd, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 103, Amount: 1}, {Price: 100, Amount: 1}},
+	}, orderbook.Spot)
+	orderbook.ProcessOrderbook(eur.Name, btceur, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 91, Amount: 2}},
+		Asks: []orderbook.Item{{Price: 92, Amount: 0.5}, {Price: 93, Amount: 1}},
+	}, orderbook.Spot)
+	return New([]exchange.IBotExchange{usd, eur}, relatable, convert), usd, eur
+}
+
+func TestPlan(t *testing.T) {
+	r, usd, eur := newTestRouter("RoutePlan")
+
+	report, err := r.Plan(Request{Pair: btcusd, Side: exchange.Buy, Amount: 2})
+	if err != nil {
+		t.Fatalf("Test failed. Plan error: %s", err)
+	}
+	if len(report.Children) != 2 || len(usd.Submitted()) != 0 {
+		t.Fatalf("Test failed. Expected 2 unsubmitted child orders got %+v", report.Children)
+	}
+
+	// the EUR balance runs out part way through the 93 EUR level so the
+	// remainder is bought at 103 USD
+	eurAmount := 0.5 + (60-0.5*92*1.002)/(93*1.002)
+	usdChild, eurChild := report.Children[0], report.Children[1]
+	if usdChild.Exchange != usd.Name || usdChild.Price != 103 || !isClose(usdChild.Amount, 2-eurAmount) {
+		t.Errorf("Test failed. Unexpected USD child order %+v", usdChild)
+	}
+	if eurChild.Exchange != eur.Name || eurChild.Price != 93 || !isClose(eurChild.Amount, eurAmount) {
+		t.Errorf("Test failed. Unexpected EUR child order %+v", eurChild)
+	}
+	value := 100 + (1-eurAmount)*103 + (0.5*92+(eurAmount-0.5)*93)*1.1
+	if !isClose(report.Amount, 2) || !isClose(report.Value, value) || !isClose(report.AveragePrice, value/2) {
+		t.Errorf("Test failed. Unexpected report totals %+v", report)
+	}
+
+	report, err = r.Plan(Request{Pair: btcusd, Side: exchange.Buy, Amount: 2, LimitPrice: 101.5})
+	if err != nil || !isClose(report.Amount, 1.5) {
+		t.Errorf("Test failed. Expected limit price to restrict levels got %+v %v", report, err)
+	}
+
+	report, err = r.Plan(Request{Pair: btcusd, Side: exchange.Sell, Amount: 1})
+	if err != nil || !isClose(report.Amount, 0.7) || report.Children[0].Exchange != eur.Name {
+		t.Errorf("Test failed. Expected sell to be limited by balances got %+v %v", report, err)
+	}
+
+	report, err = r.Plan(Request{Pair: btcusd, Side: exchange.Buy, Amount: 1, Exchanges: []string{eur.Name}})
+	if err != nil || len(report.Children) != 1 || report.Children[0].Exchange != eur.Name {
+		t.Errorf("Test failed. Expected only requested exchanges to be used got %+v %v", report, err)
+	}
+}
+
+func TestPlanAvailableBalance(t *testing.T) {
+	r, _, eur := newTestRouter("RouteAvailable")
+	// TotalValue is already the available balance, funds on hold are not
+	// subtracted again
+	eur.GetAccountInfoFunc = func() (exchange.AccountInfo, error) {
+		return exchange.AccountInfo{ExchangeName: eur.Name, Currencies: []exchange.AccountCurrencyInfo{
+			{CurrencyName: "EUR", TotalValue: 60, Hold: 40},
+		}}, nil
+	}
+
+	report, err := r.Plan(Request{Pair: btcusd, Side: exchange.Buy, Amount: 2})
+	if err != nil {
+		t.Fatalf("Test failed. Plan error: %s", err)
+	}
+	eurAmount := 0.5 + (60-0.5*92*1.002)/(93*1.002)
+	if len(report.Children) != 2 || !isClose(report.Children[1].Amount, eurAmount) {
+		t.Errorf("Test failed. Expected the EUR child order to use the available balance got %+v", report.Children)
+	}
+}
+
+func TestPlanErrors(t *testing.T) {
+	r, _, _ := newTestRouter("RouteErrors")
+
+	if _, err := r.Plan(Request{Pair: btcusd, Side: exchange.Buy}); err != ErrInvalidAmount {
+		t.Errorf("Test failed. Expected %s got %v", ErrInvalidAmount, err)
+	}
+	if _, err := r.Plan(Request{Pair: btcusd, Amount: 1}); err != ErrInvalidSide {
+		t.Errorf("Test failed. Expected %s got %v", ErrInvalidSide, err)
+	}
+	_, err := r.Plan(Request{Pair: btcusd, Side: exchange.Buy, Amount: 1, Exchanges: []string{"missing"}})
+	if err != ErrNoExchangeMatch {
+		t.Errorf("Test failed. Expected %s got %v", ErrNoExchangeMatch, err)
+	}
+	_, err = r.Plan(Request{Pair: pair.NewCurrencyPair("LTC", "USD"), Side: exchange.Buy, Amount: 1})
+	if err != ErrNoLiquidity {
+		t.Errorf("Test failed. Expected %s got %v", ErrNoLiquidity, err)
+	}
+	_, err = r.Plan(Request{Pair: btcusd, Side: exchange.Buy, Amount: 1, LimitPrice: 50})
+	if err != ErrNoLiquidity {
+		t.Errorf("Test failed. Expected %s got %v", ErrNoLiquidity, err)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	r, usd, eur := newTestRouter("RouteExecute")
+	eur.SubmitErr = errors.New("rejected")
+	fillOrders(usd, 0.5)
+
+	report, err := r.Execute(Request{Pair: btcusd, Side: exchange.Buy, Amount: 1.5})
+	if err != nil {
+		t.Fatalf("Test failed. Execute error: %s", err)
+	}
+	submitted := usd.Submitted()
+	if len(submitted) != 1 || submitted[0].TimeInForce != exchange.IOC ||
+		submitted[0].OrderType != exchange.Limit || submitted[0].Price != 100 {
+		t.Errorf("Test failed. Unexpected orders submitted %+v", submitted)
+	}
+	// the placed order is half filled at 99
+	if !isClose(report.Amount, 0.5) || !isClose(report.Value, 49.5) ||
+		!isClose(report.AveragePrice, 99) || !isClose(report.Fees, 0.0495) {
+		t.Errorf("Test failed. Expected report to total the executed fills got %+v", report)
+	}
+	if !isClose(report.Planned, 1.5) || !isClose(report.PlannedValue, 100+0.5*92*1.1) {
+		t.Errorf("Test failed. Expected planned totals of every child order got %+v", report)
+	}
+	for _, c := range report.Children {
+		if c.Exchange == eur.Name && (c.Placed || c.Error != "rejected" || c.Executed != 0) {
+			t.Errorf("Test failed. Expected rejected child order got %+v", c)
+		}
+		if c.Exchange == usd.Name && (!c.Placed || c.OrderID != "1" ||
+			!isClose(c.Amount, 1) || !isClose(c.Executed, 0.5) || c.ExecutedPrice != 99) {
+			t.Errorf("Test failed. Expected placed child order got %+v", c)
+		}
+	}
+
+	fillOrders(usd, 0)
+	if _, err = r.Execute(Request{Pair: btcusd, Side: exchange.Buy, Amount: 1}); err != ErrNoOrdersFilled {
+		t.Errorf("Test failed. Expected %s got %v", ErrNoOrdersFilled, err)
+	}
+
+	usd.SubmitErr = eur.SubmitErr
+	if _, err = r.Execute(Request{Pair: btcusd, Side: exchange.Buy, Amount: 1}); err != ErrNoOrdersPlaced {
+		t.Errorf("Test failed. Expected %s got %v", ErrNoOrdersPlaced, err)
+	}
+}
+
+func TestExecuteWithoutImmediateOrCancel(t *testing.T) {
+	r, usd, eur := newTestRouter("RouteNoIOC")
+	usd.OrderSubmissionFeatures = exchange.LimitOrderSupport
+	eur.OrderSubmissionFeatures = 0
+	// the order history is unavailable so fills are read from active orders
+	usd.GetOrderHistoryFunc = func(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+		return nil, errors.New("history unavailable")
+	}
+	usd.CancelErr = errors.New("cancel failed")
+	usd.SubmitOrderFunc = func(order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+		if order.TimeInForce != "" {
+			t.Errorf("Test failed. Expected a plain limit order got %s", order.TimeInForce)
+		}
+		usd.Active = append(usd.Active, exchange.OrderDetail{
+			ID:             "1",
+			CurrencyPair:   order.CurrencyPair,
+			Price:          order.Price,
+			Amount:         order.Amount,
+			ExecutedAmount: 0.25,
+		})
+		return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "1"}, nil
+	}
+
+	// exchanges without limit order support are not routed to
+	report, err := r.Execute(Request{Pair: btcusd, Side: exchange.Buy, Amount: 1})
+	if err != nil {
+		t.Fatalf("Test failed. Execute error: %s", err)
+	}
+	if len(report.Children) != 1 || report.Children[0].Exchange != usd.Name {
+		t.Fatalf("Test failed. Expected a single child order got %+v", report.Children)
+	}
+	c := report.Children[0]
+	if !isClose(c.Executed, 0.25) || c.Error == "" {
+		t.Errorf("Test failed. Expected partially filled child order which failed to cancel got %+v", c)
+	}
+}
@@ -0,0 +1,104 @@
+package orderrouter
+
+import (
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Request is a single logical order to be split across exchanges
+type Request struct {
+	// Pair sets the currency bought or sold and the quote currency prices and
+	// values are reported in
+	Pair   pair.CurrencyPair  `json:"pair"`
+	Side   exchange.OrderSide `json:"side"`
+	Amount float64            `json:"amount"`
+	// LimitPrice is the worst price, excluding fees, in the quote currency of
+	// Pair that liquidity is taken at. Zero takes any price
+	LimitPrice float64 `json:"limitPrice,omitempty"`
+	// Exchanges limits the exchanges routed to, all exchanges are used when
+	// empty
+	Exchanges []string `json:"exchanges,omitempty"`
+}
+
+// Report holds the child orders a request was split into and their totals.
+// Values and fees are converted into the quote currency of the request, fees
+// are estimated from each exchange's taker fee
+type Report struct {
+	Pair      pair.CurrencyPair  `json:"pair"`
+	Side      exchange.OrderSide `json:"side"`
+	Requested float64            `json:"requested"`
+	// Amount, AveragePrice, Value and Fees total the fills of the child
+	// orders executed, or the planned child orders when the report is a plan
+	Amount       float64 `json:"amount"`
+	AveragePrice float64 `json:"averagePrice"`
+	Value        float64 `json:"value"`
+	Fees         float64 `json:"fees"`
+	// Planned, PlannedValue and PlannedFees total the child orders as sized
+	// against the orderbook levels
+	Planned      float64      `json:"planned"`
+	PlannedValue float64      `json:"plannedValue"`
+	PlannedFees  float64      `json:"plannedFees"`
+	Children     []ChildOrder `json:"children"`
+}
+
+// ChildOrder is the part of a request routed to an exchange currency pair.
+// Amount, Value and Fee are planned, the Executed fields are set from the
+// exchange's record of the order once it has been submitted
+type ChildOrder struct {
+	Exchange string             `json:"exchange"`
+	Pair     pair.CurrencyPair  `json:"pair"`
+	Side     exchange.OrderSide `json:"side"`
+	// Price is the limit price in the quote currency of the child's pair
+	Price   float64 `json:"price"`
+	Amount  float64 `json:"amount"`
+	Value   float64 `json:"value"`
+	Fee     float64 `json:"fee"`
+	Placed  bool    `json:"placed"`
+	OrderID string  `json:"orderId,omitempty"`
+	// Executed is the amount filled and ExecutedPrice its average price in
+	// the quote currency of the child's pair
+	Executed      float64 `json:"executed"`
+	ExecutedPrice float64 `json:"executedPrice"`
+	ExecutedValue float64 `json:"executedValue"`
+	ExecutedFee   float64 `json:"executedFee"`
+	Error         string  `json:"error,omitempty"`
+	// rate, fee and ioc are those of the market the child was planned on
+	rate float64
+	fee  float64
+	ioc  bool
+}
+
+// RelatableFunc reports whether two currency pairs represent the same market,
+// for example BTC-USD and BTC-EUR
+type RelatableFunc func(p1, p2 pair.CurrencyPair, includeUSDT bool) bool
+
+// ConvertFunc converts an amount between two fiat currencies
+type ConvertFunc func(amount float64, from, to string) (float64, error)
+
+// Router splits orders across the orderbooks of its exchanges
+type Router struct {
+	exchanges []exchange.IBotExchange
+	relatable RelatableFunc
+	convert   ConvertFunc
+}
+
+// market is an exchange currency pair which can fill part of a request
+type market struct {
+	exchange exchange.IBotExchange
+	pair     pair.CurrencyPair
+	// rate converts prices into the quote currency of the request
+	rate float64
+	fee  float64
+	// spend is the currency given up by trading on the market
+	spend string
+	// ioc is set when the exchange supports immediate or cancel limit orders
+	ioc bool
+}
+
+// level is an orderbook price level of a market
+type level struct {
+	market    *market
+	price     float64
+	amount    float64
+	effective float64
+}
@@ -0,0 +1,513 @@
+package recorder
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	recordingsDir  = "recordings"
+	fileExtension  = ".jsonl.gz"
+	fileTimeFormat = "20060102T150405.000000000"
+
+	// maxRecordSize is the largest single record the reader accepts
+	maxRecordSize = 64 * 1024 * 1024
+
+	// defaultFlushInterval is how often buffered records are written to disk
+	// when no flush interval is configured
+	defaultFlushInterval = time.Second * 10
+)
+
+// vars related to the recorder
+var (
+	ErrRecorderClosed = errors.New("recorder is closed")
+)
+
+// New returns a recorder writing to the recordings directory within dataDir
+// for every exchange with recording enabled
+func New(dataDir string, cfg config.RecorderConfig, exchanges []config.ExchangeConfig) *Recorder {
+	r := &Recorder{
+		dir:              filepath.Join(dataDir, recordingsDir),
+		rotationInterval: cfg.RotationInterval,
+		maxFileSize:      cfg.MaxFileSize,
+		selections:       make(map[string]selection),
+		writers:          make(map[string]*writer),
+		shutdown:         make(chan struct{}),
+	}
+
+	for x := range exchanges {
+		rec := exchanges[x].Recording
+		if !exchanges[x].Enabled || rec == nil || !rec.Enabled {
+			continue
+		}
+		s := selection{
+			tickers:    rec.Tickers,
+			orderbooks: rec.Orderbooks,
+			trades:     rec.Trades,
+			klines:     rec.Klines,
+		}
+		if rec.Pairs != "" {
+			for _, p := range common.SplitStrings(rec.Pairs, ",") {
+				s.pairs = append(s.pairs, pair.NewCurrencyPairFromString(common.StringToUpper(common.TrimString(p, " "))))
+			}
+		}
+		r.selections[common.StringToLower(exchanges[x].Name)] = s
+	}
+
+	flushInterval := cfg.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+	go r.flushRoutine(flushInterval, r.shutdown)
+	return r
+}
+
+// flushRoutine periodically flushes buffered records so that recordings on
+// disk stay current and little is lost if the bot exits uncleanly
+func (r *Recorder) flushRoutine(interval time.Duration, shutdown chan struct{}) {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-tick.C:
+			err := r.Flush()
+			if err != nil {
+				log.Printf("Unable to flush market data recordings. Err: %s", err)
+			}
+		}
+	}
+}
+
+// GetDirectory returns the directory recordings are written to
+func (r *Recorder) GetDirectory() string {
+	return r.dir
+}
+
+// IsRecording returns whether any records for the exchange, pair and record
+// type are selected for recording
+func (r *Recorder) IsRecording(exchangeName string, p pair.CurrencyPair, recordType RecordType) bool {
+	s, ok := r.selections[common.StringToLower(exchangeName)]
+	if !ok {
+		return false
+	}
+
+	switch recordType {
+	case TickerRecord:
+		if !s.tickers {
+			return false
+		}
+	case OrderbookRecord:
+		if !s.orderbooks {
+			return false
+		}
+	case TradeRecord:
+		if !s.trades {
+			return false
+		}
+	case KlineRecord:
+		if !s.klines {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if len(s.pairs) == 0 {
+		return true
+	}
+	return pair.Contains(s.pairs, p, true)
+}
+
+// RecordTicker records a ticker if selected for the exchange
+func (r *Recorder) RecordTicker(exchangeName string, p pair.CurrencyPair, assetType string, price ticker.Price) error {
+	if !r.IsRecording(exchangeName, p, TickerRecord) {
+		return nil
+	}
+	return r.Write(Record{
+		Type:      TickerRecord,
+		Timestamp: price.LastUpdated,
+		Exchange:  exchangeName,
+		Pair:      p,
+		AssetType: assetType,
+		Ticker:    &price,
+	})
+}
+
+// RecordTickerData records a websocket ticker if selected for the exchange
+func (r *Recorder) RecordTickerData(data exchange.TickerData) error {
+	return r.RecordTicker(data.Exchange, data.Pair, data.AssetType, ticker.Price{
+		Pair:         data.Pair,
+		CurrencyPair: data.Pair.Pair().String(),
+		LastUpdated:  data.Timestamp,
+		Last:         data.ClosePrice,
+		High:         data.HighPrice,
+		Low:          data.LowPrice,
+		Volume:       data.Quantity,
+	})
+}
+
+// RecordOrderbook records an orderbook snapshot if selected for the exchange
+func (r *Recorder) RecordOrderbook(exchangeName string, p pair.CurrencyPair, assetType string, ob orderbook.Base) error {
+	if !r.IsRecording(exchangeName, p, OrderbookRecord) {
+		return nil
+	}
+	return r.Write(Record{
+		Type:      OrderbookRecord,
+		Timestamp: ob.LastUpdated,
+		Exchange:  exchangeName,
+		Pair:      p,
+		AssetType: assetType,
+		Orderbook: &ob,
+	})
+}
+
+// RecordOrderbookUpdate records the cached orderbook for a websocket orderbook
+// update if selected for the exchange
+func (r *Recorder) RecordOrderbookUpdate(update exchange.WebsocketOrderbookUpdate) error {
+	if !r.IsRecording(update.Exchange, update.Pair, OrderbookRecord) {
+		return nil
+	}
+	ob, err := orderbook.GetOrderbook(update.Exchange, update.Pair, update.Asset)
+	if err != nil {
+		return err
+	}
+	return r.RecordOrderbook(update.Exchange, update.Pair, update.Asset, ob)
+}
+
+// RecordTrade records a websocket trade if selected for the exchange
+func (r *Recorder) RecordTrade(data exchange.TradeData) error {
+	if !r.IsRecording(data.Exchange, data.CurrencyPair, TradeRecord) {
+		return nil
+	}
+	return r.Write(Record{
+		Type:      TradeRecord,
+		Timestamp: data.Timestamp,
+		Exchange:  data.Exchange,
+		Pair:      data.CurrencyPair,
+		AssetType: data.AssetType,
+		Trade:     &data,
+	})
+}
+
+// RecordKline records a websocket kline if selected for the exchange
+func (r *Recorder) RecordKline(data exchange.KlineData) error {
+	if !r.IsRecording(data.Exchange, data.Pair, KlineRecord) {
+		return nil
+	}
+	return r.Write(Record{
+		Type:      KlineRecord,
+		Timestamp: data.Timestamp,
+		Exchange:  data.Exchange,
+		Pair:      data.Pair,
+		AssetType: data.AssetType,
+		Kline:     &data,
+	})
+}
+
+// Write appends a record to the exchange's current recording file, rotating
+// the file when it exceeds the configured age or size
+func (r *Recorder) Write(rec Record) error {
+	if rec.Timestamp.IsZero() {
+		rec.Timestamp = time.Now()
+	}
+
+	data, err := common.JSONEncode(rec)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.writers == nil {
+		return ErrRecorderClosed
+	}
+
+	name := common.StringToLower(rec.Exchange)
+	w, ok := r.writers[name]
+	if ok && r.shouldRotate(w, int64(len(data))) {
+		err = w.close()
+		delete(r.writers, name)
+		if err != nil {
+			return err
+		}
+		ok = false
+	}
+
+	if !ok {
+		w, err = r.open(name)
+		if err != nil {
+			return err
+		}
+		r.writers[name] = w
+	}
+
+	n, err := w.buf.Write(data)
+	w.written += int64(n)
+	return err
+}
+
+func (r *Recorder) shouldRotate(w *writer, size int64) bool {
+	if r.rotationInterval > 0 && time.Since(w.opened) >= r.rotationInterval {
+		return true
+	}
+	return r.maxFileSize > 0 && w.written > 0 && w.written+size > r.maxFileSize
+}
+
+// open creates a new recording file for an exchange
+func (r *Recorder) open(exchangeName string) (*writer, error) {
+	err := common.CheckDir(r.dir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(r.dir, exchangeName)
+	err = common.CheckDir(dir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	path := filepath.Join(dir, now.UTC().Format(fileTimeFormat)+fileExtension)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(f)
+	return &writer{
+		file:   f,
+		gz:     gz,
+		buf:    bufio.NewWriter(gz),
+		opened: now,
+	}, nil
+}
+
+func (w *writer) flush() error {
+	err := w.buf.Flush()
+	if err != nil {
+		return err
+	}
+	return w.gz.Flush()
+}
+
+func (w *writer) close() error {
+	err := w.buf.Flush()
+	if err != nil {
+		w.file.Close()
+		return err
+	}
+	err = w.gz.Close()
+	if err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+// Flush writes all buffered records to disk so they can be read back while
+// the recording is in progress
+func (r *Recorder) Flush() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	for _, w := range r.writers {
+		err := w.flush()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close flushes and closes all recording files, further writes fail
+func (r *Recorder) Close() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.shutdown != nil {
+		close(r.shutdown)
+		r.shutdown = nil
+	}
+
+	var firstErr error
+	for _, w := range r.writers {
+		err := w.close()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	r.writers = nil
+	return firstErr
+}
+
+// GetFiles returns the recording files within a recordings directory for an
+// exchange, or all exchanges if the exchange name is empty, in time order
+func GetFiles(dir, exchangeName string) ([]string, error) {
+	pattern := filepath.Join(dir, "*", "*"+fileExtension)
+	if exchangeName != "" {
+		pattern = filepath.Join(dir, common.StringToLower(exchangeName), "*"+fileExtension)
+	}
+
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// file names are timestamps so sort on the name regardless of exchange
+	sort.Slice(files, func(i, j int) bool {
+		return filepath.Base(files[i]) < filepath.Base(files[j])
+	})
+	return files, nil
+}
+
+// NewReader returns a reader for all recordings within a recordings directory
+// which match the filter
+func NewReader(dir string, filter Filter) (*Reader, error) {
+	files, err := GetFiles(dir, filter.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{files: files, filter: filter}, nil
+}
+
+// Next returns the next record matching the filter, io.EOF is returned once
+// all files have been read
+func (rd *Reader) Next() (Record, error) {
+	for {
+		if rd.scanner == nil {
+			if len(rd.files) == 0 {
+				return Record{}, io.EOF
+			}
+			err := rd.openNext()
+			if err != nil {
+				return Record{}, err
+			}
+		}
+
+		if !rd.scanner.Scan() {
+			err := rd.scanner.Err()
+			rd.closeFile()
+			// a file still being recorded ends without a gzip footer
+			if err != nil && err != io.ErrUnexpectedEOF {
+				return Record{}, err
+			}
+			continue
+		}
+
+		var rec Record
+		err := common.JSONDecode(rd.scanner.Bytes(), &rec)
+		if err != nil {
+			return Record{}, err
+		}
+		if rd.filter.matches(rec) {
+			return rec, nil
+		}
+	}
+}
+
+func (rd *Reader) openNext() error {
+	f, err := os.Open(rd.files[0])
+	if err != nil {
+		return err
+	}
+	rd.files = rd.files[1:]
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		if err == io.EOF {
+			// empty file which has not been flushed yet
+			rd.scanner = bufio.NewScanner(strings.NewReader(""))
+			return nil
+		}
+		return err
+	}
+
+	rd.file = f
+	rd.gz = gz
+	rd.scanner = bufio.NewScanner(gz)
+	rd.scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+	return nil
+}
+
+func (rd *Reader) closeFile() {
+	if rd.gz != nil {
+		rd.gz.Close()
+		rd.gz = nil
+	}
+	if rd.file != nil {
+		rd.file.Close()
+		rd.file = nil
+	}
+	rd.scanner = nil
+}
+
+// ReadAll returns all remaining records matching the filter
+func (rd *Reader) ReadAll() ([]Record, error) {
+	var records []Record
+	for {
+		rec, err := rd.Next()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, rec)
+	}
+}
+
+// Close closes the file currently being read
+func (rd *Reader) Close() {
+	rd.closeFile()
+	rd.files = nil
+}
+
+// matches returns whether a record satisfies the filter
+func (f Filter) matches(rec Record) bool {
+	if f.Exchange != "" && common.StringToLower(f.Exchange) != common.StringToLower(rec.Exchange) {
+		return false
+	}
+	if !f.Pair.Empty() && !f.Pair.Equal(rec.Pair, true) {
+		return false
+	}
+	if len(f.Types) > 0 {
+		var found bool
+		for x := range f.Types {
+			if f.Types[x] == rec.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if !f.StartTime.IsZero() && rec.Timestamp.Before(f.StartTime) {
+		return false
+	}
+	if !f.EndTime.IsZero() && rec.Timestamp.After(f.EndTime) {
+		return false
+	}
+	return true
+}
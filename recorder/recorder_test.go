@@ -0,0 +1,325 @@
+package recorder
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+func setupRecorder(t *testing.T, cfg config.RecorderConfig) (*Recorder, string) {
+	dir, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := New(dir, cfg, []config.ExchangeConfig{
+		{
+			Name:    "Bitstamp",
+			Enabled: true,
+			Recording: &config.RecordingConfig{
+				Enabled:    true,
+				Pairs:      "BTC-USD",
+				Tickers:    true,
+				Orderbooks: true,
+				Trades:     true,
+			},
+		},
+		{
+			Name:    "Kraken",
+			Enabled: true,
+			Recording: &config.RecordingConfig{
+				Enabled: true,
+				Trades:  true,
+			},
+		},
+		{
+			Name:    "Bitfinex",
+			Enabled: true,
+		},
+	})
+	return r, dir
+}
+
+func TestIsRecording(t *testing.T) {
+	r, dir := setupRecorder(t, config.RecorderConfig{})
+	defer os.RemoveAll(dir)
+
+	btcusd := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+	ltcusd := pair.NewCurrencyPair(symbol.LTC, symbol.USD)
+
+	if !r.IsRecording("bitstamp", btcusd, TickerRecord) {
+		t.Error("Test failed. IsRecording() bitstamp BTCUSD tickers should be recorded")
+	}
+	if r.IsRecording("Bitstamp", ltcusd, TickerRecord) {
+		t.Error("Test failed. IsRecording() bitstamp LTCUSD should not be recorded")
+	}
+	if r.IsRecording("Bitstamp", btcusd, KlineRecord) {
+		t.Error("Test failed. IsRecording() bitstamp klines should not be recorded")
+	}
+	if !r.IsRecording("Kraken", ltcusd, TradeRecord) {
+		t.Error("Test failed. IsRecording() kraken trades should be recorded for all pairs")
+	}
+	if r.IsRecording("Bitfinex", btcusd, TradeRecord) {
+		t.Error("Test failed. IsRecording() bitfinex should not be recorded")
+	}
+}
+
+func TestWriteAndRead(t *testing.T) {
+	r, dir := setupRecorder(t, config.RecorderConfig{})
+	defer os.RemoveAll(dir)
+
+	p := pair.NewCurrencyPairDelimiter("BTC-USD", "-")
+	start := time.Now().Add(-time.Minute)
+
+	err := r.RecordTicker("Bitstamp", p, ticker.Spot, ticker.Price{
+		Pair:        p,
+		Last:        100,
+		LastUpdated: start,
+	})
+	if err != nil {
+		t.Fatalf("Test failed. RecordTicker() error: %s", err)
+	}
+
+	err = r.RecordOrderbook("Bitstamp", p, ticker.Spot, orderbook.Base{
+		Pair:        p,
+		Bids:        []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks:        []orderbook.Item{{Price: 101, Amount: 1}},
+		LastUpdated: start.Add(time.Second),
+	})
+	if err != nil {
+		t.Fatalf("Test failed. RecordOrderbook() error: %s", err)
+	}
+
+	err = r.RecordTrade(exchange.TradeData{
+		Timestamp:    start.Add(2 * time.Second),
+		CurrencyPair: p,
+		AssetType:    ticker.Spot,
+		Exchange:     "Kraken",
+		Price:        100.5,
+		Amount:       2,
+		Side:         "buy",
+	})
+	if err != nil {
+		t.Fatalf("Test failed. RecordTrade() error: %s", err)
+	}
+
+	// not selected so should not be written
+	err = r.RecordKline(exchange.KlineData{
+		Timestamp: start,
+		Pair:      p,
+		Exchange:  "Bitstamp",
+	})
+	if err != nil {
+		t.Fatalf("Test failed. RecordKline() error: %s", err)
+	}
+
+	err = r.Flush()
+	if err != nil {
+		t.Fatalf("Test failed. Flush() error: %s", err)
+	}
+
+	// recordings in progress can be read back once flushed
+	reader, err := NewReader(r.GetDirectory(), Filter{Exchange: "Bitstamp"})
+	if err != nil {
+		t.Fatalf("Test failed. NewReader() error: %s", err)
+	}
+	records, err := reader.ReadAll()
+	reader.Close()
+	if err != nil {
+		t.Fatalf("Test failed. ReadAll() error: %s", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Test failed. Expected 2 bitstamp records, received %d", len(records))
+	}
+
+	err = r.Close()
+	if err != nil {
+		t.Fatalf("Test failed. Close() error: %s", err)
+	}
+	if r.RecordTicker("Bitstamp", p, ticker.Spot, ticker.Price{}) != ErrRecorderClosed {
+		t.Error("Test failed. Expected ErrRecorderClosed after Close()")
+	}
+
+	reader, err = NewReader(r.GetDirectory(), Filter{})
+	if err != nil {
+		t.Fatalf("Test failed. NewReader() error: %s", err)
+	}
+	records, err = reader.ReadAll()
+	reader.Close()
+	if err != nil {
+		t.Fatalf("Test failed. ReadAll() error: %s", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Test failed. Expected 3 records, received %d", len(records))
+	}
+
+	if records[0].Type != TickerRecord || records[0].Ticker == nil ||
+		records[0].Ticker.Last != 100 {
+		t.Errorf("Test failed. Unexpected ticker record %+v", records[0])
+	}
+	if !records[0].Pair.Equal(p, true) {
+		t.Errorf("Test failed. Expected pair %s, received %s", p.Pair(), records[0].Pair.Pair())
+	}
+	if records[1].Type != OrderbookRecord || records[1].Orderbook == nil ||
+		len(records[1].Orderbook.Bids) != 1 || records[1].Orderbook.Asks[0].Price != 101 {
+		t.Errorf("Test failed. Unexpected orderbook record %+v", records[1])
+	}
+	if records[2].Type != TradeRecord || records[2].Trade == nil ||
+		records[2].Trade.Price != 100.5 {
+		t.Errorf("Test failed. Unexpected trade record %+v", records[2])
+	}
+}
+
+func TestReaderFilter(t *testing.T) {
+	r, dir := setupRecorder(t, config.RecorderConfig{})
+	defer os.RemoveAll(dir)
+
+	btcusd := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+	ltcusd := pair.NewCurrencyPair(symbol.LTC, symbol.USD)
+	start := time.Now().Add(-time.Hour)
+
+	for x := 0; x < 10; x++ {
+		p := btcusd
+		if x%2 == 1 {
+			p = ltcusd
+		}
+		err := r.RecordTrade(exchange.TradeData{
+			Timestamp:    start.Add(time.Duration(x) * time.Minute),
+			CurrencyPair: p,
+			Exchange:     "Kraken",
+			Price:        float64(x),
+			Amount:       1,
+		})
+		if err != nil {
+			t.Fatalf("Test failed. RecordTrade() error: %s", err)
+		}
+	}
+	err := r.Close()
+	if err != nil {
+		t.Fatalf("Test failed. Close() error: %s", err)
+	}
+
+	reader, err := NewReader(r.GetDirectory(), Filter{
+		Exchange:  "kraken",
+		Pair:      btcusd,
+		Types:     []RecordType{TradeRecord},
+		StartTime: start.Add(2 * time.Minute),
+		EndTime:   start.Add(6 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Test failed. NewReader() error: %s", err)
+	}
+	defer reader.Close()
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Test failed. ReadAll() error: %s", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Test failed. Expected 3 records, received %d", len(records))
+	}
+	for x := range records {
+		if !records[x].Pair.Equal(btcusd, true) {
+			t.Errorf("Test failed. Unexpected pair %s", records[x].Pair.Pair())
+		}
+	}
+
+	reader, err = NewReader(r.GetDirectory(), Filter{Types: []RecordType{TickerRecord}})
+	if err != nil {
+		t.Fatalf("Test failed. NewReader() error: %s", err)
+	}
+	records, err = reader.ReadAll()
+	reader.Close()
+	if err != nil {
+		t.Fatalf("Test failed. ReadAll() error: %s", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("Test failed. Expected no ticker records, received %d", len(records))
+	}
+}
+
+func TestRotation(t *testing.T) {
+	r, dir := setupRecorder(t, config.RecorderConfig{MaxFileSize: 1})
+	defer os.RemoveAll(dir)
+
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+	for x := 0; x < 3; x++ {
+		err := r.RecordTrade(exchange.TradeData{
+			Timestamp:    time.Now(),
+			CurrencyPair: p,
+			Exchange:     "Kraken",
+			Price:        1,
+			Amount:       1,
+		})
+		if err != nil {
+			t.Fatalf("Test failed. RecordTrade() error: %s", err)
+		}
+		// file names have nanosecond precision, ensure they are unique
+		time.Sleep(time.Millisecond)
+	}
+	err := r.Close()
+	if err != nil {
+		t.Fatalf("Test failed. Close() error: %s", err)
+	}
+
+	files, err := GetFiles(r.GetDirectory(), "Kraken")
+	if err != nil {
+		t.Fatalf("Test failed. GetFiles() error: %s", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("Test failed. Expected 3 rotated files, received %d", len(files))
+	}
+	if filepath.Dir(files[0]) != filepath.Join(dir, recordingsDir, "kraken") {
+		t.Errorf("Test failed. Unexpected recording directory %s", filepath.Dir(files[0]))
+	}
+
+	reader, err := NewReader(r.GetDirectory(), Filter{})
+	if err != nil {
+		t.Fatalf("Test failed. NewReader() error: %s", err)
+	}
+	defer reader.Close()
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Test failed. ReadAll() error: %s", err)
+	}
+	if len(records) != 3 {
+		t.Errorf("Test failed. Expected 3 records, received %d", len(records))
+	}
+}
+
+func TestPeriodicFlush(t *testing.T) {
+	r, dir := setupRecorder(t, config.RecorderConfig{FlushInterval: time.Millisecond * 10})
+	defer os.RemoveAll(dir)
+	defer r.Close()
+
+	p := pair.NewCurrencyPairDelimiter("BTC-USD", "-")
+	err := r.RecordTicker("Bitstamp", p, ticker.Spot, ticker.Price{Pair: p, Last: 100})
+	if err != nil {
+		t.Fatalf("Test failed. RecordTicker() error: %s", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		reader, err := NewReader(r.GetDirectory(), Filter{})
+		if err != nil {
+			t.Fatalf("Test failed. NewReader() error: %s", err)
+		}
+		records, err := reader.ReadAll()
+		reader.Close()
+		if err == nil && len(records) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Test failed. Records were not flushed periodically")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
@@ -0,0 +1,87 @@
+package recorder
+
+import (
+	"bufio"
+	"compress/gzip"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// RecordType defines the kind of market data held by a Record
+type RecordType string
+
+// Record types
+const (
+	TickerRecord    RecordType = "ticker"
+	OrderbookRecord RecordType = "orderbook"
+	TradeRecord     RecordType = "trade"
+	KlineRecord     RecordType = "kline"
+)
+
+// Record holds a single normalised market data event. Only the field matching
+// Type is set
+type Record struct {
+	Type      RecordType          `json:"type"`
+	Timestamp time.Time           `json:"timestamp"`
+	Exchange  string              `json:"exchange"`
+	Pair      pair.CurrencyPair   `json:"pair"`
+	AssetType string              `json:"assetType"`
+	Ticker    *ticker.Price       `json:"ticker,omitempty"`
+	Orderbook *orderbook.Base     `json:"orderbook,omitempty"`
+	Trade     *exchange.TradeData `json:"trade,omitempty"`
+	Kline     *exchange.KlineData `json:"kline,omitempty"`
+}
+
+// Filter selects the records to read back, zero values match all records
+type Filter struct {
+	Exchange  string
+	Pair      pair.CurrencyPair
+	Types     []RecordType
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Recorder persists market data records to compressed, rotated files within
+// the data directory
+type Recorder struct {
+	dir              string
+	rotationInterval time.Duration
+	maxFileSize      int64
+	selections       map[string]selection
+	writers          map[string]*writer
+	shutdown         chan struct{}
+	mtx              sync.Mutex
+}
+
+// selection holds the market data recorded for an exchange
+type selection struct {
+	pairs      []pair.CurrencyPair
+	tickers    bool
+	orderbooks bool
+	trades     bool
+	klines     bool
+}
+
+// writer holds an open recording file for an exchange
+type writer struct {
+	file    *os.File
+	gz      *gzip.Writer
+	buf     *bufio.Writer
+	opened  time.Time
+	written int64
+}
+
+// Reader reads records back from recording files in time order
+type Reader struct {
+	files   []string
+	filter  Filter
+	file    *os.File
+	gz      *gzip.Reader
+	scanner *bufio.Scanner
+}
@@ -100,6 +100,90 @@ func NewRouter(exchanges []exchange.IBotExchange) *mux.Router {
 			"/exchanges/{exchangeName}/orderbook/latest/{currency}",
 			RESTGetOrderbook,
 		},
+		Route{
+			"IndividualExchangeOrderbookAnalytics",
+			"GET",
+			"/exchanges/{exchangeName}/orderbook/analytics/{currency}",
+			RESTGetOrderbookAnalytics,
+		},
+		Route{
+			"IndividualExchangeOrderbookFill",
+			"GET",
+			"/exchanges/{exchangeName}/orderbook/fill/{currency}",
+			RESTGetOrderbookFill,
+		},
+		Route{
+			"IndividualExchangeOrderbookLiquidity",
+			"GET",
+			"/exchanges/{exchangeName}/orderbook/liquidity/{currency}",
+			RESTGetOrderbookLiquidity,
+		},
+		Route{
+			"AllEnabledExchangeFeatures",
+			"GET",
+			"/exchanges/enabled/features/all",
+			RESTGetAllEnabledExchangeFeatures,
+		},
+		Route{
+			"IndividualExchangeFeatures",
+			"GET",
+			"/exchanges/{exchangeName}/features",
+			RESTGetExchangeFeatures,
+		},
+		Route{
+			"RiskStatus",
+			"GET",
+			"/risk",
+			RESTAuth(RESTGetRiskStatus),
+		},
+		Route{
+			"ArbitrageOpportunities",
+			"GET",
+			"/arbitrage",
+			RESTGetArbitrageOpportunities,
+		},
+		Route{
+			"Executions",
+			"GET",
+			"/executions",
+			RESTAuth(RESTGetExecutions),
+		},
+		Route{
+			"IndividualExecution",
+			"GET",
+			"/executions/{id}",
+			RESTAuth(RESTGetExecution),
+		},
+		Route{
+			"ConditionalOrders",
+			"GET",
+			"/conditionalorders",
+			RESTAuth(RESTGetConditionalOrders),
+		},
+		Route{
+			"Events",
+			"GET",
+			"/events",
+			RESTAuth(RESTGetEvents),
+		},
+		Route{
+			"AddEvent",
+			"POST",
+			"/events",
+			RESTAuth(RESTAddEvent),
+		},
+		Route{
+			"IndividualEvent",
+			"GET",
+			"/events/{id}",
+			RESTAuth(RESTGetEvent),
+		},
+		Route{
+			"RemoveEvent",
+			"DELETE",
+			"/events/{id}",
+			RESTAuth(RESTRemoveEvent),
+		},
 		Route{
 			"ws",
 			"GET",
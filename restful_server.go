@@ -1,15 +1,24 @@
 package main
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/thrasher-/gocryptotrader/arbitrage"
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/events"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/conditional"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/risk"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/execution"
 )
 
 // AllEnabledExchangeOrderbooks holds the enabled exchange orderbooks
@@ -41,6 +50,27 @@ type AllEnabledExchangeAccounts struct {
 	Data []exchange.AccountInfo `json:"data"`
 }
 
+// AllEnabledExchangeFeatures holds the features of all enabled exchanges
+type AllEnabledExchangeFeatures struct {
+	Data []ExchangeFeatures `json:"data"`
+}
+
+// ExchangeFeatures holds the REST and websocket features, withdrawal
+// permissions and order submission features of an exchange
+type ExchangeFeatures struct {
+	ExchangeName            string            `json:"exchangeName"`
+	Features                exchange.Features `json:"features"`
+	WithdrawPermissions     string            `json:"withdrawPermissions"`
+	OrderSubmissionFeatures string            `json:"orderSubmissionFeatures"`
+}
+
+// RiskStatus holds the kill switch state and recent risk violations
+type RiskStatus struct {
+	KillSwitch       bool             `json:"killSwitch"`
+	KillSwitchReason string           `json:"killSwitchReason,omitempty"`
+	Violations       []risk.Violation `json:"violations"`
+}
+
 // RESTfulJSONResponse outputs a JSON response of the response interface
 func RESTfulJSONResponse(w http.ResponseWriter, r *http.Request, response interface{}) error {
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
@@ -54,6 +84,24 @@ func RESTfulError(method string, err error) {
 		method, err)
 }
 
+// RESTAuth wraps a handler so it requires HTTP basic auth with the webserver
+// admin username and the hex encoded SHA256 of its password, the same
+// credentials the websocket auth command takes
+func RESTAuth(inner http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		hashPW := common.HexEncodeToString(common.GetSHA256([]byte(bot.config.Webserver.AdminPassword)))
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(username), []byte(bot.config.Webserver.AdminUsername)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(password), []byte(hashPW)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="gocryptotrader"`)
+			http.Error(w, "invalid username/password", http.StatusUnauthorized)
+			return
+		}
+		inner(w, r)
+	}
+}
+
 // RESTGetAllSettings replies to a request with an encoded JSON response about the
 // trading bots configuration.
 func RESTGetAllSettings(w http.ResponseWriter, r *http.Request) {
@@ -112,6 +160,139 @@ func RESTGetOrderbook(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// defaultAnalyticsBps are the distances from the mid price, in basis points,
+// liquidity is reported at when no bps are requested
+var defaultAnalyticsBps = []float64{10, 50, 100}
+
+// getCachedOrderbook returns the cached orderbook for the exchange and
+// currency in the route and the asset type query parameter, writing an error
+// response if it is unavailable
+func getCachedOrderbook(w http.ResponseWriter, r *http.Request) (orderbook.Update, bool) {
+	vars := mux.Vars(r)
+	exchName := vars["exchangeName"]
+	currency := vars["currency"]
+	assetType := r.URL.Query().Get("assetType")
+	if assetType == "" {
+		assetType = orderbook.Spot
+	}
+
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		http.Error(w, ErrExchangeNotFound.Error(), http.StatusNotFound)
+		return orderbook.Update{}, false
+	}
+	p, err := pair.ParseCurrencyPair(common.StringToUpper(currency))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return orderbook.Update{}, false
+	}
+	u := orderbook.Update{
+		Exchange:  exch.GetName(),
+		Pair:      p,
+		AssetType: common.StringToUpper(assetType),
+	}
+	u.Orderbook, err = orderbook.GetOrderbook(u.Exchange, u.Pair, u.AssetType)
+	if err != nil {
+		log.Printf("Failed to fetch orderbook for %s currency: %s\n", exchName, currency)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return orderbook.Update{}, false
+	}
+	return u, true
+}
+
+// parseFloats parses a comma separated list of numbers
+func parseFloats(values string) ([]float64, error) {
+	var result []float64
+	for _, v := range common.SplitStrings(values, ",") {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+// RESTGetOrderbookAnalytics returns the spread, spread statistics, imbalance
+// and liquidity of a cached orderbook. The bps query parameter sets the
+// distances from the mid price liquidity is reported at
+func RESTGetOrderbookAnalytics(w http.ResponseWriter, r *http.Request) {
+	ob, ok := getCachedOrderbook(w, r)
+	if !ok {
+		return
+	}
+	bps := defaultAnalyticsBps
+	if v := r.URL.Query().Get("bps"); v != "" {
+		var err error
+		bps, err = parseFloats(v)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	analytics, err := orderbook.GetAnalytics(ob.Exchange, ob.Pair, ob.AssetType, bps...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, r, analytics)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetOrderbookFill returns the volume weighted average price and slippage
+// of a market order of the amount and side query parameters against a cached
+// orderbook. A fill smaller than the amount is returned when the orderbook is
+// not deep enough
+func RESTGetOrderbookFill(w http.ResponseWriter, r *http.Request) {
+	ob, ok := getCachedOrderbook(w, r)
+	if !ok {
+		return
+	}
+	amount, err := strconv.ParseFloat(r.URL.Query().Get("amount"), 64)
+	if err != nil {
+		http.Error(w, orderbook.ErrInvalidAmount.Error(), http.StatusBadRequest)
+		return
+	}
+	side := orderbook.Side(common.StringToUpper(r.URL.Query().Get("side")))
+
+	fill, err := ob.Orderbook.SimulateFill(side, amount)
+	if err != nil && err != orderbook.ErrInsufficientLiquidity {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, r, fill)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetOrderbookLiquidity returns the liquidity of a cached orderbook within
+// the bps query parameter of the mid price
+func RESTGetOrderbookLiquidity(w http.ResponseWriter, r *http.Request) {
+	ob, ok := getCachedOrderbook(w, r)
+	if !ok {
+		return
+	}
+	bps, err := strconv.ParseFloat(r.URL.Query().Get("bps"), 64)
+	if err != nil || bps <= 0 {
+		http.Error(w, "bps must be above zero", http.StatusBadRequest)
+		return
+	}
+
+	liquidity, err := ob.Orderbook.GetLiquidity(bps)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, r, liquidity)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
 // GetAllActiveOrderbooks returns all enabled exchanges orderbooks
 func GetAllActiveOrderbooks() []EnabledExchangeOrderbooks {
 	var orderbookData []EnabledExchangeOrderbooks
@@ -291,3 +472,209 @@ func RESTGetAllEnabledAccountInfo(w http.ResponseWriter, r *http.Request) {
 		RESTfulError(r.Method, err)
 	}
 }
+
+// GetExchangeFeatures returns the features supported by an exchange
+func GetExchangeFeatures(exch exchange.IBotExchange) ExchangeFeatures {
+	return ExchangeFeatures{
+		ExchangeName:            exch.GetName(),
+		Features:                exch.GetFeatures(),
+		WithdrawPermissions:     exch.FormatWithdrawPermissions(),
+		OrderSubmissionFeatures: exch.FormatOrderSubmissionFeatures(),
+	}
+}
+
+// GetAllEnabledExchangeFeatures returns the features supported by all enabled
+// exchanges
+func GetAllEnabledExchangeFeatures() AllEnabledExchangeFeatures {
+	var response AllEnabledExchangeFeatures
+	for _, individualBot := range bot.exchanges {
+		if individualBot != nil && individualBot.IsEnabled() {
+			response.Data = append(response.Data, GetExchangeFeatures(individualBot))
+		}
+	}
+	return response
+}
+
+// RESTGetAllEnabledExchangeFeatures returns the features supported by all
+// enabled exchanges
+func RESTGetAllEnabledExchangeFeatures(w http.ResponseWriter, r *http.Request) {
+	response := GetAllEnabledExchangeFeatures()
+	err := RESTfulJSONResponse(w, r, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetExchangeFeatures returns the features supported by a given exchange
+func RESTGetExchangeFeatures(w http.ResponseWriter, r *http.Request) {
+	exchName := mux.Vars(r)["exchangeName"]
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		log.Printf("Failed to fetch features for %s: %s", exchName, ErrExchangeNotFound)
+		http.Error(w, ErrExchangeNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	err := RESTfulJSONResponse(w, r, GetExchangeFeatures(exch))
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// GetRiskStatus returns the kill switch state and recent risk violations
+func GetRiskStatus() RiskStatus {
+	if bot.risk == nil {
+		return RiskStatus{}
+	}
+	active, reason := bot.risk.IsKillSwitchActive()
+	return RiskStatus{
+		KillSwitch:       active,
+		KillSwitchReason: reason,
+		Violations:       bot.risk.GetViolations(),
+	}
+}
+
+// RESTGetRiskStatus returns the kill switch state and recent risk violations
+func RESTGetRiskStatus(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, r, GetRiskStatus())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// GetArbitrageOpportunities returns the opportunities found by the last
+// arbitrage scan
+func GetArbitrageOpportunities() []arbitrage.Opportunity {
+	if bot.arbitrage == nil {
+		return nil
+	}
+	return bot.arbitrage.GetOpportunities()
+}
+
+// RESTGetArbitrageOpportunities returns the opportunities found by the last
+// arbitrage scan
+func RESTGetArbitrageOpportunities(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, r, GetArbitrageOpportunities())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// GetExecutions returns the progress of every order execution
+func GetExecutions() []execution.Progress {
+	if bot.execution == nil {
+		return nil
+	}
+	return bot.execution.GetAll()
+}
+
+// RESTGetExecutions returns the progress of every order execution
+func RESTGetExecutions(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, r, GetExecutions())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetExecution returns the progress of a single order execution
+func RESTGetExecution(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if bot.execution == nil {
+		http.Error(w, execution.ErrExecutionNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	progress, err := bot.execution.Get(id)
+	if err != nil {
+		log.Printf("Failed to fetch execution %s: %s", id, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	err = RESTfulJSONResponse(w, r, progress)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// GetConditionalOrders returns every stop loss, take profit and trailing stop
+// held by the bot
+func GetConditionalOrders() []conditional.Order {
+	if bot.conditional == nil {
+		return nil
+	}
+	return bot.conditional.GetOrders()
+}
+
+// RESTGetConditionalOrders returns every stop loss, take profit and trailing
+// stop held by the bot
+func RESTGetConditionalOrders(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, r, GetConditionalOrders())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetEvents returns every event on the events chain
+func RESTGetEvents(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, r, events.GetEvents())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTAddEvent adds an event from a JSON body in the same format as the
+// addevent websocket command and returns it
+func RESTAddEvent(w http.ResponseWriter, r *http.Request) {
+	var req WebsocketAddEventRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	e, err := req.toEvent()
+	if err == nil {
+		_, err = events.AddEvent(&e)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = RESTfulJSONResponse(w, r, e)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetEvent returns an event by its ID
+func RESTGetEvent(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, events.ErrEventNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	e, err := events.GetEvent(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	err = RESTfulJSONResponse(w, r, e)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTRemoveEvent removes an event by its ID
+func RESTRemoveEvent(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || !events.RemoveEvent(id) {
+		http.Error(w, events.ErrEventNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	err = RESTfulJSONResponse(w, r, WebsocketResponseSuccess)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
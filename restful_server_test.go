@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 )
 
@@ -50,3 +51,40 @@ func TestConfigAllJsonResponse(t *testing.T) {
 		t.Error("Test failed. Json not equal to config")
 	}
 }
+
+func TestRESTAuth(t *testing.T) {
+	oldConfig := bot.config
+	defer func() { bot.config = oldConfig }()
+	bot.config = &config.Config{}
+	bot.config.Webserver.AdminUsername = "admin"
+	bot.config.Webserver.AdminPassword = "Password"
+
+	handler := RESTAuth(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	hashPW := common.HexEncodeToString(common.GetSHA256([]byte("Password")))
+	tests := []struct {
+		username, password string
+		setAuth            bool
+		expected           int
+	}{
+		{expected: http.StatusUnauthorized},
+		{username: "admin", password: "Password", setAuth: true, expected: http.StatusUnauthorized},
+		{username: "bob", password: hashPW, setAuth: true, expected: http.StatusUnauthorized},
+		{username: "admin", password: hashPW, setAuth: true, expected: http.StatusOK},
+	}
+
+	for i, x := range tests {
+		req := httptest.NewRequest("POST", "http://localhost:9050/events", nil)
+		if x.setAuth {
+			req.SetBasicAuth(x.username, x.password)
+		}
+		w := httptest.NewRecorder()
+		handler(w, req)
+		if w.Code != x.expected {
+			t.Errorf("Test failed. RESTAuth test %d returned %d, expected %d",
+				i, w.Code, x.expected)
+		}
+	}
+}
@@ -210,6 +210,15 @@ func TickerUpdaterRoutine() {
 					printTickerSummary(result, c, assetType, exchangeName, err)
 					if err == nil {
 						bot.comms.StageTickerData(exchangeName, assetType, result)
+						if bot.recorder != nil {
+							recordErr := bot.recorder.RecordTicker(exchangeName, c, assetType, result)
+							if recordErr != nil {
+								log.Printf("Failed to record %s ticker. Error: %s", exchangeName, recordErr)
+							}
+						}
+						if bot.strategies != nil {
+							bot.strategies.ProcessTicker(exchangeName, assetType, result)
+						}
 						if bot.config.Webserver.Enabled {
 							relayWebsocketEvent(result, "ticker_update", assetType, exchangeName)
 						}
@@ -261,6 +270,15 @@ func OrderbookUpdaterRoutine() {
 					printOrderbookSummary(result, c, assetType, exchangeName, err)
 					if err == nil {
 						bot.comms.StageOrderbookData(exchangeName, assetType, result)
+						if bot.recorder != nil {
+							recordErr := bot.recorder.RecordOrderbook(exchangeName, c, assetType, result)
+							if recordErr != nil {
+								log.Printf("Failed to record %s orderbook. Error: %s", exchangeName, recordErr)
+							}
+						}
+						if bot.strategies != nil {
+							bot.strategies.ProcessOrderbook(exchangeName, assetType, result)
+						}
 						if bot.config.Webserver.Enabled {
 							relayWebsocketEvent(result, "orderbook_update", assetType, exchangeName)
 						}
@@ -366,6 +384,34 @@ func streamDiversion(ws *exchange.Websocket, verbose bool) {
 	}
 }
 
+// processOrderEvent routes order updates and fills, received over an
+// authenticated websocket connection or simulated by paper trading, to the
+// order manager, risk manager and strategies
+func processOrderEvent(event interface{}) {
+	switch e := event.(type) {
+	case exchange.OrderUpdate:
+		if bot.orderManager != nil {
+			bot.orderManager.ProcessOrderUpdate(e)
+		}
+	case exchange.FillEvent:
+		if bot.orderManager != nil {
+			bot.orderManager.ProcessFill(e)
+		}
+		processFill(e)
+	}
+}
+
+// processFill routes a fill, delivered by an exchange or derived by the order
+// manager, to the risk manager and strategies
+func processFill(fill exchange.FillEvent) {
+	if bot.risk != nil {
+		bot.risk.ProcessFill(fill)
+	}
+	if bot.strategies != nil {
+		bot.strategies.ProcessFill(fill)
+	}
+}
+
 // WebsocketDataHandler handles websocket data coming from a websocket feed
 // associated with an exchange
 func WebsocketDataHandler(ws *exchange.Websocket, verbose bool) {
@@ -407,22 +453,95 @@ func WebsocketDataHandler(ws *exchange.Websocket, verbose bool) {
 				if verbose {
 					log.Println("Websocket trades Updated:   ", data.(exchange.TradeData))
 				}
+				if bot.recorder != nil {
+					err := bot.recorder.RecordTrade(data.(exchange.TradeData))
+					if err != nil {
+						log.Printf("Failed to record %s trade. Error: %s", ws.GetName(), err)
+					}
+				}
+				if bot.strategies != nil {
+					bot.strategies.ProcessTrade(data.(exchange.TradeData))
+				}
+				if bot.execution != nil {
+					bot.execution.ProcessTrade(data.(exchange.TradeData))
+				}
 
 			case exchange.TickerData:
 				// Ticker data
 				if verbose {
 					log.Println("Websocket Ticker Updated:   ", data.(exchange.TickerData))
 				}
+				if bot.recorder != nil {
+					err := bot.recorder.RecordTickerData(data.(exchange.TickerData))
+					if err != nil {
+						log.Printf("Failed to record %s ticker. Error: %s", ws.GetName(), err)
+					}
+				}
+				if bot.strategies != nil {
+					bot.strategies.ProcessTickerData(data.(exchange.TickerData))
+				}
 			case exchange.KlineData:
 				// Kline data
 				if verbose {
 					log.Println("Websocket Kline Updated:    ", data.(exchange.KlineData))
 				}
+				if bot.recorder != nil {
+					err := bot.recorder.RecordKline(data.(exchange.KlineData))
+					if err != nil {
+						log.Printf("Failed to record %s kline. Error: %s", ws.GetName(), err)
+					}
+				}
 			case exchange.WebsocketOrderbookUpdate:
 				// Orderbook data
 				if verbose {
 					log.Println("Websocket Orderbook Updated:", data.(exchange.WebsocketOrderbookUpdate))
 				}
+				if bot.recorder != nil {
+					err := bot.recorder.RecordOrderbookUpdate(data.(exchange.WebsocketOrderbookUpdate))
+					if err != nil {
+						log.Printf("Failed to record %s orderbook. Error: %s", ws.GetName(), err)
+					}
+				}
+				if bot.strategies != nil {
+					err := bot.strategies.ProcessOrderbookUpdate(data.(exchange.WebsocketOrderbookUpdate))
+					if err != nil {
+						log.Printf("Failed to route %s orderbook to strategies. Error: %s", ws.GetName(), err)
+					}
+				}
+			case exchange.WebsocketOrderbookResync:
+				// Orderbook failed validation and was reloaded from REST
+				resync := data.(exchange.WebsocketOrderbookResync)
+				log.Printf("Websocket %s %s %s orderbook resynced: %s",
+					resync.Exchange, resync.Pair.Pair(), resync.Asset, resync.Reason)
+			case exchange.OrderUpdate:
+				// Authenticated order state change
+				update := data.(exchange.OrderUpdate)
+				if verbose {
+					log.Println("Websocket Order Updated:    ", update)
+				}
+				processOrderEvent(update)
+			case exchange.FillEvent:
+				// Authenticated order fill
+				if verbose {
+					log.Println("Websocket Order Filled:     ", data.(exchange.FillEvent))
+				}
+				processOrderEvent(data)
+			case exchange.BalanceUpdate:
+				// Authenticated account balance change
+				balance := data.(exchange.BalanceUpdate)
+				if verbose {
+					log.Println("Websocket Balance Updated:  ", balance)
+				}
+				// AccountCurrencyInfo holds the available balance in
+				// TotalValue, as returned by GetAccountInfo
+				SeedExchangeAccountInfo([]exchange.AccountInfo{{
+					ExchangeName: balance.Exchange,
+					Currencies: []exchange.AccountCurrencyInfo{{
+						CurrencyName: balance.Currency,
+						TotalValue:   balance.Total - balance.Hold,
+						Hold:         balance.Hold,
+					}},
+				}})
 			default:
 				if verbose {
 					log.Println("Websocket Unknown type:     ", data)
@@ -453,8 +572,14 @@ func WebsocketReconnect(ws *exchange.Websocket, verbose bool) {
 			return
 
 		case <-ticker.C:
+			// Tracked channel subscriptions persist across the shutdown and
+			// are resubscribed by the websocket once connected
 			err = ws.Connect()
 			if err == nil {
+				if verbose && ws.SupportsChannelSubscriptions() {
+					log.Printf("Websocket reconnected for %s, resubscribed to %d channels",
+						ws.GetName(), len(ws.GetSubscriptions()))
+				}
 				return
 			}
 		}
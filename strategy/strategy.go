@@ -0,0 +1,510 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// eventBufferSize is the number of events queued for a strategy before new
+// events are dropped
+const eventBufferSize = 100
+
+// vars related to strategies
+var (
+	ErrStrategyNotFound      = errors.New("strategy not registered")
+	ErrStrategyAlreadyExists = errors.New("strategy already registered")
+	ErrExchangeNotPermitted  = errors.New("exchange not enabled for strategy")
+	ErrPairNotPermitted      = errors.New("currency pair not enabled for strategy")
+	ErrRuntimeAlreadyStarted = errors.New("strategy runtime already started")
+
+	factories = make(map[string]Factory)
+	fMtx      sync.RWMutex
+)
+
+// Register adds a strategy factory under the name used to enable it in config
+func Register(name string, f Factory) error {
+	fMtx.Lock()
+	defer fMtx.Unlock()
+
+	name = common.StringToLower(name)
+	if _, ok := factories[name]; ok {
+		return ErrStrategyAlreadyExists
+	}
+	factories[name] = f
+	return nil
+}
+
+// GetRegistered returns the names of all registered strategies
+func GetRegistered() []string {
+	fMtx.RLock()
+	defer fMtx.RUnlock()
+
+	var names []string
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns a runtime for the enabled strategies in cfgs, strategies may
+// only use the loaded exchanges listed in their config
+func New(cfgs []config.StrategyConfig, exchanges []exchange.IBotExchange) (*Runtime, error) {
+	r := &Runtime{}
+	for x := range cfgs {
+		if !cfgs[x].Enabled {
+			continue
+		}
+
+		fMtx.RLock()
+		f, ok := factories[common.StringToLower(cfgs[x].Name)]
+		fMtx.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("%s: %s", cfgs[x].Name, ErrStrategyNotFound)
+		}
+
+		h, err := newHandle(cfgs[x], exchanges)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := f(cfgs[x].Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to create strategy. Err: %s",
+				cfgs[x].Name, err)
+		}
+
+		r.runners = append(r.runners, &runner{
+			strategy:      s,
+			handle:        h,
+			events:        make(chan interface{}, eventBufferSize),
+			timerInterval: cfgs[x].TimerInterval,
+		})
+	}
+	return r, nil
+}
+
+// newHandle returns a handle restricted to the strategy's configured
+// exchanges and pairs
+func newHandle(cfg config.StrategyConfig, exchanges []exchange.IBotExchange) (*Handle, error) {
+	h := &Handle{
+		name:      cfg.Name,
+		exchanges: make(map[string]exchange.IBotExchange),
+	}
+
+	for _, name := range common.SplitStrings(cfg.Exchanges, ",") {
+		name = common.TrimString(name, " ")
+		var found bool
+		for x := range exchanges {
+			if exchanges[x] != nil &&
+				common.StringToLower(exchanges[x].GetName()) == common.StringToLower(name) {
+				h.exchanges[common.StringToLower(name)] = exchanges[x]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s: exchange %s is not loaded", cfg.Name, name)
+		}
+	}
+
+	if cfg.Pairs != "" {
+		for _, p := range common.SplitStrings(cfg.Pairs, ",") {
+			h.pairs = append(h.pairs, pair.NewCurrencyPairFromString(common.StringToUpper(common.TrimString(p, " "))))
+		}
+	}
+	return h, nil
+}
+
+// Start starts a goroutine for each strategy to process its events
+func (r *Runtime) Start() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.started {
+		return ErrRuntimeAlreadyStarted
+	}
+	r.started = true
+	r.shutdown = make(chan struct{})
+	for x := range r.runners {
+		r.wg.Add(1)
+		go r.runners[x].run(r.shutdown, &r.wg)
+	}
+	r.setRunning(true)
+	return nil
+}
+
+// Stop stops all strategies and waits for their current callbacks to return
+func (r *Runtime) Stop() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if !r.started {
+		return
+	}
+	r.setRunning(false)
+	close(r.shutdown)
+	r.wg.Wait()
+	r.started = false
+}
+
+// setRunning sets whether events are dispatched to the strategies
+func (r *Runtime) setRunning(running bool) {
+	r.runMtx.Lock()
+	defer r.runMtx.Unlock()
+	r.running = running
+}
+
+// isRunning returns whether events are dispatched to the strategies
+func (r *Runtime) isRunning() bool {
+	r.runMtx.RLock()
+	defer r.runMtx.RUnlock()
+	return r.running
+}
+
+// GetStrategies returns the names of the strategies in the runtime
+func (r *Runtime) GetStrategies() []string {
+	var names []string
+	for x := range r.runners {
+		names = append(names, r.runners[x].handle.name)
+	}
+	return names
+}
+
+// ProcessTicker routes a ticker to the strategies enabled for its exchange and
+// pair
+func (r *Runtime) ProcessTicker(exchangeName, assetType string, price ticker.Price) {
+	r.dispatch(exchangeName, price.Pair, Ticker{
+		Exchange:  exchangeName,
+		AssetType: assetType,
+		Price:     price,
+	})
+}
+
+// ProcessTickerData routes a websocket ticker to the strategies enabled for
+// its exchange and pair
+func (r *Runtime) ProcessTickerData(data exchange.TickerData) {
+	r.ProcessTicker(data.Exchange, data.AssetType, ticker.Price{
+		Pair:         data.Pair,
+		CurrencyPair: data.Pair.Pair().String(),
+		LastUpdated:  data.Timestamp,
+		Last:         data.ClosePrice,
+		High:         data.HighPrice,
+		Low:          data.LowPrice,
+		Volume:       data.Quantity,
+	})
+}
+
+// ProcessOrderbook routes an orderbook to the strategies enabled for its
+// exchange and pair
+func (r *Runtime) ProcessOrderbook(exchangeName, assetType string, ob orderbook.Base) {
+	r.dispatch(exchangeName, ob.Pair, Orderbook{
+		Exchange:  exchangeName,
+		AssetType: assetType,
+		Orderbook: ob,
+	})
+}
+
+// ProcessOrderbookUpdate routes the cached orderbook for a websocket orderbook
+// update to the strategies enabled for its exchange and pair
+func (r *Runtime) ProcessOrderbookUpdate(update exchange.WebsocketOrderbookUpdate) error {
+	if !r.isRouted(update.Exchange, update.Pair) {
+		return nil
+	}
+	ob, err := orderbook.GetOrderbook(update.Exchange, update.Pair, update.Asset)
+	if err != nil {
+		return err
+	}
+	r.ProcessOrderbook(update.Exchange, update.Asset, ob)
+	return nil
+}
+
+// ProcessTrade routes a trade to the strategies enabled for its exchange and
+// pair
+func (r *Runtime) ProcessTrade(data exchange.TradeData) {
+	r.dispatch(data.Exchange, data.CurrencyPair, data)
+}
+
+// ProcessFill routes an order fill to the strategies enabled for its exchange
+// and pair
+func (r *Runtime) ProcessFill(fill exchange.FillEvent) {
+	r.dispatch(fill.Exchange, fill.Pair, fill)
+}
+
+// isRouted returns whether any strategy receives events for the exchange and
+// pair
+func (r *Runtime) isRouted(exchangeName string, p pair.CurrencyPair) bool {
+	for x := range r.runners {
+		if r.runners[x].handle.permits(exchangeName, p) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// dispatch queues an event for each strategy enabled for the exchange and
+// pair without blocking, events are dropped for strategies which are behind
+// and silently once the runtime has stopped
+func (r *Runtime) dispatch(exchangeName string, p pair.CurrencyPair, event interface{}) {
+	if !r.isRunning() {
+		return
+	}
+	for x := range r.runners {
+		if r.runners[x].handle.permits(exchangeName, p) != nil {
+			continue
+		}
+		select {
+		case r.runners[x].events <- event:
+		default:
+			log.Printf("Strategy %s event queue full, dropping %s %s event",
+				r.runners[x].handle.name, exchangeName, p.Pair())
+		}
+	}
+}
+
+// run calls the strategy for each queued event and timer tick until shutdown
+func (rn *runner) run(shutdown chan struct{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	var timer <-chan time.Time
+	if rn.timerInterval > 0 {
+		t := time.NewTicker(rn.timerInterval)
+		defer t.Stop()
+		timer = t.C
+	}
+
+	for {
+		select {
+		case <-shutdown:
+			return
+		case t := <-timer:
+			rn.handleEvent(timerEvent(t))
+		case event := <-rn.events:
+			rn.handleEvent(event)
+		}
+	}
+}
+
+// handleEvent calls the strategy callback for the event, a panicking strategy
+// is logged rather than taking down the bot
+func (rn *runner) handleEvent(event interface{}) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Printf("Strategy %s panicked handling %T event: %v",
+				rn.handle.name, event, err)
+		}
+	}()
+
+	switch e := event.(type) {
+	case Ticker:
+		rn.strategy.OnTicker(rn.handle, e)
+	case Orderbook:
+		rn.strategy.OnOrderbook(rn.handle, e)
+	case exchange.TradeData:
+		rn.strategy.OnTrade(rn.handle, e)
+	case exchange.FillEvent:
+		rn.strategy.OnFill(rn.handle, e)
+	case timerEvent:
+		rn.strategy.OnTimer(rn.handle, time.Time(e))
+	}
+}
+
+// GetName returns the name of the strategy
+func (h *Handle) GetName() string {
+	return h.name
+}
+
+// GetExchanges returns the names of the exchanges the strategy may use
+func (h *Handle) GetExchanges() []string {
+	var names []string
+	for _, exch := range h.exchanges {
+		names = append(names, exch.GetName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetPairs returns the currency pairs the strategy may use on an exchange
+func (h *Handle) GetPairs(exchangeName string) ([]pair.CurrencyPair, error) {
+	exch, err := h.getExchange(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	if len(h.pairs) == 0 {
+		return exch.GetEnabledCurrencies(), nil
+	}
+
+	var pairs []pair.CurrencyPair
+	for _, p := range exch.GetEnabledCurrencies() {
+		if pair.Contains(h.pairs, p, true) {
+			pairs = append(pairs, p)
+		}
+	}
+	return pairs, nil
+}
+
+// GetTicker returns the latest cached ticker for a permitted exchange and pair
+func (h *Handle) GetTicker(exchangeName string, p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	exch, err := h.check(exchangeName, p)
+	if err != nil {
+		return ticker.Price{}, err
+	}
+	return ticker.GetTicker(exch.GetName(), p, assetType)
+}
+
+// GetOrderbook returns the latest cached orderbook for a permitted exchange
+// and pair
+func (h *Handle) GetOrderbook(exchangeName string, p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	exch, err := h.check(exchangeName, p)
+	if err != nil {
+		return orderbook.Base{}, err
+	}
+	return orderbook.GetOrderbook(exch.GetName(), p, assetType)
+}
+
+// GetAccountInfo returns the account balances held on a permitted exchange
+func (h *Handle) GetAccountInfo(exchangeName string) (exchange.AccountInfo, error) {
+	exch, err := h.getExchange(exchangeName)
+	if err != nil {
+		return exchange.AccountInfo{}, err
+	}
+	return exch.GetAccountInfo()
+}
+
+// GetFeeByType returns a fee from a permitted exchange
+func (h *Handle) GetFeeByType(exchangeName string, feeBuilder exchange.FeeBuilder) (float64, error) {
+	exch, err := h.getExchange(exchangeName)
+	if err != nil {
+		return 0, err
+	}
+	return exch.GetFeeByType(feeBuilder)
+}
+
+// SubmitOrder submits an order for a permitted exchange and pair
+func (h *Handle) SubmitOrder(exchangeName string, order *exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	if order == nil {
+		return exchange.SubmitOrderResponse{}, exchange.ErrOrderSubmissionIsNil
+	}
+	exch, err := h.check(exchangeName, order.CurrencyPair)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+	return exch.SubmitOrder(order)
+}
+
+// CancelOrder cancels an order for a permitted exchange and pair, the order's
+// currency pair must be set
+func (h *Handle) CancelOrder(exchangeName string, order exchange.OrderCancellation) error {
+	exch, err := h.check(exchangeName, order.CurrencyPair)
+	if err != nil {
+		return err
+	}
+	return exch.CancelOrder(order)
+}
+
+// GetOrder returns the state of an order placed on a permitted exchange and
+// pair from the exchange's order history, active orders or order info
+func (h *Handle) GetOrder(exchangeName, orderID string, p pair.CurrencyPair) (exchange.OrderDetail, error) {
+	exch, err := h.check(exchangeName, p)
+	if err != nil {
+		return exchange.OrderDetail{}, err
+	}
+	return exchange.GetOrderDetail(exch, orderID, p)
+}
+
+// SupportsOrderSubmissionFeatures returns whether a permitted exchange
+// supports all of the supplied order submission features
+func (h *Handle) SupportsOrderSubmissionFeatures(exchangeName string, features uint32) bool {
+	exch, err := h.getExchange(exchangeName)
+	if err != nil {
+		return false
+	}
+	return exch.SupportsOrderSubmissionFeatures(features)
+}
+
+// GetActiveOrders returns the open orders on a permitted exchange for the
+// strategy's pairs
+func (h *Handle) GetActiveOrders(exchangeName string, req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	pairs, err := h.GetPairs(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+
+	var currencies []pair.CurrencyPair
+	for x := range req.Currencies {
+		if pair.Contains(pairs, req.Currencies[x], true) {
+			currencies = append(currencies, req.Currencies[x])
+		}
+	}
+	if len(req.Currencies) == 0 {
+		currencies = pairs
+	}
+	if len(currencies) == 0 {
+		return nil, ErrPairNotPermitted
+	}
+	req.Currencies = currencies
+
+	orders, err := h.exchanges[common.StringToLower(exchangeName)].GetActiveOrders(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var permitted []exchange.OrderDetail
+	for x := range orders {
+		if pair.Contains(currencies, orders[x].CurrencyPair, true) {
+			permitted = append(permitted, orders[x])
+		}
+	}
+	return permitted, nil
+}
+
+// check returns the exchange if both it and the pair are permitted
+func (h *Handle) check(exchangeName string, p pair.CurrencyPair) (exchange.IBotExchange, error) {
+	err := h.permits(exchangeName, p)
+	if err != nil {
+		return nil, err
+	}
+	return h.exchanges[common.StringToLower(exchangeName)], nil
+}
+
+// getExchange returns a permitted exchange
+func (h *Handle) getExchange(exchangeName string) (exchange.IBotExchange, error) {
+	exch, ok := h.exchanges[common.StringToLower(exchangeName)]
+	if !ok {
+		return nil, ErrExchangeNotPermitted
+	}
+	return exch, nil
+}
+
+// permits returns an error unless the strategy is enabled for the exchange and
+// pair
+func (h *Handle) permits(exchangeName string, p pair.CurrencyPair) error {
+	exch, err := h.getExchange(exchangeName)
+	if err != nil {
+		return err
+	}
+	if p.Empty() {
+		return ErrPairNotPermitted
+	}
+	if len(h.pairs) > 0 {
+		if !pair.Contains(h.pairs, p, true) {
+			return ErrPairNotPermitted
+		}
+		return nil
+	}
+	if !pair.Contains(exch.GetEnabledCurrencies(), p, true) {
+		return ErrPairNotPermitted
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package strategy
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/exchangetest"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+var (
+	btcusd = pair.NewCurrencyPair("BTC", "USD")
+	ltcusd = pair.NewCurrencyPair("LTC", "USD")
+)
+
+// newTestExchange returns an exchange trading BTCUSD and LTCUSD with an open
+// order on each pair
+func newTestExchange(name string) *exchangetest.Exchange {
+	e := exchangetest.New(name, btcusd, ltcusd)
+	e.Active = []exchange.OrderDetail{
+		{ID: "1", CurrencyPair: btcusd},
+		{ID: "2", CurrencyPair: ltcusd},
+	}
+	return e
+}
+
+// testStrategy records its callbacks and buys on every ticker
+type testStrategy struct {
+	params  testParams
+	events  chan interface{}
+	handles chan *Handle
+}
+
+type testParams struct {
+	Amount float64 `json:"amount"`
+}
+
+func (s *testStrategy) OnTicker(h *Handle, t Ticker) {
+	_, err := h.SubmitOrder(t.Exchange, &exchange.OrderSubmission{
+		CurrencyPair: t.Price.Pair,
+		OrderSide:    exchange.Buy,
+		OrderType:    exchange.Market,
+		Amount:       s.params.Amount,
+	})
+	if err != nil {
+		s.events <- err
+		return
+	}
+	s.events <- t
+}
+
+func (s *testStrategy) OnOrderbook(h *Handle, ob Orderbook) {
+	s.events <- ob
+}
+
+func (s *testStrategy) OnTrade(h *Handle, trade exchange.TradeData) {
+	panic("test panic")
+}
+
+func (s *testStrategy) OnFill(h *Handle, fill exchange.FillEvent) {
+	s.events <- fill
+}
+
+func (s *testStrategy) OnTimer(h *Handle, t time.Time) {
+	s.events <- t
+}
+
+var testStrategies = make(chan *testStrategy, 10)
+
+func init() {
+	err := Register("Test", func(params json.RawMessage) (Strategy, error) {
+		s := &testStrategy{events: make(chan interface{}, 10)}
+		if len(params) > 0 {
+			err := json.Unmarshal(params, &s.params)
+			if err != nil {
+				return nil, err
+			}
+		}
+		testStrategies <- s
+		return s, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = Register("broken", func(params json.RawMessage) (Strategy, error) {
+		return nil, errors.New("broken")
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func receive(t *testing.T, s *testStrategy) interface{} {
+	select {
+	case e := <-s.events:
+		return e
+	case <-time.After(time.Second * 5):
+		t.Fatal("Test failed. Timed out waiting for strategy event")
+	}
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	if err := Register("test", nil); err != ErrStrategyAlreadyExists {
+		t.Errorf("Test failed. Register expected %s got %v", ErrStrategyAlreadyExists, err)
+	}
+
+	names := GetRegistered()
+	if len(names) != 2 || names[0] != "broken" || names[1] != "test" {
+		t.Errorf("Test failed. GetRegistered unexpected result %v", names)
+	}
+}
+
+func TestNew(t *testing.T) {
+	exchanges := []exchange.IBotExchange{newTestExchange("Bitstamp")}
+
+	_, err := New([]config.StrategyConfig{
+		{Name: "missing", Enabled: true, Exchanges: "Bitstamp"},
+	}, exchanges)
+	if err == nil {
+		t.Error("Test failed. New expected error for unregistered strategy")
+	}
+
+	_, err = New([]config.StrategyConfig{
+		{Name: "broken", Enabled: true, Exchanges: "Bitstamp"},
+	}, exchanges)
+	if err == nil {
+		t.Error("Test failed. New expected error from strategy factory")
+	}
+
+	_, err = New([]config.StrategyConfig{
+		{Name: "broken", Enabled: true, Exchanges: "Kraken"},
+	}, exchanges)
+	if err == nil {
+		t.Error("Test failed. New expected error for exchange not loaded")
+	}
+
+	r, err := New([]config.StrategyConfig{
+		{Name: "broken", Enabled: false},
+	}, exchanges)
+	if err != nil {
+		t.Fatalf("Test failed. New error: %s", err)
+	}
+	if len(r.GetStrategies()) != 0 {
+		t.Error("Test failed. New expected disabled strategies to be skipped")
+	}
+}
+
+func TestRuntime(t *testing.T) {
+	exch := newTestExchange("Bitstamp")
+	r, err := New([]config.StrategyConfig{
+		{
+			Name:          "test",
+			Enabled:       true,
+			Exchanges:     "bitstamp",
+			Pairs:         "BTC-USD",
+			TimerInterval: time.Millisecond * 50,
+			Parameters:    json.RawMessage(`{"amount":0.5}`),
+		},
+	}, []exchange.IBotExchange{exch, newTestExchange("Kraken")})
+	if err != nil {
+		t.Fatalf("Test failed. New error: %s", err)
+	}
+	s := <-testStrategies
+
+	if s.params.Amount != 0.5 {
+		t.Errorf("Test failed. Expected amount parameter 0.5 got %f", s.params.Amount)
+	}
+
+	err = r.Start()
+	if err != nil {
+		t.Fatalf("Test failed. Start error: %s", err)
+	}
+	if r.Start() != ErrRuntimeAlreadyStarted {
+		t.Error("Test failed. Expected ErrRuntimeAlreadyStarted")
+	}
+	defer r.Stop()
+
+	// the timer fires first as no market data has been routed
+	if _, ok := receive(t, s).(time.Time); !ok {
+		t.Error("Test failed. Expected timer event")
+	}
+
+	// events for other exchanges and pairs are not routed
+	r.ProcessTicker("Kraken", ticker.Spot, ticker.Price{Pair: btcusd, Last: 1})
+	r.ProcessTicker("Bitstamp", ticker.Spot, ticker.Price{Pair: ltcusd, Last: 1})
+	// panics are recovered and the strategy continues
+	r.ProcessTrade(exchange.TradeData{Exchange: "Bitstamp", CurrencyPair: btcusd})
+	r.ProcessTicker("Bitstamp", ticker.Spot, ticker.Price{Pair: btcusd, Last: 100})
+
+	for {
+		e := receive(t, s)
+		if _, ok := e.(time.Time); ok {
+			continue
+		}
+		tick, ok := e.(Ticker)
+		if !ok {
+			t.Fatalf("Test failed. Expected ticker event got %v", e)
+		}
+		if tick.Exchange != "Bitstamp" || tick.Price.Last != 100 {
+			t.Errorf("Test failed. Unexpected ticker event %+v", tick)
+		}
+		break
+	}
+
+	if submitted := exch.Submitted(); len(submitted) != 1 || submitted[0].Amount != 0.5 {
+		t.Errorf("Test failed. Expected a single order to be submitted got %v", submitted)
+	}
+
+	r.ProcessOrderbook("bitstamp", ticker.Spot, orderbook.Base{Pair: btcusd})
+	r.ProcessFill(exchange.FillEvent{Exchange: "Bitstamp", Pair: btcusd, Amount: 1})
+	var gotOrderbook, gotFill bool
+	for !gotOrderbook || !gotFill {
+		switch receive(t, s).(type) {
+		case Orderbook:
+			gotOrderbook = true
+		case exchange.FillEvent:
+			gotFill = true
+		}
+	}
+
+	// events are dropped once the runtime has stopped
+	r.Stop()
+	r.ProcessFill(exchange.FillEvent{Exchange: "Bitstamp", Pair: btcusd, Amount: 1})
+	if queued := len(r.runners[0].events); queued != 0 {
+		t.Errorf("Test failed. Expected no events queued after Stop got %d", queued)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	exch := newTestExchange("Bitstamp")
+	h, err := newHandle(config.StrategyConfig{
+		Name:      "test",
+		Exchanges: "Bitstamp",
+		Pairs:     "BTCUSD",
+	}, []exchange.IBotExchange{exch, newTestExchange("Kraken")})
+	if err != nil {
+		t.Fatalf("Test failed. newHandle error: %s", err)
+	}
+
+	if h.GetName() != "test" {
+		t.Errorf("Test failed. GetName expected test got %s", h.GetName())
+	}
+	if names := h.GetExchanges(); len(names) != 1 || names[0] != "Bitstamp" {
+		t.Errorf("Test failed. GetExchanges unexpected result %v", names)
+	}
+
+	pairs, err := h.GetPairs("bitstamp")
+	if err != nil || len(pairs) != 1 || !pairs[0].Equal(btcusd, true) {
+		t.Errorf("Test failed. GetPairs unexpected result %v %v", pairs, err)
+	}
+
+	_, err = h.SubmitOrder("Kraken", &exchange.OrderSubmission{CurrencyPair: btcusd})
+	if err != ErrExchangeNotPermitted {
+		t.Errorf("Test failed. Expected %s got %v", ErrExchangeNotPermitted, err)
+	}
+
+	_, err = h.SubmitOrder("Bitstamp", &exchange.OrderSubmission{CurrencyPair: ltcusd})
+	if err != ErrPairNotPermitted {
+		t.Errorf("Test failed. Expected %s got %v", ErrPairNotPermitted, err)
+	}
+
+	err = h.CancelOrder("Bitstamp", exchange.OrderCancellation{OrderID: "1"})
+	if err != ErrPairNotPermitted {
+		t.Errorf("Test failed. Expected %s got %v", ErrPairNotPermitted, err)
+	}
+
+	orders, err := h.GetActiveOrders("Bitstamp", exchange.GetOrdersRequest{})
+	if err != nil {
+		t.Fatalf("Test failed. GetActiveOrders error: %s", err)
+	}
+	if len(orders) != 1 || orders[0].ID != "1" {
+		t.Errorf("Test failed. GetActiveOrders expected only BTCUSD orders got %v", orders)
+	}
+
+	_, err = h.GetActiveOrders("Bitstamp", exchange.GetOrdersRequest{
+		Currencies: []pair.CurrencyPair{ltcusd},
+	})
+	if err != ErrPairNotPermitted {
+		t.Errorf("Test failed. Expected %s got %v", ErrPairNotPermitted, err)
+	}
+}
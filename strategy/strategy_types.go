@@ -0,0 +1,72 @@
+package strategy
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Strategy is implemented by trading logic run by the strategy runtime. The
+// callbacks of a strategy are called from a single goroutine
+type Strategy interface {
+	OnTicker(h *Handle, t Ticker)
+	OnOrderbook(h *Handle, ob Orderbook)
+	OnTrade(h *Handle, trade exchange.TradeData)
+	OnFill(h *Handle, fill exchange.FillEvent)
+	OnTimer(h *Handle, t time.Time)
+}
+
+// Factory creates a strategy from the parameters set in its config
+type Factory func(params json.RawMessage) (Strategy, error)
+
+// Ticker holds a ticker update for an exchange
+type Ticker struct {
+	Exchange  string
+	AssetType string
+	Price     ticker.Price
+}
+
+// Orderbook holds an orderbook update for an exchange
+type Orderbook struct {
+	Exchange  string
+	AssetType string
+	Orderbook orderbook.Base
+}
+
+// timerEvent is sent to a strategy when its timer fires
+type timerEvent time.Time
+
+// Runtime routes market data and fills to the enabled strategies
+type Runtime struct {
+	runners  []*runner
+	shutdown chan struct{}
+	started  bool
+	wg       sync.WaitGroup
+	mtx      sync.Mutex
+	// running is set while strategies process events, it is guarded
+	// separately from mtx so that events may be dispatched by strategy
+	// callbacks while the runtime is stopping
+	running bool
+	runMtx  sync.RWMutex
+}
+
+// runner holds an enabled strategy and its pending events
+type runner struct {
+	strategy      Strategy
+	handle        *Handle
+	events        chan interface{}
+	timerInterval time.Duration
+}
+
+// Handle gives a strategy access to the exchanges and pairs it has been
+// enabled for
+type Handle struct {
+	name      string
+	exchanges map[string]exchange.IBotExchange
+	pairs     []pair.CurrencyPair
+}
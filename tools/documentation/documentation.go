@@ -13,6 +13,7 @@ import (
 )
 
 const (
+	backtestPath                    = "..%s..%sbacktest%s"
 	commonPath                      = "..%s..%scommon%s"
 	communicationsPath              = "..%s..%scommunications%s"
 	communicationsBasePath          = "..%s..%scommunications%sbase%s"
@@ -38,8 +39,18 @@ const (
 	exchangesStatsPath              = "..%s..%sexchanges%sstats%s"
 	exchangesTickerPath             = "..%s..%sexchanges%sticker%s"
 	exchangesOrdersPath             = "..%s..%sexchanges%sorders%s"
+	exchangesPaperTradePath         = "..%s..%sexchanges%spapertrade%s"
+	exchangesRiskPath               = "..%s..%sexchanges%srisk%s"
+	exchangesExchangeTestPath       = "..%s..%sexchanges%sexchangetest%s"
+	exchangesConditionalPath        = "..%s..%sexchanges%sconditional%s"
+	exchangesKlinePath              = "..%s..%sexchanges%skline%s"
 	exchangesRequestPath            = "..%s..%sexchanges%srequest%s"
 	portfolioPath                   = "..%s..%sportfolio%s"
+	recorderPath                    = "..%s..%srecorder%s"
+	strategyPath                    = "..%s..%sstrategy%s"
+	arbitragePath                   = "..%s..%sarbitrage%s"
+	orderrouterPath                 = "..%s..%sorderrouter%s"
+	executionPath                   = "..%s..%sexecution%s"
 	testdataPath                    = "..%s..%stestdata%s"
 	toolsPath                       = "..%s..%stools%s"
 	webPath                         = "..%s..%sweb%s"
@@ -183,6 +194,8 @@ func updateReadme() error {
 
 // addPaths adds paths to different potential README.md files in the codebase
 func addPaths() {
+	codebasePaths["backtest"] = fmt.Sprintf(backtestPath, path, path, path)
+
 	codebasePaths["common"] = fmt.Sprintf(commonPath, path, path, path)
 
 	codebasePaths["communications comms"] = fmt.Sprintf(communicationsPath, path, path, path)
@@ -208,6 +221,11 @@ func addPaths() {
 	codebasePaths["events"] = fmt.Sprintf(eventsPath, path, path, path)
 
 	codebasePaths["portfolio"] = fmt.Sprintf(portfolioPath, path, path, path)
+	codebasePaths["recorder"] = fmt.Sprintf(recorderPath, path, path, path)
+	codebasePaths["strategy"] = fmt.Sprintf(strategyPath, path, path, path)
+	codebasePaths["arbitrage"] = fmt.Sprintf(arbitragePath, path, path, path)
+	codebasePaths["orderrouter"] = fmt.Sprintf(orderrouterPath, path, path, path)
+	codebasePaths["execution"] = fmt.Sprintf(executionPath, path, path, path)
 	codebasePaths["testdata"] = fmt.Sprintf(testdataPath, path, path, path)
 	codebasePaths["tools"] = fmt.Sprintf(toolsPath, path, path, path)
 	codebasePaths["web"] = fmt.Sprintf(webPath, path, path, path)
@@ -219,6 +237,11 @@ func addPaths() {
 	codebasePaths["exchanges stats"] = fmt.Sprintf(exchangesStatsPath, path, path, path, path)
 	codebasePaths["exchanges ticker"] = fmt.Sprintf(exchangesTickerPath, path, path, path, path)
 	codebasePaths["exchanges orders"] = fmt.Sprintf(exchangesOrdersPath, path, path, path, path)
+	codebasePaths["exchanges papertrade"] = fmt.Sprintf(exchangesPaperTradePath, path, path, path, path)
+	codebasePaths["exchanges risk"] = fmt.Sprintf(exchangesRiskPath, path, path, path, path)
+	codebasePaths["exchanges exchangetest"] = fmt.Sprintf(exchangesExchangeTestPath, path, path, path, path)
+	codebasePaths["exchanges conditional"] = fmt.Sprintf(exchangesConditionalPath, path, path, path, path)
+	codebasePaths["exchanges kline"] = fmt.Sprintf(exchangesKlinePath, path, path, path, path)
 	codebasePaths["exchanges request"] = fmt.Sprintf(exchangesRequestPath, path, path, path, path)
 
 	codebasePaths["exchanges alphapoint"] = fmt.Sprintf(alphapoint, path, path, path, path)
@@ -301,6 +324,7 @@ func getslashFromName(packageName string) string {
 }
 
 var globS = []string{
+	fmt.Sprintf("backtest_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("common_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("communications_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("config_templates%s*", common.GetOSPathSlash()),
@@ -308,6 +332,11 @@ var globS = []string{
 	fmt.Sprintf("events_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("exchanges_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("portfolio_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("recorder_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("strategy_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("arbitrage_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("orderrouter_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("execution_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("root_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("sub_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("testdata_templates%s*", common.GetOSPathSlash()),
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/anx"
+	"github.com/thrasher-/gocryptotrader/exchanges/binance"
+	"github.com/thrasher-/gocryptotrader/exchanges/bitfinex"
+	"github.com/thrasher-/gocryptotrader/exchanges/bitflyer"
+	"github.com/thrasher-/gocryptotrader/exchanges/bithumb"
+	"github.com/thrasher-/gocryptotrader/exchanges/bitmex"
+	"github.com/thrasher-/gocryptotrader/exchanges/bitstamp"
+	"github.com/thrasher-/gocryptotrader/exchanges/bittrex"
+	"github.com/thrasher-/gocryptotrader/exchanges/btcc"
+	"github.com/thrasher-/gocryptotrader/exchanges/btcmarkets"
+	"github.com/thrasher-/gocryptotrader/exchanges/coinbasepro"
+	"github.com/thrasher-/gocryptotrader/exchanges/coinut"
+	"github.com/thrasher-/gocryptotrader/exchanges/exmo"
+	"github.com/thrasher-/gocryptotrader/exchanges/gateio"
+	"github.com/thrasher-/gocryptotrader/exchanges/gemini"
+	"github.com/thrasher-/gocryptotrader/exchanges/hitbtc"
+	"github.com/thrasher-/gocryptotrader/exchanges/huobi"
+	"github.com/thrasher-/gocryptotrader/exchanges/huobihadax"
+	"github.com/thrasher-/gocryptotrader/exchanges/itbit"
+	"github.com/thrasher-/gocryptotrader/exchanges/kraken"
+	"github.com/thrasher-/gocryptotrader/exchanges/lakebtc"
+	"github.com/thrasher-/gocryptotrader/exchanges/liqui"
+	"github.com/thrasher-/gocryptotrader/exchanges/localbitcoins"
+	"github.com/thrasher-/gocryptotrader/exchanges/okcoin"
+	"github.com/thrasher-/gocryptotrader/exchanges/okex"
+	"github.com/thrasher-/gocryptotrader/exchanges/poloniex"
+	"github.com/thrasher-/gocryptotrader/exchanges/wex"
+	"github.com/thrasher-/gocryptotrader/exchanges/yobit"
+	"github.com/thrasher-/gocryptotrader/exchanges/zb"
+)
+
+// exchangeFeatures is the JSON output format of the tool
+type exchangeFeatures struct {
+	ExchangeName            string            `json:"exchangeName"`
+	Features                exchange.Features `json:"features"`
+	WithdrawPermissions     string            `json:"withdrawPermissions"`
+	OrderSubmissionFeatures string            `json:"orderSubmissionFeatures"`
+}
+
+// exchangeEntry pairs an exchange with the name it is listed under, some
+// exchanges only set their name during setup
+type exchangeEntry struct {
+	name string
+	exch exchange.IBotExchange
+}
+
+func getExchanges() []exchangeEntry {
+	exchanges := []exchange.IBotExchange{
+		new(anx.ANX),
+		new(binance.Binance),
+		new(bitfinex.Bitfinex),
+		new(bitflyer.Bitflyer),
+		new(bithumb.Bithumb),
+		new(bitmex.Bitmex),
+		new(bitstamp.Bitstamp),
+		new(bittrex.Bittrex),
+		new(btcc.BTCC),
+		new(btcmarkets.BTCMarkets),
+		new(coinbasepro.CoinbasePro),
+		new(coinut.COINUT),
+		new(exmo.EXMO),
+		new(gateio.Gateio),
+		new(gemini.Gemini),
+		new(hitbtc.HitBTC),
+		new(huobi.HUOBI),
+		new(huobihadax.HUOBIHADAX),
+		new(itbit.ItBit),
+		new(kraken.Kraken),
+		new(lakebtc.LakeBTC),
+		new(liqui.Liqui),
+		new(localbitcoins.LocalBitcoins),
+		new(okcoin.OKCoin),
+		new(okex.OKEX),
+		new(poloniex.Poloniex),
+		new(wex.WEX),
+		new(yobit.Yobit),
+		new(zb.ZB),
+	}
+
+	var entries []exchangeEntry
+	for x := range exchanges {
+		exchanges[x].SetDefaults()
+		name := exchanges[x].GetName()
+		if name == "" {
+			typeName := fmt.Sprintf("%T", exchanges[x])
+			name = typeName[strings.LastIndex(typeName, ".")+1:]
+		}
+		entries = append(entries, exchangeEntry{name: name, exch: exchanges[x]})
+	}
+	return entries
+}
+
+func printFeatures(name string, exch exchange.IBotExchange) {
+	features := exch.GetFeatures()
+
+	fmt.Println(name)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, f := range features.List() {
+		fmt.Fprintf(w, "\t%s:\t%s\n", f.Name, f.Support)
+	}
+
+	subscriptions := exchange.NoFeatureSupportText
+	if len(features.Subscriptions) > 0 {
+		subscriptions = strings.Join(features.Subscriptions, ", ")
+	}
+	fmt.Fprintf(w, "\tWebsocket subscriptions:\t%s\n", subscriptions)
+	fmt.Fprintf(w, "\tOrder submission features:\t%s\n", exch.FormatOrderSubmissionFeatures())
+	fmt.Fprintf(w, "\tWithdraw permissions:\t%s\n", exch.FormatWithdrawPermissions())
+	w.Flush()
+	fmt.Println()
+}
+
+func main() {
+	var exchName string
+	var outputJSON bool
+
+	flag.StringVar(&exchName, "exchange", "", "The exchange to list the features of, lists all exchanges if empty.")
+	flag.BoolVar(&outputJSON, "json", false, "Outputs the exchange features in JSON format.")
+	flag.Parse()
+
+	var result []exchangeFeatures
+	found := false
+	for _, entry := range getExchanges() {
+		exch := entry.exch
+		if exchName != "" &&
+			common.StringToLower(entry.name) != common.StringToLower(exchName) {
+			continue
+		}
+		found = true
+
+		if !outputJSON {
+			printFeatures(entry.name, exch)
+			continue
+		}
+
+		result = append(result, exchangeFeatures{
+			ExchangeName:            entry.name,
+			Features:                exch.GetFeatures(),
+			WithdrawPermissions:     exch.FormatWithdrawPermissions(),
+			OrderSubmissionFeatures: exch.FormatOrderSubmissionFeatures(),
+		})
+	}
+
+	if !found {
+		log.Fatalf("Exchange %s not found.", exchName)
+	}
+
+	if outputJSON {
+		data, err := common.JSONEncode(result)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(data))
+	}
+}
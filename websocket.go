@@ -4,11 +4,18 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/events"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/conditional"
+	"github.com/thrasher-/gocryptotrader/execution"
+	"github.com/thrasher-/gocryptotrader/orderrouter"
 )
 
 // Const vars for websocket
@@ -27,16 +34,30 @@ type wsCommandHandler struct {
 }
 
 var wsHandlers = map[string]wsCommandHandler{
-	"auth":             {authRequired: false, handler: wsAuth},
-	"getconfig":        {authRequired: true, handler: wsGetConfig},
-	"saveconfig":       {authRequired: true, handler: wsSaveConfig},
-	"getaccountinfo":   {authRequired: true, handler: wsGetAccountInfo},
-	"gettickers":       {authRequired: false, handler: wsGetTickers},
-	"getticker":        {authRequired: false, handler: wsGetTicker},
-	"getorderbooks":    {authRequired: false, handler: wsGetOrderbooks},
-	"getorderbook":     {authRequired: false, handler: wsGetOrderbook},
-	"getexchangerates": {authRequired: false, handler: wsGetExchangeRates},
-	"getportfolio":     {authRequired: true, handler: wsGetPortfolio},
+	"auth":                   {authRequired: false, handler: wsAuth},
+	"getconfig":              {authRequired: true, handler: wsGetConfig},
+	"saveconfig":             {authRequired: true, handler: wsSaveConfig},
+	"getaccountinfo":         {authRequired: true, handler: wsGetAccountInfo},
+	"gettickers":             {authRequired: false, handler: wsGetTickers},
+	"getticker":              {authRequired: false, handler: wsGetTicker},
+	"getorderbooks":          {authRequired: false, handler: wsGetOrderbooks},
+	"getorderbook":           {authRequired: false, handler: wsGetOrderbook},
+	"getexchangerates":       {authRequired: false, handler: wsGetExchangeRates},
+	"getportfolio":           {authRequired: true, handler: wsGetPortfolio},
+	"getrisk":                {authRequired: true, handler: wsGetRisk},
+	"activatekillswitch":     {authRequired: true, handler: wsActivateKillSwitch},
+	"resetkillswitch":        {authRequired: true, handler: wsResetKillSwitch},
+	"getarbitrage":           {authRequired: false, handler: wsGetArbitrage},
+	"routeorder":             {authRequired: true, handler: wsRouteOrder},
+	"startexecution":         {authRequired: true, handler: wsStartExecution},
+	"cancelexecution":        {authRequired: true, handler: wsCancelExecution},
+	"getexecutions":          {authRequired: true, handler: wsGetExecutions},
+	"addconditionalorder":    {authRequired: true, handler: wsAddConditionalOrder},
+	"cancelconditionalorder": {authRequired: true, handler: wsCancelConditionalOrder},
+	"getconditionalorders":   {authRequired: true, handler: wsGetConditionalOrders},
+	"addevent":               {authRequired: true, handler: wsAddEvent},
+	"getevents":              {authRequired: true, handler: wsGetEvents},
+	"removeevent":            {authRequired: true, handler: wsRemoveEvent},
 }
 
 // WebsocketClient stores information related to the websocket client
@@ -71,6 +92,113 @@ type WebsocketEventResponse struct {
 	Error string      `json:"error"`
 }
 
+// WebsocketKillSwitchRequest is a struct used to activate the kill switch
+type WebsocketKillSwitchRequest struct {
+	Reason string `json:"reason"`
+}
+
+// WebsocketRouteOrderRequest is a struct used to split an order across
+// exchanges, DryRun returns the planned child orders without submitting them
+type WebsocketRouteOrderRequest struct {
+	Pair       string   `json:"pair"`
+	Side       string   `json:"side"`
+	Amount     float64  `json:"amount"`
+	LimitPrice float64  `json:"limitPrice"`
+	Exchanges  []string `json:"exchanges"`
+	DryRun     bool     `json:"dryRun"`
+}
+
+// WebsocketStartExecutionRequest is a struct used to start slicing an order
+// with an execution algorithm. Duration and Interval are Go duration strings
+// such as "30m"
+type WebsocketStartExecutionRequest struct {
+	Algorithm     string  `json:"algorithm"`
+	Exchange      string  `json:"exchangeName"`
+	Pair          string  `json:"pair"`
+	Side          string  `json:"side"`
+	Amount        float64 `json:"amount"`
+	LimitPrice    float64 `json:"limitPrice"`
+	Duration      string  `json:"duration"`
+	Slices        int     `json:"slices"`
+	DisplayAmount float64 `json:"displayAmount"`
+	Participation float64 `json:"participation"`
+	Interval      string  `json:"interval"`
+}
+
+// WebsocketCancelExecutionRequest is a struct used to cancel a running
+// execution
+type WebsocketCancelExecutionRequest struct {
+	ID string `json:"id"`
+}
+
+// WebsocketConditionalOrderRequest is a struct used to add a conditional
+// order, setting OCO adds a second order linked so that the first to trigger
+// cancels the other
+type WebsocketConditionalOrderRequest struct {
+	Exchange     string                            `json:"exchangeName"`
+	Pair         string                            `json:"pair"`
+	AssetType    string                            `json:"assetType"`
+	Type         string                            `json:"type"`
+	Side         string                            `json:"side"`
+	Amount       float64                           `json:"amount"`
+	Source       string                            `json:"source"`
+	TriggerPrice float64                           `json:"triggerPrice"`
+	TrailAmount  float64                           `json:"trailAmount"`
+	TrailPercent float64                           `json:"trailPercent"`
+	OrderType    string                            `json:"orderType"`
+	LimitPrice   float64                           `json:"limitPrice"`
+	OCO          *WebsocketConditionalOrderRequest `json:"oco"`
+}
+
+// WebsocketCancelConditionalOrderRequest is a struct used to cancel a pending
+// conditional order
+type WebsocketCancelConditionalOrderRequest struct {
+	ID int64 `json:"id"`
+}
+
+// WebsocketEventConditionRequest is a condition of an event request. Window
+// is a Go duration string such as "1h"
+type WebsocketEventConditionRequest struct {
+	Exchange  string  `json:"exchangeName"`
+	Pair      string  `json:"pair"`
+	AssetType string  `json:"assetType"`
+	Item      string  `json:"item"`
+	Param     float64 `json:"param"`
+	Window    string  `json:"window"`
+	Currency  string  `json:"currency"`
+	Operator  string  `json:"operator"`
+	Value     float64 `json:"value"`
+}
+
+// WebsocketEventActionRequest is an action of an event request, the order
+// fields are used by ORDER actions
+type WebsocketEventActionRequest struct {
+	Type      string  `json:"type"`
+	Medium    string  `json:"medium"`
+	URL       string  `json:"url"`
+	Exchange  string  `json:"exchangeName"`
+	Pair      string  `json:"pair"`
+	Side      string  `json:"side"`
+	OrderType string  `json:"orderType"`
+	Amount    float64 `json:"amount"`
+	Price     float64 `json:"price"`
+}
+
+// WebsocketAddEventRequest is a struct used to add an event. Logic is AND or
+// OR and Cooldown is a Go duration string such as "15m"
+type WebsocketAddEventRequest struct {
+	Logic      string                           `json:"logic"`
+	Conditions []WebsocketEventConditionRequest `json:"conditions"`
+	Actions    []WebsocketEventActionRequest    `json:"actions"`
+	Rearm      bool                             `json:"rearm"`
+	Cooldown   string                           `json:"cooldown"`
+}
+
+// WebsocketRemoveEventRequest is a struct used to remove an event
+type WebsocketRemoveEventRequest struct {
+	ID int `json:"id"`
+}
+
 // WebsocketOrderbookTickerRequest is a struct used for ticker and orderbook
 // requests
 type WebsocketOrderbookTickerRequest struct {
@@ -448,3 +576,377 @@ func wsGetPortfolio(client *WebsocketClient, data interface{}) error {
 	wsResp.Data = bot.portfolio.GetPortfolioSummary()
 	return client.SendWebsocketMessage(wsResp)
 }
+
+func wsGetRisk(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "GetRisk",
+		Data:  GetRiskStatus(),
+	}
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsGetArbitrage(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "GetArbitrage",
+		Data:  GetArbitrageOpportunities(),
+	}
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsRouteOrder(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "RouteOrder",
+	}
+	var req WebsocketRouteOrderRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	p, err := pair.ParseCurrencyPair(common.StringToUpper(req.Pair))
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	request := orderrouter.Request{
+		Pair:       p,
+		Side:       exchange.FormatOrderSide(req.Side),
+		Amount:     req.Amount,
+		LimitPrice: req.LimitPrice,
+		Exchanges:  req.Exchanges,
+	}
+	var report orderrouter.Report
+	if req.DryRun {
+		report, err = bot.orderRouter.Plan(request)
+	} else {
+		report, err = bot.orderRouter.Execute(request)
+	}
+	wsResp.Data = report
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsStartExecution(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "StartExecution",
+	}
+	var req WebsocketStartExecutionRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	p, err := pair.ParseCurrencyPair(common.StringToUpper(req.Pair))
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	request := execution.Request{
+		Algorithm:     execution.Algorithm(common.StringToUpper(req.Algorithm)),
+		Exchange:      req.Exchange,
+		Pair:          p,
+		Side:          exchange.FormatOrderSide(req.Side),
+		Amount:        req.Amount,
+		LimitPrice:    req.LimitPrice,
+		Slices:        req.Slices,
+		DisplayAmount: req.DisplayAmount,
+		Participation: req.Participation,
+	}
+	if req.Duration != "" {
+		request.Duration, err = time.ParseDuration(req.Duration)
+	}
+	if err == nil && req.Interval != "" {
+		request.Interval, err = time.ParseDuration(req.Interval)
+	}
+	if err == nil {
+		wsResp.Data, err = bot.execution.Start(request)
+	}
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsCancelExecution(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "CancelExecution",
+	}
+	var req WebsocketCancelExecutionRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	err = bot.execution.Cancel(req.ID)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+	wsResp.Data = WebsocketResponseSuccess
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsGetExecutions(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "GetExecutions",
+		Data:  GetExecutions(),
+	}
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func (r *WebsocketConditionalOrderRequest) toOrder() (conditional.Order, error) {
+	p, err := pair.ParseCurrencyPair(common.StringToUpper(r.Pair))
+	if err != nil {
+		return conditional.Order{}, err
+	}
+
+	o := conditional.Order{
+		Exchange:     r.Exchange,
+		CurrencyPair: p,
+		AssetType:    common.StringToUpper(r.AssetType),
+		Type:         conditional.Type(common.StringToUpper(r.Type)),
+		Side:         exchange.FormatOrderSide(r.Side),
+		Amount:       r.Amount,
+		Source:       conditional.Source(common.StringToUpper(r.Source)),
+		TriggerPrice: r.TriggerPrice,
+		TrailAmount:  r.TrailAmount,
+		TrailPercent: r.TrailPercent,
+		LimitPrice:   r.LimitPrice,
+	}
+	if r.OrderType != "" {
+		o.OrderType = exchange.FormatOrderType(r.OrderType)
+	}
+	return o, nil
+}
+
+func wsAddConditionalOrder(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "AddConditionalOrder",
+	}
+	var req WebsocketConditionalOrderRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	o, err := req.toOrder()
+	if err == nil {
+		if req.OCO != nil {
+			var oco conditional.Order
+			oco, err = req.OCO.toOrder()
+			if err == nil {
+				var first, second int64
+				first, second, err = bot.conditional.AddOCO(o, oco)
+				wsResp.Data = []int64{first, second}
+			}
+		} else {
+			wsResp.Data, err = bot.conditional.Add(o)
+		}
+	}
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsCancelConditionalOrder(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "CancelConditionalOrder",
+	}
+	var req WebsocketCancelConditionalOrderRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	err = bot.conditional.Cancel(req.ID)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+	wsResp.Data = WebsocketResponseSuccess
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsGetConditionalOrders(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "GetConditionalOrders",
+		Data:  GetConditionalOrders(),
+	}
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func (r *WebsocketAddEventRequest) toEvent() (events.Event, error) {
+	e := events.Event{
+		Logic: events.Logic(common.StringToUpper(r.Logic)),
+		Rearm: r.Rearm,
+	}
+	var err error
+	if r.Cooldown != "" {
+		e.Cooldown, err = time.ParseDuration(r.Cooldown)
+		if err != nil {
+			return e, err
+		}
+	}
+
+	for _, c := range r.Conditions {
+		condition := events.Condition{
+			Exchange: c.Exchange,
+			Asset:    common.StringToUpper(c.AssetType),
+			Item:     events.Item(common.StringToUpper(c.Item)),
+			Param:    c.Param,
+			Currency: common.StringToUpper(c.Currency),
+			Operator: events.Operator(c.Operator),
+			Value:    c.Value,
+		}
+		if c.Pair != "" {
+			condition.Pair, err = pair.ParseCurrencyPair(common.StringToUpper(c.Pair))
+			if err != nil {
+				return e, err
+			}
+		}
+		if c.Window != "" {
+			condition.Window, err = time.ParseDuration(c.Window)
+			if err != nil {
+				return e, err
+			}
+		}
+		e.Conditions = append(e.Conditions, condition)
+	}
+
+	for _, a := range r.Actions {
+		action := events.Action{
+			Type:   events.ActionType(common.StringToUpper(a.Type)),
+			Medium: a.Medium,
+			URL:    a.URL,
+		}
+		if action.Type == events.ActionOrder {
+			action.Order = &events.OrderAction{
+				Exchange:  a.Exchange,
+				Side:      exchange.FormatOrderSide(a.Side),
+				OrderType: exchange.FormatOrderType(a.OrderType),
+				Amount:    a.Amount,
+				Price:     a.Price,
+			}
+			if a.Pair != "" {
+				action.Order.Pair, err = pair.ParseCurrencyPair(common.StringToUpper(a.Pair))
+				if err != nil {
+					return e, err
+				}
+			}
+		}
+		e.Actions = append(e.Actions, action)
+	}
+	return e, nil
+}
+
+func wsAddEvent(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "AddEvent",
+	}
+	var req WebsocketAddEventRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	e, err := req.toEvent()
+	if err == nil {
+		wsResp.Data, err = events.AddEvent(&e)
+	}
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsGetEvents(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "GetEvents",
+		Data:  events.GetEvents(),
+	}
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsRemoveEvent(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "RemoveEvent",
+	}
+	var req WebsocketRemoveEventRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	if !events.RemoveEvent(req.ID) {
+		wsResp.Error = events.ErrEventNotFound.Error()
+		client.SendWebsocketMessage(wsResp)
+		return events.ErrEventNotFound
+	}
+	wsResp.Data = WebsocketResponseSuccess
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsActivateKillSwitch(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "ActivateKillSwitch",
+	}
+	var req WebsocketKillSwitchRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+	if req.Reason == "" {
+		req.Reason = "activated via websocket"
+	}
+
+	err = bot.risk.ActivateKillSwitch(req.Reason)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+	wsResp.Data = WebsocketResponseSuccess
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsResetKillSwitch(client *WebsocketClient, data interface{}) error {
+	bot.risk.ResetKillSwitch()
+	wsResp := WebsocketEventResponse{
+		Event: "ResetKillSwitch",
+		Data:  WebsocketResponseSuccess,
+	}
+	return client.SendWebsocketMessage(wsResp)
+}